@@ -16,6 +16,15 @@ func main() {
 
 	serverCmd := flag.NewFlagSet("server", flag.ExitOnError)
 	seedCmd := flag.NewFlagSet("seed", flag.ExitOnError)
+	statementCmd := flag.NewFlagSet("statement", flag.ExitOnError)
+	outboxRelayCmd := flag.NewFlagSet("outbox-relay", flag.ExitOnError)
+	webhookDispatchCmd := flag.NewFlagSet("webhook-dispatch", flag.ExitOnError)
+	notificationWorkerCmd := flag.NewFlagSet("notification-worker", flag.ExitOnError)
+	notificationReminderCmd := flag.NewFlagSet("notification-reminder", flag.ExitOnError)
+	contractWorkerCmd := flag.NewFlagSet("contract-worker", flag.ExitOnError)
+	contractBackfillCmd := flag.NewFlagSet("contract-backfill", flag.ExitOnError)
+	exportWorkerCmd := flag.NewFlagSet("export-worker", flag.ExitOnError)
+	workerCmd := flag.NewFlagSet("worker", flag.ExitOnError)
 
 	if len(os.Args) < 2 {
 		log.Info().Msg("No command provided, defaulting to 'server'")
@@ -26,6 +35,24 @@ func main() {
 	switch os.Args[1] {
 	case "seed":
 		cmd.RunSeed(seedCmd, os.Args[2:])
+	case "statement":
+		cmd.RunStatement(statementCmd, os.Args[2:])
+	case "outbox-relay":
+		cmd.RunOutboxRelay(outboxRelayCmd, os.Args[2:])
+	case "webhook-dispatch":
+		cmd.RunWebhookDispatch(webhookDispatchCmd, os.Args[2:])
+	case "notification-worker":
+		cmd.RunNotificationWorker(notificationWorkerCmd, os.Args[2:])
+	case "notification-reminder":
+		cmd.RunNotificationReminder(notificationReminderCmd, os.Args[2:])
+	case "contract-worker":
+		cmd.RunContractWorker(contractWorkerCmd, os.Args[2:])
+	case "contract-backfill":
+		cmd.RunContractBackfill(contractBackfillCmd, os.Args[2:])
+	case "export-worker":
+		cmd.RunExportWorker(exportWorkerCmd, os.Args[2:])
+	case "worker":
+		cmd.RunWorker(workerCmd, os.Args[2:])
 	case "server":
 		cmd.RunServerHTTP(serverCmd, os.Args[2:])
 	default:
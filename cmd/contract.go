@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	customerRepository "github.com/hilmiikhsan/multifinance-service/internal/module/customer/repository"
+	transactionRepository "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/repository"
+	transactionService "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/service"
+	"github.com/hilmiikhsan/multifinance-service/pkg/document"
+	"github.com/hilmiikhsan/multifinance-service/pkg/outbox"
+	"github.com/rs/zerolog/log"
+)
+
+// RunContractWorker issues the contract of every booking on the customer
+// stream until it is stopped. Workers of the same group share the events.
+func RunContractWorker(cmd *flag.FlagSet, args []string) {
+	var (
+		envs        = config.Envs
+		hostname, _ = os.Hostname()
+		group       = cmd.String("group", envs.Document.ConsumerGroup, "consumer group to read the event stream with")
+		consumer    = cmd.String("consumer", fmt.Sprintf("%s-%d", hostname, os.Getpid()), "name of this worker within the group")
+		batchSize   = cmd.Int("batch_size", envs.Document.ConsumerBatchSize, "events read per poll")
+		retry       = cmd.Duration("retry", 5*time.Second, "how long a failed event stays pending before it is tried again")
+		once        = cmd.Bool("once", false, "handle a single batch and exit")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	if *batchSize < 1 {
+		log.Fatal().Int("batch_size", *batchSize).Msg("Invalid -batch_size, expected at least 1")
+	}
+
+	if _, err := document.LoadContractTemplate(envs.Document.ContractTemplateVersion); err != nil {
+		log.Fatal().Err(err).Msg("Failed to load contract template")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithMultifinanceDB(),
+		adapter.WithMultifinanceRedis(),
+	)
+
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Error().Err(err).Msg("Error while closing adapters")
+		}
+	}()
+
+	handler := transactionService.NewContractService(
+		transactionRepository.NewTransactionRepository(adapter.Adapters.MultifinanceDB),
+		customerRepository.NewCustomerRepository(adapter.Adapters.MultifinanceDB),
+		filepath.Join(envs.App.LocalStoragePrivatePath, "contracts"),
+		envs.Document.ContractTemplateVersion,
+	)
+	stream := envs.Outbox.StreamPrefix + constants.AggregateTypeCustomer
+	reader := outbox.NewRedisStreamConsumer(
+		adapter.Adapters.MultifinanceRedis,
+		stream,
+		*group,
+		*consumer,
+		int64(*batchSize),
+		time.Duration(envs.Document.BlockMs)*time.Millisecond,
+		*retry,
+		int64(envs.Outbox.MaxDeliveries),
+	)
+
+	shutdownSignals := []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGINT}
+	if runtime.GOOS == "windows" {
+		shutdownSignals = []os.Signal{os.Interrupt}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), shutdownSignals...)
+	defer stop()
+
+	if err := reader.EnsureGroup(ctx); err != nil {
+		log.Fatal().Err(err).Msg("Failed to prepare the contract consumer group")
+	}
+
+	log.Info().Str("stream", stream).Str("group", *group).Str("consumer", *consumer).Msg("Contract worker is running")
+
+	for ctx.Err() == nil {
+		res, err := reader.Poll(ctx, handler.HandleEvent)
+		if err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("Failed to read the event stream")
+		}
+
+		if res != nil && res.Handled+res.Failed+res.Dropped+res.DeadLettered > 0 {
+			log.Info().Any("result", res).Msg("Events handled")
+		}
+
+		if *once {
+			return
+		}
+
+		// a failed event is claimed again once it has been pending for -retry,
+		// only a stream that cannot be read is waited on here
+		if err != nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(*retry):
+			}
+		}
+	}
+
+	log.Info().Msg("Contract worker stopped")
+}
+
+// RunContractBackfill issues the contract of every booked transaction that has
+// none yet, for bookings whose event ended up in the dead-letter stream or was
+// never relayed. It is safe to run again, issued contracts are left as they
+// are.
+func RunContractBackfill(cmd *flag.FlagSet, args []string) {
+	var (
+		envs      = config.Envs
+		batchSize = cmd.Int("batch_size", 100, "transactions read per query")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	if *batchSize < 1 {
+		log.Fatal().Int("batch_size", *batchSize).Msg("Invalid -batch_size, expected at least 1")
+	}
+
+	if _, err := document.LoadContractTemplate(envs.Document.ContractTemplateVersion); err != nil {
+		log.Fatal().Err(err).Msg("Failed to load contract template")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithMultifinanceDB(),
+	)
+
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Error().Err(err).Msg("Error while closing adapters")
+		}
+	}()
+
+	issuer := transactionService.NewContractService(
+		transactionRepository.NewTransactionRepository(adapter.Adapters.MultifinanceDB),
+		customerRepository.NewCustomerRepository(adapter.Adapters.MultifinanceDB),
+		filepath.Join(envs.App.LocalStoragePrivatePath, "contracts"),
+		envs.Document.ContractTemplateVersion,
+	)
+
+	shutdownSignals := []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGINT}
+	if runtime.GOOS == "windows" {
+		shutdownSignals = []os.Signal{os.Interrupt}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), shutdownSignals...)
+	defer stop()
+
+	res, err := issuer.BackfillContracts(ctx, *batchSize)
+	if err != nil {
+		log.Error().Err(err).Any("result", res).Msg("Failed to backfill contracts")
+		return
+	}
+
+	log.Info().Any("result", res).Msg("Contracts backfilled")
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	transactionRepository "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/repository"
+	transactionService "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/service"
+	"github.com/rs/zerolog/log"
+)
+
+// RunExportWorker writes the queued transaction exports until it is stopped.
+// Several workers can run side by side, each claims its own exports, and the
+// exports of a worker that died are claimed again once their lease runs out.
+func RunExportWorker(cmd *flag.FlagSet, args []string) {
+	var (
+		envs        = config.Envs.Export
+		interval    = cmd.Duration("interval", time.Duration(envs.IntervalMs)*time.Millisecond, "wait between polls for new exports")
+		concurrency = cmd.Int("concurrency", envs.Concurrency, "exports written at the same time")
+		once        = cmd.Bool("once", false, "write the exports that are queued now and exit")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	if *concurrency < 1 {
+		log.Fatal().Int("concurrency", *concurrency).Msg("Invalid -concurrency, expected at least 1")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithMultifinanceDB(),
+	)
+
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Error().Err(err).Msg("Error while closing adapters")
+		}
+	}()
+
+	runner := transactionService.NewExportRunnerService(
+		adapter.Adapters.MultifinanceDB,
+		transactionRepository.NewTransactionRepository(adapter.Adapters.MultifinanceDB),
+		filepath.Join(config.Envs.App.LocalStoragePrivatePath, "exports"),
+		envs.MaxRows,
+		*concurrency,
+		time.Duration(envs.JobTimeoutSeconds)*time.Second,
+		envs.MaxAttempts,
+	)
+
+	if *once {
+		started, err := runner.RunPendingExports(context.Background())
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to run transaction exports")
+			return
+		}
+
+		log.Info().Int("started", started).Msg("Transaction exports written")
+		return
+	}
+
+	shutdownSignals := []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGINT}
+	if runtime.GOOS == "windows" {
+		shutdownSignals = []os.Signal{os.Interrupt}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), shutdownSignals...)
+	defer stop()
+
+	log.Info().Dur("interval", *interval).Int("concurrency", *concurrency).Msg("Export worker is running")
+	runner.Run(ctx, *interval)
+	log.Info().Msg("Export worker stopped")
+}
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"flag"
+	"net"
 	"os"
 	"os/signal"
 	"runtime"
@@ -9,6 +11,7 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/monitor"
@@ -16,7 +19,10 @@ import (
 	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
 	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure"
 	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
 	"github.com/hilmiikhsan/multifinance-service/internal/route"
+	"github.com/hilmiikhsan/multifinance-service/pkg/metrics"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
 	"github.com/hilmiikhsan/multifinance-service/pkg/validator"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -24,9 +30,10 @@ import (
 
 func RunServerHTTP(cmd *flag.FlagSet, args []string) {
 	var (
-		envs        = config.Envs
-		flagAppPort = cmd.String("port", envs.App.Port, "Application port")
-		SERVER_PORT string
+		envs         = config.Envs
+		flagAppPort  = cmd.String("port", envs.App.Port, "Application port")
+		flagGrpcPort = cmd.String("grpc_port", envs.App.GrpcPort, "gRPC port, the gRPC server is off when empty")
+		SERVER_PORT  string
 	)
 
 	logLevel, err := zerolog.ParseLevel(envs.App.LogLevel)
@@ -44,26 +51,48 @@ func RunServerHTTP(cmd *flag.FlagSet, args []string) {
 		SERVER_PORT = *flagAppPort
 	}
 
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Options{
+		ServiceName:  envs.App.Name,
+		Environment:  envs.App.Environtment,
+		Exporter:     envs.Tracing.Exporter,
+		OTLPEndpoint: envs.Tracing.OTLPEndpoint,
+		OTLPInsecure: envs.Tracing.OTLPInsecure,
+		SampleRatio:  envs.Tracing.SampleRatio,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error while initializing tracing")
+	}
+
 	app := fiber.New()
 
 	// Application Middlewares
+	app.Use(middleware.Tracing)
+	app.Use(middleware.RequestID)
+	app.Use(middleware.RequestTimeout(time.Duration(envs.App.RequestTimeoutSeconds) * time.Second))
+	app.Use(middleware.Metrics)
+
 	if envs.App.Environtment == constants.EnvProduction {
 		app.Use(limiter.New(limiter.Config{
 			Max:        50,
 			Expiration: 30 * time.Second,
+			// probes come often from few addresses
+			Next: func(c *fiber.Ctx) bool {
+				return c.Path() == "/healthz" || c.Path() == "/readyz"
+			},
 		}))
 	}
 
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD",
-		AllowHeaders: "Origin,Content-Type,Accept,Content-Length,Accept-Language,Accept-Encoding,Connection,Access-Control-Allow-Origin,Authorization",
+		AllowOrigins:  "*",
+		AllowMethods:  "GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD",
+		AllowHeaders:  "Origin,Content-Type,Accept,Content-Length,Accept-Language,Accept-Encoding,Connection,Access-Control-Allow-Origin,Authorization,X-Request-ID",
+		ExposeHeaders: "X-Request-ID",
 	}))
 	// End Application Middlewares
 
 	adapter.Adapters.Sync(
 		adapter.WithRestServer(app),
-		adapter.WithMultifinanceMySQL(),
+		adapter.WithMultifinanceDB(),
 		adapter.WithMultifinanceRedis(),
 		adapter.WithValidator(validator.NewValidator()),
 	)
@@ -74,9 +103,21 @@ func RunServerHTTP(cmd *flag.FlagSet, args []string) {
 		logLevel,
 	)
 
-	app.Get("/metrics", monitor.New(monitor.Config{Title: config.Envs.App.Name + config.Envs.App.Environtment + " Metrics"}))
+	// pool stats and command latency of the driven adapters
+	if err := metrics.RegisterDB(adapter.Adapters.MultifinanceDB.DB, "multifinance_"+adapter.Adapters.MultifinanceDB.DriverName()); err != nil {
+		log.Error().Err(err).Msg("Error while registering database metrics")
+	}
+	adapter.Adapters.MultifinanceRedis.AddHook(metrics.RedisHook{})
+
+	app.Get("/metrics", adaptor.HTTPHandler(metrics.Handler()))
+	app.Get("/monitor", monitor.New(monitor.Config{Title: config.Envs.App.Name + config.Envs.App.Environtment + " Metrics"}))
 	route.SetupRoutes(app)
 
+	// the gRPC services use the driven adapters, so the server is added once they are connected
+	if *flagGrpcPort != "" {
+		adapter.Adapters.Sync(adapter.WithGrpcServer(route.NewGRPCServer()))
+	}
+
 	// print all routes that are registered
 	// for _, route := range app.Stack() {
 	// 	for _, handler := range route {
@@ -91,6 +132,20 @@ func RunServerHTTP(cmd *flag.FlagSet, args []string) {
 			log.Fatal().Msgf("Error while starting server: %v", err)
 		}
 	}()
+
+	if adapter.Adapters.GrpcServer != nil {
+		go func() {
+			listener, err := net.Listen("tcp", ":"+*flagGrpcPort)
+			if err != nil {
+				log.Fatal().Msgf("Error while listening on gRPC port: %v", err)
+			}
+
+			log.Info().Msgf("gRPC server is running on port %s", *flagGrpcPort)
+			if err := adapter.Adapters.GrpcServer.Serve(listener); err != nil {
+				log.Fatal().Msgf("Error while starting gRPC server: %v", err)
+			}
+		}()
+	}
 	// End Run server in goroutine
 
 	// Handle graceful shutdown
@@ -105,10 +160,24 @@ func RunServerHTTP(cmd *flag.FlagSet, args []string) {
 	<-quit
 	log.Info().Msg("Server is shutting down ...")
 
+	// fail readiness first so load balancers stop sending requests before the servers close
+	adapter.Adapters.Drain()
+	if drain := time.Duration(envs.Health.DrainSeconds) * time.Second; drain > 0 {
+		log.Info().Msgf("Draining for %s", drain)
+		time.Sleep(drain)
+	}
+
 	err = adapter.Adapters.Unsync()
 	if err != nil {
 		log.Error().Msgf("Error while closing adapters: %v", err)
 	}
 
+	// flush the spans still buffered
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error().Err(err).Msg("Error while flushing traces")
+	}
+
 	log.Info().Msg("Server gracefully stopped")
 }
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	notificationRepository "github.com/hilmiikhsan/multifinance-service/internal/module/notification/repository"
+	notificationService "github.com/hilmiikhsan/multifinance-service/internal/module/notification/service"
+	"github.com/hilmiikhsan/multifinance-service/pkg/notification"
+	"github.com/hilmiikhsan/multifinance-service/pkg/outbox"
+	"github.com/rs/zerolog/log"
+)
+
+// RunNotificationWorker sends the notifications of the events on the customer
+// stream until it is stopped. Workers of the same group share the events.
+func RunNotificationWorker(cmd *flag.FlagSet, args []string) {
+	var (
+		envs        = config.Envs
+		hostname, _ = os.Hostname()
+		group       = cmd.String("group", envs.Notification.ConsumerGroup, "consumer group to read the event stream with")
+		consumer    = cmd.String("consumer", fmt.Sprintf("%s-%d", hostname, os.Getpid()), "name of this worker within the group")
+		batchSize   = cmd.Int("batch_size", envs.Notification.ConsumerBatchSize, "events read per poll")
+		retry       = cmd.Duration("retry", 5*time.Second, "how long a failed event stays pending before it is tried again")
+		once        = cmd.Bool("once", false, "handle a single batch and exit")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	if *batchSize < 1 {
+		log.Fatal().Int("batch_size", *batchSize).Msg("Invalid -batch_size, expected at least 1")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithMultifinanceDB(),
+		adapter.WithMultifinanceRedis(),
+	)
+
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Error().Err(err).Msg("Error while closing adapters")
+		}
+	}()
+
+	email, sms := notificationSenders()
+	handler := notificationService.NewNotificationService(notificationRepository.NewNotificationRepository(adapter.Adapters.MultifinanceDB), email, sms)
+	stream := envs.Outbox.StreamPrefix + constants.AggregateTypeCustomer
+	reader := outbox.NewRedisStreamConsumer(
+		adapter.Adapters.MultifinanceRedis,
+		stream,
+		*group,
+		*consumer,
+		int64(*batchSize),
+		time.Duration(envs.Notification.BlockMs)*time.Millisecond,
+		*retry,
+		int64(envs.Outbox.MaxDeliveries),
+	)
+
+	shutdownSignals := []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGINT}
+	if runtime.GOOS == "windows" {
+		shutdownSignals = []os.Signal{os.Interrupt}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), shutdownSignals...)
+	defer stop()
+
+	if err := reader.EnsureGroup(ctx); err != nil {
+		log.Fatal().Err(err).Msg("Failed to prepare the notification consumer group")
+	}
+
+	log.Info().Str("stream", stream).Str("group", *group).Str("consumer", *consumer).Msg("Notification worker is running")
+
+	for ctx.Err() == nil {
+		res, err := reader.Poll(ctx, handler.HandleEvent)
+		if err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("Failed to read the event stream")
+		}
+
+		if res != nil && res.Handled+res.Failed+res.Dropped+res.DeadLettered > 0 {
+			log.Info().Any("result", res).Msg("Events handled")
+		}
+
+		if *once {
+			return
+		}
+
+		// a failed event is claimed again once it has been pending for -retry,
+		// only a stream that cannot be read is waited on here
+		if err != nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(*retry):
+			}
+		}
+	}
+
+	log.Info().Msg("Notification worker stopped")
+}
+
+// RunNotificationReminder reminds customers of installments due in a few
+// days. It is meant to run once a day; a second run on the same day only
+// sends what the first one missed.
+func RunNotificationReminder(cmd *flag.FlagSet, args []string) {
+	days := cmd.Int("days", config.Envs.Notification.ReminderDaysAhead, "remind of installments due this many days from today")
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	if *days < 0 {
+		log.Fatal().Int("days", *days).Msg("Invalid -days, expected 0 or more")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithMultifinanceDB(),
+	)
+
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Error().Err(err).Msg("Error while closing adapters")
+		}
+	}()
+
+	email, sms := notificationSenders()
+	reminder := notificationService.NewNotificationService(notificationRepository.NewNotificationRepository(adapter.Adapters.MultifinanceDB), email, sms)
+
+	res, err := reminder.SendDueReminders(context.Background(), *days)
+	if err != nil {
+		log.Error().Err(err).Any("result", res).Msg("Failed to send installment reminders")
+		return
+	}
+
+	log.Info().Any("result", res).Msg("Installment reminders sent")
+}
+
+// notificationSenders turns on email and SMS only when they are configured,
+// the other channels are skipped and the in-app inbox still gets the message.
+func notificationSenders() (email, sms notification.Sender) {
+	envs := config.Envs.Notification
+
+	if envs.SMTPHost != "" {
+		email = notification.NewSMTPSender(envs.SMTPHost, envs.SMTPPort, envs.SMTPUsername, envs.SMTPPassword, envs.SMTPFrom)
+	}
+
+	if envs.SMSGatewayURL != "" {
+		sms = notification.NewSMSGateway(envs.SMSGatewayURL, envs.SMSGatewayAPIKey, time.Duration(envs.SMSTimeoutSeconds)*time.Second)
+	}
+
+	return email, sms
+}
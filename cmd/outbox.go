@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	outboxRepository "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/repository"
+	outboxService "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/service"
+	"github.com/hilmiikhsan/multifinance-service/pkg/outbox"
+	"github.com/rs/zerolog/log"
+)
+
+// RunOutboxRelay publishes the events written to the outbox table to Redis
+// Streams until it is stopped. Several relays can run side by side, they take
+// turns on the pending rows so the order within an aggregate is kept.
+func RunOutboxRelay(cmd *flag.FlagSet, args []string) {
+	var (
+		envs      = config.Envs
+		interval  = cmd.Duration("interval", time.Duration(envs.Outbox.RelayIntervalMs)*time.Millisecond, "wait between polls once the relay has caught up")
+		batchSize = cmd.Int("batch_size", envs.Outbox.RelayBatchSize, "events published per relay transaction")
+		once      = cmd.Bool("once", false, "relay a single batch and exit")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	if *batchSize < 1 {
+		log.Fatal().Int("batch_size", *batchSize).Msg("Invalid -batch_size, expected at least 1")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithMultifinanceDB(),
+		adapter.WithMultifinanceRedis(),
+	)
+
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Error().Err(err).Msg("Error while closing adapters")
+		}
+	}()
+
+	relay := outboxService.NewRelayService(
+		adapter.Adapters.MultifinanceDB,
+		outboxRepository.NewOutboxRepository(adapter.Adapters.MultifinanceDB),
+		outbox.NewRedisStreamPublisher(adapter.Adapters.MultifinanceRedis, envs.Outbox.StreamPrefix, int64(envs.Outbox.StreamMaxLen)),
+		*batchSize,
+	)
+
+	if *once {
+		res, err := relay.RelayPendingEvents(context.Background())
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to relay outbox events")
+			return
+		}
+
+		log.Info().Any("result", res).Msg("Outbox events relayed")
+		return
+	}
+
+	shutdownSignals := []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGINT}
+	if runtime.GOOS == "windows" {
+		shutdownSignals = []os.Signal{os.Interrupt}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), shutdownSignals...)
+	defer stop()
+
+	log.Info().Dur("interval", *interval).Int("batch_size", *batchSize).Msg("Outbox relay is running")
+	relay.Run(ctx, *interval)
+	log.Info().Msg("Outbox relay stopped")
+}
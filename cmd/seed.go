@@ -19,7 +19,7 @@ func RunSeed(cmd *flag.FlagSet, args []string) {
 	}
 
 	adapter.Adapters.Sync(
-		adapter.WithMultifinanceMySQL(),
+		adapter.WithMultifinanceDB(),
 	)
 
 	defer func() {
@@ -28,5 +28,5 @@ func RunSeed(cmd *flag.FlagSet, args []string) {
 		}
 	}()
 
-	seeds.Execute(adapter.Adapters.MultifinanceMysql, *table, *total)
+	seeds.Execute(adapter.Adapters.MultifinanceDB, *table, *total)
 }
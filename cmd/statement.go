@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	customerRepository "github.com/hilmiikhsan/multifinance-service/internal/module/customer/repository"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/dto"
+	statementRepository "github.com/hilmiikhsan/multifinance-service/internal/module/statement/repository"
+	statementService "github.com/hilmiikhsan/multifinance-service/internal/module/statement/service"
+	"github.com/rs/zerolog/log"
+)
+
+// RunStatement issues the statements of every month from -from to -to. Months
+// run oldest first so each opening balance can carry over the closing balance
+// of the month before; statements that already exist are left as they are.
+func RunStatement(cmd *flag.FlagSet, args []string) {
+	var (
+		from       = cmd.String("from", "", "first month to issue, YYYY-MM")
+		to         = cmd.String("to", "", "last month to issue, YYYY-MM, defaults to -from")
+		customerID = cmd.Int("customer_id", 0, "only issue statements of this customer, 0 for all customers")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	if *to == "" {
+		*to = *from
+	}
+
+	start, err := time.Parse(constants.PeriodFormat, *from)
+	if err != nil {
+		log.Fatal().Err(err).Str("from", *from).Msg("Invalid -from month, expected YYYY-MM")
+	}
+
+	end, err := time.Parse(constants.PeriodFormat, *to)
+	if err != nil || end.Before(start) {
+		log.Fatal().Err(err).Str("to", *to).Msg("Invalid -to month, expected YYYY-MM not before -from")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithMultifinanceDB(),
+	)
+
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Fatal().Err(err).Msg("Error while closing database connection")
+		}
+	}()
+
+	generator := statementService.NewStatementService(
+		statementRepository.NewStatementRepository(adapter.Adapters.MultifinanceDB),
+		customerRepository.NewCustomerRepository(adapter.Adapters.MultifinanceDB),
+	)
+
+	for month := start; !month.After(end); month = month.AddDate(0, 1, 0) {
+		res, err := generator.GenerateStatements(context.Background(), &dto.GenerateStatementsRequest{
+			Period:     month.Format(constants.PeriodFormat),
+			CustomerID: *customerID,
+		})
+		if err != nil {
+			log.Error().Err(err).Str("period", month.Format(constants.PeriodFormat)).Msg("Failed to issue statements")
+			return
+		}
+
+		log.Info().Any("result", res).Msg("Statements issued")
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	webhookRepository "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/repository"
+	webhookService "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/service"
+	"github.com/hilmiikhsan/multifinance-service/pkg/webhook"
+	"github.com/rs/zerolog/log"
+)
+
+// RunWebhookDispatch sends the queued partner webhooks until it is stopped.
+// Several dispatchers can run side by side, each claims its own deliveries.
+func RunWebhookDispatch(cmd *flag.FlagSet, args []string) {
+	var (
+		envs      = config.Envs.Webhook
+		interval  = cmd.Duration("interval", time.Duration(envs.IntervalMs)*time.Millisecond, "wait between polls once the dispatcher has caught up")
+		batchSize = cmd.Int("batch_size", envs.BatchSize, "deliveries claimed per batch")
+		once      = cmd.Bool("once", false, "dispatch a single batch and exit")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	if *batchSize < 1 {
+		log.Fatal().Int("batch_size", *batchSize).Msg("Invalid -batch_size, expected at least 1")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithMultifinanceDB(),
+	)
+
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Error().Err(err).Msg("Error while closing adapters")
+		}
+	}()
+
+	dispatcher := webhookService.NewDispatcherService(
+		adapter.Adapters.MultifinanceDB,
+		webhookRepository.NewWebhookRepository(adapter.Adapters.MultifinanceDB),
+		webhook.NewSender(time.Duration(envs.TimeoutSeconds)*time.Second),
+		webhook.Backoff{
+			Base:        time.Duration(envs.BackoffBaseSeconds) * time.Second,
+			Max:         time.Duration(envs.BackoffMaxSeconds) * time.Second,
+			MaxAttempts: envs.MaxAttempts,
+		},
+		*batchSize,
+		time.Duration(envs.LeaseSeconds)*time.Second,
+	)
+
+	if *once {
+		res, err := dispatcher.DispatchDueDeliveries(context.Background())
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to dispatch webhook deliveries")
+			return
+		}
+
+		log.Info().Any("result", res).Msg("Webhook deliveries dispatched")
+		return
+	}
+
+	shutdownSignals := []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGINT}
+	if runtime.GOOS == "windows" {
+		shutdownSignals = []os.Signal{os.Interrupt}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), shutdownSignals...)
+	defer stop()
+
+	log.Info().Dur("interval", *interval).Int("batch_size", *batchSize).Msg("Webhook dispatcher is running")
+	dispatcher.Run(ctx, *interval)
+	log.Info().Msg("Webhook dispatcher stopped")
+}
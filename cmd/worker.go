@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	customerRepository "github.com/hilmiikhsan/multifinance-service/internal/module/customer/repository"
+	jobRepository "github.com/hilmiikhsan/multifinance-service/internal/module/job/repository"
+	jobService "github.com/hilmiikhsan/multifinance-service/internal/module/job/service"
+	notificationRepository "github.com/hilmiikhsan/multifinance-service/internal/module/notification/repository"
+	notificationService "github.com/hilmiikhsan/multifinance-service/internal/module/notification/service"
+	statementDto "github.com/hilmiikhsan/multifinance-service/internal/module/statement/dto"
+	statementRepository "github.com/hilmiikhsan/multifinance-service/internal/module/statement/repository"
+	statementService "github.com/hilmiikhsan/multifinance-service/internal/module/statement/service"
+	"github.com/hilmiikhsan/multifinance-service/pkg/export"
+	"github.com/hilmiikhsan/multifinance-service/pkg/scheduler"
+	"github.com/rs/zerolog/log"
+)
+
+// RunWorker runs the scheduled jobs until it is stopped. Every replica can run
+// a worker, each scheduled run is claimed in Redis by one of them. On shutdown
+// no new run starts and the runs in flight are left to finish.
+func RunWorker(cmd *flag.FlagSet, args []string) {
+	var (
+		hostname, _ = os.Hostname()
+		instance    = cmd.String("instance", fmt.Sprintf("%s-%d", hostname, os.Getpid()), "name of this worker in the job history")
+		run         = cmd.String("run", "", "run this job once right away and exit")
+		list        = cmd.Bool("list", false, "list the scheduled jobs and exit")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithMultifinanceDB(),
+		adapter.WithMultifinanceRedis(),
+	)
+
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Error().Err(err).Msg("Error while closing adapters")
+		}
+	}()
+
+	location := export.LookupLocale(export.LocaleID).Location
+
+	jobs := scheduler.New(
+		scheduler.NewRedisLocker(adapter.Adapters.MultifinanceRedis, *instance),
+		jobService.NewJobRunRecorder(jobRepository.NewJobRunRepository(adapter.Adapters.MultifinanceDB)),
+		*instance,
+		location,
+	)
+
+	for _, job := range workerJobs(location) {
+		if job.Spec == "" {
+			log.Info().Str("job", job.Name).Msg("Job has no schedule, skipping")
+			continue
+		}
+
+		if err := jobs.Add(job); err != nil {
+			log.Fatal().Err(err).Str("job", job.Name).Msg("Invalid job")
+		}
+	}
+
+	if *list {
+		for _, job := range jobs.Jobs() {
+			log.Info().Str("job", job.Name).Str("schedule", job.Spec).Dur("timeout", job.Timeout).Msg("Scheduled job")
+		}
+		return
+	}
+
+	if *run != "" {
+		res, err := jobs.RunNow(context.Background(), *run)
+		if err != nil {
+			log.Error().Err(err).Str("job", *run).Msg("Failed to run job")
+			return
+		}
+
+		if res.Err != nil {
+			log.Error().Err(res.Err).Str("job", *run).Msg("Job failed")
+			return
+		}
+
+		log.Info().Str("job", *run).Any("result", res.Result).Msg("Job finished")
+		return
+	}
+
+	shutdownSignals := []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGINT}
+	if runtime.GOOS == "windows" {
+		shutdownSignals = []os.Signal{os.Interrupt}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), shutdownSignals...)
+	defer stop()
+
+	log.Info().Str("instance", *instance).Int("jobs", len(jobs.Jobs())).Msg("Worker is running")
+	jobs.Start(ctx)
+	log.Info().Msg("Worker stopped")
+}
+
+// workerJobs lists the recurring work of the service. Jobs are safe to run
+// again for the same day or month, so a run lost to a crash can be redone
+// with -run.
+//
+// Some work stays out of the schedule on purpose:
+//   - outbox-relay, webhook-dispatch and export-worker poll every few seconds
+//     and share their rows between replicas with leases. A cron run is claimed
+//     by a single replica at most once a minute, which would add latency and
+//     leave one replica doing all the work, so they keep their own processes.
+//   - notification-reminder sends the same reminders as the
+//     installment-reminder job. It stays a command to send them by hand, for
+//     a different -days or after an outage.
+//   - Overdue marking has nothing to update, installments are derived from
+//     the schedule of the transaction and no installment state is stored.
+//   - OTP purging has nothing to purge, the service issues no OTPs.
+func workerJobs(location *time.Location) []scheduler.Job {
+	var (
+		envs = config.Envs
+		db   = adapter.Adapters.MultifinanceDB
+	)
+
+	email, sms := notificationSenders()
+	reminders := notificationService.NewNotificationService(notificationRepository.NewNotificationRepository(db), email, sms)
+
+	statements := statementService.NewStatementService(
+		statementRepository.NewStatementRepository(db),
+		customerRepository.NewCustomerRepository(db),
+	)
+
+	return []scheduler.Job{
+		{
+			Name:    constants.JobInstallmentReminder,
+			Spec:    envs.Worker.ReminderSchedule,
+			Timeout: time.Hour,
+			Run: func(ctx context.Context) (any, error) {
+				return reminders.SendDueReminders(ctx, envs.Notification.ReminderDaysAhead)
+			},
+		},
+		{
+			Name:    constants.JobMonthlyStatement,
+			Spec:    envs.Worker.StatementSchedule,
+			Timeout: 6 * time.Hour,
+			Run: func(ctx context.Context) (any, error) {
+				now := time.Now().In(location)
+				period := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, location).AddDate(0, -1, 0)
+
+				return statements.GenerateStatements(ctx, &statementDto.GenerateStatementsRequest{
+					Period: period.Format(constants.PeriodFormat),
+				})
+			},
+		},
+	}
+}
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	CreditScoreTriggerRegistration = "registration"
+	CreditScoreTriggerTransaction  = "transaction"
+)
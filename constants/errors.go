@@ -16,4 +16,44 @@ var (
 	ErrInvalidOrCreditLimit       = "Invalid tenor or credit limit"
 	ErrTransactionNotFound        = "Transaction not found"
 	ErrParamIdIsRequired          = "Param id is required"
+	ErrNikIsNotValid              = "NIK is not valid"
+	ErrNikBirthDateMismatch       = "NIK does not match birth date"
+	ErrCustomerNotEligible        = "Customer is not eligible for financing"
+	ErrDebtToIncomeRatioExceeded  = "Monthly installments exceed the allowed share of income"
+	ErrCreditScoreTooLow          = "Credit score is too low for financing"
+	ErrAccountUnderReview         = "Account is under manual review"
+	ErrAccountRejected            = "Account has been rejected after review"
+	ErrWatchlistEntryNotFound     = "Watchlist entry not found"
+	ErrWatchlistEntryExists       = "Watchlist entry already exists"
+	ErrFraudReviewNotFound        = "Fraud review not found"
+	ErrFraudReviewAlreadyDecided  = "Fraud review has already been decided"
+	ErrInvalidStaffKey            = "Invalid staff key"
+	ErrVelocityLimitExceeded      = "Too many transactions in a short period, please try again later"
+	ErrInvalidDateRange           = "Start date must not be after end date"
+	ErrInvalidAmountRange         = "Minimum amount must not be above maximum amount"
+	ErrInvalidCursor              = "Invalid or expired cursor"
+	ErrCursorSortUnsupported      = "Cursor pagination only supports sorting by created_at"
+	ErrExportTooLarge             = "Too many transactions to export, please narrow the filters"
+	ErrExportNotFound             = "Export not found"
+	ErrExportNotReady             = "Export is not ready yet"
+	ErrDocumentNotFound           = "Document not found"
+	ErrContractNotIssued          = "Contract is not issued yet, please try again shortly"
+	ErrTransactionNotActive       = "Only an active transaction can be cancelled or paid off"
+	ErrInvalidStatementPeriod     = "Statement period must be a month in YYYY-MM format"
+	ErrStatementPeriodNotClosed   = "Statements are only issued once the month has ended"
+	ErrStatementNotFound          = "Statement not found"
+	ErrInvalidPartnerKey          = "Invalid partner key"
+	ErrPartnerNotFound            = "Partner not found"
+	ErrPartnerCodeExists          = "Partner code is already registered"
+	ErrWebhookSubscriptionMissing = "Webhook subscription not found"
+	ErrWebhookDeliveryNotFound    = "Webhook delivery not found"
+	ErrWebhookDeliveryPending     = "Webhook delivery is still being retried"
+	ErrNotificationNotFound       = "Notification not found"
+	ErrServiceNotReady            = "Service is not ready"
+	ErrDuplicateEntry             = "Data already exists"
+	ErrRowIsReferenced            = "Data is still in use and cannot be removed"
+	ErrReferencedRowMissing       = "Referenced data does not exist"
+	ErrConcurrentUpdate           = "Your request conflicted with another request, please try again"
+	ErrLockWaitTimeout            = "Your request waited too long for another request, please try again"
+	ErrDataTooLong                = "Data is too long"
 )
@@ -0,0 +1,16 @@
+package constants
+
+const (
+	AggregateTypeCustomer = "customer"
+
+	EventCustomerRegistered   = "customer.registered"
+	EventCreditLimitsAssigned = "customer.credit_limits_assigned"
+	EventTransactionBooked    = "transaction.booked"
+
+	CreditLimitTriggerRegistration = "registration"
+
+	// payload schema versions currently written for each event type
+	EventCustomerRegisteredVersion   = 1
+	EventCreditLimitsAssignedVersion = 1
+	EventTransactionBookedVersion    = 1
+)
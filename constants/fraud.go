@@ -0,0 +1,33 @@
+package constants
+
+const (
+	HeaderDeviceID = "X-Device-ID"
+	HeaderStaffKey = "X-Staff-Key"
+	HeaderStaffID  = "X-Staff-ID"
+	HeaderChannel  = "X-Channel"
+
+	WatchlistTypeNik      = "nik"
+	WatchlistTypeEmail    = "email"
+	WatchlistTypePhone    = "phone"
+	WatchlistTypeDeviceID = "device_id"
+
+	CustomerReviewStatusClear         = "clear"
+	CustomerReviewStatusPendingReview = "pending_review"
+	CustomerReviewStatusRejected      = "rejected"
+
+	FraudReviewStatusPending  = "pending"
+	FraudReviewStatusApproved = "approved"
+	FraudReviewStatusRejected = "rejected"
+
+	FraudTriggerRegistration = "registration"
+	FraudTriggerTransaction  = "transaction"
+
+	FraudReasonWatchlistPrefix      = "watchlist_"
+	FraudReasonDuplicateKtpPhoto    = "duplicate_ktp_photo"
+	FraudReasonDuplicateSelfiePhoto = "duplicate_selfie_photo"
+
+	FraudEventVelocityBreach = "velocity_breach"
+
+	PhotoKtp    = "ktp"
+	PhotoSelfie = "selfie"
+)
@@ -0,0 +1,11 @@
+package constants
+
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusNotReady = "not_ready"
+
+	HealthCheckDB         = "database"
+	HealthCheckRedis      = "redis"
+	HealthCheckMigrations = "migrations"
+	HealthCheckShutdown   = "shutdown"
+)
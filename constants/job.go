@@ -0,0 +1,10 @@
+package constants
+
+const (
+	JobRunStatusRunning   = "running"
+	JobRunStatusSucceeded = "succeeded"
+	JobRunStatusFailed    = "failed"
+
+	JobInstallmentReminder = "installment-reminder"
+	JobMonthlyStatement    = "monthly-statement"
+)
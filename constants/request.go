@@ -0,0 +1,9 @@
+package constants
+
+const (
+	HeaderRequestID   = "X-Request-ID"
+	LogFieldRequestID = "request_id"
+
+	// incoming request IDs longer than this are replaced
+	MaxRequestIDLength = 128
+)
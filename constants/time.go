@@ -3,4 +3,5 @@ package constants
 const (
 	DateFormat     = "2006-01-02"
 	DateTimeFormat = "2006-01-02 15:04:05"
+	PeriodFormat   = "2006-01"
 )
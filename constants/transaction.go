@@ -0,0 +1,20 @@
+package constants
+
+const (
+	TransactionStatusActive    = "active"
+	TransactionStatusPaidOff   = "paid_off"
+	TransactionStatusCancelled = "cancelled"
+)
+
+const (
+	ExportStatusPending = "pending"
+	ExportStatusRunning = "running"
+	ExportStatusDone    = "done"
+	ExportStatusFailed  = "failed"
+)
+
+const (
+	StatementLineBooking = "booking"
+	StatementLineFee     = "fee"
+	StatementLinePayment = "payment"
+)
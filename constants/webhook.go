@@ -0,0 +1,14 @@
+package constants
+
+const (
+	HeaderPartnerKey  = "X-Partner-Key"
+	HeaderPartnerCode = "X-Partner-Code"
+
+	WebhookEventContractCreated   = "contract.created"
+	WebhookEventContractCancelled = "contract.cancelled"
+	WebhookEventContractPaidOff   = "contract.paid_off"
+
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusDelivered = "delivered"
+	WebhookDeliveryStatusDead      = "dead"
+)
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+)
+
+// Migrations holds the goose migrations of every dialect, so the binary knows
+// which versions the database should be at without the files next to it.
+//
+//go:embed migrations/*/*.sql
+var Migrations embed.FS
+
+// MigrationsDir returns the directory in Migrations with the migrations of
+// the driver. Both dialects have the same versions.
+func MigrationsDir(driverName string) string {
+	if dialect.IsPostgres(driverName) {
+		return path.Join("migrations", dialect.Postgres)
+	}
+
+	return path.Join("migrations", dialect.MySQL)
+}
+
+// MigrationVersions returns the versions of the migrations of the driver in
+// ascending order, the version is the number before the first underscore of
+// the file name.
+func MigrationVersions(driverName string) ([]int64, error) {
+	dir := MigrationsDir(driverName)
+
+	files, err := fs.Glob(Migrations, path.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]int64, 0, len(files))
+	for _, file := range files {
+		name := path.Base(file)
+		prefix, _, _ := strings.Cut(name, "_")
+
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no version: %w", name, err)
+		}
+
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	return versions, nil
+}
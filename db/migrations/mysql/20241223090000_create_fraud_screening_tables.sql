@@ -0,0 +1,82 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE customers
+    ADD COLUMN phone_number VARCHAR(20) NULL AFTER email,
+    ADD COLUMN device_id VARCHAR(100) NULL AFTER phone_number,
+    ADD COLUMN ktp_photo_hash CHAR(64) NULL AFTER ktp_photo_path,
+    ADD COLUMN ktp_photo_phash BIGINT NULL AFTER ktp_photo_hash,
+    ADD COLUMN selfie_photo_hash CHAR(64) NULL AFTER selfie_photo_path,
+    ADD COLUMN selfie_photo_phash BIGINT NULL AFTER selfie_photo_hash,
+    ADD COLUMN review_status VARCHAR(20) NOT NULL DEFAULT 'clear' AFTER district_code;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_customers_ktp_photo_hash ON customers (ktp_photo_hash);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_customers_selfie_photo_hash ON customers (selfie_photo_hash);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS watchlist_entries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    entry_type VARCHAR(20) NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    created_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_watchlist_type_value (entry_type, value)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS fraud_reviews (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    trigger_event VARCHAR(30) NOT NULL,
+    reasons JSON NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    decision_note VARCHAR(255) NULL,
+    reviewed_by VARCHAR(100) NULL,
+    reviewed_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_fraud_reviews_status_created_at ON fraud_reviews (status, created_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_fraud_reviews_customer_id_status ON fraud_reviews (customer_id, status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS fraud_reviews;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS watchlist_entries;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX idx_customers_selfie_photo_hash ON customers;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX idx_customers_ktp_photo_hash ON customers;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE customers
+    DROP COLUMN phone_number,
+    DROP COLUMN device_id,
+    DROP COLUMN ktp_photo_hash,
+    DROP COLUMN ktp_photo_phash,
+    DROP COLUMN selfie_photo_hash,
+    DROP COLUMN selfie_photo_phash,
+    DROP COLUMN review_status;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS customer_statements (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    period CHAR(7) NOT NULL,
+    opening_balance DECIMAL(15,2) NOT NULL,
+    new_bookings DECIMAL(15,2) NOT NULL,
+    payments DECIMAL(15,2) NOT NULL,
+    fees DECIMAL(15,2) NOT NULL,
+    closing_balance DECIMAL(15,2) NOT NULL,
+    statement_lines JSON NOT NULL,
+    issued_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_customer_statements_customer_id_period (customer_id, period),
+    FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- an issued statement is never rewritten, a correction shows up in a later month
+-- +goose StatementBegin
+CREATE TRIGGER trg_customer_statements_immutable BEFORE UPDATE ON customer_statements
+FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'customer statements cannot be changed after issue';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trg_customer_statements_immutable;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS customer_statements;
+-- +goose StatementEnd
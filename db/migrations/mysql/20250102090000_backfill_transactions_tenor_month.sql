@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- contracts booked before tenor_month existed are 0, the installment is the
+-- price plus interest spread over the tenor
+UPDATE transactions
+SET tenor_month = ROUND((on_the_road_price + interest_amount) / installment_amount)
+WHERE tenor_month = 0
+    AND on_the_road_price IS NOT NULL
+    AND interest_amount IS NOT NULL
+    AND installment_amount > 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- a backfilled tenor cannot be told apart from a booked one, so it is kept
+SELECT 1;
+-- +goose StatementEnd
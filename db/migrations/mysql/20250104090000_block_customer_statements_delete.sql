@@ -0,0 +1,12 @@
+-- +goose Up
+-- an issued statement is not removed either; removing the customer still takes
+-- the statements along, MySQL fires no triggers for cascaded deletes
+-- +goose StatementBegin
+CREATE TRIGGER trg_customer_statements_undeletable BEFORE DELETE ON customer_statements
+FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'customer statements cannot be deleted after issue';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trg_customer_statements_undeletable;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE customers
+    ADD COLUMN gender CHAR(1) NULL,
+    ADD COLUMN province_code CHAR(2) NULL,
+    ADD COLUMN regency_code CHAR(4) NULL,
+    ADD COLUMN district_code CHAR(6) NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_customers_regency_code ON customers (regency_code);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_customers_regency_code;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE customers
+    DROP COLUMN gender,
+    DROP COLUMN province_code,
+    DROP COLUMN regency_code,
+    DROP COLUMN district_code;
+-- +goose StatementEnd
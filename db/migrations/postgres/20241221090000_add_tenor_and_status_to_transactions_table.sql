@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE transactions
+    ADD COLUMN tenor_month INT NOT NULL DEFAULT 0,
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_transactions_customer_id_status ON transactions (customer_id, status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_customer_id_status;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE transactions
+    DROP COLUMN tenor_month,
+    DROP COLUMN status;
+-- +goose StatementEnd
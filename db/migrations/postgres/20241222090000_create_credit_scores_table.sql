@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS credit_scores (
+    id BIGSERIAL PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    scorecard_version VARCHAR(20) NOT NULL,
+    trigger_event VARCHAR(30) NOT NULL,
+    score INT NOT NULL,
+    grade VARCHAR(5) NOT NULL,
+    reason_codes JSONB NOT NULL,
+    inputs JSONB NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_credit_scores_customer_id_created_at ON credit_scores (customer_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS credit_scores;
+-- +goose StatementEnd
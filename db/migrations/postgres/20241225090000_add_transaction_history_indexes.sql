@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX idx_transactions_customer_id_created_at ON transactions (customer_id, created_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_transactions_customer_id_on_the_road_price ON transactions (customer_id, on_the_road_price);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_transactions_customer_id_tenor_month ON transactions (customer_id, tenor_month);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_customer_id_tenor_month;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_customer_id_on_the_road_price;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_customer_id_created_at;
+-- +goose StatementEnd
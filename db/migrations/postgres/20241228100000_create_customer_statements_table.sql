@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS customer_statements (
+    id BIGSERIAL PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    period CHAR(7) NOT NULL,
+    opening_balance NUMERIC(15,2) NOT NULL,
+    new_bookings NUMERIC(15,2) NOT NULL,
+    payments NUMERIC(15,2) NOT NULL,
+    fees NUMERIC(15,2) NOT NULL,
+    closing_balance NUMERIC(15,2) NOT NULL,
+    statement_lines JSONB NOT NULL,
+    issued_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_customer_statements_customer_id_period UNIQUE (customer_id, period),
+    FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- an issued statement is never rewritten, a correction shows up in a later month
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION customer_statements_immutable() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'customer statements cannot be changed after issue' USING ERRCODE = '45000';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER trg_customer_statements_immutable BEFORE UPDATE ON customer_statements
+FOR EACH ROW EXECUTE FUNCTION customer_statements_immutable();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trg_customer_statements_immutable ON customer_statements;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS customer_statements_immutable();
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS customer_statements;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE transaction_exports
+    ADD COLUMN attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN lease_until TIMESTAMPTZ NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- jobs left running by the old in-process runner have no lease to expire
+UPDATE transaction_exports SET status = 'pending' WHERE status = 'running';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_transaction_exports_status_lease_until ON transaction_exports (status, lease_until);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transaction_exports_status_lease_until;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE transaction_exports
+    DROP COLUMN attempts,
+    DROP COLUMN lease_until;
+-- +goose StatementEnd
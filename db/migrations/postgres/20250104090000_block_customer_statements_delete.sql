@@ -0,0 +1,46 @@
+-- +goose Up
+-- an issued statement is not removed either; removing the customer still takes
+-- the statements along, the cascade runs one trigger level down, as in MySQL
+-- where cascaded deletes fire no triggers
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION customer_statements_immutable() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        IF pg_trigger_depth() > 1 THEN
+            RETURN OLD;
+        END IF;
+
+        RAISE EXCEPTION 'customer statements cannot be deleted after issue' USING ERRCODE = '45000';
+    END IF;
+
+    RAISE EXCEPTION 'customer statements cannot be changed after issue' USING ERRCODE = '45000';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trg_customer_statements_immutable ON customer_statements;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER trg_customer_statements_immutable BEFORE UPDATE OR DELETE ON customer_statements
+FOR EACH ROW EXECUTE FUNCTION customer_statements_immutable();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trg_customer_statements_immutable ON customer_statements;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION customer_statements_immutable() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'customer statements cannot be changed after issue' USING ERRCODE = '45000';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER trg_customer_statements_immutable BEFORE UPDATE ON customer_statements
+FOR EACH ROW EXECUTE FUNCTION customer_statements_immutable();
+-- +goose StatementEnd
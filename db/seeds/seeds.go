@@ -81,7 +81,7 @@ func (s *Seed) customersSeed(total int) {
 		ktpPhotoPath := fmt.Sprintf("/path/to/ktp/user%d.jpg", i+1)
 		selfiePhotoPath := fmt.Sprintf("/path/to/selfie/user%d.jpg", i+1)
 
-		_, err := s.db.Exec(query, nik, email, password, fullName, legalName, birthPlace, birthDate, salary, ktpPhotoPath, selfiePhotoPath)
+		_, err := s.db.Exec(s.db.Rebind(query), nik, email, password, fullName, legalName, birthPlace, birthDate, salary, ktpPhotoPath, selfiePhotoPath)
 		if err != nil {
 			log.Error().Err(err).Msg("Error seeding customers table")
 			return
@@ -118,7 +118,7 @@ func (s *Seed) creditLimitsSeed() {
 		// Insert data for each tenor_month and limit_amount
 		for i := 0; i < len(tenorMonths); i++ {
 			query := `INSERT INTO credit_limits (customer_id, tenor_month, limit_amount) VALUES (?, ?, ?)`
-			_, err := s.db.Exec(query, customerId, tenorMonths[i], limitAmounts[i])
+			_, err := s.db.Exec(s.db.Rebind(query), customerId, tenorMonths[i], limitAmounts[i])
 			if err != nil {
 				log.Error().Err(err).Msg("Error seeding credit_limits table")
 				return
@@ -159,7 +159,7 @@ func (s *Seed) transactionsSeed() {
 
 		query := `INSERT INTO transactions (customer_id, contract_number, on_the_road_price, admin_fee, installment_amount, interest_amount, asset_name) 
                   VALUES (?, ?, ?, ?, ?, ?, ?)`
-		_, err := s.db.Exec(query, customerId, contractNumber, onTheRoadPrice, adminFee, installmentAmount, interestAmount, assetName)
+		_, err := s.db.Exec(s.db.Rebind(query), customerId, contractNumber, onTheRoadPrice, adminFee, installmentAmount, interestAmount, assetName)
 		if err != nil {
 			log.Error().Err(err).Msg("Error seeding transactions table")
 			return
@@ -2,14 +2,25 @@ CREATE TABLE IF NOT EXISTS customers (
     id BIGINT AUTO_INCREMENT PRIMARY KEY,
     nik VARCHAR(16) NOT NULL UNIQUE,
     email VARCHAR(255) NOT NULL UNIQUE,
+    phone_number VARCHAR(20) NULL,
+    device_id VARCHAR(100) NULL,
     password VARCHAR(255) NOT NULL,
     full_name VARCHAR(255) NOT NULL,
     legal_name VARCHAR(255) NOT NULL,
+    gender CHAR(1) NULL,
     birth_place VARCHAR(100),
     birth_date DATE,
     salary DECIMAL(15,2),
     ktp_photo_path VARCHAR(255),
+    ktp_photo_hash CHAR(64) NULL,
+    ktp_photo_phash BIGINT NULL,
     selfie_photo_path VARCHAR(255),
+    selfie_photo_hash CHAR(64) NULL,
+    selfie_photo_phash BIGINT NULL,
+    province_code CHAR(2) NULL,
+    regency_code CHAR(4) NULL,
+    district_code CHAR(6) NULL,
+    review_status VARCHAR(20) NOT NULL DEFAULT 'clear',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP NULL
@@ -24,21 +35,269 @@ CREATE TABLE credit_limits (
     FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS partners (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    api_key_hash CHAR(64) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_partners_code (code),
+    UNIQUE KEY uq_partners_api_key_hash (api_key_hash)
+);
+
 CREATE TABLE IF NOT EXISTS transactions (
     id BIGINT AUTO_INCREMENT PRIMARY KEY,
     customer_id BIGINT NOT NULL,
+    partner_id BIGINT NULL,
     contract_number VARCHAR(50) NOT NULL UNIQUE,
     on_the_road_price DECIMAL(15,2),
     admin_fee DECIMAL(15,2),
     installment_amount DECIMAL(15,2),
     interest_amount DECIMAL(15,2),
+    tenor_month INT NOT NULL DEFAULT 0,
     asset_name VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_transactions_partner_id FOREIGN KEY (partner_id) REFERENCES partners(id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS credit_scores (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    scorecard_version VARCHAR(20) NOT NULL,
+    trigger_event VARCHAR(30) NOT NULL,
+    score INT NOT NULL,
+    grade VARCHAR(5) NOT NULL,
+    reason_codes JSON NOT NULL,
+    inputs JSON NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS watchlist_entries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    entry_type VARCHAR(20) NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    created_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_watchlist_type_value (entry_type, value)
+);
+
+CREATE TABLE IF NOT EXISTS fraud_reviews (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    trigger_event VARCHAR(30) NOT NULL,
+    reasons JSON NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    decision_note VARCHAR(255) NULL,
+    reviewed_by VARCHAR(100) NULL,
+    reviewed_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS fraud_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    event_type VARCHAR(30) NOT NULL,
+    channel VARCHAR(30) NOT NULL,
+    detail JSON NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS transaction_exports (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    locale VARCHAR(10) NOT NULL,
+    filter JSON NOT NULL,
+    status ENUM('pending', 'running', 'done', 'failed') NOT NULL DEFAULT 'pending',
+    file_name VARCHAR(255) NULL,
+    row_count INT NOT NULL DEFAULT 0,
+    error_message VARCHAR(255) NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    lease_until TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP NULL,
+    FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS transaction_documents (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    transaction_id BIGINT NOT NULL,
+    document_type VARCHAR(30) NOT NULL,
+    template_version VARCHAR(20) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_transaction_documents_transaction_id_document_type (transaction_id, document_type),
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS transaction_payments (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    transaction_id BIGINT NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    paid_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS customer_statements (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    period CHAR(7) NOT NULL,
+    opening_balance DECIMAL(15,2) NOT NULL,
+    new_bookings DECIMAL(15,2) NOT NULL,
+    payments DECIMAL(15,2) NOT NULL,
+    fees DECIMAL(15,2) NOT NULL,
+    closing_balance DECIMAL(15,2) NOT NULL,
+    statement_lines JSON NOT NULL,
+    issued_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_customer_statements_customer_id_period (customer_id, period),
     FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE
 );
 
+CREATE TRIGGER trg_customer_statements_immutable BEFORE UPDATE ON customer_statements
+FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'customer statements cannot be changed after issue';
+
+CREATE TRIGGER trg_customer_statements_undeletable BEFORE DELETE ON customer_statements
+FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'customer statements cannot be deleted after issue';
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event_id CHAR(36) NOT NULL,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id VARCHAR(64) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    event_version INT NOT NULL,
+    payload JSON NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    published_at TIMESTAMP NULL DEFAULT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(255) NULL,
+    UNIQUE KEY uq_outbox_event_id (event_id)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    partner_id BIGINT NOT NULL,
+    url VARCHAR(255) NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    event_types JSON NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (partner_id) REFERENCES partners(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    subscription_id BIGINT NOT NULL,
+    event_id CHAR(36) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSON NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_status_code INT NULL,
+    last_error VARCHAR(255) NULL,
+    delivered_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_webhook_deliveries_subscription_id_event_id (subscription_id, event_id),
+    FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    delivery_id BIGINT NOT NULL,
+    attempt INT NOT NULL,
+    status_code INT NULL,
+    response_body VARCHAR(1024) NULL,
+    error_message VARCHAR(255) NULL,
+    duration_ms INT NOT NULL,
+    attempted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (delivery_id) REFERENCES webhook_deliveries(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    customer_id BIGINT PRIMARY KEY,
+    locale VARCHAR(5) NOT NULL DEFAULT 'id',
+    email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    sms_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    in_app_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS notification_logs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    template VARCHAR(50) NOT NULL,
+    reference VARCHAR(100) NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    locale VARCHAR(5) NOT NULL,
+    recipient VARCHAR(255) NULL,
+    subject VARCHAR(255) NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    error_message VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP NULL,
+    UNIQUE KEY uq_notification_logs_customer_id_template_reference_channel (customer_id, template, reference, channel),
+    FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS notification_inbox (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    read_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS job_runs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    job_name VARCHAR(100) NOT NULL,
+    instance VARCHAR(100) NOT NULL,
+    scheduled_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    result JSON NULL,
+    error_message VARCHAR(255) NULL,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NULL,
+    duration_ms BIGINT NULL
+);
+
 CREATE INDEX idx_customers_nik ON customers (nik);
+CREATE INDEX idx_customers_regency_code ON customers (regency_code);
+CREATE INDEX idx_customers_ktp_photo_hash ON customers (ktp_photo_hash);
+CREATE INDEX idx_customers_selfie_photo_hash ON customers (selfie_photo_hash);
 CREATE INDEX idx_credit_limits_customer_id ON credit_limits (customer_id);
 CREATE INDEX idx_transactions_customer_id ON transactions (customer_id);
+CREATE INDEX idx_transactions_customer_id_status ON transactions (customer_id, status);
+CREATE INDEX idx_transactions_customer_id_created_at ON transactions (customer_id, created_at);
+CREATE INDEX idx_transactions_customer_id_on_the_road_price ON transactions (customer_id, on_the_road_price);
+CREATE INDEX idx_transactions_customer_id_tenor_month ON transactions (customer_id, tenor_month);
+CREATE INDEX idx_credit_scores_customer_id_created_at ON credit_scores (customer_id, created_at);
+CREATE INDEX idx_fraud_reviews_status_created_at ON fraud_reviews (status, created_at);
+CREATE INDEX idx_fraud_reviews_customer_id_status ON fraud_reviews (customer_id, status);
+CREATE INDEX idx_fraud_events_customer_id_created_at ON fraud_events (customer_id, created_at);
+CREATE INDEX idx_transaction_exports_customer_id_created_at ON transaction_exports (customer_id, created_at);
+CREATE INDEX idx_transaction_exports_status_lease_until ON transaction_exports (status, lease_until);
+CREATE INDEX idx_transaction_payments_transaction_id_paid_at ON transaction_payments (transaction_id, paid_at);
+CREATE INDEX idx_outbox_published_at_id ON outbox (published_at, id);
+CREATE INDEX idx_webhook_deliveries_status_next_attempt_at ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX idx_webhook_delivery_attempts_delivery_id ON webhook_delivery_attempts (delivery_id);
+CREATE INDEX idx_notification_logs_customer_id_created_at ON notification_logs (customer_id, created_at);
+CREATE INDEX idx_notification_inbox_customer_id_id ON notification_inbox (customer_id, id);
+CREATE INDEX idx_job_runs_job_name_started_at ON job_runs (job_name, started_at);
@@ -3,17 +3,24 @@ package adapter
 import (
 	"errors"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/gofiber/fiber/v2"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
 )
 
 var (
 	Adapters *Adapter
 )
 
+// grpcStopTimeout bounds how long Unsync waits for the calls in flight on the
+// gRPC server before it closes them.
+const grpcStopTimeout = 10 * time.Second
+
 type Option func(adapter *Adapter)
 
 //go:generate mockgen -source=adapters.go -destination=service_validator_mock_test.go -package=adapter
@@ -24,11 +31,24 @@ type Validator interface {
 type Adapter struct {
 	// Driving Adapters
 	RestServer *fiber.App
+	GrpcServer *grpc.Server
 
 	//Driven Adapters
-	MultifinanceMysql *sqlx.DB
+	MultifinanceDB    *sqlx.DB
 	MultifinanceRedis *redis.Client
 	Validator         Validator // *validator.Validator
+
+	draining atomic.Bool
+}
+
+// Drain marks the adapters as shutting down, the readiness check fails from
+// then on so load balancers stop sending requests before Unsync runs.
+func (a *Adapter) Drain() {
+	a.draining.Store(true)
+}
+
+func (a *Adapter) Draining() bool {
+	return a.draining.Load()
 }
 
 func (a *Adapter) Sync(opts ...Option) error {
@@ -38,15 +58,15 @@ func (a *Adapter) Sync(opts ...Option) error {
 		opt(a)
 	}
 
-	if a.MultifinanceMysql == nil {
-		errs = append(errs, "Multifinance Mysql not initialized")
+	if a.MultifinanceDB == nil {
+		errs = append(errs, "Multifinance DB not initialized")
 	}
 
 	if a.MultifinanceRedis == nil {
 		errs = append(errs, "Multifinance Redis not initialized")
 	}
 
-	if a.RestServer == nil {
+	if a.RestServer == nil && a.GrpcServer == nil {
 		errs = append(errs, "No server initialized")
 	}
 
@@ -67,11 +87,27 @@ func (a *Adapter) Unsync() error {
 		log.Info().Msg("Rest server disconnected")
 	}
 
-	if a.MultifinanceMysql != nil {
-		if err := a.MultifinanceMysql.Close(); err != nil {
+	if a.GrpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			a.GrpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(grpcStopTimeout):
+			a.GrpcServer.Stop()
+			errs = append(errs, "gRPC server did not stop in time, calls in flight were closed")
+		}
+		log.Info().Msg("gRPC server disconnected")
+	}
+
+	if a.MultifinanceDB != nil {
+		if err := a.MultifinanceDB.Close(); err != nil {
 			errs = append(errs, err.Error())
 		}
-		log.Info().Msg("Multifinance Mysql disconnected")
+		log.Info().Msg("Multifinance DB disconnected")
 	}
 
 	if a.MultifinanceRedis != nil {
@@ -0,0 +1,13 @@
+package adapter
+
+import (
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+)
+
+func WithGrpcServer(server *grpc.Server) Option {
+	log.Info().Msg("gRPC server connected")
+	return func(a *Adapter) {
+		a.GrpcServer = server
+	}
+}
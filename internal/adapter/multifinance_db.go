@@ -0,0 +1,60 @@
+package adapter
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"github.com/XSAM/otelsql"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// WithMultifinanceDB connects the database DB_DRIVER points at.
+func WithMultifinanceDB() Option {
+	switch config.Envs.DB.Driver {
+	case dialect.MySQL:
+		return WithMultifinanceMySQL()
+	case dialect.Postgres:
+		return WithMultifinancePostgres()
+	}
+
+	log.Fatal().Str("driver", config.Envs.DB.Driver).Msg("Unknown database driver, use mysql or postgres")
+	return nil
+}
+
+// openMultifinanceDB opens and checks the pool both database adapters share.
+func openMultifinanceDB(driverName, connectionString string, system attribute.KeyValue) (*sqlx.DB, error) {
+	// queries become child spans of the request or job that runs them
+	sqlDB, err := otelsql.Open(driverName, connectionString,
+		otelsql.WithAttributes(system),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			DisableErrSkip:       true,
+			OmitConnResetSession: true,
+			OmitRows:             true,
+			SpanFilter: func(ctx context.Context, _ otelsql.Method, _ string, _ []driver.NamedValue) bool {
+				return tracing.HasParent(ctx)
+			},
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	db := sqlx.NewDb(sqlDB, driverName)
+
+	db.SetMaxOpenConns(config.Envs.DB.MaxOpenCons)
+	db.SetMaxIdleConns(config.Envs.DB.MaxIdleCons)
+	db.SetConnMaxLifetime(time.Duration(config.Envs.DB.ConnMaxLifetime) * time.Second)
+
+	// Check connection
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
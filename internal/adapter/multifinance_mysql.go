@@ -2,12 +2,12 @@ package adapter
 
 import (
 	"fmt"
-	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
-	"github.com/jmoiron/sqlx"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect"
 	"github.com/rs/zerolog/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 func WithMultifinanceMySQL() Option {
@@ -18,30 +18,16 @@ func WithMultifinanceMySQL() Option {
 		dbHost := config.Envs.MultifinanceMysql.Host
 		dbPort := config.Envs.MultifinanceMysql.Port
 
-		dbMaxPoolSize := config.Envs.DB.MaxOpenCons
-		dbMaxIdleConns := config.Envs.DB.MaxIdleCons
-		dbConnMaxLifetime := config.Envs.DB.ConnMaxLifetime
-
 		connectionString := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&charset=utf8mb4&loc=UTC",
 			dbUser, dbPassword, dbHost, dbPort, dbName,
 		)
 
-		db, err := sqlx.Connect("mysql", connectionString)
-		if err != nil {
-			log.Fatal().Err(err).Msg("Error connecting to MySQL")
-		}
-
-		db.SetMaxOpenConns(dbMaxPoolSize)
-		db.SetMaxIdleConns(dbMaxIdleConns)
-		db.SetConnMaxLifetime(time.Duration(dbConnMaxLifetime) * time.Second)
-
-		// Check connection
-		err = db.Ping()
+		db, err := openMultifinanceDB(dialect.MySQL, connectionString, semconv.DBSystemMySQL)
 		if err != nil {
 			log.Fatal().Err(err).Msg("Error connecting to Multifinance MySQL")
 		}
 
-		a.MultifinanceMysql = db
+		a.MultifinanceDB = db
 		log.Info().Msg("Multifinance MySQL connected")
 	}
 }
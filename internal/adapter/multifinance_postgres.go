@@ -0,0 +1,35 @@
+package adapter
+
+import (
+	"fmt"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+	_ "github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func WithMultifinancePostgres() Option {
+	return func(a *Adapter) {
+		dbUser := config.Envs.MultifinancePostgres.Username
+		dbPassword := config.Envs.MultifinancePostgres.Password
+		dbName := config.Envs.MultifinancePostgres.Database
+		dbHost := config.Envs.MultifinancePostgres.Host
+		dbPort := config.Envs.MultifinancePostgres.Port
+		dbSslMode := config.Envs.MultifinancePostgres.SslMode
+
+		// timestamps are read and written in UTC, like loc=UTC does for MySQL
+		connectionString := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s connect_timeout=%d timezone=UTC",
+			dbHost, dbPort, dbUser, dbPassword, dbName, dbSslMode, config.Envs.DB.ConnectionTimeout,
+		)
+
+		db, err := openMultifinanceDB(dialect.Postgres, connectionString, semconv.DBSystemPostgreSQL)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Error connecting to Multifinance Postgres")
+		}
+
+		a.MultifinanceDB = db
+		log.Info().Msg("Multifinance Postgres connected")
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-redis/redis/v8"
 	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
 	"github.com/rs/zerolog/log"
 )
 
@@ -32,6 +33,8 @@ func WithMultifinanceRedis() Option {
 			log.Fatal().Err(err).Msg("Error connecting to Multifinance Redis")
 		}
 
+		rdb.AddHook(tracing.RedisHook{})
+
 		a.MultifinanceRedis = rdb
 		log.Info().Msg("Multifinance Redis connected")
 	}
@@ -20,17 +20,20 @@ type Config struct {
 		Environtment            string `env:"APP_ENV" env-default:"development"`
 		BaseURL                 string `env:"APP_BASE_URL" env-default:"http://localhost:9090"`
 		Port                    string `env:"APP_PORT" env-default:"9090"`
+		GrpcPort                string `env:"APP_GRPC_PORT" env-default:"7000" env-description:"port of the gRPC API, the gRPC server is off when empty"`
 		LogLevel                string `env:"APP_LOG_LEVEL" env-default:"debug"`
 		LogFile                 string `env:"APP_LOG_FILE" env-default:"./logs/app.log"`
 		LogFileWs               string `env:"APP_LOG_FILE_WS" env-default:"./logs/ws.log"`
+		RequestTimeoutSeconds   int    `env:"APP_REQUEST_TIMEOUT_SECONDS" env-default:"30" env-description:"how long a request may run before its queries are cancelled, 0 turns it off"`
 		LocalStoragePublicPath  string `env:"LOCAL_STORAGE_PUBLIC_PATH" env-default:"./storage/public"`
 		LocalStoragePrivatePath string `env:"LOCAL_STORAGE_PRIVATE_PATH" env-default:"./storage/private"`
 	}
 	DB struct {
-		ConnectionTimeout int `env:"DB_CONN_TIMEOUT" env-default:"30" env-description:"database timeout in seconds"`
-		MaxOpenCons       int `env:"DB_MAX_OPEN_CONS" env-default:"20" env-description:"database max open conn in seconds"`
-		MaxIdleCons       int `env:"DB_MAX_IdLE_CONS" env-default:"20" env-description:"database max idle conn in seconds"`
-		ConnMaxLifetime   int `env:"DB_CONN_MAX_LIFETIME" env-default:"0" env-description:"database conn max lifetime in seconds"`
+		Driver            string `env:"DB_DRIVER" env-default:"mysql" env-description:"database the repositories run on, mysql or postgres"`
+		ConnectionTimeout int    `env:"DB_CONN_TIMEOUT" env-default:"30" env-description:"database timeout in seconds"`
+		MaxOpenCons       int    `env:"DB_MAX_OPEN_CONS" env-default:"20" env-description:"database max open conn in seconds"`
+		MaxIdleCons       int    `env:"DB_MAX_IdLE_CONS" env-default:"20" env-description:"database max idle conn in seconds"`
+		ConnMaxLifetime   int    `env:"DB_CONN_MAX_LIFETIME" env-default:"0" env-description:"database conn max lifetime in seconds"`
 	}
 	Guard struct {
 		JwtPrivateKey             string `env:"JWT_PRIVATE_KEY" env-default:""`
@@ -45,6 +48,92 @@ type Config struct {
 		Database string `env:"MULTIFINANCE_MYSQL_DB" env-default:"multifinance"`
 		SslMode  string `env:"MULTIFINANCE_MYSQL_SSL_MODE" env-default:"disable"`
 	}
+	MultifinancePostgres struct {
+		Host     string `env:"MULTIFINANCE_POSTGRES_HOST" env-default:"localhost"`
+		Port     string `env:"MULTIFINANCE_POSTGRES_PORT" env-default:"5432"`
+		Username string `env:"MULTIFINANCE_POSTGRES_USER" env-default:"postgres"`
+		Password string `env:"MULTIFINANCE_POSTGRES_PASSWORD" env-default:"password"`
+		Database string `env:"MULTIFINANCE_POSTGRES_DB" env-default:"multifinance"`
+		SslMode  string `env:"MULTIFINANCE_POSTGRES_SSL_MODE" env-default:"disable"`
+	}
+	Eligibility struct {
+		MinAge           int `env:"ELIGIBILITY_MIN_AGE" env-default:"21" env-description:"minimum applicant age at application"`
+		MaxAgeAtTenorEnd int `env:"ELIGIBILITY_MAX_AGE_AT_TENOR_END" env-default:"60" env-description:"maximum applicant age when the final installment is due"`
+	}
+	Affordability struct {
+		MaxDebtToIncomeRatio float64 `env:"AFFORDABILITY_MAX_DEBT_TO_INCOME_RATIO" env-default:"0.3" env-description:"maximum share of monthly income spent on installments"`
+	}
+	Fraud struct {
+		StaffApiKey          string `env:"FRAUD_STAFF_API_KEY" env-default:"" env-description:"shared key for the back office fraud routes, the routes are closed when empty"`
+		PhotoHashMaxDistance int    `env:"FRAUD_PHOTO_HASH_MAX_DISTANCE" env-default:"6" env-description:"maximum differing bits for two photos to count as the same picture"`
+	}
+	Velocity struct {
+		RulesPath string `env:"VELOCITY_RULES_PATH" env-default:"" env-description:"path to the velocity rules file, the bundled rules are used when empty"`
+	}
+	Export struct {
+		MaxInlineRows     int `env:"EXPORT_MAX_INLINE_ROWS" env-default:"5000" env-description:"exports up to this many rows are streamed in the request, larger ones are queued for the export worker"`
+		MaxRows           int `env:"EXPORT_MAX_ROWS" env-default:"100000" env-description:"exports above this many rows are refused"`
+		Concurrency       int `env:"EXPORT_CONCURRENCY" env-default:"2" env-description:"exports one export worker writes at the same time"`
+		JobTimeoutSeconds int `env:"EXPORT_JOB_TIMEOUT_SECONDS" env-default:"1800" env-description:"how long one export may run before it is failed, a crashed worker's jobs are picked up again shortly after"`
+		MaxAttempts       int `env:"EXPORT_MAX_ATTEMPTS" env-default:"3" env-description:"claims of an export whose worker keeps dying before it is failed"`
+		IntervalMs        int `env:"EXPORT_INTERVAL_MS" env-default:"2000" env-description:"how long the export worker waits before looking for new exports once it is idle or busy"`
+	}
+	Document struct {
+		ContractTemplateVersion string `env:"DOCUMENT_CONTRACT_TEMPLATE_VERSION" env-default:"v1" env-description:"contract template used for new contracts, existing contracts keep their version"`
+		ConsumerGroup           string `env:"DOCUMENT_CONSUMER_GROUP" env-default:"contract" env-description:"consumer group the contract worker reads the event stream with"`
+		ConsumerBatchSize       int    `env:"DOCUMENT_CONSUMER_BATCH_SIZE" env-default:"20" env-description:"events read per poll"`
+		BlockMs                 int    `env:"DOCUMENT_BLOCK_MS" env-default:"5000" env-description:"how long a poll waits for new events"`
+	}
+	Summary struct {
+		CacheTTLSeconds int `env:"CUSTOMER_SUMMARY_CACHE_TTL_SECONDS" env-default:"300" env-description:"how long a cached dashboard summary is served, it is also dropped on every booking"`
+	}
+	Outbox struct {
+		RelayIntervalMs int    `env:"OUTBOX_RELAY_INTERVAL_MS" env-default:"1000" env-description:"how long the relay waits before looking for new events once it has caught up"`
+		RelayBatchSize  int    `env:"OUTBOX_RELAY_BATCH_SIZE" env-default:"100" env-description:"events published per relay transaction"`
+		StreamPrefix    string `env:"OUTBOX_STREAM_PREFIX" env-default:"multifinance:events:" env-description:"events go to the redis stream <prefix><aggregate type>"`
+		StreamMaxLen    int    `env:"OUTBOX_STREAM_MAX_LEN" env-default:"100000" env-description:"approximate number of entries kept per stream, 0 keeps every entry"`
+		MaxDeliveries   int    `env:"OUTBOX_MAX_DELIVERIES" env-default:"5" env-description:"deliveries of a failing event to a consumer group before it is moved to the <stream>:dead stream"`
+	}
+	Webhook struct {
+		TimeoutSeconds     int `env:"WEBHOOK_TIMEOUT_SECONDS" env-default:"10" env-description:"how long a partner endpoint has to answer a delivery"`
+		MaxAttempts        int `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"8" env-description:"attempts before a delivery is marked dead"`
+		BackoffBaseSeconds int `env:"WEBHOOK_BACKOFF_BASE_SECONDS" env-default:"30" env-description:"wait after the first failed attempt, doubled after every further one"`
+		BackoffMaxSeconds  int `env:"WEBHOOK_BACKOFF_MAX_SECONDS" env-default:"3600" env-description:"longest wait between two attempts"`
+		BatchSize          int `env:"WEBHOOK_BATCH_SIZE" env-default:"50" env-description:"deliveries claimed per dispatcher batch"`
+		LeaseSeconds       int `env:"WEBHOOK_LEASE_SECONDS" env-default:"60" env-description:"how long claimed deliveries are hidden from other dispatchers while they are sent"`
+		IntervalMs         int `env:"WEBHOOK_INTERVAL_MS" env-default:"1000" env-description:"how long the dispatcher waits before looking for due deliveries once it has caught up"`
+	}
+	Notification struct {
+		SMTPHost          string `env:"NOTIFICATION_SMTP_HOST" env-default:"" env-description:"SMTP server for email notifications, email is off when empty"`
+		SMTPPort          int    `env:"NOTIFICATION_SMTP_PORT" env-default:"587" env-description:"port of the SMTP server"`
+		SMTPUsername      string `env:"NOTIFICATION_SMTP_USERNAME" env-default:"" env-description:"SMTP login, the server is used without authentication when empty"`
+		SMTPPassword      string `env:"NOTIFICATION_SMTP_PASSWORD" env-default:"" env-description:"SMTP password"`
+		SMTPFrom          string `env:"NOTIFICATION_SMTP_FROM" env-default:"no-reply@multifinance.local" env-description:"sender address of email notifications"`
+		SMSGatewayURL     string `env:"NOTIFICATION_SMS_GATEWAY_URL" env-default:"" env-description:"endpoint of the SMS gateway, SMS is off when empty"`
+		SMSGatewayAPIKey  string `env:"NOTIFICATION_SMS_GATEWAY_API_KEY" env-default:"" env-description:"bearer key of the SMS gateway"`
+		SMSTimeoutSeconds int    `env:"NOTIFICATION_SMS_TIMEOUT_SECONDS" env-default:"10" env-description:"how long the SMS gateway has to answer"`
+		ReminderDaysAhead int    `env:"NOTIFICATION_REMINDER_DAYS_AHEAD" env-default:"3" env-description:"how many days before its due date an installment is reminded of"`
+		ConsumerGroup     string `env:"NOTIFICATION_CONSUMER_GROUP" env-default:"notification" env-description:"consumer group the notification worker reads the event stream with"`
+		ConsumerBatchSize int    `env:"NOTIFICATION_CONSUMER_BATCH_SIZE" env-default:"50" env-description:"events read per poll"`
+		BlockMs           int    `env:"NOTIFICATION_BLOCK_MS" env-default:"5000" env-description:"how long a poll waits for new events"`
+	}
+	Worker struct {
+		ReminderSchedule  string `env:"WORKER_REMINDER_SCHEDULE" env-default:"0 8 * * *" env-description:"cron expression, Jakarta time, of the installment reminders, empty turns the job off"`
+		StatementSchedule string `env:"WORKER_STATEMENT_SCHEDULE" env-default:"0 2 1 * *" env-description:"cron expression, Jakarta time, of the monthly statement run, empty turns the job off"`
+	}
+	Health struct {
+		CheckTimeoutMs int `env:"HEALTH_CHECK_TIMEOUT_MS" env-default:"2000" env-description:"how long each readiness check may take before it counts as failed"`
+		DrainSeconds   int `env:"HEALTH_DRAIN_SECONDS" env-default:"5" env-description:"how long the server keeps serving as not ready after a shutdown signal, so load balancers stop sending requests first"`
+	}
+	Tracing struct {
+		Exporter     string  `env:"TRACING_EXPORTER" env-default:"none" env-description:"where spans are sent: otlp, stdout or none"`
+		OTLPEndpoint string  `env:"TRACING_OTLP_ENDPOINT" env-default:"localhost:4317" env-description:"host:port of the OTLP gRPC collector"`
+		OTLPInsecure bool    `env:"TRACING_OTLP_INSECURE" env-default:"true" env-description:"send spans to the collector without TLS"`
+		SampleRatio  float64 `env:"TRACING_SAMPLE_RATIO" env-default:"1" env-description:"share of the traces started here that are kept, traces of callers keep their decision"`
+	}
+	Scoring struct {
+		ScorecardPath string `env:"SCORING_SCORECARD_PATH" env-default:"" env-description:"path to the scorecard file, the bundled scorecard is used when empty"`
+	}
 	RedisDB struct {
 		Host     string `env:"MULTIFINANCE_REDIS_HOST" env-default:"redis"`
 		Port     string `env:"MULTIFINANCE_REDIS_PORT" env-default:"6379"`
@@ -89,11 +178,14 @@ func (c *Configure) Initialize() {
 
 		Envs.App.Name = utils.GetEnv("APP_NAME", Envs.App.Name)
 		Envs.App.Port = utils.GetEnv("APP_PORT", Envs.App.Port)
+		Envs.App.GrpcPort = utils.GetEnv("APP_GRPC_PORT", Envs.App.GrpcPort)
 		Envs.App.LogLevel = utils.GetEnv("APP_LOG_LEVEL", Envs.App.LogLevel)
 		Envs.App.LogFile = utils.GetEnv("APP_LOG_FILE", Envs.App.LogFile)
 		Envs.App.LogFileWs = utils.GetEnv("APP_LOG_FILE_WS", Envs.App.LogFileWs)
+		Envs.App.RequestTimeoutSeconds = utils.GetIntEnv("APP_REQUEST_TIMEOUT_SECONDS", Envs.App.RequestTimeoutSeconds)
 		Envs.App.LocalStoragePublicPath = utils.GetEnv("LOCAL_STORAGE_PUBLIC_PATH", Envs.App.LocalStoragePublicPath)
 		Envs.App.LocalStoragePrivatePath = utils.GetEnv("LOCAL_STORAGE_PRIVATE_PATH", Envs.App.LocalStoragePrivatePath)
+		Envs.DB.Driver = utils.GetEnv("DB_DRIVER", Envs.DB.Driver)
 		Envs.DB.ConnectionTimeout = utils.GetIntEnv("DB_CONN_TIMEOUT", Envs.DB.ConnectionTimeout)
 		Envs.DB.MaxOpenCons = utils.GetIntEnv("DB_MAX_OPEN_CONS", Envs.DB.MaxOpenCons)
 		Envs.DB.MaxIdleCons = utils.GetIntEnv("DB_MAX_IdLE_CONS", Envs.DB.MaxIdleCons)
@@ -107,10 +199,66 @@ func (c *Configure) Initialize() {
 		Envs.MultifinanceMysql.Password = utils.GetEnv("MULTIFINANCE_MYSQL_PASSWORD", Envs.MultifinanceMysql.Password)
 		Envs.MultifinanceMysql.Database = utils.GetEnv("MULTIFINANCE_MYSQL_DB", Envs.MultifinanceMysql.Database)
 		Envs.MultifinanceMysql.SslMode = utils.GetEnv("MULTIFINANCE_MYSQL_SSL_MODE", Envs.MultifinanceMysql.SslMode)
+		Envs.MultifinancePostgres.Host = utils.GetEnv("MULTIFINANCE_POSTGRES_HOST", Envs.MultifinancePostgres.Host)
+		Envs.MultifinancePostgres.Port = utils.GetEnv("MULTIFINANCE_POSTGRES_PORT", Envs.MultifinancePostgres.Port)
+		Envs.MultifinancePostgres.Username = utils.GetEnv("MULTIFINANCE_POSTGRES_USER", Envs.MultifinancePostgres.Username)
+		Envs.MultifinancePostgres.Password = utils.GetEnv("MULTIFINANCE_POSTGRES_PASSWORD", Envs.MultifinancePostgres.Password)
+		Envs.MultifinancePostgres.Database = utils.GetEnv("MULTIFINANCE_POSTGRES_DB", Envs.MultifinancePostgres.Database)
+		Envs.MultifinancePostgres.SslMode = utils.GetEnv("MULTIFINANCE_POSTGRES_SSL_MODE", Envs.MultifinancePostgres.SslMode)
 		Envs.RedisDB.Host = utils.GetEnv("MULTIFINANCE_REDIS_HOST", Envs.RedisDB.Host)
 		Envs.RedisDB.Port = utils.GetEnv("MULTIFINANCE_REDIS_PORT", Envs.RedisDB.Port)
 		Envs.RedisDB.Password = utils.GetEnv("MULTIFINANCE_REDIS_PASSWORD", Envs.RedisDB.Password)
 		Envs.RedisDB.Database = utils.GetIntEnv("MULTIFINANCE_REDIS_DB", Envs.RedisDB.Database)
+		Envs.Eligibility.MinAge = utils.GetIntEnv("ELIGIBILITY_MIN_AGE", Envs.Eligibility.MinAge)
+		Envs.Eligibility.MaxAgeAtTenorEnd = utils.GetIntEnv("ELIGIBILITY_MAX_AGE_AT_TENOR_END", Envs.Eligibility.MaxAgeAtTenorEnd)
+		Envs.Affordability.MaxDebtToIncomeRatio = utils.GetFloatEnv("AFFORDABILITY_MAX_DEBT_TO_INCOME_RATIO", Envs.Affordability.MaxDebtToIncomeRatio)
+		Envs.Fraud.StaffApiKey = utils.GetEnv("FRAUD_STAFF_API_KEY", Envs.Fraud.StaffApiKey)
+		Envs.Fraud.PhotoHashMaxDistance = utils.GetIntEnv("FRAUD_PHOTO_HASH_MAX_DISTANCE", Envs.Fraud.PhotoHashMaxDistance)
+		Envs.Scoring.ScorecardPath = utils.GetEnv("SCORING_SCORECARD_PATH", Envs.Scoring.ScorecardPath)
+		Envs.Velocity.RulesPath = utils.GetEnv("VELOCITY_RULES_PATH", Envs.Velocity.RulesPath)
+		Envs.Export.MaxInlineRows = utils.GetIntEnv("EXPORT_MAX_INLINE_ROWS", Envs.Export.MaxInlineRows)
+		Envs.Export.MaxRows = utils.GetIntEnv("EXPORT_MAX_ROWS", Envs.Export.MaxRows)
+		Envs.Export.Concurrency = utils.GetIntEnv("EXPORT_CONCURRENCY", Envs.Export.Concurrency)
+		Envs.Export.JobTimeoutSeconds = utils.GetIntEnv("EXPORT_JOB_TIMEOUT_SECONDS", Envs.Export.JobTimeoutSeconds)
+		Envs.Export.MaxAttempts = utils.GetIntEnv("EXPORT_MAX_ATTEMPTS", Envs.Export.MaxAttempts)
+		Envs.Export.IntervalMs = utils.GetIntEnv("EXPORT_INTERVAL_MS", Envs.Export.IntervalMs)
+		Envs.Document.ContractTemplateVersion = utils.GetEnv("DOCUMENT_CONTRACT_TEMPLATE_VERSION", Envs.Document.ContractTemplateVersion)
+		Envs.Document.ConsumerGroup = utils.GetEnv("DOCUMENT_CONSUMER_GROUP", Envs.Document.ConsumerGroup)
+		Envs.Document.ConsumerBatchSize = utils.GetIntEnv("DOCUMENT_CONSUMER_BATCH_SIZE", Envs.Document.ConsumerBatchSize)
+		Envs.Document.BlockMs = utils.GetIntEnv("DOCUMENT_BLOCK_MS", Envs.Document.BlockMs)
+		Envs.Summary.CacheTTLSeconds = utils.GetIntEnv("CUSTOMER_SUMMARY_CACHE_TTL_SECONDS", Envs.Summary.CacheTTLSeconds)
+		Envs.Outbox.RelayIntervalMs = utils.GetIntEnv("OUTBOX_RELAY_INTERVAL_MS", Envs.Outbox.RelayIntervalMs)
+		Envs.Outbox.RelayBatchSize = utils.GetIntEnv("OUTBOX_RELAY_BATCH_SIZE", Envs.Outbox.RelayBatchSize)
+		Envs.Outbox.StreamPrefix = utils.GetEnv("OUTBOX_STREAM_PREFIX", Envs.Outbox.StreamPrefix)
+		Envs.Outbox.StreamMaxLen = utils.GetIntEnv("OUTBOX_STREAM_MAX_LEN", Envs.Outbox.StreamMaxLen)
+		Envs.Outbox.MaxDeliveries = utils.GetIntEnv("OUTBOX_MAX_DELIVERIES", Envs.Outbox.MaxDeliveries)
+		Envs.Webhook.TimeoutSeconds = utils.GetIntEnv("WEBHOOK_TIMEOUT_SECONDS", Envs.Webhook.TimeoutSeconds)
+		Envs.Webhook.MaxAttempts = utils.GetIntEnv("WEBHOOK_MAX_ATTEMPTS", Envs.Webhook.MaxAttempts)
+		Envs.Webhook.BackoffBaseSeconds = utils.GetIntEnv("WEBHOOK_BACKOFF_BASE_SECONDS", Envs.Webhook.BackoffBaseSeconds)
+		Envs.Webhook.BackoffMaxSeconds = utils.GetIntEnv("WEBHOOK_BACKOFF_MAX_SECONDS", Envs.Webhook.BackoffMaxSeconds)
+		Envs.Webhook.BatchSize = utils.GetIntEnv("WEBHOOK_BATCH_SIZE", Envs.Webhook.BatchSize)
+		Envs.Webhook.LeaseSeconds = utils.GetIntEnv("WEBHOOK_LEASE_SECONDS", Envs.Webhook.LeaseSeconds)
+		Envs.Webhook.IntervalMs = utils.GetIntEnv("WEBHOOK_INTERVAL_MS", Envs.Webhook.IntervalMs)
+		Envs.Notification.SMTPHost = utils.GetEnv("NOTIFICATION_SMTP_HOST", Envs.Notification.SMTPHost)
+		Envs.Notification.SMTPPort = utils.GetIntEnv("NOTIFICATION_SMTP_PORT", Envs.Notification.SMTPPort)
+		Envs.Notification.SMTPUsername = utils.GetEnv("NOTIFICATION_SMTP_USERNAME", Envs.Notification.SMTPUsername)
+		Envs.Notification.SMTPPassword = utils.GetEnv("NOTIFICATION_SMTP_PASSWORD", Envs.Notification.SMTPPassword)
+		Envs.Notification.SMTPFrom = utils.GetEnv("NOTIFICATION_SMTP_FROM", Envs.Notification.SMTPFrom)
+		Envs.Notification.SMSGatewayURL = utils.GetEnv("NOTIFICATION_SMS_GATEWAY_URL", Envs.Notification.SMSGatewayURL)
+		Envs.Notification.SMSGatewayAPIKey = utils.GetEnv("NOTIFICATION_SMS_GATEWAY_API_KEY", Envs.Notification.SMSGatewayAPIKey)
+		Envs.Notification.SMSTimeoutSeconds = utils.GetIntEnv("NOTIFICATION_SMS_TIMEOUT_SECONDS", Envs.Notification.SMSTimeoutSeconds)
+		Envs.Notification.ReminderDaysAhead = utils.GetIntEnv("NOTIFICATION_REMINDER_DAYS_AHEAD", Envs.Notification.ReminderDaysAhead)
+		Envs.Notification.ConsumerGroup = utils.GetEnv("NOTIFICATION_CONSUMER_GROUP", Envs.Notification.ConsumerGroup)
+		Envs.Notification.ConsumerBatchSize = utils.GetIntEnv("NOTIFICATION_CONSUMER_BATCH_SIZE", Envs.Notification.ConsumerBatchSize)
+		Envs.Notification.BlockMs = utils.GetIntEnv("NOTIFICATION_BLOCK_MS", Envs.Notification.BlockMs)
+		Envs.Worker.ReminderSchedule = utils.GetEnv("WORKER_REMINDER_SCHEDULE", Envs.Worker.ReminderSchedule)
+		Envs.Worker.StatementSchedule = utils.GetEnv("WORKER_STATEMENT_SCHEDULE", Envs.Worker.StatementSchedule)
+		Envs.Health.CheckTimeoutMs = utils.GetIntEnv("HEALTH_CHECK_TIMEOUT_MS", Envs.Health.CheckTimeoutMs)
+		Envs.Health.DrainSeconds = utils.GetIntEnv("HEALTH_DRAIN_SECONDS", Envs.Health.DrainSeconds)
+		Envs.Tracing.Exporter = utils.GetEnv("TRACING_EXPORTER", Envs.Tracing.Exporter)
+		Envs.Tracing.OTLPEndpoint = utils.GetEnv("TRACING_OTLP_ENDPOINT", Envs.Tracing.OTLPEndpoint)
+		Envs.Tracing.OTLPInsecure = utils.GetBoolEnv("TRACING_OTLP_INSECURE", Envs.Tracing.OTLPInsecure)
+		Envs.Tracing.SampleRatio = utils.GetFloatEnv("TRACING_SAMPLE_RATIO", Envs.Tracing.SampleRatio)
 	})
 }
 
@@ -7,11 +7,20 @@ import (
 	"syscall"
 
 	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/pkg/redact"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+func init() {
+	// log.Ctx(ctx) falls back to the global logger outside of a request
+	zerolog.DefaultContextLogger = &log.Logger
+	// fields tagged log:"redact" or log:"mask" are hidden from Any/Interface
+	zerolog.InterfaceMarshalFunc = redact.Marshal
+}
+
 // InitializeLogger will set logging format.
 func InitializeLogger(stage string, filename string, logLevel zerolog.Level) {
 
@@ -32,7 +41,8 @@ func InitializeLogger(stage string, filename string, logLevel zerolog.Level) {
 	} else {
 		logger = zerolog.New(mw).With().Timestamp().Caller().Logger().Level(logLevel)
 	}
-	log.Logger = logger
+	// events logged through log.Ctx(ctx) carry the IDs of the span in ctx
+	log.Logger = logger.Hook(tracing.LogHook{})
 
 	q := make(chan os.Signal, 1)
 	c := make(chan os.Signal, 1)
@@ -0,0 +1,192 @@
+package infrastructure
+
+import (
+	"bytes"
+	"database/sql"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	authDto "github.com/hilmiikhsan/multifinance-service/internal/module/auth/dto"
+	customerDto "github.com/hilmiikhsan/multifinance-service/internal/module/customer/dto"
+	customerEntity "github.com/hilmiikhsan/multifinance-service/internal/module/customer/entity"
+	fraudDto "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/dto"
+	notificationEntity "github.com/hilmiikhsan/multifinance-service/internal/module/notification/entity"
+	webhookDto "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/redact"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testNik      = "3171011501900001"
+	testPassword = "Secret123!"
+	testHash     = "$2a$10$7EqJtq98hPqEX7fNZaFWoO"
+)
+
+func TestLoggedDTOs(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{
+			name: "Register Request",
+			value: &authDto.RegisterRequest{
+				Nik:         testNik,
+				Email:       "jane@example.com",
+				PhoneNumber: "081234567890",
+				Password:    testPassword,
+				FullName:    "Jane Doe",
+				Salary:      10000000,
+			},
+		},
+		{
+			name:  "Login Request",
+			value: &authDto.LoginRequest{Email: "jane@example.com", Password: testPassword},
+		},
+		{
+			name:  "Login Response",
+			value: &authDto.LoginResponse{ID: 1, Email: "jane@example.com", Token: testPassword, RefreshToken: testPassword},
+		},
+		{
+			name:  "Customer Profile",
+			value: &customerDto.GetCustomerProfileResponse{ID: 1, Nik: testNik, Salary: 10000000},
+		},
+		{
+			name: "Customer Entity",
+			value: &customerEntity.Customer{
+				ID:          1,
+				Nik:         testNik,
+				Email:       "jane@example.com",
+				PhoneNumber: sql.NullString{String: "081234567890", Valid: true},
+				Password:    testHash,
+				Salary:      10000000,
+			},
+		},
+		{
+			name:  "Customer With Limits",
+			value: []customerEntity.CustomerWithLimits{{CustomerID: 1, Nik: testNik}},
+		},
+		{
+			name:  "Screening Subject",
+			value: fraudDto.ScreeningSubject{CustomerID: 1, Nik: testNik, Email: "jane@example.com"},
+		},
+		{
+			name:  "Watchlist Entry",
+			value: &fraudDto.AddWatchlistEntryRequest{EntryType: "nik", Value: testNik, Reason: "Chargeback"},
+		},
+		{
+			name:  "Recipient",
+			value: &notificationEntity.Recipient{CustomerID: 1, Email: "jane@example.com"},
+		},
+		{
+			name:  "Partner API Key",
+			value: &webhookDto.CreatePartnerResponse{ID: 1, Code: "DEALER01", APIKey: testPassword},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := zerolog.New(&buf)
+			logger.Info().Any("payload", tt.value).Msg("logged")
+
+			out := buf.String()
+			assert.NotContains(t, out, testNik)
+			assert.NotContains(t, out, testPassword)
+			assert.NotContains(t, out, testHash)
+			assert.NotContains(t, out, "jane@example.com")
+			assert.NotContains(t, out, "10000000")
+		})
+	}
+}
+
+var (
+	// fields that must never reach a log line
+	secretField = regexp.MustCompile(`(?i)^(password|.*secret|.*apikey.*|.*token)$`)
+	// fields that may be logged masked
+	personalField = regexp.MustCompile(`(?i)^(nik|email|phonenumber|salary|value)$`)
+)
+
+// TestSensitiveFieldsTagged fails when a DTO or entity gets a password, key,
+// token or personal field without a log tag, so it cannot be logged in full.
+func TestSensitiveFieldsTagged(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "module", "*", "*", "*.go"))
+	assert.NoError(t, err)
+
+	fset := token.NewFileSet()
+	checked := 0
+	for _, file := range files {
+		dir := filepath.Base(filepath.Dir(file))
+		if (dir != "dto" && dir != "entity") || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				return true
+			}
+
+			for _, field := range st.Fields.List {
+				if !holdsValue(field.Type) {
+					continue
+				}
+
+				var tag string
+				if field.Tag != nil {
+					unquoted, _ := strconv.Unquote(field.Tag.Value)
+					tag = reflect.StructTag(unquoted).Get(redact.TagName)
+				}
+
+				for _, name := range field.Names {
+					switch {
+					case secretField.MatchString(name.Name):
+						checked++
+						assert.Equal(t, redact.TagRedact, tag, "%s: %s.%s must be tagged log:%q", file, spec.Name.Name, name.Name, redact.TagRedact)
+					case personalField.MatchString(name.Name):
+						checked++
+						assert.Contains(t, []string{redact.TagRedact, redact.TagMask}, tag, "%s: %s.%s must be tagged log:%q or log:%q", file, spec.Name.Name, name.Name, redact.TagMask, redact.TagRedact)
+					}
+				}
+			}
+
+			return true
+		})
+	}
+
+	assert.NotZero(t, checked)
+}
+
+// holdsValue tells whether a field can carry a secret: strings, numbers such
+// as a salary and their nullable forms.
+func holdsValue(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return holdsValue(e.X)
+	case *ast.Ident:
+		switch e.Name {
+		case "string", "int", "int64", "float64":
+			return true
+		}
+	case *ast.SelectorExpr:
+		return e.Sel.Name == "NullString"
+	}
+
+	return false
+}
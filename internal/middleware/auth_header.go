@@ -7,6 +7,7 @@ import (
 )
 
 func (m *AuthMiddleware) AuthBearer(c *fiber.Ctx) error {
+	ctx := c.UserContext()
 	accessToken := c.Get(constants.HeaderAuthorization)
 	unauthorizedResponse := fiber.Map{
 		"message": constants.ErrTokenAlreadyExpired,
@@ -15,7 +16,7 @@ func (m *AuthMiddleware) AuthBearer(c *fiber.Ctx) error {
 
 	// If the cookie is not set, return an unauthorized status
 	if accessToken == "" {
-		log.Error().Msg("middleware::AuthBearer - Unauthorized [Header not set]")
+		log.Ctx(ctx).Error().Msg("middleware::AuthBearer - Unauthorized [Header not set]")
 		return c.Status(fiber.StatusUnauthorized).JSON(unauthorizedResponse)
 	}
 
@@ -25,9 +26,9 @@ func (m *AuthMiddleware) AuthBearer(c *fiber.Ctx) error {
 	}
 
 	// Parse the JWT string and store the result in `claims`
-	claims, err := m.jwt.ParseTokenString(c.Context(), accessToken)
+	claims, err := m.jwt.ParseTokenString(ctx, accessToken)
 	if err != nil {
-		log.Error().Err(err).Any("payload", accessToken).Msg("middleware::AuthBearer - Error while parsing token")
+		log.Ctx(ctx).Error().Err(err).Msg("middleware::AuthBearer - Error while parsing token")
 		return c.Status(fiber.StatusUnauthorized).JSON(unauthorizedResponse)
 	}
 
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type localsKey struct{}
+
+// UnaryAuth is AuthBearer for gRPC. The token is read from the authorization
+// metadata and the claims are put in the context for GetContextLocals.
+func (m *AuthMiddleware) UnaryAuth(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	unauthorized := err_msg.NewCustomErrors(fiber.StatusUnauthorized, err_msg.WithMessage(constants.ErrTokenAlreadyExpired))
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(constants.HeaderAuthorization)
+	if len(values) == 0 || values[0] == "" {
+		log.Ctx(ctx).Error().Str("method", info.FullMethod).Msg("middleware::UnaryAuth - Unauthorized [Metadata not set]")
+		return nil, unauthorized
+	}
+
+	// remove the Bearer prefix
+	accessToken := values[0]
+	if len(accessToken) > 7 {
+		accessToken = accessToken[7:]
+	}
+
+	claims, err := m.jwt.ParseTokenString(ctx, accessToken)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("method", info.FullMethod).Msg("middleware::UnaryAuth - Error while parsing token")
+		return nil, unauthorized
+	}
+
+	ctx = context.WithValue(ctx, localsKey{}, &Locals{
+		CustomerID: int(claims.CustomerID),
+		Nik:        claims.Nik,
+		Email:      claims.Email,
+		FullName:   claims.FullName,
+	})
+
+	return handler(ctx, req)
+}
+
+// UnaryRequestID is RequestID for gRPC, the ID is read from and sent back in
+// the x-request-id metadata.
+func UnaryRequestID(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(constants.HeaderRequestID); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if !validRequestID(requestID) {
+		requestID = uuid.NewString()
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(constants.HeaderRequestID, requestID)); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("method", info.FullMethod).Msg("middleware::UnaryRequestID - Failed to set request ID header")
+	}
+
+	return handler(withRequestLogger(ctx, requestID), req)
+}
+
+// UnaryError turns the errors of the handlers into gRPC statuses, the same
+// way err_msg.Errors picks the HTTP status over REST.
+func UnaryError(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		return nil, err_msg.GRPCStatus(err).Err()
+	}
+
+	return res, nil
+}
+
+// GetContextLocals returns the claims UnaryAuth put in the context.
+func GetContextLocals(ctx context.Context) *Locals {
+	l, ok := ctx.Value(localsKey{}).(*Locals)
+	if !ok {
+		log.Ctx(ctx).Warn().Msg("middleware::Locals-GetContextLocals failed to get locals from context")
+		return new(Locals)
+	}
+
+	return l
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/pkg/metrics"
+)
+
+// Metrics records every request under the route pattern it matched.
+func Metrics(c *fiber.Ctx) error {
+	start := time.Now()
+
+	err := c.Next()
+
+	metrics.ObserveHTTPRequest(c.Method(), c.Route().Path, responseStatus(c, err), time.Since(start))
+
+	return err
+}
+
+// responseStatus is the status the request is answered with, errors returned
+// by the handlers get theirs from the error handler later on.
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/rs/zerolog/log"
+)
+
+// PartnerAuthenticator resolves a partner API key to the partner it belongs to.
+type PartnerAuthenticator interface {
+	AuthenticatePartner(ctx context.Context, apiKey string) (int64, error)
+}
+
+type PartnerMiddleware struct {
+	authenticator PartnerAuthenticator
+}
+
+func NewPartnerMiddleware(authenticator PartnerAuthenticator) *PartnerMiddleware {
+	return &PartnerMiddleware{
+		authenticator: authenticator,
+	}
+}
+
+// PartnerKey guards the routes partners call with their own API key.
+func (m *PartnerMiddleware) PartnerKey(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	partnerID, err := m.authenticator.AuthenticatePartner(ctx, c.Get(constants.HeaderPartnerKey))
+	if err != nil {
+		var customErr *err_msg.CustomError
+		if errors.As(err, &customErr) && customErr.Code != fiber.StatusUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("middleware::PartnerKey - Failed to authenticate partner")
+			return c.Status(customErr.Code).JSON(fiber.Map{
+				"message": customErr.Msg,
+				"success": false,
+			})
+		}
+
+		log.Ctx(ctx).Error().Str("ip", c.IP()).Msg("middleware::PartnerKey - Unauthorized [Invalid partner key]")
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": constants.ErrInvalidPartnerKey,
+			"success": false,
+		})
+	}
+
+	c.Locals("partner_id", partnerID)
+
+	return c.Next()
+}
+
+func GetPartnerID(c *fiber.Ctx) int64 {
+	partnerID, ok := c.Locals("partner_id").(int64)
+	if !ok {
+		log.Warn().Msg("middleware::GetPartnerID failed to get partner_id from locals")
+	}
+
+	return partnerID
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/rs/zerolog/log"
+)
+
+// RequestID tags the request with the X-Request-ID sent by the caller, or a
+// new one when it is missing or unusable, and answers with it. Services and
+// repositories log through log.Ctx(ctx), which carries the ID.
+func RequestID(c *fiber.Ctx) error {
+	requestID := c.Get(constants.HeaderRequestID)
+	if !validRequestID(requestID) {
+		requestID = uuid.NewString()
+	}
+
+	c.Set(constants.HeaderRequestID, requestID)
+	c.SetUserContext(withRequestLogger(c.UserContext(), requestID))
+
+	return c.Next()
+}
+
+// RequestTimeout puts a deadline on c.UserContext(), so the queries of a
+// request that ran out of time are cancelled. A zero timeout turns it off.
+func RequestTimeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if timeout <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+
+		c.SetUserContext(ctx)
+
+		return c.Next()
+	}
+}
+
+func withRequestLogger(ctx context.Context, requestID string) context.Context {
+	return log.With().Str(constants.LogFieldRequestID, requestID).Ctx(ctx).Logger().WithContext(ctx)
+}
+
+// validRequestID keeps caller IDs that are safe to echo in headers and logs.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > constants.MaxRequestIDLength {
+		return false
+	}
+
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
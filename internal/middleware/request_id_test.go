@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&buf)
+	defer func() { log.Logger = previous }()
+
+	app := fiber.New()
+	app.Use(RequestID)
+	app.Get("/", func(c *fiber.Ctx) error {
+		log.Ctx(c.UserContext()).Info().Msg("handled")
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	tests := []struct {
+		name      string
+		requestID string
+		wantSame  bool
+	}{
+		{name: "Accepted", requestID: "b6a1c2d4-7f3e-4c59-9e4b-0e2f5a1d8c37", wantSame: true},
+		{name: "Generated"},
+		{name: "Invalid Characters", requestID: "id\twith spaces"},
+		{name: "Too Long", requestID: strings.Repeat("a", constants.MaxRequestIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.requestID != "" {
+				req.Header.Set(constants.HeaderRequestID, tt.requestID)
+			}
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+
+			got := resp.Header.Get(constants.HeaderRequestID)
+			assert.NotEmpty(t, got)
+			if tt.wantSame {
+				assert.Equal(t, tt.requestID, got)
+			} else {
+				assert.NotEqual(t, tt.requestID, got)
+			}
+
+			var fields map[string]any
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &fields))
+			assert.Equal(t, got, fields[constants.LogFieldRequestID])
+		})
+	}
+}
+
+func TestRequestID_KeepsSpan(t *testing.T) {
+	var buf bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&buf).Hook(tracing.LogHook{})
+	defer func() { log.Logger = previous }()
+
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	defer otel.SetTracerProvider(previousProvider)
+
+	var spanContext trace.SpanContext
+
+	app := fiber.New()
+	app.Use(Tracing)
+	app.Use(RequestID)
+	app.Get("/", func(c *fiber.Ctx) error {
+		spanContext = trace.SpanContextFromContext(c.UserContext())
+		log.Ctx(c.UserContext()).Info().Msg("handled")
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	_, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	assert.NoError(t, err)
+
+	var fields map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &fields))
+	assert.Equal(t, spanContext.TraceID().String(), fields["trace_id"])
+	assert.Equal(t, spanContext.SpanID().String(), fields["span_id"])
+	assert.NotEmpty(t, fields[constants.LogFieldRequestID])
+}
+
+func TestRequestTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		wantDeadline bool
+	}{
+		{name: "With Timeout", timeout: time.Second, wantDeadline: true},
+		{name: "Disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hasDeadline bool
+
+			app := fiber.New()
+			app.Use(RequestTimeout(tt.timeout))
+			app.Get("/", func(c *fiber.Ctx) error {
+				_, hasDeadline = c.UserContext().Deadline()
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+
+			_, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDeadline, hasDeadline)
+		})
+	}
+}
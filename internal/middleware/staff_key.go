@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/rs/zerolog/log"
+)
+
+type StaffMiddleware struct {
+	apiKey string
+}
+
+func NewStaffMiddleware(apiKey string) *StaffMiddleware {
+	return &StaffMiddleware{
+		apiKey: apiKey,
+	}
+}
+
+// StaffKey guards back office routes with a shared key. The staff ID header
+// is recorded on every change made through these routes.
+func (m *StaffMiddleware) StaffKey(c *fiber.Ctx) error {
+	var (
+		ctx     = c.UserContext()
+		key     = c.Get(constants.HeaderStaffKey)
+		staffID = c.Get(constants.HeaderStaffID)
+	)
+
+	unauthorizedResponse := fiber.Map{
+		"message": constants.ErrInvalidStaffKey,
+		"success": false,
+	}
+
+	// an unset key disables the back office instead of leaving it open
+	if m.apiKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(m.apiKey)) != 1 {
+		log.Ctx(ctx).Error().Str("ip", c.IP()).Msg("middleware::StaffKey - Unauthorized [Invalid staff key]")
+		return c.Status(fiber.StatusUnauthorized).JSON(unauthorizedResponse)
+	}
+
+	if staffID == "" {
+		log.Ctx(ctx).Error().Str("ip", c.IP()).Msg("middleware::StaffKey - Unauthorized [Staff ID not set]")
+		return c.Status(fiber.StatusUnauthorized).JSON(unauthorizedResponse)
+	}
+
+	c.Locals("staff_id", staffID)
+
+	return c.Next()
+}
+
+func GetStaffID(c *fiber.Ctx) string {
+	staffID, ok := c.Locals("staff_id").(string)
+	if !ok {
+		log.Warn().Msg("middleware::GetStaffID failed to get staff_id from locals")
+	}
+
+	return staffID
+}
@@ -1,36 +1,39 @@
 package dto
 
 type RegisterRequest struct {
-	Nik             string `json:"nik" validate:"required,max=16,nik"`
-	Email           string `json:"email" validate:"required,email,email_blacklist"`
-	Password        string `json:"password" validate:"required,strong_password"`
+	Nik             string `json:"nik" validate:"required,max=16,nik" log:"mask"`
+	Email           string `json:"email" validate:"required,email,email_blacklist" log:"mask"`
+	PhoneNumber     string `json:"phone_number" validate:"omitempty,phone" log:"mask"`
+	Password        string `json:"password" validate:"required,strong_password" log:"redact"`
 	FullName        string `json:"full_name" validate:"required,max=100,valid_text"`
 	LegalName       string `json:"legal_name" validate:"required,max=100,valid_text"`
 	BirthPlace      string `json:"birth_place" validate:"required,max=100,valid_text"`
 	BirthDate       string `json:"birth_date" validate:"required,birth_date"`
-	Salary          int    `json:"salary" validate:"required,numeric,amount_number"`
+	Salary          int    `json:"salary" validate:"required,numeric,amount_number" log:"redact"`
 	KtpPhotoPath    string `json:"ktp_photo_path" validate:"required,file_path"`
 	SelfiePhotoPath string `json:"selfie_photo_path" validate:"required,file_path"`
+	DeviceID        string `json:"-"`
 }
 
 type RegisterResponse struct {
-	ID    int64  `json:"id"`
-	Email string `json:"email"`
+	ID           int64  `json:"id"`
+	Email        string `json:"email" log:"mask"`
+	ReviewStatus string `json:"review_status"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email,email_blacklist"`
-	Password string `json:"password" validate:"required"`
+	Email    string `json:"email" validate:"required,email,email_blacklist" log:"mask"`
+	Password string `json:"password" validate:"required" log:"redact"`
 }
 
 type LoginResponse struct {
 	ID           int64  `json:"id"`
-	Email        string `json:"email"`
+	Email        string `json:"email" log:"mask"`
 	FullName     string `json:"full_name"`
-	Token        string `json:"token"`
-	RefreshToken string `json:"refresh_token"`
+	Token        string `json:"token" log:"redact"`
+	RefreshToken string `json:"refresh_token" log:"redact"`
 }
 
 type RefreshTokenResponse struct {
-	Token string `json:"token"`
+	Token string `json:"token" log:"redact"`
 }
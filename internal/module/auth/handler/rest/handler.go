@@ -4,16 +4,23 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/hilmiikhsan/multifinance-service/constants"
 	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
 	redisRepository "github.com/hilmiikhsan/multifinance-service/internal/infrastructure/redis"
 	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/auth/dto"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/auth/ports"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/auth/service"
 	creditLimitRepository "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/repository"
+	creditScoreRepository "github.com/hilmiikhsan/multifinance-service/internal/module/credit_score/repository"
 	customerRepository "github.com/hilmiikhsan/multifinance-service/internal/module/customer/repository"
+	fraudRepository "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/repository"
+	fraudService "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/service"
+	outboxRepository "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/repository"
+	"github.com/hilmiikhsan/multifinance-service/pkg/eligibility"
 	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
 	jwtHandler "github.com/hilmiikhsan/multifinance-service/pkg/jwt_handler"
 	"github.com/hilmiikhsan/multifinance-service/pkg/response"
+	"github.com/hilmiikhsan/multifinance-service/pkg/scoring"
 	"github.com/rs/zerolog/log"
 )
 
@@ -39,16 +46,45 @@ func NewAuthHandler() *authHandler {
 	middlewareHandler := middleware.NewAuthMiddleware(jwt)
 
 	// repository
-	customerRepository := customerRepository.NewCustomerRepository(adapter.Adapters.MultifinanceMysql)
-	creditLimitRepository := creditLimitRepository.NewCreditLimitRepository(adapter.Adapters.MultifinanceMysql)
+	customerRepository := customerRepository.NewCustomerRepository(adapter.Adapters.MultifinanceDB)
+	creditLimitRepository := creditLimitRepository.NewCreditLimitRepository(adapter.Adapters.MultifinanceDB)
+	creditScoreRepository := creditScoreRepository.NewCreditScoreRepository(adapter.Adapters.MultifinanceDB)
+	fraudRepository := fraudRepository.NewFraudRepository(adapter.Adapters.MultifinanceDB)
+	outboxRepository := outboxRepository.NewOutboxRepository(adapter.Adapters.MultifinanceDB)
+
+	// scoring
+	scorecard, err := scoring.LoadScorecard(config.Envs.Scoring.ScorecardPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("handler::NewAuthHandler - Failed to load scorecard")
+	}
+
+	// eligibility
+	eligibilityEngine := eligibility.NewEngine(
+		eligibility.MinAgeAtApplication(config.Envs.Eligibility.MinAge),
+		eligibility.MaxAgeAtTenorEnd(config.Envs.Eligibility.MaxAgeAtTenorEnd),
+	)
+
+	// fraud screening
+	fraudScreener := fraudService.NewFraudService(
+		adapter.Adapters.MultifinanceDB,
+		fraudRepository,
+		customerRepository,
+		config.Envs.App.LocalStoragePrivatePath,
+		config.Envs.Fraud.PhotoHashMaxDistance,
+	)
 
 	// service
 	authService := service.NewUserService(
-		adapter.Adapters.MultifinanceMysql,
+		adapter.Adapters.MultifinanceDB,
 		customerRepository,
 		redisRepository,
 		jwt,
 		creditLimitRepository,
+		eligibilityEngine,
+		creditScoreRepository,
+		scorecard,
+		fraudScreener,
+		outboxRepository,
 	)
 
 	// handler
@@ -69,22 +105,24 @@ func (h *authHandler) AuthRoute(router fiber.Router) {
 func (h *authHandler) register(c *fiber.Ctx) error {
 	var (
 		req = new(dto.RegisterRequest)
-		ctx = c.Context()
+		ctx = c.UserContext()
 	)
 
 	if err := c.BodyParser(req); err != nil {
-		log.Warn().Err(err).Msg("handler::register - Failed to parse request body")
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::register - Failed to parse request body")
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
 	if err := h.validator.Validate(req); err != nil {
-		log.Warn().Err(err).Msg("handler::register - Invalid request body")
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::register - Invalid request body")
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
+	req.DeviceID = c.Get(constants.HeaderDeviceID)
+
 	res, err := h.service.Register(ctx, req)
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("handler::register - Failed to register user")
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("handler::register - Failed to register user")
 		code, errs := err_msg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
@@ -95,22 +133,22 @@ func (h *authHandler) register(c *fiber.Ctx) error {
 func (h *authHandler) login(c *fiber.Ctx) error {
 	var (
 		req = new(dto.LoginRequest)
-		ctx = c.Context()
+		ctx = c.UserContext()
 	)
 
 	if err := c.BodyParser(req); err != nil {
-		log.Warn().Err(err).Msg("handler::login - Failed to parse request body")
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::login - Failed to parse request body")
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
 	if err := h.validator.Validate(req); err != nil {
-		log.Warn().Err(err).Msg("handler::login - Invalid request body")
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::login - Invalid request body")
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
 	res, err := h.service.Login(ctx, req)
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("handler::login - Failed to login user")
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("handler::login - Failed to login user")
 		code, errs := err_msg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
@@ -120,12 +158,12 @@ func (h *authHandler) login(c *fiber.Ctx) error {
 
 func (h *authHandler) refreshToken(c *fiber.Ctx) error {
 	var (
-		ctx         = c.Context()
+		ctx         = c.UserContext()
 		accessToken = c.Get(constants.HeaderAuthorization)
 	)
 
 	if accessToken == "" {
-		log.Warn().Msg("handler::refreshToken - Access token is required")
+		log.Ctx(ctx).Warn().Msg("handler::refreshToken - Access token is required")
 		return c.Status(fiber.StatusUnauthorized).JSON(response.Error(constants.ErrAccessTokenIsRequired))
 	}
 
@@ -135,7 +173,7 @@ func (h *authHandler) refreshToken(c *fiber.Ctx) error {
 
 	res, err := h.service.RefreshToken(ctx, accessToken)
 	if err != nil {
-		log.Error().Err(err).Any("access_token", accessToken).Msg("handler::refreshToken - Failed to refresh token")
+		log.Ctx(ctx).Error().Err(err).Msg("handler::refreshToken - Failed to refresh token")
 		code, errs := err_msg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
@@ -145,13 +183,13 @@ func (h *authHandler) refreshToken(c *fiber.Ctx) error {
 
 func (h *authHandler) logout(c *fiber.Ctx) error {
 	var (
-		ctx         = c.Context()
+		ctx         = c.UserContext()
 		accessToken = c.Get(constants.HeaderAuthorization)
 		locals      = middleware.GetLocals(c)
 	)
 
 	if accessToken == "" {
-		log.Warn().Msg("handler::logout - Access token is required")
+		log.Ctx(ctx).Warn().Msg("handler::logout - Access token is required")
 		return c.Status(fiber.StatusUnauthorized).JSON(response.Error(constants.ErrAccessTokenIsRequired))
 	}
 
@@ -161,7 +199,7 @@ func (h *authHandler) logout(c *fiber.Ctx) error {
 
 	err := h.service.Logout(ctx, accessToken, locals)
 	if err != nil {
-		log.Error().Err(err).Any("access_token", accessToken).Msg("handler::logout - Failed to logout user")
+		log.Ctx(ctx).Error().Err(err).Msg("handler::logout - Failed to logout user")
 		code, errs := err_msg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
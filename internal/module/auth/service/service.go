@@ -2,9 +2,11 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,10 +17,23 @@ import (
 	authPorts "github.com/hilmiikhsan/multifinance-service/internal/module/auth/ports"
 	creditLimitEntity "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/entity"
 	creditLimitPorts "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/ports"
+	creditScoreEntity "github.com/hilmiikhsan/multifinance-service/internal/module/credit_score/entity"
+	creditScorePorts "github.com/hilmiikhsan/multifinance-service/internal/module/credit_score/ports"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/entity"
 	customerPorts "github.com/hilmiikhsan/multifinance-service/internal/module/customer/ports"
+	fraudDto "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/dto"
+	fraudPorts "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/ports"
+	outboxDto "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/dto"
+	outboxEntity "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/entity"
+	outboxPorts "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/eligibility"
 	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
 	"github.com/hilmiikhsan/multifinance-service/pkg/jwt_handler"
+	"github.com/hilmiikhsan/multifinance-service/pkg/metrics"
+	"github.com/hilmiikhsan/multifinance-service/pkg/nik"
+	"github.com/hilmiikhsan/multifinance-service/pkg/redact"
+	"github.com/hilmiikhsan/multifinance-service/pkg/scoring"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
 	"github.com/hilmiikhsan/multifinance-service/pkg/utils"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
@@ -32,66 +47,144 @@ type authService struct {
 	redisDB               redisPorts.RedisRepository
 	jwt                   jwt_handler.JWT
 	creditLimitRepository creditLimitPorts.CreditLimitRepository
+	eligibility           *eligibility.Engine
+	creditScoreRepository creditScorePorts.CreditScoreRepository
+	scorer                scoring.Scorer
+	fraud                 fraudPorts.FraudScreener
+	outboxRepository      outboxPorts.OutboxRepository
 }
 
-func NewUserService(db *sqlx.DB, customerRepository customerPorts.CustomerRepository, redisDB redisPorts.RedisRepository, jwt jwt_handler.JWT, creditLimitRepository creditLimitPorts.CreditLimitRepository) *authService {
+func NewUserService(db *sqlx.DB, customerRepository customerPorts.CustomerRepository, redisDB redisPorts.RedisRepository, jwt jwt_handler.JWT, creditLimitRepository creditLimitPorts.CreditLimitRepository, eligibility *eligibility.Engine, creditScoreRepository creditScorePorts.CreditScoreRepository, scorer scoring.Scorer, fraud fraudPorts.FraudScreener, outboxRepository outboxPorts.OutboxRepository) *authService {
 	return &authService{
 		db:                    db,
 		customerRepository:    customerRepository,
 		redisDB:               redisDB,
 		jwt:                   jwt,
 		creditLimitRepository: creditLimitRepository,
+		eligibility:           eligibility,
+		creditScoreRepository: creditScoreRepository,
+		scorer:                scorer,
+		fraud:                 fraud,
+		outboxRepository:      outboxRepository,
 	}
 }
 
 func (s *authService) Register(ctx context.Context, req *dto.RegisterRequest) (*dto.RegisterResponse, error) {
+	ctx, span := tracing.Start(ctx, "authService.Register")
+	defer span.End()
+
+	birthDate, _ := time.Parse(constants.DateFormat, req.BirthDate)
+
+	identity, err := nik.Parse(req.Nik)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("nik", redact.Mask(req.Nik)).Msg("service::Register - Failed to decode NIK")
+		return nil, err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrNikIsNotValid), err_msg.WithErrors("nik", err.Error()))
+	}
+
+	if !identity.MatchesBirthDate(birthDate) {
+		log.Ctx(ctx).Warn().Str("nik", redact.Mask(req.Nik)).Str("birth_date", req.BirthDate).Msg("service::Register - NIK does not match birth date")
+		return nil, err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrNikBirthDateMismatch), err_msg.WithErrors("birth_date", constants.ErrNikBirthDateMismatch))
+	}
+
+	reasons := s.eligibility.Evaluate(eligibility.Applicant{
+		BirthDate: birthDate,
+		AppliedAt: time.Now(),
+	})
+	if len(reasons) > 0 {
+		log.Ctx(ctx).Warn().Any("reasons", reasons).Str("birth_date", req.BirthDate).Msg("service::Register - Customer is not eligible")
+		return nil, eligibility.NewRejectionError(reasons)
+	}
+
+	// a new customer has no tenure, repayment history or utilisation yet
+	score, err := s.scorer.Score(scoring.Inputs{
+		Age:    eligibility.AgeAt(birthDate, time.Now()),
+		Salary: float64(req.Salary),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::Register - Failed to calculate credit score")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	screening, err := s.fraud.Screen(ctx, &fraudDto.ScreeningSubject{
+		Nik:             req.Nik,
+		Email:           req.Email,
+		PhoneNumber:     req.PhoneNumber,
+		DeviceID:        req.DeviceID,
+		KtpPhotoPath:    req.KtpPhotoPath,
+		SelfiePhotoPath: req.SelfiePhotoPath,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::Register - Failed to screen customer")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	// a flagged applicant is still registered, but cannot book until a staff member clears the review
+	reviewStatus := constants.CustomerReviewStatusClear
+	if screening.Flagged() {
+		log.Ctx(ctx).Warn().Any("reasons", screening.Reasons).Str("nik", redact.Mask(req.Nik)).Msg("service::Register - Customer flagged for manual review")
+		reviewStatus = constants.CustomerReviewStatusPendingReview
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("service::Register - Failed to hash password")
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::Register - Failed to hash password")
 		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
 	req.Password = hashedPassword
 
-	birthDate, _ := time.Parse(constants.DateFormat, req.BirthDate)
-
-	tx, err := s.db.Begin()
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("service::Register - Failed to begin transaction")
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::Register - Failed to begin transaction")
 		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 	defer func() {
 		if err != nil {
 			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				log.Error().Err(rollbackErr).Any("payload", req).Msg("service::Register - Failed to rollback transaction")
+				log.Ctx(ctx).Error().Err(rollbackErr).Any("payload", req).Msg("service::Register - Failed to rollback transaction")
 			}
 		}
 	}()
 
-	result, err := s.customerRepository.InsertNewUser(ctx, tx, &entity.Customer{
+	customer := &entity.Customer{
 		Nik:             req.Nik,
 		Email:           req.Email,
+		PhoneNumber:     sql.NullString{String: req.PhoneNumber, Valid: req.PhoneNumber != ""},
+		DeviceID:        sql.NullString{String: req.DeviceID, Valid: req.DeviceID != ""},
 		Password:        req.Password,
 		FullName:        req.FullName,
 		LegalName:       req.LegalName,
+		Gender:          identity.Gender,
 		BirthPlace:      req.BirthPlace,
 		BirthDate:       birthDate,
 		Salary:          float64(req.Salary),
 		KtpPhotoPath:    req.KtpPhotoPath,
 		SelfiePhotoPath: req.SelfiePhotoPath,
-	})
-	if err != nil {
-		if strings.Contains(err.Error(), constants.ErrNikAlreadyRegistered) {
-			log.Error().Err(err).Any("payload", req).Msg("service::Register - Failed to insert new user")
-			return nil, err_msg.NewCustomErrors(fiber.StatusConflict, err_msg.WithMessage(constants.ErrNikAlreadyRegistered))
-		}
+		ProvinceCode:    identity.ProvinceCode,
+		RegencyCode:     identity.RegencyCode,
+		DistrictCode:    identity.DistrictCode,
+		ReviewStatus:    reviewStatus,
+	}
+
+	if hashes := screening.KtpPhoto; hashes != nil {
+		customer.KtpPhotoHash = sql.NullString{String: hashes.SHA256, Valid: true}
+		customer.KtpPhotoPHash = sql.NullInt64{Int64: int64(hashes.Perceptual), Valid: hashes.HasPerceptual}
+	}
+
+	if hashes := screening.SelfiePhoto; hashes != nil {
+		customer.SelfiePhotoHash = sql.NullString{String: hashes.SHA256, Valid: true}
+		customer.SelfiePhotoPHash = sql.NullInt64{Int64: int64(hashes.Perceptual), Valid: hashes.HasPerceptual}
+	}
 
-		if strings.Contains(err.Error(), constants.ErrEmailAlreadyRegistered) {
-			log.Error().Err(err).Any("payload", req).Msg("service::Register - Failed to insert new user")
-			return nil, err_msg.NewCustomErrors(fiber.StatusConflict, err_msg.WithMessage(constants.ErrEmailAlreadyRegistered))
+	result, err := s.customerRepository.InsertNewUser(ctx, tx, customer)
+	if err != nil {
+		// the NIK or email is already registered
+		if err_msg.HasCode(err, fiber.StatusConflict) {
+			log.Ctx(ctx).Warn().Err(err).Any("payload", req).Msg("service::Register - Customer already registered")
+			return nil, registeredConflict(err)
 		}
 
-		log.Error().Err(err).Any("payload", req).Msg("service::Register - Failed to insert new user")
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::Register - Failed to insert new user")
 		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
@@ -120,42 +213,140 @@ func (s *authService) Register(ctx context.Context, req *dto.RegisterRequest) (*
 		}
 	}
 
+	if screening.Flagged() {
+		if _, err = s.fraud.OpenReview(ctx, tx, result.ID, constants.FraudTriggerRegistration, screening.Reasons); err != nil {
+			log.Ctx(ctx).Error().Err(err).Int64("customer_id", result.ID).Msg("service::Register - Failed to open fraud review")
+			return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		}
+	}
+
+	creditScore, err := creditScoreEntity.NewCreditScore(result.ID, constants.CreditScoreTriggerRegistration, score)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("score", score).Msg("service::Register - Failed to build credit score")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	if err = s.creditScoreRepository.InsertNewCreditScore(ctx, tx, creditScore); err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", creditScore).Msg("service::Register - Failed to insert new credit score")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	// the salary band sets the base limits, the grade scales them
+	for i := range defaultLimits {
+		defaultLimits[i].LimitAmount = math.Round(defaultLimits[i].LimitAmount * score.Grade.LimitMultiplier)
+	}
+
 	for _, limit := range defaultLimits {
 		if err := s.creditLimitRepository.InsertNewCreditLimit(ctx, tx, &limit); err != nil {
-			log.Error().Err(err).Any("payload", limit).Msg("service::Register - Failed to insert new credit limit")
+			log.Ctx(ctx).Error().Err(err).Any("payload", limit).Msg("service::Register - Failed to insert new credit limit")
 			return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 		}
 	}
 
+	// the events commit or roll back together with the customer
+	if err = s.recordRegistrationEvents(ctx, tx, result.ID, reviewStatus, defaultLimits); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", result.ID).Msg("service::Register - Failed to record registration events")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
 	if err := tx.Commit(); err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("service::Register - Failed to commit transaction")
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::Register - Failed to commit transaction")
 		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
+	metrics.Registrations.WithLabelValues(reviewStatus).Inc()
+
 	return &dto.RegisterResponse{
-		ID:    result.ID,
-		Email: result.Email,
+		ID:           result.ID,
+		Email:        result.Email,
+		ReviewStatus: reviewStatus,
 	}, nil
 }
 
+// registeredFields holds the conflict message a client gets for each unique
+// field of a customer.
+var registeredFields = map[string]string{
+	"nik":   constants.ErrNikAlreadyRegistered,
+	"email": constants.ErrEmailAlreadyRegistered,
+}
+
+// registeredConflict swaps the generic duplicate entry error for the message of
+// the field that is already registered.
+func registeredConflict(err error) error {
+	var customErr *err_msg.CustomError
+	if !errors.As(err, &customErr) {
+		return err
+	}
+
+	for field, msg := range registeredFields {
+		if _, ok := customErr.Errors[field]; ok {
+			return err_msg.NewCustomErrors(fiber.StatusConflict, err_msg.WithMessage(msg), err_msg.WithErrors(field, msg))
+		}
+	}
+
+	return err
+}
+
+func (s *authService) recordRegistrationEvents(ctx context.Context, tx *sql.Tx, customerID int64, reviewStatus string, limits []creditLimitEntity.CreditLimit) error {
+	aggregateID := strconv.FormatInt(customerID, 10)
+
+	registered, err := outboxEntity.NewOutboxEvent(constants.AggregateTypeCustomer, aggregateID, constants.EventCustomerRegistered, constants.EventCustomerRegisteredVersion, &outboxDto.CustomerRegisteredV1{
+		CustomerID:   customerID,
+		ReviewStatus: reviewStatus,
+	})
+	if err != nil {
+		return err
+	}
+
+	assigned := &outboxDto.CreditLimitsAssignedV1{
+		CustomerID: customerID,
+		Trigger:    constants.CreditLimitTriggerRegistration,
+		Limits:     make([]outboxDto.CreditLimitV1, 0, len(limits)),
+	}
+	for _, limit := range limits {
+		assigned.Limits = append(assigned.Limits, outboxDto.CreditLimitV1{
+			TenorMonth:  limit.TenorMonth,
+			LimitAmount: limit.LimitAmount,
+		})
+	}
+
+	limitsAssigned, err := outboxEntity.NewOutboxEvent(constants.AggregateTypeCustomer, aggregateID, constants.EventCreditLimitsAssigned, constants.EventCreditLimitsAssignedVersion, assigned)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range []*outboxEntity.OutboxEvent{registered, limitsAssigned} {
+		if err := s.outboxRepository.InsertNewOutboxEvent(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *authService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
+	ctx, span := tracing.Start(ctx, "authService.Login")
+	defer span.End()
+
 	var (
 		res = new(dto.LoginResponse)
 	)
 
 	customerData, err := s.customerRepository.FindCustomerByEmail(ctx, req.Email)
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("service::Login - Failed to find user")
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::Login - Failed to find user")
 		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
 	if customerData == nil {
-		log.Error().Any("payload", req).Msg("service::Login - Email not found")
+		log.Ctx(ctx).Error().Any("payload", req).Msg("service::Login - Email not found")
+		metrics.LoginFailures.WithLabelValues(metrics.LoginFailureUnknownEmail).Inc()
 		return nil, err_msg.NewCustomErrors(fiber.StatusUnprocessableEntity, err_msg.WithMessage(constants.ErrEmailOrPasswordIsIncorrect))
 	}
 
 	if !utils.ComparePassword(customerData.Password, req.Password) {
-		log.Error().Any("payload", req).Msg("service::Login - Password is incorrect")
+		log.Ctx(ctx).Error().Any("payload", req).Msg("service::Login - Password is incorrect")
+		metrics.LoginFailures.WithLabelValues(metrics.LoginFailureWrongPassword).Inc()
 		return nil, err_msg.NewCustomErrors(fiber.StatusUnprocessableEntity, err_msg.WithMessage(constants.ErrEmailOrPasswordIsIncorrect))
 	}
 
@@ -167,7 +358,7 @@ func (s *authService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.Lo
 		TokenType:  constants.AccessTokenType,
 	})
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("service::Login - Failed to generate token string")
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::Login - Failed to generate token string")
 		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
@@ -179,7 +370,7 @@ func (s *authService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.Lo
 		TokenType:  constants.RefreshTokenType,
 	})
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("service::Login - Failed to generate token string")
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::Login - Failed to generate token string")
 		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
@@ -193,13 +384,16 @@ func (s *authService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.Lo
 }
 
 func (s *authService) RefreshToken(ctx context.Context, accessToken string) (*dto.RefreshTokenResponse, error) {
+	ctx, span := tracing.Start(ctx, "authService.RefreshToken")
+	defer span.End()
+
 	var (
 		res = new(dto.RefreshTokenResponse)
 	)
 
 	claims, err := s.jwt.ParseTokenString(ctx, accessToken)
 	if err != nil {
-		log.Error().Err(err).Any("access_token", accessToken).Msg("service::RefreshToken - Failed to parse access token")
+		log.Ctx(ctx).Error().Err(err).Msg("service::RefreshToken - Failed to parse access token")
 		return nil, err_msg.NewCustomErrors(fiber.StatusUnauthorized, err_msg.WithMessage(constants.ErrInvalidAccessToken))
 	}
 
@@ -213,7 +407,7 @@ func (s *authService) RefreshToken(ctx context.Context, accessToken string) (*dt
 		TokenType:  constants.AccessTokenType,
 	})
 	if err != nil {
-		log.Error().Err(err).Any("payload", claims).Msg("service::RefreshToken - Failed to generate token string")
+		log.Ctx(ctx).Error().Err(err).Any("payload", claims).Msg("service::RefreshToken - Failed to generate token string")
 		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
@@ -223,17 +417,20 @@ func (s *authService) RefreshToken(ctx context.Context, accessToken string) (*dt
 }
 
 func (s *authService) Logout(ctx context.Context, accessToken string, locals *middleware.Locals) error {
+	ctx, span := tracing.Start(ctx, "authService.Logout")
+	defer span.End()
+
 	key := fmt.Sprintf("%s:%s", locals.Nik, constants.AccessTokenType)
 
 	_, err := s.redisDB.Get(ctx, key)
 	if err != nil {
-		log.Error().Err(err).Msg("jwthandler::ParseTokenString - Token not found in Redis")
+		log.Ctx(ctx).Error().Err(err).Msg("jwthandler::ParseTokenString - Token not found in Redis")
 		return err_msg.NewCustomErrors(fiber.StatusUnauthorized, err_msg.WithMessage(constants.ErrTokenAlreadyExpired))
 	}
 
 	claims, err := s.jwt.ParseTokenString(ctx, accessToken)
 	if err != nil {
-		log.Error().Err(err).Any("access_token", accessToken).Msg("service::Logout - Failed to parse access token")
+		log.Ctx(ctx).Error().Err(err).Msg("service::Logout - Failed to parse access token")
 		return err_msg.NewCustomErrors(fiber.StatusUnauthorized, err_msg.WithMessage(constants.ErrInvalidAccessToken))
 	}
 
@@ -241,7 +438,7 @@ func (s *authService) Logout(ctx context.Context, accessToken string, locals *mi
 
 	err = s.redisDB.Del(ctx, key)
 	if err != nil {
-		log.Error().Err(err).Any("access_token", accessToken).Msg("service::Logout - Failed to set access token to redis")
+		log.Ctx(ctx).Error().Err(err).Msg("service::Logout - Failed to set access token to redis")
 		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
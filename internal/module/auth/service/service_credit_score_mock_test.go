@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ports.go -destination=../../auth/service/service_credit_score_mock_test.go -package=service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	entity "github.com/hilmiikhsan/multifinance-service/internal/module/credit_score/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCreditScoreRepository is a mock of CreditScoreRepository interface.
+type MockCreditScoreRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreditScoreRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCreditScoreRepositoryMockRecorder is the mock recorder for MockCreditScoreRepository.
+type MockCreditScoreRepositoryMockRecorder struct {
+	mock *MockCreditScoreRepository
+}
+
+// NewMockCreditScoreRepository creates a new mock instance.
+func NewMockCreditScoreRepository(ctrl *gomock.Controller) *MockCreditScoreRepository {
+	mock := &MockCreditScoreRepository{ctrl: ctrl}
+	mock.recorder = &MockCreditScoreRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreditScoreRepository) EXPECT() *MockCreditScoreRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindLatestCreditScoreByCustomerID mocks base method.
+func (m *MockCreditScoreRepository) FindLatestCreditScoreByCustomerID(ctx context.Context, customerID int) (*entity.CreditScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestCreditScoreByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].(*entity.CreditScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestCreditScoreByCustomerID indicates an expected call of FindLatestCreditScoreByCustomerID.
+func (mr *MockCreditScoreRepositoryMockRecorder) FindLatestCreditScoreByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestCreditScoreByCustomerID", reflect.TypeOf((*MockCreditScoreRepository)(nil).FindLatestCreditScoreByCustomerID), ctx, customerID)
+}
+
+// InsertNewCreditScore mocks base method.
+func (m *MockCreditScoreRepository) InsertNewCreditScore(ctx context.Context, tx *sql.Tx, data *entity.CreditScore) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewCreditScore", ctx, tx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNewCreditScore indicates an expected call of InsertNewCreditScore.
+func (mr *MockCreditScoreRepositoryMockRecorder) InsertNewCreditScore(ctx, tx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewCreditScore", reflect.TypeOf((*MockCreditScoreRepository)(nil).InsertNewCreditScore), ctx, tx, data)
+}
@@ -5,28 +5,76 @@ import (
 	"errors"
 	"fmt"
 	reflect "reflect"
+	"strconv"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-redis/redis/v8"
+	"github.com/go-sql-driver/mysql"
+	"github.com/gofiber/fiber/v2"
 	"github.com/hilmiikhsan/multifinance-service/constants"
 	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/auth/dto"
 	creditLimitEntity "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/entity"
+	creditScoreEntity "github.com/hilmiikhsan/multifinance-service/internal/module/credit_score/entity"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/entity"
+	fraudDto "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/dto"
+	outboxEntity "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/eligibility"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/imagehash"
 	"github.com/hilmiikhsan/multifinance-service/pkg/jwt_handler"
+	"github.com/hilmiikhsan/multifinance-service/pkg/scoring"
 	"github.com/hilmiikhsan/multifinance-service/pkg/utils"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
+type stubScorer struct {
+	result *scoring.Result
+}
+
+func (s stubScorer) Score(inputs scoring.Inputs) (*scoring.Result, error) {
+	res := *s.result
+	res.Inputs = inputs
+	return &res, nil
+}
+
+func registrationScore(customerID int64, grade string) gomock.Matcher {
+	return gomock.Cond(func(data *creditScoreEntity.CreditScore) bool {
+		return data.CustomerID == customerID &&
+			data.TriggerEvent == constants.CreditScoreTriggerRegistration &&
+			data.Grade == grade
+	})
+}
+
+func registrationEvent(customerID int64, eventType string) gomock.Matcher {
+	return gomock.Cond(func(data *outboxEntity.OutboxEvent) bool {
+		return data.AggregateType == constants.AggregateTypeCustomer &&
+			data.AggregateID == strconv.FormatInt(customerID, 10) &&
+			data.EventType == eventType &&
+			data.EventID != ""
+	})
+}
+
 func Test_authService_Register(t *testing.T) {
 	ctrlMock := gomock.NewController(t)
 	defer ctrlMock.Finish()
 
 	customerMockRepo := NewMockCustomerRepository(ctrlMock)
 	creditLimitMockRepo := NewMockCreditLimitRepository(ctrlMock)
+	creditScoreMockRepo := NewMockCreditScoreRepository(ctrlMock)
+	fraudMockScreener := NewMockFraudScreener(ctrlMock)
+	outboxMockRepo := NewMockOutboxRepository(ctrlMock)
+
+	gradeB := &scoring.Result{
+		Version:     "v1",
+		Score:       710,
+		Grade:       scoring.Grade{Name: "B", MinScore: 680, LimitMultiplier: 1.0, Approve: true},
+		ReasonCodes: []string{"SALARY_BELOW_AVERAGE", "TENURE_SHORT"},
+	}
 
 	type args struct {
 		ctx context.Context
@@ -35,8 +83,10 @@ func Test_authService_Register(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
+		score   *scoring.Result
 		want    *dto.RegisterResponse
 		wantErr bool
+		wantMsg string
 		mockFn  func(args args, dbMock sqlmock.Sqlmock)
 	}{
 		{
@@ -44,7 +94,7 @@ func Test_authService_Register(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				req: &dto.RegisterRequest{
-					Nik:             "123456789",
+					Nik:             "3174010101900001",
 					Email:           "test@example.com",
 					Password:        "testpass",
 					FullName:        "Test User",
@@ -57,37 +107,53 @@ func Test_authService_Register(t *testing.T) {
 				},
 			},
 			want: &dto.RegisterResponse{
-				ID:    1,
-				Email: "test@example.com",
+				ID:           1,
+				Email:        "test@example.com",
+				ReviewStatus: constants.CustomerReviewStatusClear,
 			},
 			wantErr: false,
 			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				fraudMockScreener.EXPECT().
+					Screen(gomock.Any(), gomock.Any()).
+					Return(&fraudDto.ScreeningResult{}, nil)
+
 				dbMock.ExpectBegin()
 
 				customerMockRepo.EXPECT().
-					InsertNewUser(args.ctx, gomock.Any(), gomock.Any()).
+					InsertNewUser(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Customer{
 						ID:    1,
 						Email: "test@example.com",
 					}, nil)
 
+				creditScoreMockRepo.EXPECT().
+					InsertNewCreditScore(gomock.Any(), gomock.Any(), registrationScore(1, "B")).
+					Return(nil)
+
 				creditLimitMockRepo.EXPECT().
-					InsertNewCreditLimit(args.ctx, gomock.Any(), &creditLimitEntity.CreditLimit{
+					InsertNewCreditLimit(gomock.Any(), gomock.Any(), &creditLimitEntity.CreditLimit{
 						CustomerID: 1, TenorMonth: 1, LimitAmount: 100000,
 					}).Return(nil)
 				creditLimitMockRepo.EXPECT().
-					InsertNewCreditLimit(args.ctx, gomock.Any(), &creditLimitEntity.CreditLimit{
+					InsertNewCreditLimit(gomock.Any(), gomock.Any(), &creditLimitEntity.CreditLimit{
 						CustomerID: 1, TenorMonth: 2, LimitAmount: 200000,
 					}).Return(nil)
 				creditLimitMockRepo.EXPECT().
-					InsertNewCreditLimit(args.ctx, gomock.Any(), &creditLimitEntity.CreditLimit{
+					InsertNewCreditLimit(gomock.Any(), gomock.Any(), &creditLimitEntity.CreditLimit{
 						CustomerID: 1, TenorMonth: 3, LimitAmount: 500000,
 					}).Return(nil)
 				creditLimitMockRepo.EXPECT().
-					InsertNewCreditLimit(args.ctx, gomock.Any(), &creditLimitEntity.CreditLimit{
+					InsertNewCreditLimit(gomock.Any(), gomock.Any(), &creditLimitEntity.CreditLimit{
 						CustomerID: 1, TenorMonth: 6, LimitAmount: 700000,
 					}).Return(nil)
 
+				outboxMockRepo.EXPECT().
+					InsertNewOutboxEvent(gomock.Any(), gomock.Any(), registrationEvent(1, constants.EventCustomerRegistered)).
+					Return(nil)
+				outboxMockRepo.EXPECT().
+					InsertNewOutboxEvent(gomock.Any(), gomock.Any(), registrationEvent(1, constants.EventCreditLimitsAssigned)).
+					Return(nil)
+
 				dbMock.ExpectCommit()
 			},
 		},
@@ -96,7 +162,7 @@ func Test_authService_Register(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				req: &dto.RegisterRequest{
-					Nik:             "987654321",
+					Nik:             "3273020106850002",
 					Email:           "middle@example.com",
 					Password:        "midpass",
 					FullName:        "Middle User",
@@ -109,41 +175,242 @@ func Test_authService_Register(t *testing.T) {
 				},
 			},
 			want: &dto.RegisterResponse{
-				ID:    2,
-				Email: "middle@example.com",
+				ID:           2,
+				Email:        "middle@example.com",
+				ReviewStatus: constants.CustomerReviewStatusClear,
 			},
 			wantErr: false,
 			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				fraudMockScreener.EXPECT().
+					Screen(gomock.Any(), gomock.Any()).
+					Return(&fraudDto.ScreeningResult{}, nil)
+
 				dbMock.ExpectBegin()
 
 				customerMockRepo.EXPECT().
-					InsertNewUser(args.ctx, gomock.Any(), gomock.Any()).
+					InsertNewUser(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Customer{
 						ID:    2,
 						Email: "middle@example.com",
 					}, nil)
 
+				creditScoreMockRepo.EXPECT().
+					InsertNewCreditScore(gomock.Any(), gomock.Any(), registrationScore(2, "B")).
+					Return(nil)
+
 				// Sesuaikan ekspektasi sesuai logika salary 5M-10M
 				creditLimitMockRepo.EXPECT().
-					InsertNewCreditLimit(args.ctx, gomock.Any(), &creditLimitEntity.CreditLimit{
+					InsertNewCreditLimit(gomock.Any(), gomock.Any(), &creditLimitEntity.CreditLimit{
 						CustomerID: 2, TenorMonth: 1, LimitAmount: 200000,
 					}).Return(nil)
 				creditLimitMockRepo.EXPECT().
-					InsertNewCreditLimit(args.ctx, gomock.Any(), &creditLimitEntity.CreditLimit{
+					InsertNewCreditLimit(gomock.Any(), gomock.Any(), &creditLimitEntity.CreditLimit{
 						CustomerID: 2, TenorMonth: 2, LimitAmount: 400000,
 					}).Return(nil)
 				creditLimitMockRepo.EXPECT().
-					InsertNewCreditLimit(args.ctx, gomock.Any(), &creditLimitEntity.CreditLimit{
+					InsertNewCreditLimit(gomock.Any(), gomock.Any(), &creditLimitEntity.CreditLimit{
 						CustomerID: 2, TenorMonth: 3, LimitAmount: 800000,
 					}).Return(nil)
 				creditLimitMockRepo.EXPECT().
-					InsertNewCreditLimit(args.ctx, gomock.Any(), &creditLimitEntity.CreditLimit{
+					InsertNewCreditLimit(gomock.Any(), gomock.Any(), &creditLimitEntity.CreditLimit{
 						CustomerID: 2, TenorMonth: 6, LimitAmount: 1200000,
 					}).Return(nil)
 
+				outboxMockRepo.EXPECT().
+					InsertNewOutboxEvent(gomock.Any(), gomock.Any(), registrationEvent(2, constants.EventCustomerRegistered)).
+					Return(nil)
+				outboxMockRepo.EXPECT().
+					InsertNewOutboxEvent(gomock.Any(), gomock.Any(), registrationEvent(2, constants.EventCreditLimitsAssigned)).
+					Return(nil)
+
 				dbMock.ExpectCommit()
 			},
 		},
+		{
+			name: "Register Success - Grade A Raises Limits",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.RegisterRequest{
+					Nik:             "3174010101900001",
+					Email:           "prime@example.com",
+					Password:        "primepass",
+					FullName:        "Prime User",
+					LegalName:       "Prime Legal",
+					BirthPlace:      "City",
+					BirthDate:       "1990-01-01",
+					Salary:          4000000,
+					KtpPhotoPath:    "/path/prime_ktp.jpg",
+					SelfiePhotoPath: "/path/prime_selfie.jpg",
+				},
+			},
+			score: &scoring.Result{
+				Version:     "v1",
+				Score:       780,
+				Grade:       scoring.Grade{Name: "A", MinScore: 760, LimitMultiplier: 1.25, Approve: true},
+				ReasonCodes: []string{},
+			},
+			want: &dto.RegisterResponse{
+				ID:           3,
+				Email:        "prime@example.com",
+				ReviewStatus: constants.CustomerReviewStatusClear,
+			},
+			wantErr: false,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				fraudMockScreener.EXPECT().
+					Screen(gomock.Any(), gomock.Any()).
+					Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				customerMockRepo.EXPECT().
+					InsertNewUser(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&entity.Customer{
+						ID:    3,
+						Email: "prime@example.com",
+					}, nil)
+
+				creditScoreMockRepo.EXPECT().
+					InsertNewCreditScore(gomock.Any(), gomock.Any(), registrationScore(3, "A")).
+					Return(nil)
+
+				creditLimitMockRepo.EXPECT().
+					InsertNewCreditLimit(gomock.Any(), gomock.Any(), &creditLimitEntity.CreditLimit{
+						CustomerID: 3, TenorMonth: 1, LimitAmount: 125000,
+					}).Return(nil)
+				creditLimitMockRepo.EXPECT().
+					InsertNewCreditLimit(gomock.Any(), gomock.Any(), &creditLimitEntity.CreditLimit{
+						CustomerID: 3, TenorMonth: 2, LimitAmount: 250000,
+					}).Return(nil)
+				creditLimitMockRepo.EXPECT().
+					InsertNewCreditLimit(gomock.Any(), gomock.Any(), &creditLimitEntity.CreditLimit{
+						CustomerID: 3, TenorMonth: 3, LimitAmount: 625000,
+					}).Return(nil)
+				creditLimitMockRepo.EXPECT().
+					InsertNewCreditLimit(gomock.Any(), gomock.Any(), &creditLimitEntity.CreditLimit{
+						CustomerID: 3, TenorMonth: 6, LimitAmount: 875000,
+					}).Return(nil)
+
+				outboxMockRepo.EXPECT().
+					InsertNewOutboxEvent(gomock.Any(), gomock.Any(), registrationEvent(3, constants.EventCustomerRegistered)).
+					Return(nil)
+				outboxMockRepo.EXPECT().
+					InsertNewOutboxEvent(gomock.Any(), gomock.Any(), registrationEvent(3, constants.EventCreditLimitsAssigned)).
+					Return(nil)
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name: "Register Success - Flagged For Manual Review",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.RegisterRequest{
+					Nik:             "3174010101900001",
+					Email:           "flagged@example.com",
+					PhoneNumber:     "081234567890",
+					Password:        "flagpass",
+					FullName:        "Flagged User",
+					LegalName:       "Flagged Legal",
+					BirthPlace:      "City",
+					BirthDate:       "1990-01-01",
+					Salary:          4000000,
+					KtpPhotoPath:    "/path/flag_ktp.jpg",
+					SelfiePhotoPath: "/path/flag_selfie.jpg",
+					DeviceID:        "device-1",
+				},
+			},
+			want: &dto.RegisterResponse{
+				ID:           4,
+				Email:        "flagged@example.com",
+				ReviewStatus: constants.CustomerReviewStatusPendingReview,
+			},
+			wantErr: false,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				reasons := []fraudDto.Reason{{Code: constants.FraudReasonDuplicateKtpPhoto, Detail: "matches customer 1"}}
+
+				fraudMockScreener.EXPECT().
+					Screen(gomock.Any(), &fraudDto.ScreeningSubject{
+						Nik:             "3174010101900001",
+						Email:           "flagged@example.com",
+						PhoneNumber:     "081234567890",
+						DeviceID:        "device-1",
+						KtpPhotoPath:    "/path/flag_ktp.jpg",
+						SelfiePhotoPath: "/path/flag_selfie.jpg",
+					}).
+					Return(&fraudDto.ScreeningResult{
+						Reasons:  reasons,
+						KtpPhoto: &imagehash.Hashes{SHA256: "abc", Perceptual: 42, HasPerceptual: true},
+					}, nil)
+
+				dbMock.ExpectBegin()
+
+				customerMockRepo.EXPECT().
+					InsertNewUser(gomock.Any(), gomock.Any(), gomock.Cond(func(data *entity.Customer) bool {
+						return data.ReviewStatus == constants.CustomerReviewStatusPendingReview &&
+							data.KtpPhotoHash.String == "abc" &&
+							data.KtpPhotoPHash.Int64 == 42 &&
+							!data.SelfiePhotoHash.Valid &&
+							data.PhoneNumber.String == "081234567890" &&
+							data.DeviceID.String == "device-1"
+					})).
+					Return(&entity.Customer{
+						ID:    4,
+						Email: "flagged@example.com",
+					}, nil)
+
+				fraudMockScreener.EXPECT().
+					OpenReview(gomock.Any(), gomock.Any(), int64(4), constants.FraudTriggerRegistration, reasons).
+					Return(true, nil)
+
+				creditScoreMockRepo.EXPECT().
+					InsertNewCreditScore(gomock.Any(), gomock.Any(), registrationScore(4, "B")).
+					Return(nil)
+
+				creditLimitMockRepo.EXPECT().
+					InsertNewCreditLimit(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(4)
+
+				outboxMockRepo.EXPECT().
+					InsertNewOutboxEvent(gomock.Any(), gomock.Any(), registrationEvent(4, constants.EventCustomerRegistered)).
+					Return(nil)
+				outboxMockRepo.EXPECT().
+					InsertNewOutboxEvent(gomock.Any(), gomock.Any(), registrationEvent(4, constants.EventCreditLimitsAssigned)).
+					Return(nil)
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name: "Error NIK Does Not Match Birth Date",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.RegisterRequest{
+					Nik:       "3174010101900001",
+					BirthDate: "1991-01-01",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				// NIK is rejected before the transaction begins
+			},
+		},
+		{
+			name: "Error Customer Below Minimum Age",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.RegisterRequest{
+					Nik:       "3174010101150001",
+					BirthDate: "2015-01-01",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				// eligibility is checked before the transaction begins
+			},
+		},
 		{
 			name: "Error Saat Hashing Password",
 			args: args{
@@ -163,17 +430,49 @@ func Test_authService_Register(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				req: &dto.RegisterRequest{
-					Nik: "duplicate_nik",
+					Nik:       "3174010101900001",
+					BirthDate: "1990-01-01",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			wantMsg: constants.ErrNikAlreadyRegistered,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				fraudMockScreener.EXPECT().
+					Screen(gomock.Any(), gomock.Any()).
+					Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				customerMockRepo.EXPECT().
+					InsertNewUser(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, err_msg.NewDatabaseErrors(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '3174010101900001' for key 'customers.nik'"}))
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name: "Error Saat InsertNewUser - Email Sudah Terdaftar",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.RegisterRequest{
+					Nik:       "3174010101900001",
+					BirthDate: "1990-01-01",
 				},
 			},
 			want:    nil,
 			wantErr: true,
+			wantMsg: constants.ErrEmailAlreadyRegistered,
 			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				fraudMockScreener.EXPECT().
+					Screen(gomock.Any(), gomock.Any()).
+					Return(&fraudDto.ScreeningResult{}, nil)
+
 				dbMock.ExpectBegin()
 
 				customerMockRepo.EXPECT().
-					InsertNewUser(args.ctx, gomock.Any(), gomock.Any()).
-					Return(nil, errors.New(constants.ErrNikAlreadyRegistered))
+					InsertNewUser(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, err_msg.NewDatabaseErrors(&pq.Error{Code: err_msg.PostgresErrUniqueViolation, Table: "customers", Constraint: "customers_email_key"}))
 
 				dbMock.ExpectRollback()
 			},
@@ -192,10 +491,23 @@ func Test_authService_Register(t *testing.T) {
 
 			tt.mockFn(tt.args, dbMock)
 
+			score := tt.score
+			if score == nil {
+				score = gradeB
+			}
+
 			s := &authService{
 				db:                    mockDB,
 				customerRepository:    customerMockRepo,
 				creditLimitRepository: creditLimitMockRepo,
+				eligibility: eligibility.NewEngine(
+					eligibility.MinAgeAtApplication(21),
+					eligibility.MaxAgeAtTenorEnd(60),
+				),
+				creditScoreRepository: creditScoreMockRepo,
+				scorer:                stubScorer{result: score},
+				fraud:                 fraudMockScreener,
+				outboxRepository:      outboxMockRepo,
 			}
 
 			got, err := s.Register(tt.args.ctx, tt.args.req)
@@ -204,6 +516,10 @@ func Test_authService_Register(t *testing.T) {
 				t.Errorf("authService.Register() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantMsg != "" {
+				assert.True(t, err_msg.HasCode(err, fiber.StatusConflict))
+				assert.Equal(t, tt.wantMsg, err.Error())
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("authService.Register() = %v, want %v", got, tt.want)
 			}
@@ -254,7 +570,7 @@ func Test_authService_Login(t *testing.T) {
 			wantErr: false,
 			mockFn: func(args args) {
 				customerMockRepo.EXPECT().
-					FindCustomerByEmail(args.ctx, args.req.Email).
+					FindCustomerByEmail(gomock.Any(), args.req.Email).
 					Return(&entity.Customer{
 						ID:       1,
 						Nik:      "123456789",
@@ -264,7 +580,7 @@ func Test_authService_Login(t *testing.T) {
 					}, nil)
 
 				mockJWT.EXPECT().
-					GenerateTokenString(args.ctx, jwt_handler.CostumClaimsPayload{
+					GenerateTokenString(gomock.Any(), jwt_handler.CostumClaimsPayload{
 						CustomerID: 1,
 						Nik:        "123456789",
 						Email:      "test@example.com",
@@ -274,7 +590,7 @@ func Test_authService_Login(t *testing.T) {
 					Return("access-token", nil)
 
 				mockJWT.EXPECT().
-					GenerateTokenString(args.ctx, jwt_handler.CostumClaimsPayload{
+					GenerateTokenString(gomock.Any(), jwt_handler.CostumClaimsPayload{
 						CustomerID: 1,
 						Nik:        "123456789",
 						Email:      "test@example.com",
@@ -297,7 +613,7 @@ func Test_authService_Login(t *testing.T) {
 			wantErr: true,
 			mockFn: func(args args) {
 				customerMockRepo.EXPECT().
-					FindCustomerByEmail(args.ctx, args.req.Email).
+					FindCustomerByEmail(gomock.Any(), args.req.Email).
 					Return(nil, nil)
 			},
 		},
@@ -314,7 +630,7 @@ func Test_authService_Login(t *testing.T) {
 			wantErr: true,
 			mockFn: func(args args) {
 				customerMockRepo.EXPECT().
-					FindCustomerByEmail(args.ctx, args.req.Email).
+					FindCustomerByEmail(gomock.Any(), args.req.Email).
 					Return(&entity.Customer{
 						ID:       1,
 						Nik:      "123456789",
@@ -337,7 +653,7 @@ func Test_authService_Login(t *testing.T) {
 			wantErr: true,
 			mockFn: func(args args) {
 				customerMockRepo.EXPECT().
-					FindCustomerByEmail(args.ctx, args.req.Email).
+					FindCustomerByEmail(gomock.Any(), args.req.Email).
 					Return(&entity.Customer{
 						ID:       1,
 						Nik:      "123456789",
@@ -347,7 +663,7 @@ func Test_authService_Login(t *testing.T) {
 					}, nil)
 
 				mockJWT.EXPECT().
-					GenerateTokenString(args.ctx, jwt_handler.CostumClaimsPayload{
+					GenerateTokenString(gomock.Any(), jwt_handler.CostumClaimsPayload{
 						CustomerID: 1,
 						Nik:        "123456789",
 						Email:      "test@example.com",
@@ -370,7 +686,7 @@ func Test_authService_Login(t *testing.T) {
 			wantErr: true,
 			mockFn: func(args args) {
 				customerMockRepo.EXPECT().
-					FindCustomerByEmail(args.ctx, args.req.Email).
+					FindCustomerByEmail(gomock.Any(), args.req.Email).
 					Return(&entity.Customer{
 						ID:       1,
 						Nik:      "123456789",
@@ -380,7 +696,7 @@ func Test_authService_Login(t *testing.T) {
 					}, nil)
 
 				mockJWT.EXPECT().
-					GenerateTokenString(args.ctx, jwt_handler.CostumClaimsPayload{
+					GenerateTokenString(gomock.Any(), jwt_handler.CostumClaimsPayload{
 						CustomerID: 1,
 						Nik:        "123456789",
 						Email:      "test@example.com",
@@ -390,7 +706,7 @@ func Test_authService_Login(t *testing.T) {
 					Return("access-token", nil)
 
 				mockJWT.EXPECT().
-					GenerateTokenString(args.ctx, jwt_handler.CostumClaimsPayload{
+					GenerateTokenString(gomock.Any(), jwt_handler.CostumClaimsPayload{
 						CustomerID: 1,
 						Nik:        "123456789",
 						Email:      "test@example.com",
@@ -452,7 +768,7 @@ func Test_authService_RefreshToken(t *testing.T) {
 			wantErr: false,
 			mockFn: func(args args) {
 				mockJWT.EXPECT().
-					ParseTokenString(args.ctx, args.accessToken).
+					ParseTokenString(gomock.Any(), args.accessToken).
 					Return(&jwt_handler.CustomClaims{
 						Nik:      "123456789",
 						Email:    "test@example.com",
@@ -460,7 +776,7 @@ func Test_authService_RefreshToken(t *testing.T) {
 					}, nil)
 
 				mockJWT.EXPECT().
-					GenerateTokenString(args.ctx, jwt_handler.CostumClaimsPayload{
+					GenerateTokenString(gomock.Any(), jwt_handler.CostumClaimsPayload{
 						Nik:       "123456789",
 						Email:     "test@example.com",
 						FullName:  "Test User",
@@ -479,7 +795,7 @@ func Test_authService_RefreshToken(t *testing.T) {
 			wantErr: true,
 			mockFn: func(args args) {
 				mockJWT.EXPECT().
-					ParseTokenString(args.ctx, args.accessToken).
+					ParseTokenString(gomock.Any(), args.accessToken).
 					Return(nil, errors.New("invalid token"))
 			},
 		},
@@ -493,7 +809,7 @@ func Test_authService_RefreshToken(t *testing.T) {
 			wantErr: true,
 			mockFn: func(args args) {
 				mockJWT.EXPECT().
-					ParseTokenString(args.ctx, args.accessToken).
+					ParseTokenString(gomock.Any(), args.accessToken).
 					Return(&jwt_handler.CustomClaims{
 						Nik:      "123456789",
 						Email:    "test@example.com",
@@ -501,7 +817,7 @@ func Test_authService_RefreshToken(t *testing.T) {
 					}, nil)
 
 				mockJWT.EXPECT().
-					GenerateTokenString(args.ctx, jwt_handler.CostumClaimsPayload{
+					GenerateTokenString(gomock.Any(), jwt_handler.CostumClaimsPayload{
 						Nik:       "123456789",
 						Email:     "test@example.com",
 						FullName:  "Test User",
@@ -565,17 +881,17 @@ func Test_authService_Logout(t *testing.T) {
 				key := fmt.Sprintf("%s:%s", args.locals.Nik, constants.AccessTokenType)
 
 				mockRedis.EXPECT().
-					Get(args.ctx, key).
+					Get(gomock.Any(), key).
 					Return("token-in-redis", nil)
 
 				mockJWT.EXPECT().
-					ParseTokenString(args.ctx, args.accessToken).
+					ParseTokenString(gomock.Any(), args.accessToken).
 					Return(&jwt_handler.CustomClaims{
 						Nik: args.locals.Nik,
 					}, nil)
 
 				mockRedis.EXPECT().
-					Del(args.ctx, key).
+					Del(gomock.Any(), key).
 					Return(nil)
 			},
 		},
@@ -593,7 +909,7 @@ func Test_authService_Logout(t *testing.T) {
 				key := fmt.Sprintf("%s:%s", args.locals.Nik, constants.AccessTokenType)
 
 				mockRedis.EXPECT().
-					Get(args.ctx, key).
+					Get(gomock.Any(), key).
 					Return("", redis.Nil)
 			},
 		},
@@ -611,11 +927,11 @@ func Test_authService_Logout(t *testing.T) {
 				key := fmt.Sprintf("%s:%s", args.locals.Nik, constants.AccessTokenType)
 
 				mockRedis.EXPECT().
-					Get(args.ctx, key).
+					Get(gomock.Any(), key).
 					Return("token-in-redis", nil)
 
 				mockJWT.EXPECT().
-					ParseTokenString(args.ctx, args.accessToken).
+					ParseTokenString(gomock.Any(), args.accessToken).
 					Return(nil, errors.New("invalid token"))
 			},
 		},
@@ -633,17 +949,17 @@ func Test_authService_Logout(t *testing.T) {
 				key := fmt.Sprintf("%s:%s", args.locals.Nik, constants.AccessTokenType)
 
 				mockRedis.EXPECT().
-					Get(args.ctx, key).
+					Get(gomock.Any(), key).
 					Return("token-in-redis", nil)
 
 				mockJWT.EXPECT().
-					ParseTokenString(args.ctx, args.accessToken).
+					ParseTokenString(gomock.Any(), args.accessToken).
 					Return(&jwt_handler.CustomClaims{
 						Nik: args.locals.Nik,
 					}, nil)
 
 				mockRedis.EXPECT().
-					Del(args.ctx, key).
+					Del(gomock.Any(), key).
 					Return(errors.New("redis delete error"))
 			},
 		},
@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/ports"
+	creditLimitRepository "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/repository"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/service"
+	pb "github.com/hilmiikhsan/multifinance-service/pkg/pb/multifinance/v1"
+	"github.com/rs/zerolog/log"
+	googleGrpc "google.golang.org/grpc"
+)
+
+type creditLimitHandler struct {
+	pb.UnimplementedCreditLimitServiceServer
+	service ports.CreditLimitService
+}
+
+func NewCreditLimitHandler() *creditLimitHandler {
+	var handler = new(creditLimitHandler)
+
+	// repository
+	creditLimitRepository := creditLimitRepository.NewCreditLimitRepository(adapter.Adapters.MultifinanceDB)
+
+	// service
+	creditLimitService := service.NewCreditLimitService(
+		adapter.Adapters.MultifinanceDB,
+		creditLimitRepository,
+	)
+
+	// handler
+	handler.service = creditLimitService
+
+	return handler
+}
+
+func (h *creditLimitHandler) CreditLimitService(server googleGrpc.ServiceRegistrar) {
+	pb.RegisterCreditLimitServiceServer(server, h)
+}
+
+func (h *creditLimitHandler) ListCreditLimits(ctx context.Context, req *pb.ListCreditLimitsRequest) (*pb.ListCreditLimitsResponse, error) {
+	locals := middleware.GetContextLocals(ctx)
+
+	res, err := h.service.GetCreditLimits(ctx, locals.GetCustomerID())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", locals.GetCustomerID()).Msg("handler::ListCreditLimits - Failed to get credit limits")
+		return nil, err
+	}
+
+	limits := make([]*pb.CreditLimit, 0, len(*res))
+	for _, limit := range *res {
+		limits = append(limits, &pb.CreditLimit{
+			Tenor:       int32(limit.Tenor),
+			LimitAmount: limit.LimitAmount,
+		})
+	}
+
+	return &pb.ListCreditLimitsResponse{Limits: limits}, nil
+}
@@ -0,0 +1,147 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/jwt_handler"
+	pb "github.com/hilmiikhsan/multifinance-service/pkg/pb/multifinance/v1"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	googleGrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeJWT struct{}
+
+func (fakeJWT) GenerateTokenString(ctx context.Context, payload jwt_handler.CostumClaimsPayload) (string, error) {
+	return "", nil
+}
+
+func (fakeJWT) ParseTokenString(ctx context.Context, tokenString string) (*jwt_handler.CustomClaims, error) {
+	if tokenString != "valid-token" {
+		return nil, errors.New("token is malformed")
+	}
+
+	return &jwt_handler.CustomClaims{CustomerID: 1}, nil
+}
+
+func newTestClient(t *testing.T, handler *creditLimitHandler) pb.CreditLimitServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := googleGrpc.NewServer(googleGrpc.ChainUnaryInterceptor(
+		middleware.UnaryError,
+		middleware.NewAuthMiddleware(fakeJWT{}).UnaryAuth,
+	))
+	handler.CreditLimitService(server)
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := googleGrpc.NewClient("passthrough:///bufnet",
+		googleGrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		googleGrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewCreditLimitServiceClient(conn)
+}
+
+func Test_creditLimitHandler_ListCreditLimits(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockCreditLimitService(ctrlMock)
+	client := newTestClient(t, &creditLimitHandler{service: mockSvc})
+
+	type args struct {
+		token  string
+		mockFn func()
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		wantCode codes.Code
+		wantMsg  string
+	}{
+		{
+			name: "JWT Valid - Success",
+			args: args{
+				token: "Bearer valid-token",
+				mockFn: func() {
+					mockSvc.EXPECT().GetCreditLimits(gomock.Any(), 1).Return(&[]dto.GetCreditLimitsResponse{
+						{Tenor: 1, LimitAmount: 100000},
+						{Tenor: 12, LimitAmount: 5000000},
+					}, nil)
+				},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "JWT Invalid - Unauthenticated",
+			args: args{
+				token:  "Bearer invalid-token",
+				mockFn: func() {},
+			},
+			wantCode: codes.Unauthenticated,
+			wantMsg:  constants.ErrTokenAlreadyExpired,
+		},
+		{
+			name: "Custom Error - Keeps Message",
+			args: args{
+				token: "Bearer valid-token",
+				mockFn: func() {
+					mockSvc.EXPECT().GetCreditLimits(gomock.Any(), 1).Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound)))
+				},
+			},
+			wantCode: codes.NotFound,
+			wantMsg:  constants.ErrUserNotFound,
+		},
+		{
+			name: "Internal Server Error",
+			args: args{
+				token: "Bearer valid-token",
+				mockFn: func() {
+					mockSvc.EXPECT().GetCreditLimits(gomock.Any(), 1).Return(nil, errors.New(constants.ErrInternalServerError))
+				},
+			},
+			wantCode: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.mockFn()
+
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", tt.args.token)
+
+			res, err := client.ListCreditLimits(ctx, &pb.ListCreditLimitsRequest{})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantMsg != "" {
+				assert.Equal(t, tt.wantMsg, status.Convert(err).Message())
+			}
+
+			if tt.wantCode == codes.OK {
+				assert.Len(t, res.GetLimits(), 2)
+				assert.Equal(t, 5000000.0, res.GetLimits()[1].GetLimitAmount())
+			}
+		})
+	}
+}
@@ -32,11 +32,11 @@ func NewCreditLimitHandler() *creditLimitHandler {
 	middlewareHandler := middleware.NewAuthMiddleware(jwt)
 
 	// repository
-	creditLimitRepository := creditLimitRepository.NewCreditLimitRepository(adapter.Adapters.MultifinanceMysql)
+	creditLimitRepository := creditLimitRepository.NewCreditLimitRepository(adapter.Adapters.MultifinanceDB)
 
 	// service
 	creditLimitervice := service.NewCreditLimitService(
-		adapter.Adapters.MultifinanceMysql,
+		adapter.Adapters.MultifinanceDB,
 		creditLimitRepository,
 	)
 
@@ -53,13 +53,13 @@ func (h *creditLimitHandler) CreditLimitRoute(router fiber.Router) {
 
 func (h *creditLimitHandler) getCreditLimits(c *fiber.Ctx) error {
 	var (
-		ctx    = c.Context()
+		ctx    = c.UserContext()
 		locals = middleware.GetLocals(c)
 	)
 
 	res, err := h.service.GetCreditLimits(ctx, locals.GetCustomerID())
 	if err != nil {
-		log.Error().Err(err).Any("payload", locals.CustomerID).Msg("handler::CreditLimitRoute - Failed to get credit limit user")
+		log.Ctx(ctx).Error().Err(err).Any("payload", locals.CustomerID).Msg("handler::CreditLimitRoute - Failed to get credit limit user")
 		code, errs := err_msg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
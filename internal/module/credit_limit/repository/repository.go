@@ -5,10 +5,10 @@ import (
 	"database/sql"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/hilmiikhsan/multifinance-service/constants"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/entity"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/ports"
 	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
 )
@@ -26,45 +26,54 @@ func NewCreditLimitRepository(db *sqlx.DB) *creditLimitRepository {
 }
 
 func (r *creditLimitRepository) InsertNewCreditLimit(ctx context.Context, tx *sql.Tx, data *entity.CreditLimit) error {
+	ctx, span := tracing.Start(ctx, "creditLimitRepository.InsertNewCreditLimit")
+	defer span.End()
+
 	_, err := tx.ExecContext(ctx, r.db.Rebind(queryInsertNewCreditLimit),
 		data.CustomerID,
 		data.TenorMonth,
 		data.LimitAmount,
 	)
 	if err != nil {
-		log.Error().Err(err).Any("payload", data).Msg("repository::InsertNewCreditLimit - Failed to insert new credit limit")
-		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		log.Ctx(ctx).Error().Err(err).Any("payload", data).Msg("repository::InsertNewCreditLimit - Failed to insert new credit limit")
+		return err_msg.NewDatabaseErrors(err)
 	}
 
 	return nil
 }
 
 func (r *creditLimitRepository) FindCreditLimitByCustomerID(ctx context.Context, customerID int) (*[]entity.Limits, error) {
+	ctx, span := tracing.Start(ctx, "creditLimitRepository.FindCreditLimitByCustomerID")
+	defer span.End()
+
 	var limits []entity.Limits
 
 	err := r.db.SelectContext(ctx, &limits, r.db.Rebind(queryFindCreditLimitByCustomerID), customerID)
 	if err != nil {
-		log.Error().Err(err).Int("customerID", customerID).Msg("repository::FindCreditLimitByCustomerID - Failed to find credit limit by customer ID")
-		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		log.Ctx(ctx).Error().Err(err).Int("customerID", customerID).Msg("repository::FindCreditLimitByCustomerID - Failed to find credit limit by customer ID")
+		return nil, err_msg.NewDatabaseErrors(err)
 	}
 
 	return &limits, nil
 }
 
 func (r *creditLimitRepository) FindLimitByCustomerAndTenor(ctx context.Context, tx *sql.Tx, customerID int, tenorMonth int) (*entity.Limits, error) {
+	ctx, span := tracing.Start(ctx, "creditLimitRepository.FindLimitByCustomerAndTenor")
+	defer span.End()
+
 	var limit entity.Limits
 
-	err := tx.QueryRowContext(ctx, queryLockCreditLimitByCustomerAndTenor, customerID, tenorMonth).Scan(&limit.TenorMonth, &limit.LimitAmount)
+	err := tx.QueryRowContext(ctx, r.db.Rebind(queryLockCreditLimitByCustomerAndTenor), customerID, tenorMonth).Scan(&limit.TenorMonth, &limit.LimitAmount)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			log.Error().
+			log.Ctx(ctx).Error().
 				Err(err).
 				Int("customer_id", customerID).
 				Int("tenor_month", tenorMonth).
 				Msg("repository::FindLimitByCustomerAndTenor - No credit limit found")
 			return nil, err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage("Invalid tenor or customer ID"))
 		}
-		log.Error().
+		log.Ctx(ctx).Error().
 			Err(err).
 			Int("customer_id", customerID).
 			Int("tenor_month", tenorMonth).
@@ -8,6 +8,7 @@ import (
 	"github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/dto"
 	creditLimitPorts "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/ports"
 	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
 )
@@ -27,11 +28,14 @@ func NewCreditLimitService(db *sqlx.DB, creditLimitRepository creditLimitPorts.C
 }
 
 func (s *creditLimitService) GetCreditLimits(ctx context.Context, customerID int) (*[]dto.GetCreditLimitsResponse, error) {
+	ctx, span := tracing.Start(ctx, "creditLimitService.GetCreditLimits")
+	defer span.End()
+
 	res := new([]dto.GetCreditLimitsResponse)
 
 	creditLimits, err := s.creditLimitRepository.FindCreditLimitByCustomerID(ctx, customerID)
 	if err != nil {
-		log.Error().Err(err).Int("customerID", customerID).Msg("service::GetCreditLimits - Failed to find credit limits by customer ID")
+		log.Ctx(ctx).Error().Err(err).Int("customerID", customerID).Msg("service::GetCreditLimits - Failed to find credit limits by customer ID")
 		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
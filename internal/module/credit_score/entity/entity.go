@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/pkg/scoring"
+)
+
+// CreditScore keeps the reason codes and inputs as JSON so a score can be
+// explained with the scorecard version it was computed with.
+type CreditScore struct {
+	ID               int64     `db:"id"`
+	CustomerID       int64     `db:"customer_id"`
+	ScorecardVersion string    `db:"scorecard_version"`
+	TriggerEvent     string    `db:"trigger_event"`
+	Score            int       `db:"score"`
+	Grade            string    `db:"grade"`
+	ReasonCodes      string    `db:"reason_codes"`
+	Inputs           string    `db:"inputs"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+func NewCreditScore(customerID int64, triggerEvent string, result *scoring.Result) (*CreditScore, error) {
+	reasonCodes, err := json.Marshal(result.ReasonCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := json.Marshal(result.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreditScore{
+		CustomerID:       customerID,
+		ScorecardVersion: result.Version,
+		TriggerEvent:     triggerEvent,
+		Score:            result.Score,
+		Grade:            result.Grade.Name,
+		ReasonCodes:      string(reasonCodes),
+		Inputs:           string(inputs),
+	}, nil
+}
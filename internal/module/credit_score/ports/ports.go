@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/module/credit_score/entity"
+)
+
+//go:generate mockgen -source=ports.go -destination=../../auth/service/service_credit_score_mock_test.go -package=service
+//go:generate mockgen -source=ports.go -destination=../../transaction/service/service_credit_score_mock_test.go -package=service
+type CreditScoreRepository interface {
+	InsertNewCreditScore(ctx context.Context, tx *sql.Tx, data *entity.CreditScore) error
+	FindLatestCreditScoreByCustomerID(ctx context.Context, customerID int) (*entity.CreditScore, error)
+}
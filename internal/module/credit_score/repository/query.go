@@ -0,0 +1,33 @@
+package repository
+
+const (
+	queryInsertNewCreditScore = `
+		INSERT INTO credit_scores
+		(
+			customer_id,
+			scorecard_version,
+			trigger_event,
+			score,
+			grade,
+			reason_codes,
+			inputs
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	queryFindLatestCreditScoreByCustomerID = `
+		SELECT
+			id,
+			customer_id,
+			scorecard_version,
+			trigger_event,
+			score,
+			grade,
+			reason_codes,
+			inputs,
+			created_at
+		FROM credit_scores
+		WHERE customer_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+)
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/module/credit_score/entity"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/credit_score/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.CreditScoreRepository = &creditScoreRepository{}
+
+type creditScoreRepository struct {
+	db *sqlx.DB
+}
+
+func NewCreditScoreRepository(db *sqlx.DB) *creditScoreRepository {
+	return &creditScoreRepository{
+		db: db,
+	}
+}
+
+func (r *creditScoreRepository) InsertNewCreditScore(ctx context.Context, tx *sql.Tx, data *entity.CreditScore) error {
+	ctx, span := tracing.Start(ctx, "creditScoreRepository.InsertNewCreditScore")
+	defer span.End()
+
+	_, err := tx.ExecContext(ctx, r.db.Rebind(queryInsertNewCreditScore),
+		data.CustomerID,
+		data.ScorecardVersion,
+		data.TriggerEvent,
+		data.Score,
+		data.Grade,
+		data.ReasonCodes,
+		data.Inputs,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", data).Msg("repository::InsertNewCreditScore - Failed to insert new credit score")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *creditScoreRepository) FindLatestCreditScoreByCustomerID(ctx context.Context, customerID int) (*entity.CreditScore, error) {
+	ctx, span := tracing.Start(ctx, "creditScoreRepository.FindLatestCreditScoreByCustomerID")
+	defer span.End()
+
+	var res = new(entity.CreditScore)
+
+	err := r.db.GetContext(ctx, res, r.db.Rebind(queryFindLatestCreditScoreByCustomerID), customerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("repository::FindLatestCreditScoreByCustomerID - Failed to find latest credit score")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/credit_score/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect/dialecttest"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_creditScoreRepository_InsertNewCreditScore(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		type args struct {
+			ctx   context.Context
+			model *entity.CreditScore
+		}
+
+		tests := []struct {
+			name    string
+			args    args
+			wantErr bool
+			mockFn  func(args args, mock sqlmock.Sqlmock)
+		}{
+			{
+				name: "Insert New Credit Score Successfully",
+				args: args{
+					ctx: context.Background(),
+					model: &entity.CreditScore{
+						CustomerID:       1,
+						ScorecardVersion: "v1",
+						TriggerEvent:     "registration",
+						Score:            750,
+						Grade:            "B",
+						ReasonCodes:      `["TENURE_SHORT"]`,
+						Inputs:           `{"age":36}`,
+					},
+				},
+				wantErr: false,
+				mockFn: func(args args, mock sqlmock.Sqlmock) {
+					mock.ExpectBegin()
+					mock.ExpectExec("INSERT INTO credit_scores").WithArgs(
+						args.model.CustomerID,
+						args.model.ScorecardVersion,
+						args.model.TriggerEvent,
+						args.model.Score,
+						args.model.Grade,
+						args.model.ReasonCodes,
+						args.model.Inputs,
+					).WillReturnResult(sqlmock.NewResult(1, 1))
+				},
+			},
+			{
+				name: "Insert New Credit Score With Query Error",
+				args: args{
+					ctx: context.Background(),
+					model: &entity.CreditScore{
+						CustomerID:       1,
+						ScorecardVersion: "v1",
+						TriggerEvent:     "registration",
+						Score:            750,
+						Grade:            "B",
+						ReasonCodes:      `[]`,
+						Inputs:           `{}`,
+					},
+				},
+				wantErr: true,
+				mockFn: func(args args, mock sqlmock.Sqlmock) {
+					mock.ExpectBegin()
+					mock.ExpectExec("INSERT INTO credit_scores").WillReturnError(fmt.Errorf("insert failed"))
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn(tt.args, mock)
+				r := &creditScoreRepository{
+					db: db,
+				}
+
+				tx, err := db.BeginTx(tt.args.ctx, nil)
+				assert.NoError(t, err)
+
+				err = r.InsertNewCreditScore(tt.args.ctx, tx, tt.args.model)
+
+				if tt.wantErr {
+					assert.Error(t, err, "Expected error, got nil")
+				} else {
+					assert.NoError(t, err, "Expected no error, got: %v", err)
+				}
+
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_creditScoreRepository_FindLatestCreditScoreByCustomerID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		createdAt := time.Date(2024, 12, 22, 9, 0, 0, 0, time.UTC)
+
+		columns := []string{"id", "customer_id", "scorecard_version", "trigger_event", "score", "grade", "reason_codes", "inputs", "created_at"}
+
+		tests := []struct {
+			name    string
+			want    *entity.CreditScore
+			wantErr bool
+			mockFn  func(mock sqlmock.Sqlmock)
+		}{
+			{
+				name: "Find Latest Credit Score Successfully",
+				want: &entity.CreditScore{
+					ID:               3,
+					CustomerID:       1,
+					ScorecardVersion: "v1",
+					TriggerEvent:     "transaction",
+					Score:            700,
+					Grade:            "B",
+					ReasonCodes:      `["UTILISATION_MEDIUM"]`,
+					Inputs:           `{"age":36}`,
+					CreatedAt:        createdAt,
+				},
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery("SELECT (.+) FROM credit_scores").WithArgs(1).WillReturnRows(
+						sqlmock.NewRows(columns).AddRow(3, 1, "v1", "transaction", 700, "B", `["UTILISATION_MEDIUM"]`, `{"age":36}`, createdAt),
+					)
+				},
+			},
+			{
+				name: "Customer Without Credit Score",
+				want: nil,
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery("SELECT (.+) FROM credit_scores").WithArgs(1).WillReturnRows(sqlmock.NewRows(columns))
+				},
+			},
+			{
+				name:    "Find Latest Credit Score With Query Error",
+				wantErr: true,
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery("SELECT (.+) FROM credit_scores").WithArgs(1).WillReturnError(fmt.Errorf("query failed"))
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn(mock)
+				r := &creditScoreRepository{
+					db: db,
+				}
+
+				got, err := r.FindLatestCreditScoreByCustomerID(context.Background(), 1)
+
+				if tt.wantErr {
+					assert.Error(t, err)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, tt.want, got)
+				}
+
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
@@ -4,15 +4,38 @@ import "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit
 
 type GetCustomerProfileResponse struct {
 	ID              int64             `json:"id"`
-	Nik             string            `json:"nik"`
+	Nik             string            `json:"nik" log:"mask"`
 	FullName        string            `json:"full_name"`
 	LegalName       string            `json:"legal_name"`
+	Gender          string            `json:"gender"`
 	BirthPlace      string            `json:"birth_place"`
 	BirthDate       string            `json:"birth_date"`
-	Salary          float64           `json:"salary"`
+	Salary          float64           `json:"salary" log:"redact"`
 	KtpPhotoPath    string            `json:"ktp_photo_path"`
 	SelfiePhotoPath string            `json:"selfie_photo_path"`
+	ProvinceCode    string            `json:"province_code"`
+	RegencyCode     string            `json:"regency_code"`
+	DistrictCode    string            `json:"district_code"`
 	Limits          []dto.CreditLimit `json:"limits"`
 	CreatedAt       string            `json:"created_at"`
 	UpdatedAt       string            `json:"updated_at"`
 }
+
+// LimitUtilisation compares the limit of a tenor with the on the road price
+// of the active contracts booked on it.
+type LimitUtilisation struct {
+	Tenor           int     `json:"tenor"`
+	LimitAmount     float64 `json:"limit_amount"`
+	UsedAmount      float64 `json:"used_amount"`
+	AvailableAmount float64 `json:"available_amount"`
+	Utilisation     float64 `json:"utilisation"`
+}
+
+type GetCustomerSummaryResponse struct {
+	TotalOutstanding float64            `json:"total_outstanding"`
+	ActiveContracts  int                `json:"active_contracts"`
+	NextDueDate      string             `json:"next_due_date,omitempty"`
+	NextDueAmount    float64            `json:"next_due_amount"`
+	Limits           []LimitUtilisation `json:"limits"`
+	GeneratedAt      string             `json:"generated_at"`
+}
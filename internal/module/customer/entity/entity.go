@@ -8,37 +8,68 @@ import (
 )
 
 type Customer struct {
-	ID              int64           `db:"id"`
-	Nik             string          `db:"nik"`
-	Email           string          `db:"email"`
-	Password        string          `db:"password"`
-	FullName        string          `db:"full_name"`
-	LegalName       string          `db:"legal_name"`
-	BirthPlace      string          `db:"birth_place"`
-	BirthDate       time.Time       `db:"birth_date"`
-	Salary          float64         `db:"salary"`
-	KtpPhotoPath    string          `db:"ktp_photo_path"`
-	SelfiePhotoPath string          `db:"selfie_photo_path"`
-	TenorMonth      int             `db:"tenor_month"`
-	LimitAmount     float64         `db:"limit_amount"`
-	Limits          []entity.Limits `db:"-"`
-	CreatedAt       time.Time       `db:"created_at"`
-	UpdatedAt       time.Time       `db:"updated_at"`
+	ID               int64           `db:"id"`
+	Nik              string          `db:"nik" log:"mask"`
+	Email            string          `db:"email" log:"mask"`
+	PhoneNumber      sql.NullString  `db:"phone_number" log:"mask"`
+	DeviceID         sql.NullString  `db:"device_id"`
+	Password         string          `db:"password" log:"redact"`
+	FullName         string          `db:"full_name"`
+	LegalName        string          `db:"legal_name"`
+	Gender           string          `db:"gender"`
+	BirthPlace       string          `db:"birth_place"`
+	BirthDate        time.Time       `db:"birth_date"`
+	Salary           float64         `db:"salary" log:"redact"`
+	KtpPhotoPath     string          `db:"ktp_photo_path"`
+	KtpPhotoHash     sql.NullString  `db:"ktp_photo_hash"`
+	KtpPhotoPHash    sql.NullInt64   `db:"ktp_photo_phash"`
+	SelfiePhotoPath  string          `db:"selfie_photo_path"`
+	SelfiePhotoHash  sql.NullString  `db:"selfie_photo_hash"`
+	SelfiePhotoPHash sql.NullInt64   `db:"selfie_photo_phash"`
+	ProvinceCode     string          `db:"province_code"`
+	RegencyCode      string          `db:"regency_code"`
+	DistrictCode     string          `db:"district_code"`
+	ReviewStatus     string          `db:"review_status"`
+	TenorMonth       int             `db:"tenor_month"`
+	LimitAmount      float64         `db:"limit_amount"`
+	Limits           []entity.Limits `db:"-"`
+	CreatedAt        time.Time       `db:"created_at"`
+	UpdatedAt        time.Time       `db:"updated_at"`
 }
 
 type CustomerWithLimits struct {
 	CustomerID      int64           `db:"id"`
-	Nik             string          `db:"nik"`
-	Email           string          `db:"email"`
+	Nik             string          `db:"nik" log:"mask"`
+	Email           string          `db:"email" log:"mask"`
+	PhoneNumber     sql.NullString  `db:"phone_number" log:"mask"`
+	DeviceID        sql.NullString  `db:"device_id"`
 	FullName        string          `db:"full_name"`
 	LegalName       string          `db:"legal_name"`
+	Gender          sql.NullString  `db:"gender"`
 	BirthPlace      string          `db:"birth_place"`
 	BirthDate       time.Time       `db:"birth_date"`
-	Salary          float64         `db:"salary"`
+	Salary          float64         `db:"salary" log:"redact"`
 	KtpPhotoPath    string          `db:"ktp_photo_path"`
 	SelfiePhotoPath string          `db:"selfie_photo_path"`
+	ProvinceCode    sql.NullString  `db:"province_code"`
+	RegencyCode     sql.NullString  `db:"regency_code"`
+	DistrictCode    sql.NullString  `db:"district_code"`
+	ReviewStatus    sql.NullString  `db:"review_status"`
 	CreatedAt       time.Time       `db:"created_at"`
 	UpdatedAt       time.Time       `db:"updated_at"`
 	TenorMonth      sql.NullInt64   `db:"tenor_month"`
 	LimitAmount     sql.NullFloat64 `db:"limit_amount"`
 }
+
+// ActiveContract is an active transaction of the customer with what has been
+// paid towards it so far, as read for the dashboard summary.
+type ActiveContract struct {
+	ID                int       `db:"id"`
+	OnTheRoadPrice    float64   `db:"on_the_road_price"`
+	AdminFee          float64   `db:"admin_fee"`
+	InstallmentAmount float64   `db:"installment_amount"`
+	InterestAmount    float64   `db:"interest_amount"`
+	TenorMonth        int       `db:"tenor_month"`
+	PaidAmount        float64   `db:"paid_amount"`
+	CreatedAt         time.Time `db:"created_at"`
+}
@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	redisRepository "github.com/hilmiikhsan/multifinance-service/internal/infrastructure/redis"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	creditLimitRepository "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/repository"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/ports"
+	customerRepository "github.com/hilmiikhsan/multifinance-service/internal/module/customer/repository"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/service"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	pb "github.com/hilmiikhsan/multifinance-service/pkg/pb/multifinance/v1"
+	"github.com/rs/zerolog/log"
+	googleGrpc "google.golang.org/grpc"
+)
+
+type customerHandler struct {
+	pb.UnimplementedCustomerServiceServer
+	service ports.CustomerService
+}
+
+func NewCustomerHandler() *customerHandler {
+	var handler = new(customerHandler)
+
+	// redis
+	redisRepository := redisRepository.NewRedisRepository(adapter.Adapters.MultifinanceRedis)
+
+	// repository
+	customerRepository := customerRepository.NewCustomerRepository(adapter.Adapters.MultifinanceDB)
+	creditLimitRepository := creditLimitRepository.NewCreditLimitRepository(adapter.Adapters.MultifinanceDB)
+
+	// service
+	customerService := service.NewCustomerService(
+		adapter.Adapters.MultifinanceDB,
+		customerRepository,
+		creditLimitRepository,
+		redisRepository,
+		time.Duration(config.Envs.Summary.CacheTTLSeconds)*time.Second,
+	)
+
+	// handler
+	handler.service = customerService
+
+	return handler
+}
+
+func (h *customerHandler) CustomerService(server googleGrpc.ServiceRegistrar) {
+	pb.RegisterCustomerServiceServer(server, h)
+}
+
+func (h *customerHandler) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.GetProfileResponse, error) {
+	locals := middleware.GetContextLocals(ctx)
+
+	res, err := h.service.GetCustomerProfile(ctx, locals.GetCustomerID())
+	if err != nil {
+		if err_msg.HasCode(err, fiber.StatusNotFound) {
+			log.Ctx(ctx).Error().Err(err).Msg("handler::GetProfile - Customer not found")
+			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", locals.GetCustomerID()).Msg("handler::GetProfile - Failed to get customer profile")
+		return nil, err
+	}
+
+	limits := make([]*pb.CreditLimit, 0, len(res.Limits))
+	for _, limit := range res.Limits {
+		limits = append(limits, &pb.CreditLimit{
+			Tenor:       int32(limit.Tenor),
+			LimitAmount: limit.LimitAmount,
+		})
+	}
+
+	return &pb.GetProfileResponse{
+		Profile: &pb.CustomerProfile{
+			Id:              res.ID,
+			Nik:             res.Nik,
+			FullName:        res.FullName,
+			LegalName:       res.LegalName,
+			Gender:          res.Gender,
+			BirthPlace:      res.BirthPlace,
+			BirthDate:       res.BirthDate,
+			Salary:          res.Salary,
+			KtpPhotoPath:    res.KtpPhotoPath,
+			SelfiePhotoPath: res.SelfiePhotoPath,
+			ProvinceCode:    res.ProvinceCode,
+			RegencyCode:     res.RegencyCode,
+			DistrictCode:    res.DistrictCode,
+			Limits:          limits,
+			CreatedAt:       res.CreatedAt,
+			UpdatedAt:       res.UpdatedAt,
+		},
+	}, nil
+}
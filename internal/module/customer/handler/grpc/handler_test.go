@@ -0,0 +1,161 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	creditLimitDto "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/jwt_handler"
+	pb "github.com/hilmiikhsan/multifinance-service/pkg/pb/multifinance/v1"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	googleGrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeJWT struct{}
+
+func (fakeJWT) GenerateTokenString(ctx context.Context, payload jwt_handler.CostumClaimsPayload) (string, error) {
+	return "", nil
+}
+
+func (fakeJWT) ParseTokenString(ctx context.Context, tokenString string) (*jwt_handler.CustomClaims, error) {
+	if tokenString != "valid-token" {
+		return nil, errors.New("token is malformed")
+	}
+
+	return &jwt_handler.CustomClaims{CustomerID: 1}, nil
+}
+
+func newTestClient(t *testing.T, handler *customerHandler) pb.CustomerServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := googleGrpc.NewServer(googleGrpc.ChainUnaryInterceptor(
+		middleware.UnaryError,
+		middleware.NewAuthMiddleware(fakeJWT{}).UnaryAuth,
+	))
+	handler.CustomerService(server)
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := googleGrpc.NewClient("passthrough:///bufnet",
+		googleGrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		googleGrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewCustomerServiceClient(conn)
+}
+
+func Test_customerHandler_GetProfile(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockCustomerService(ctrlMock)
+	client := newTestClient(t, &customerHandler{service: mockSvc})
+
+	type args struct {
+		token  string
+		mockFn func()
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		wantCode codes.Code
+	}{
+		{
+			name: "JWT Valid - Success",
+			args: args{
+				token: "Bearer valid-token",
+				mockFn: func() {
+					mockSvc.EXPECT().GetCustomerProfile(gomock.Any(), 1).Return(&dto.GetCustomerProfileResponse{
+						ID:       1,
+						Nik:      "3171234567890001",
+						FullName: "Budi Santoso",
+						Limits: []creditLimitDto.CreditLimit{
+							{Tenor: 12, LimitAmount: 5000000},
+						},
+					}, nil)
+				},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "JWT Missing - Unauthenticated",
+			args: args{
+				mockFn: func() {},
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "JWT Invalid - Unauthenticated",
+			args: args{
+				token:  "Bearer invalid-token",
+				mockFn: func() {},
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "Profile Not Found",
+			args: args{
+				token: "Bearer valid-token",
+				mockFn: func() {
+					mockSvc.EXPECT().GetCustomerProfile(gomock.Any(), 1).Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound)))
+				},
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Internal Server Error",
+			args: args{
+				token: "Bearer valid-token",
+				mockFn: func() {
+					mockSvc.EXPECT().GetCustomerProfile(gomock.Any(), 1).Return(nil, errors.New("connection refused"))
+				},
+			},
+			wantCode: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.mockFn()
+
+			ctx := context.Background()
+			if tt.args.token != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.args.token)
+			}
+
+			res, err := client.GetProfile(ctx, &pb.GetProfileRequest{})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, "Budi Santoso", res.GetProfile().GetFullName())
+				assert.Len(t, res.GetProfile().GetLimits(), 1)
+				assert.Equal(t, int32(12), res.GetProfile().GetLimits()[0].GetTenor())
+			}
+
+			if tt.wantCode == codes.Internal {
+				// the cause stays in the logs, the client gets the generic message
+				assert.NotContains(t, status.Convert(err).Message(), "connection refused")
+			}
+		})
+	}
+}
@@ -1,13 +1,15 @@
 package rest
 
 import (
-	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hilmiikhsan/multifinance-service/constants"
 	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
 	redisRepository "github.com/hilmiikhsan/multifinance-service/internal/infrastructure/redis"
 	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	creditLimitRepository "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/repository"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/ports"
 	customerRepository "github.com/hilmiikhsan/multifinance-service/internal/module/customer/repository"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/service"
@@ -35,12 +37,16 @@ func NewCustomerHandler() *customerHandler {
 	middlewareHandler := middleware.NewAuthMiddleware(jwt)
 
 	// repository
-	customerRepository := customerRepository.NewCustomerRepository(adapter.Adapters.MultifinanceMysql)
+	customerRepository := customerRepository.NewCustomerRepository(adapter.Adapters.MultifinanceDB)
+	creditLimitRepository := creditLimitRepository.NewCreditLimitRepository(adapter.Adapters.MultifinanceDB)
 
 	// service
 	customerService := service.NewCustomerService(
-		adapter.Adapters.MultifinanceMysql,
+		adapter.Adapters.MultifinanceDB,
 		customerRepository,
+		creditLimitRepository,
+		redisRepository,
+		time.Duration(config.Envs.Summary.CacheTTLSeconds)*time.Second,
 	)
 
 	// handler
@@ -52,22 +58,39 @@ func NewCustomerHandler() *customerHandler {
 
 func (h *customerHandler) CustomerRoute(router fiber.Router) {
 	router.Get("/profile", h.middleware.AuthBearer, h.getCustomerProfile)
+	router.Get("/summary", h.middleware.AuthBearer, h.getCustomerSummary)
 }
 
 func (h *customerHandler) getCustomerProfile(c *fiber.Ctx) error {
 	var (
-		ctx    = c.Context()
+		ctx    = c.UserContext()
 		locals = middleware.GetLocals(c)
 	)
 
 	res, err := h.service.GetCustomerProfile(ctx, locals.GetCustomerID())
 	if err != nil {
-		if strings.Contains(err.Error(), constants.ErrUserNotFound) {
-			log.Error().Err(err).Msg("handler::getCustomerProfile - Customer not found")
+		if err_msg.HasCode(err, fiber.StatusNotFound) {
+			log.Ctx(ctx).Error().Err(err).Msg("handler::getCustomerProfile - Customer not found")
 			return c.Status(fiber.StatusNotFound).JSON(response.Error(constants.ErrUserNotFound))
 		}
 
-		log.Error().Err(err).Any("response", res).Msg("handler::getCustomerProfile - Failed to logout user")
+		log.Ctx(ctx).Error().Err(err).Any("response", res).Msg("handler::getCustomerProfile - Failed to logout user")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
+
+func (h *customerHandler) getCustomerSummary(c *fiber.Ctx) error {
+	var (
+		ctx    = c.UserContext()
+		locals = middleware.GetLocals(c)
+	)
+
+	res, err := h.service.GetCustomerSummary(ctx, locals.GetCustomerID())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", locals.GetCustomerID()).Msg("handler::getCustomerSummary - Failed to get customer summary")
 		code, errs := err_msg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
@@ -1,5 +1,10 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ports.go -destination=../handler/rest/handler_mock_test.go -package=rest
+//
 
 // Package rest is a generated GoMock package.
 package rest
@@ -9,15 +14,16 @@ import (
 	sql "database/sql"
 	reflect "reflect"
 
-	gomock "go.uber.org/mock/gomock"
 	dto "github.com/hilmiikhsan/multifinance-service/internal/module/customer/dto"
 	entity "github.com/hilmiikhsan/multifinance-service/internal/module/customer/entity"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockCustomerRepository is a mock of CustomerRepository interface.
 type MockCustomerRepository struct {
 	ctrl     *gomock.Controller
 	recorder *MockCustomerRepositoryMockRecorder
+	isgomock struct{}
 }
 
 // MockCustomerRepositoryMockRecorder is the mock recorder for MockCustomerRepository.
@@ -37,6 +43,21 @@ func (m *MockCustomerRepository) EXPECT() *MockCustomerRepositoryMockRecorder {
 	return m.recorder
 }
 
+// FindActiveContractsByCustomerID mocks base method.
+func (m *MockCustomerRepository) FindActiveContractsByCustomerID(ctx context.Context, customerID int) ([]entity.ActiveContract, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveContractsByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].([]entity.ActiveContract)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveContractsByCustomerID indicates an expected call of FindActiveContractsByCustomerID.
+func (mr *MockCustomerRepositoryMockRecorder) FindActiveContractsByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveContractsByCustomerID", reflect.TypeOf((*MockCustomerRepository)(nil).FindActiveContractsByCustomerID), ctx, customerID)
+}
+
 // FindCustomerByEmail mocks base method.
 func (m *MockCustomerRepository) FindCustomerByEmail(ctx context.Context, email string) (*entity.Customer, error) {
 	m.ctrl.T.Helper()
@@ -47,7 +68,7 @@ func (m *MockCustomerRepository) FindCustomerByEmail(ctx context.Context, email
 }
 
 // FindCustomerByEmail indicates an expected call of FindCustomerByEmail.
-func (mr *MockCustomerRepositoryMockRecorder) FindCustomerByEmail(ctx, email interface{}) *gomock.Call {
+func (mr *MockCustomerRepositoryMockRecorder) FindCustomerByEmail(ctx, email any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCustomerByEmail", reflect.TypeOf((*MockCustomerRepository)(nil).FindCustomerByEmail), ctx, email)
 }
@@ -62,7 +83,7 @@ func (m *MockCustomerRepository) FindCustomerByID(ctx context.Context, id int) (
 }
 
 // FindCustomerByID indicates an expected call of FindCustomerByID.
-func (mr *MockCustomerRepositoryMockRecorder) FindCustomerByID(ctx, id interface{}) *gomock.Call {
+func (mr *MockCustomerRepositoryMockRecorder) FindCustomerByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCustomerByID", reflect.TypeOf((*MockCustomerRepository)(nil).FindCustomerByID), ctx, id)
 }
@@ -77,15 +98,45 @@ func (m *MockCustomerRepository) InsertNewUser(ctx context.Context, tx *sql.Tx,
 }
 
 // InsertNewUser indicates an expected call of InsertNewUser.
-func (mr *MockCustomerRepositoryMockRecorder) InsertNewUser(ctx, tx, data interface{}) *gomock.Call {
+func (mr *MockCustomerRepositoryMockRecorder) InsertNewUser(ctx, tx, data any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewUser", reflect.TypeOf((*MockCustomerRepository)(nil).InsertNewUser), ctx, tx, data)
 }
 
+// LockCustomerByID mocks base method.
+func (m *MockCustomerRepository) LockCustomerByID(ctx context.Context, tx *sql.Tx, id int) (*entity.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCustomerByID", ctx, tx, id)
+	ret0, _ := ret[0].(*entity.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockCustomerByID indicates an expected call of LockCustomerByID.
+func (mr *MockCustomerRepositoryMockRecorder) LockCustomerByID(ctx, tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCustomerByID", reflect.TypeOf((*MockCustomerRepository)(nil).LockCustomerByID), ctx, tx, id)
+}
+
+// UpdateReviewStatus mocks base method.
+func (m *MockCustomerRepository) UpdateReviewStatus(ctx context.Context, tx *sql.Tx, id int64, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReviewStatus", ctx, tx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReviewStatus indicates an expected call of UpdateReviewStatus.
+func (mr *MockCustomerRepositoryMockRecorder) UpdateReviewStatus(ctx, tx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReviewStatus", reflect.TypeOf((*MockCustomerRepository)(nil).UpdateReviewStatus), ctx, tx, id, status)
+}
+
 // MockCustomerService is a mock of CustomerService interface.
 type MockCustomerService struct {
 	ctrl     *gomock.Controller
 	recorder *MockCustomerServiceMockRecorder
+	isgomock struct{}
 }
 
 // MockCustomerServiceMockRecorder is the mock recorder for MockCustomerService.
@@ -115,7 +166,60 @@ func (m *MockCustomerService) GetCustomerProfile(ctx context.Context, id int) (*
 }
 
 // GetCustomerProfile indicates an expected call of GetCustomerProfile.
-func (mr *MockCustomerServiceMockRecorder) GetCustomerProfile(ctx, id interface{}) *gomock.Call {
+func (mr *MockCustomerServiceMockRecorder) GetCustomerProfile(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerProfile", reflect.TypeOf((*MockCustomerService)(nil).GetCustomerProfile), ctx, id)
 }
+
+// GetCustomerSummary mocks base method.
+func (m *MockCustomerService) GetCustomerSummary(ctx context.Context, id int) (*dto.GetCustomerSummaryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerSummary", ctx, id)
+	ret0, _ := ret[0].(*dto.GetCustomerSummaryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerSummary indicates an expected call of GetCustomerSummary.
+func (mr *MockCustomerServiceMockRecorder) GetCustomerSummary(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerSummary", reflect.TypeOf((*MockCustomerService)(nil).GetCustomerSummary), ctx, id)
+}
+
+// MockCustomerSummaryCache is a mock of CustomerSummaryCache interface.
+type MockCustomerSummaryCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerSummaryCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerSummaryCacheMockRecorder is the mock recorder for MockCustomerSummaryCache.
+type MockCustomerSummaryCacheMockRecorder struct {
+	mock *MockCustomerSummaryCache
+}
+
+// NewMockCustomerSummaryCache creates a new mock instance.
+func NewMockCustomerSummaryCache(ctrl *gomock.Controller) *MockCustomerSummaryCache {
+	mock := &MockCustomerSummaryCache{ctrl: ctrl}
+	mock.recorder = &MockCustomerSummaryCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerSummaryCache) EXPECT() *MockCustomerSummaryCacheMockRecorder {
+	return m.recorder
+}
+
+// InvalidateCustomerSummary mocks base method.
+func (m *MockCustomerSummaryCache) InvalidateCustomerSummary(ctx context.Context, customerID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateCustomerSummary", ctx, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateCustomerSummary indicates an expected call of InvalidateCustomerSummary.
+func (mr *MockCustomerSummaryCacheMockRecorder) InvalidateCustomerSummary(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateCustomerSummary", reflect.TypeOf((*MockCustomerSummaryCache)(nil).InvalidateCustomerSummary), ctx, customerID)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hilmiikhsan/multifinance-service/constants"
 	creditLimitDto "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/dto"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -73,7 +74,7 @@ func Test_customerHandler_getCustomerProfile(t *testing.T) {
 				token:  "Bearer valid-token",
 				status: http.StatusNotFound,
 				mockFn: func() {
-					mockSvc.EXPECT().GetCustomerProfile(gomock.Any(), 1).Return(nil, errors.New(constants.ErrUserNotFound))
+					mockSvc.EXPECT().GetCustomerProfile(gomock.Any(), 1).Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound)))
 				},
 			},
 			wantErr: true,
@@ -123,3 +124,53 @@ func Test_customerHandler_getCustomerProfile(t *testing.T) {
 		})
 	}
 }
+
+func Test_customerHandler_getCustomerSummary(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockCustomerService(ctrlMock)
+
+	tests := []struct {
+		name   string
+		status int
+		mockFn func()
+	}{
+		{
+			name:   "Success",
+			status: http.StatusOK,
+			mockFn: func() {
+				mockSvc.EXPECT().GetCustomerSummary(gomock.Any(), 1).Return(&dto.GetCustomerSummaryResponse{
+					TotalOutstanding: 1918000,
+					ActiveContracts:  2,
+					NextDueDate:      "2025-02-01",
+					NextDueAmount:    112000,
+				}, nil)
+			},
+		},
+		{
+			name:   "Internal Server Error",
+			status: http.StatusInternalServerError,
+			mockFn: func() {
+				mockSvc.EXPECT().GetCustomerSummary(gomock.Any(), 1).Return(nil, errors.New("internal server error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			handler := &customerHandler{service: mockSvc}
+			app.Get("/summary", func(c *fiber.Ctx) error {
+				c.Locals("customer_id", 1)
+				return handler.getCustomerSummary(c)
+			})
+
+			tt.mockFn()
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/summary", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
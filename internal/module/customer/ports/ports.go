@@ -13,9 +13,19 @@ type CustomerRepository interface {
 	InsertNewUser(ctx context.Context, tx *sql.Tx, data *entity.Customer) (*entity.Customer, error)
 	FindCustomerByEmail(ctx context.Context, email string) (*entity.Customer, error)
 	FindCustomerByID(ctx context.Context, id int) (*entity.Customer, error)
+	LockCustomerByID(ctx context.Context, tx *sql.Tx, id int) (*entity.Customer, error)
+	UpdateReviewStatus(ctx context.Context, tx *sql.Tx, id int64, status string) error
+	FindActiveContractsByCustomerID(ctx context.Context, customerID int) ([]entity.ActiveContract, error)
 }
 
 //go:generate mockgen -source=ports.go -destination=../handler/rest/handler_mock_test.go -package=rest
 type CustomerService interface {
 	GetCustomerProfile(ctx context.Context, id int) (*dto.GetCustomerProfileResponse, error)
+	GetCustomerSummary(ctx context.Context, id int) (*dto.GetCustomerSummaryResponse, error)
+}
+
+// CustomerSummaryCache drops the cached dashboard summary of a customer after
+// a change that affects it, such as a new booking.
+type CustomerSummaryCache interface {
+	InvalidateCustomerSummary(ctx context.Context, customerID int) error
 }
@@ -6,6 +6,8 @@ const (
 		(
 			nik,
 			email,
+			phone_number,
+			device_id,
 			password,
 			full_name,
 			legal_name,
@@ -13,9 +15,18 @@ const (
 			birth_date,
 			salary,
 			ktp_photo_path,
-			selfie_photo_path
+			ktp_photo_hash,
+			ktp_photo_phash,
+			selfie_photo_path,
+			selfie_photo_hash,
+			selfie_photo_phash,
+			gender,
+			province_code,
+			regency_code,
+			district_code,
+			review_status
 		) VALUES (
-			?, ?, ?, ?, ?, ?, ?, ?, ?, ? 
+			?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 		)
 	`
 
@@ -33,11 +44,21 @@ const (
 		SELECT id, email FROM customers WHERE id = ?
 	`
 
+	queryLockCustomerByID = `
+		SELECT id, salary, review_status FROM customers WHERE id = ? FOR UPDATE
+	`
+
+	queryUpdateCustomerReviewStatus = `
+		UPDATE customers SET review_status = ? WHERE id = ?
+	`
+
 	queryFindCustomerByID = `
 		SELECT
 			c.id,
 			c.nik,
 			c.email,
+			c.phone_number,
+			c.device_id,
 			c.full_name,
 			c.legal_name,
 			c.birth_place,
@@ -45,6 +66,11 @@ const (
 			c.salary,
 			c.ktp_photo_path,
 			c.selfie_photo_path,
+			c.gender,
+			c.province_code,
+			c.regency_code,
+			c.district_code,
+			c.review_status,
 			c.created_at,
 			c.updated_at,
 			cl.tenor_month,
@@ -53,4 +79,19 @@ const (
 		LEFT JOIN credit_limits cl ON c.id = cl.customer_id
 		WHERE c.id = ?
 	`
+
+	queryFindActiveContractsByCustomerID = `
+		SELECT
+			t.id,
+			t.on_the_road_price,
+			t.admin_fee,
+			t.installment_amount,
+			t.interest_amount,
+			t.tenor_month,
+			COALESCE((SELECT SUM(p.amount) FROM transaction_payments p WHERE p.transaction_id = t.id), 0) AS paid_amount,
+			t.created_at
+		FROM transactions t
+		WHERE t.customer_id = ? AND t.status = ?
+		ORDER BY t.created_at ASC, t.id ASC
+	`
 )
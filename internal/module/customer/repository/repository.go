@@ -9,8 +9,10 @@ import (
 	creditLimitEntity "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/entity"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/entity"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect"
 	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
-	"github.com/hilmiikhsan/multifinance-service/pkg/utils"
+	"github.com/hilmiikhsan/multifinance-service/pkg/redact"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
 )
@@ -28,11 +30,16 @@ func NewCustomerRepository(db *sqlx.DB) *customerRepository {
 }
 
 func (r *customerRepository) InsertNewUser(ctx context.Context, tx *sql.Tx, data *entity.Customer) (*entity.Customer, error) {
+	ctx, span := tracing.Start(ctx, "customerRepository.InsertNewUser")
+	defer span.End()
+
 	var res = new(entity.Customer)
 
-	result, err := tx.ExecContext(ctx, r.db.Rebind(queryInsertNewUser),
+	lastInsertID, err := dialect.InsertID(ctx, tx, r.db.DriverName(), r.db.Rebind(queryInsertNewUser),
 		data.Nik,
 		data.Email,
+		data.PhoneNumber,
+		data.DeviceID,
 		data.Password,
 		data.FullName,
 		data.LegalName,
@@ -40,55 +47,50 @@ func (r *customerRepository) InsertNewUser(ctx context.Context, tx *sql.Tx, data
 		data.BirthDate,
 		data.Salary,
 		data.KtpPhotoPath,
+		data.KtpPhotoHash,
+		data.KtpPhotoPHash,
 		data.SelfiePhotoPath,
+		data.SelfiePhotoHash,
+		data.SelfiePhotoPHash,
+		data.Gender,
+		data.ProvinceCode,
+		data.RegencyCode,
+		data.DistrictCode,
+		data.ReviewStatus,
 	)
 	if err != nil {
-		uniqueConstraints := map[string]string{
-			"nik":   constants.ErrNikAlreadyRegistered,
-			"email": constants.ErrEmailAlreadyRegistered,
-		}
-
-		val, handleErr := utils.HandleInsertUniqueError(err, data, uniqueConstraints)
-		if handleErr != nil {
-			log.Error().Err(handleErr).Any("payload", data).Msg("repository::InsertNewUser - Failed to insert new user")
-			return nil, handleErr
-		}
-
-		if customer, ok := val.(*entity.Customer); ok {
-			log.Error().Err(err).Any("payload", data).Msg("repository::InsertNewUser - Failed to insert new user")
-			return customer, nil
+		if err_msg.IsDuplicateEntry(err) {
+			log.Ctx(ctx).Warn().Err(err).Any("payload", data).Msg("repository::InsertNewUser - Customer already registered")
+			return nil, err_msg.NewDatabaseErrors(err)
 		}
 
-		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
-
+		log.Ctx(ctx).Error().Err(err).Any("payload", data).Msg("repository::InsertNewUser - Failed to insert new user")
+		return nil, err_msg.NewDatabaseErrors(err)
 	}
 
-	lastInsertID, err := result.LastInsertId()
+	err = tx.QueryRowContext(ctx, r.db.Rebind(queryFindCustomer), lastInsertID).Scan(&res.ID, &res.Email)
 	if err != nil {
-		log.Error().Err(err).Msg("repository::InsertNewUser - Failed to retrieve last inserted ID")
-		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
-	}
-
-	err = tx.QueryRowContext(ctx, queryFindCustomer, lastInsertID).Scan(&res.ID, &res.Email)
-	if err != nil {
-		log.Error().Err(err).Msg("repository::InsertNewUser - Failed to fetch inserted user details")
-		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		log.Ctx(ctx).Error().Err(err).Msg("repository::InsertNewUser - Failed to fetch inserted user details")
+		return nil, err_msg.NewDatabaseErrors(err)
 	}
 
 	return res, nil
 }
 
 func (r *customerRepository) FindCustomerByEmail(ctx context.Context, email string) (*entity.Customer, error) {
+	ctx, span := tracing.Start(ctx, "customerRepository.FindCustomerByEmail")
+	defer span.End()
+
 	var res = new(entity.Customer)
 
 	err := r.db.GetContext(ctx, res, r.db.Rebind(queryFindCustomerByEmail), email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			log.Error().Err(err).Any("email", email).Msg("repository::FindCustomerByEmail - Email not found")
+			log.Ctx(ctx).Error().Err(err).Str("email", redact.Mask(email)).Msg("repository::FindCustomerByEmail - Email not found")
 			return nil, nil
 		}
 
-		log.Error().Err(err).Any("email", email).Msg("repository::FindCustomerByEmail - Failed to find user by email")
+		log.Ctx(ctx).Error().Err(err).Str("email", redact.Mask(email)).Msg("repository::FindCustomerByEmail - Failed to find user by email")
 		return nil, err
 	}
 
@@ -96,15 +98,18 @@ func (r *customerRepository) FindCustomerByEmail(ctx context.Context, email stri
 }
 
 func (r *customerRepository) FindCustomerByID(ctx context.Context, id int) (*entity.Customer, error) {
+	ctx, span := tracing.Start(ctx, "customerRepository.FindCustomerByID")
+	defer span.End()
+
 	var rows []entity.CustomerWithLimits
 
 	err := r.db.SelectContext(ctx, &rows, r.db.Rebind(queryFindCustomerByID), id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			log.Error().Err(err).Int("id", id).Msg("repository::FindCustomerByID - ID not found")
+			log.Ctx(ctx).Error().Err(err).Int("id", id).Msg("repository::FindCustomerByID - ID not found")
 			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound))
 		}
-		log.Error().Err(err).Int("id", id).Msg("repository::FindCustomerByID - Failed to find user by ID")
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Msg("repository::FindCustomerByID - Failed to find user by ID")
 		return nil, err
 	}
 
@@ -116,6 +121,8 @@ func (r *customerRepository) FindCustomerByID(ctx context.Context, id int) (*ent
 		ID:              rows[0].CustomerID,
 		Nik:             rows[0].Nik,
 		Email:           rows[0].Email,
+		PhoneNumber:     rows[0].PhoneNumber,
+		DeviceID:        rows[0].DeviceID,
 		FullName:        rows[0].FullName,
 		LegalName:       rows[0].LegalName,
 		BirthPlace:      rows[0].BirthPlace,
@@ -123,6 +130,11 @@ func (r *customerRepository) FindCustomerByID(ctx context.Context, id int) (*ent
 		Salary:          rows[0].Salary,
 		KtpPhotoPath:    rows[0].KtpPhotoPath,
 		SelfiePhotoPath: rows[0].SelfiePhotoPath,
+		Gender:          rows[0].Gender.String,
+		ProvinceCode:    rows[0].ProvinceCode.String,
+		RegencyCode:     rows[0].RegencyCode.String,
+		DistrictCode:    rows[0].DistrictCode.String,
+		ReviewStatus:    rows[0].ReviewStatus.String,
 		CreatedAt:       rows[0].CreatedAt,
 		UpdatedAt:       rows[0].UpdatedAt,
 	}
@@ -138,3 +150,50 @@ func (r *customerRepository) FindCustomerByID(ctx context.Context, id int) (*ent
 
 	return customer, nil
 }
+
+func (r *customerRepository) LockCustomerByID(ctx context.Context, tx *sql.Tx, id int) (*entity.Customer, error) {
+	ctx, span := tracing.Start(ctx, "customerRepository.LockCustomerByID")
+	defer span.End()
+
+	var res = new(entity.Customer)
+
+	err := tx.QueryRowContext(ctx, r.db.Rebind(queryLockCustomerByID), id).Scan(&res.ID, &res.Salary, &res.ReviewStatus)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Ctx(ctx).Error().Err(err).Int("id", id).Msg("repository::LockCustomerByID - ID not found")
+			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound))
+		}
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Msg("repository::LockCustomerByID - Failed to lock customer")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *customerRepository) UpdateReviewStatus(ctx context.Context, tx *sql.Tx, id int64, status string) error {
+	ctx, span := tracing.Start(ctx, "customerRepository.UpdateReviewStatus")
+	defer span.End()
+
+	_, err := tx.ExecContext(ctx, r.db.Rebind(queryUpdateCustomerReviewStatus), status, id)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Str("review_status", status).Msg("repository::UpdateReviewStatus - Failed to update review status")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *customerRepository) FindActiveContractsByCustomerID(ctx context.Context, customerID int) ([]entity.ActiveContract, error) {
+	ctx, span := tracing.Start(ctx, "customerRepository.FindActiveContractsByCustomerID")
+	defer span.End()
+
+	var res = make([]entity.ActiveContract, 0)
+
+	err := r.db.SelectContext(ctx, &res, r.db.Rebind(queryFindActiveContractsByCustomerID), customerID, constants.TransactionStatusActive)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("repository::FindActiveContractsByCustomerID - Failed to find active contracts")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
@@ -2,41 +2,58 @@ package service
 
 import (
 	"context"
-	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hilmiikhsan/multifinance-service/constants"
+	redisRepository "github.com/hilmiikhsan/multifinance-service/internal/infrastructure/redis"
 	dtoLimit "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/dto"
+	creditLimitPorts "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/ports"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/dto"
 	customerPorts "github.com/hilmiikhsan/multifinance-service/internal/module/customer/ports"
 	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
 )
 
-var _ customerPorts.CustomerService = &customerService{}
+var (
+	_ customerPorts.CustomerService      = &customerService{}
+	_ customerPorts.CustomerSummaryCache = &customerService{}
+)
 
 type customerService struct {
-	db                 *sqlx.DB
-	customerRepository customerPorts.CustomerRepository
+	db                    *sqlx.DB
+	customerRepository    customerPorts.CustomerRepository
+	creditLimitRepository creditLimitPorts.CreditLimitRepository
+	redisRepository       redisRepository.RedisRepository
+	summaryCacheTTL       time.Duration
+	now                   func() time.Time
 }
 
-func NewCustomerService(db *sqlx.DB, customerRepository customerPorts.CustomerRepository) *customerService {
+func NewCustomerService(db *sqlx.DB, customerRepository customerPorts.CustomerRepository, creditLimitRepository creditLimitPorts.CreditLimitRepository, redisRepository redisRepository.RedisRepository, summaryCacheTTL time.Duration) *customerService {
 	return &customerService{
-		db:                 db,
-		customerRepository: customerRepository,
+		db:                    db,
+		customerRepository:    customerRepository,
+		creditLimitRepository: creditLimitRepository,
+		redisRepository:       redisRepository,
+		summaryCacheTTL:       summaryCacheTTL,
+		now:                   time.Now,
 	}
 }
 
 func (s *customerService) GetCustomerProfile(ctx context.Context, id int) (*dto.GetCustomerProfileResponse, error) {
+	ctx, span := tracing.Start(ctx, "customerService.GetCustomerProfile")
+	defer span.End()
+
 	customer, err := s.customerRepository.FindCustomerByID(ctx, id)
 	if err != nil {
-		if strings.Contains(err.Error(), constants.ErrUserNotFound) {
-			log.Error().Err(err).Msg("service::GetCustomerProfile - Customer not found")
+		if err_msg.HasCode(err, fiber.StatusNotFound) {
+			log.Ctx(ctx).Error().Err(err).Msg("service::GetCustomerProfile - Customer not found")
 			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound))
 		}
 
-		log.Error().Err(err).Msg("service::GetCustomerProfile - Failed to find customer by ID")
+		log.Ctx(ctx).Error().Err(err).Msg("service::GetCustomerProfile - Failed to find customer by ID")
 		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
@@ -53,11 +70,15 @@ func (s *customerService) GetCustomerProfile(ctx context.Context, id int) (*dto.
 		Nik:             customer.Nik,
 		FullName:        customer.FullName,
 		LegalName:       customer.LegalName,
+		Gender:          customer.Gender,
 		BirthPlace:      customer.BirthPlace,
 		BirthDate:       customer.BirthDate.Format(constants.DateFormat),
 		Salary:          customer.Salary,
 		KtpPhotoPath:    customer.KtpPhotoPath,
 		SelfiePhotoPath: customer.SelfiePhotoPath,
+		ProvinceCode:    customer.ProvinceCode,
+		RegencyCode:     customer.RegencyCode,
+		DistrictCode:    customer.DistrictCode,
 		Limits:          limits,
 		CreatedAt:       customer.CreatedAt.Format(constants.DateTimeFormat),
 		UpdatedAt:       customer.UpdatedAt.Format(constants.DateTimeFormat),
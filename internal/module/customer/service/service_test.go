@@ -6,11 +6,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gofiber/fiber/v2"
 	"github.com/hilmiikhsan/multifinance-service/constants"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/dto"
 	creditLimitEntity "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/entity"
 	customerDto "github.com/hilmiikhsan/multifinance-service/internal/module/customer/dto"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -85,7 +87,7 @@ func Test_customerService_GetCustomerProfile(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 			mockFn: func(args args) {
-				mockRepo.EXPECT().FindCustomerByID(gomock.Any(), args.id).Return(nil, errors.New(constants.ErrUserNotFound))
+				mockRepo.EXPECT().FindCustomerByID(gomock.Any(), args.id).Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound)))
 			},
 		},
 		{
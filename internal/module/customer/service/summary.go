@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/document"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+func summaryCacheKey(customerID int) string {
+	return fmt.Sprintf("customer:summary:%d", customerID)
+}
+
+// GetCustomerSummary serves the dashboard from the cache and computes it on a
+// miss. The cache is dropped on every booking; the TTL bounds how stale it can
+// get when a change slips past invalidation.
+func (s *customerService) GetCustomerSummary(ctx context.Context, id int) (*dto.GetCustomerSummaryResponse, error) {
+	ctx, span := tracing.Start(ctx, "customerService.GetCustomerSummary")
+	defer span.End()
+
+	key := summaryCacheKey(id)
+
+	if cached, err := s.redisRepository.Get(ctx, key); err == nil {
+		res := new(dto.GetCustomerSummaryResponse)
+		if err := json.Unmarshal([]byte(cached), res); err == nil {
+			return res, nil
+		}
+		log.Ctx(ctx).Warn().Int("customer_id", id).Msg("service::GetCustomerSummary - Ignoring unreadable cached summary")
+	}
+
+	res, err := s.buildCustomerSummary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(res)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int("customer_id", id).Msg("service::GetCustomerSummary - Failed to encode summary")
+		return res, nil
+	}
+
+	// a reader should not fail because the cache is down
+	if err := s.redisRepository.Set(ctx, key, string(encoded), s.summaryCacheTTL); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int("customer_id", id).Msg("service::GetCustomerSummary - Failed to cache summary")
+	}
+
+	return res, nil
+}
+
+func (s *customerService) InvalidateCustomerSummary(ctx context.Context, customerID int) error {
+	ctx, span := tracing.Start(ctx, "customerService.InvalidateCustomerSummary")
+	defer span.End()
+
+	if err := s.redisRepository.Del(ctx, summaryCacheKey(customerID)); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("service::InvalidateCustomerSummary - Failed to drop cached summary")
+		return err
+	}
+
+	return nil
+}
+
+func (s *customerService) buildCustomerSummary(ctx context.Context, id int) (*dto.GetCustomerSummaryResponse, error) {
+	contracts, err := s.customerRepository.FindActiveContractsByCustomerID(ctx, id)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", id).Msg("service::GetCustomerSummary - Failed to find active contracts")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	limits, err := s.creditLimitRepository.FindCreditLimitByCustomerID(ctx, id)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", id).Msg("service::GetCustomerSummary - Failed to find credit limits")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	res := &dto.GetCustomerSummaryResponse{
+		ActiveContracts: len(contracts),
+		Limits:          make([]dto.LimitUtilisation, 0, len(*limits)),
+		GeneratedAt:     s.now().Format(constants.DateTimeFormat),
+	}
+
+	used := make(map[int]float64, len(*limits))
+	dues := make(map[string]float64)
+	for _, contract := range contracts {
+		res.TotalOutstanding += math.Max(contract.OnTheRoadPrice+contract.InterestAmount+contract.AdminFee-contract.PaidAmount, 0)
+		used[contract.TenorMonth] += contract.OnTheRoadPrice
+
+		if date, amount, ok := nextDue(&contract); ok {
+			dues[date] += amount
+		}
+	}
+
+	for date, amount := range dues {
+		if res.NextDueDate == "" || date < res.NextDueDate {
+			res.NextDueDate, res.NextDueAmount = date, amount
+		}
+	}
+
+	for _, limit := range *limits {
+		item := dto.LimitUtilisation{
+			Tenor:           limit.TenorMonth,
+			LimitAmount:     limit.LimitAmount,
+			UsedAmount:      used[limit.TenorMonth],
+			AvailableAmount: math.Max(limit.LimitAmount-used[limit.TenorMonth], 0),
+		}
+		if limit.LimitAmount > 0 {
+			item.Utilisation = math.Round(item.UsedAmount/limit.LimitAmount*10000) / 10000
+		}
+
+		res.Limits = append(res.Limits, item)
+	}
+
+	sort.Slice(res.Limits, func(i, j int) bool { return res.Limits[i].Tenor < res.Limits[j].Tenor })
+
+	res.TotalOutstanding = math.Round(res.TotalOutstanding*100) / 100
+	res.NextDueAmount = math.Round(res.NextDueAmount*100) / 100
+
+	return res, nil
+}
+
+// nextDue finds the first installment of the contract that is not fully paid
+// and what is left of it.
+func nextDue(contract *entity.ActiveContract) (string, float64, bool) {
+	schedule := document.BuildSchedule(contract.CreatedAt, contract.OnTheRoadPrice, contract.InterestAmount, contract.InstallmentAmount, contract.TenorMonth)
+
+	installment, amount, ok := document.NextDue(schedule, contract.AdminFee, contract.PaidAmount)
+	if !ok {
+		return "", 0, false
+	}
+
+	return installment.DueDate.Format(constants.DateFormat), amount, true
+}
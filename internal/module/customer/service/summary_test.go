@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+	creditLimitEntity "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/entity"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/customer/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_customerService_GetCustomerSummary(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockCustomerRepository(ctrlMock)
+	mockCreditLimitRepo := NewMockCreditLimitRepository(ctrlMock)
+	mockRedis := NewMockRedisRepository(ctrlMock)
+
+	contracts := []entity.ActiveContract{
+		{
+			// first installment and the admin fee are paid
+			ID:                1,
+			OnTheRoadPrice:    1200000,
+			AdminFee:          50000,
+			InstallmentAmount: 112000,
+			InterestAmount:    144000,
+			TenorMonth:        12,
+			PaidAmount:        162000,
+			CreatedAt:         time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:                2,
+			OnTheRoadPrice:    600000,
+			AdminFee:          50000,
+			InstallmentAmount: 106000,
+			InterestAmount:    36000,
+			TenorMonth:        6,
+			CreatedAt:         time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC),
+		},
+	}
+	limits := &[]creditLimitEntity.Limits{
+		{TenorMonth: 12, LimitAmount: 1000000},
+		{TenorMonth: 6, LimitAmount: 1000000},
+	}
+	summary := &dto.GetCustomerSummaryResponse{
+		TotalOutstanding: 1918000,
+		ActiveContracts:  2,
+		NextDueDate:      "2025-02-01",
+		NextDueAmount:    112000,
+		Limits: []dto.LimitUtilisation{
+			{Tenor: 6, LimitAmount: 1000000, UsedAmount: 600000, AvailableAmount: 400000, Utilisation: 0.6},
+			{Tenor: 12, LimitAmount: 1000000, UsedAmount: 1200000, AvailableAmount: 0, Utilisation: 1.2},
+		},
+		GeneratedAt: "2025-01-20 08:00:00",
+	}
+	cached := `{"total_outstanding":1918000,"active_contracts":2,"next_due_date":"2025-02-01","next_due_amount":112000,"limits":[{"tenor":6,"limit_amount":1000000,"used_amount":600000,"available_amount":400000,"utilisation":0.6},{"tenor":12,"limit_amount":1000000,"used_amount":1200000,"available_amount":0,"utilisation":1.2}],"generated_at":"2025-01-20 08:00:00"}`
+
+	tests := []struct {
+		name     string
+		mockFn   func()
+		want     *dto.GetCustomerSummaryResponse
+		wantCode int
+	}{
+		{
+			name: "Cache Miss Computes And Caches The Summary",
+			mockFn: func() {
+				mockRedis.EXPECT().Get(gomock.Any(), "customer:summary:1").Return("", redis.Nil)
+				mockRepo.EXPECT().FindActiveContractsByCustomerID(gomock.Any(), 1).Return(contracts, nil)
+				mockCreditLimitRepo.EXPECT().FindCreditLimitByCustomerID(gomock.Any(), 1).Return(limits, nil)
+				mockRedis.EXPECT().Set(gomock.Any(), "customer:summary:1", cached, 5*time.Minute).Return(nil)
+			},
+			want: summary,
+		},
+		{
+			name: "Cache Hit",
+			mockFn: func() {
+				mockRedis.EXPECT().Get(gomock.Any(), "customer:summary:1").Return(cached, nil)
+			},
+			want: summary,
+		},
+		{
+			name: "Redis Down Still Answers",
+			mockFn: func() {
+				mockRedis.EXPECT().Get(gomock.Any(), "customer:summary:1").Return("", errors.New("connection refused"))
+				mockRepo.EXPECT().FindActiveContractsByCustomerID(gomock.Any(), 1).Return(contracts, nil)
+				mockCreditLimitRepo.EXPECT().FindCreditLimitByCustomerID(gomock.Any(), 1).Return(limits, nil)
+				mockRedis.EXPECT().Set(gomock.Any(), "customer:summary:1", gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+			},
+			want: summary,
+		},
+		{
+			name: "No Contracts",
+			mockFn: func() {
+				mockRedis.EXPECT().Get(gomock.Any(), "customer:summary:1").Return("", redis.Nil)
+				mockRepo.EXPECT().FindActiveContractsByCustomerID(gomock.Any(), 1).Return([]entity.ActiveContract{}, nil)
+				mockCreditLimitRepo.EXPECT().FindCreditLimitByCustomerID(gomock.Any(), 1).Return(&[]creditLimitEntity.Limits{{TenorMonth: 6, LimitAmount: 1000000}}, nil)
+				mockRedis.EXPECT().Set(gomock.Any(), "customer:summary:1", gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: &dto.GetCustomerSummaryResponse{
+				Limits: []dto.LimitUtilisation{
+					{Tenor: 6, LimitAmount: 1000000, AvailableAmount: 1000000},
+				},
+				GeneratedAt: "2025-01-20 08:00:00",
+			},
+		},
+		{
+			name: "Failed To Find Contracts",
+			mockFn: func() {
+				mockRedis.EXPECT().Get(gomock.Any(), "customer:summary:1").Return("", redis.Nil)
+				mockRepo.EXPECT().FindActiveContractsByCustomerID(gomock.Any(), 1).Return(nil, errors.New("connection refused"))
+			},
+			wantCode: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			s := NewCustomerService(nil, mockRepo, mockCreditLimitRepo, mockRedis, 5*time.Minute)
+			s.now = func() time.Time { return time.Date(2025, 1, 20, 8, 0, 0, 0, time.UTC) }
+
+			got, err := s.GetCustomerSummary(context.Background(), 1)
+			if tt.wantCode != 0 {
+				assert.Equal(t, tt.wantCode, err.(*err_msg.CustomError).Code)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_customerService_InvalidateCustomerSummary(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRedis := NewMockRedisRepository(ctrlMock)
+	s := &customerService{redisRepository: mockRedis}
+
+	mockRedis.EXPECT().Del(gomock.Any(), "customer:summary:7").Return(nil)
+	assert.NoError(t, s.InvalidateCustomerSummary(context.Background(), 7))
+
+	mockRedis.EXPECT().Del(gomock.Any(), "customer:summary:7").Return(errors.New("connection refused"))
+	assert.Error(t, s.InvalidateCustomerSummary(context.Background(), 7))
+}
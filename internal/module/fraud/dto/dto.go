@@ -0,0 +1,140 @@
+package dto
+
+import (
+	"github.com/hilmiikhsan/multifinance-service/pkg/imagehash"
+	"github.com/hilmiikhsan/multifinance-service/pkg/types"
+)
+
+// ScreeningSubject is what registration and booking know about the customer.
+// CustomerID is zero while the customer is still registering.
+type ScreeningSubject struct {
+	CustomerID      int64
+	Nik             string `log:"mask"`
+	Email           string `log:"mask"`
+	PhoneNumber     string `log:"mask"`
+	DeviceID        string
+	KtpPhotoPath    string
+	SelfiePhotoPath string
+}
+
+type Reason struct {
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
+
+type ScreeningResult struct {
+	Reasons     []Reason
+	KtpPhoto    *imagehash.Hashes
+	SelfiePhoto *imagehash.Hashes
+}
+
+func (r *ScreeningResult) Flagged() bool {
+	return len(r.Reasons) > 0
+}
+
+type AddWatchlistEntryRequest struct {
+	EntryType string `json:"entry_type" validate:"required,oneof=nik email phone device_id"`
+	Value     string `json:"value" validate:"required,max=255" log:"mask"`
+	Reason    string `json:"reason" validate:"required,max=255,valid_text"`
+	CreatedBy string `json:"-"`
+}
+
+type WatchlistEntryResponse struct {
+	ID        int64  `json:"id" db:"id"`
+	EntryType string `json:"entry_type" db:"entry_type"`
+	Value     string `json:"value" db:"value" log:"mask"`
+	Reason    string `json:"reason" db:"reason"`
+	CreatedBy string `json:"created_by" db:"created_by"`
+	CreatedAt string `json:"created_at" db:"created_at"`
+}
+
+type GetWatchlistEntriesRequest struct {
+	Page      int    `query:"page" validate:"required,min=1"`
+	Paginate  int    `query:"paginate" validate:"required,min=1,max=100"`
+	EntryType string `query:"entry_type" validate:"omitempty,oneof=nik email phone device_id"`
+}
+
+type GetWatchlistEntriesResponse struct {
+	Items []WatchlistEntryResponse `json:"items"`
+	Meta  types.Meta               `json:"meta"`
+}
+
+func (r *GetWatchlistEntriesRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type FraudReviewResponse struct {
+	ID           int64    `json:"id"`
+	CustomerID   int64    `json:"customer_id"`
+	TriggerEvent string   `json:"trigger_event"`
+	Reasons      []Reason `json:"reasons"`
+	Status       string   `json:"status"`
+	DecisionNote string   `json:"decision_note"`
+	ReviewedBy   string   `json:"reviewed_by"`
+	ReviewedAt   string   `json:"reviewed_at"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+type GetFraudReviewsRequest struct {
+	Page     int    `query:"page" validate:"required,min=1"`
+	Paginate int    `query:"paginate" validate:"required,min=1,max=100"`
+	Status   string `query:"status" validate:"omitempty,oneof=pending approved rejected"`
+}
+
+type GetFraudReviewsResponse struct {
+	Items []FraudReviewResponse `json:"items"`
+	Meta  types.Meta            `json:"meta"`
+}
+
+func (r *GetFraudReviewsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type DecideFraudReviewRequest struct {
+	Decision   string `json:"decision" validate:"required,oneof=approved rejected"`
+	Note       string `json:"note" validate:"omitempty,max=255,valid_text"`
+	ReviewedBy string `json:"-"`
+}
+
+type FraudEventResponse struct {
+	ID         int64          `json:"id"`
+	CustomerID int64          `json:"customer_id"`
+	EventType  string         `json:"event_type"`
+	Channel    string         `json:"channel"`
+	Detail     map[string]any `json:"detail"`
+	CreatedAt  string         `json:"created_at"`
+}
+
+type GetFraudEventsRequest struct {
+	Page       int    `query:"page" validate:"required,min=1"`
+	Paginate   int    `query:"paginate" validate:"required,min=1,max=100"`
+	CustomerID int64  `query:"customer_id" validate:"omitempty,min=1"`
+	EventType  string `query:"event_type" validate:"omitempty,oneof=velocity_breach"`
+}
+
+type GetFraudEventsResponse struct {
+	Items []FraudEventResponse `json:"items"`
+	Meta  types.Meta           `json:"meta"`
+}
+
+func (r *GetFraudEventsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+type WatchlistEntry struct {
+	ID        int64     `db:"id"`
+	EntryType string    `db:"entry_type"`
+	Value     string    `db:"value" log:"mask"`
+	Reason    string    `db:"reason"`
+	CreatedBy string    `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// FraudReview is a manual review case. Reasons holds the JSON encoded
+// screening reasons that opened the case.
+type FraudReview struct {
+	ID           int64          `db:"id"`
+	CustomerID   int64          `db:"customer_id"`
+	TriggerEvent string         `db:"trigger_event"`
+	Reasons      string         `db:"reasons"`
+	Status       string         `db:"status"`
+	DecisionNote sql.NullString `db:"decision_note"`
+	ReviewedBy   sql.NullString `db:"reviewed_by"`
+	ReviewedAt   sql.NullTime   `db:"reviewed_at"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
+
+// FraudEvent records a fraud signal that does not need a review case on its
+// own, such as a velocity breach. Detail holds the JSON encoded event data.
+type FraudEvent struct {
+	ID         int64     `db:"id"`
+	CustomerID int64     `db:"customer_id"`
+	EventType  string    `db:"event_type"`
+	Channel    string    `db:"channel"`
+	Detail     string    `db:"detail"`
+	CreatedAt  time.Time `db:"created_at"`
+}
@@ -0,0 +1,228 @@
+package rest
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	customerRepository "github.com/hilmiikhsan/multifinance-service/internal/module/customer/repository"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/ports"
+	fraudRepository "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/repository"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/service"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/response"
+	"github.com/rs/zerolog/log"
+)
+
+type fraudHandler struct {
+	service    ports.FraudService
+	middleware middleware.StaffMiddleware
+	validator  adapter.Validator
+}
+
+func NewFraudHandler() *fraudHandler {
+	var handler = new(fraudHandler)
+
+	// validator
+	validator := adapter.Adapters.Validator
+
+	// middleware
+	middlewareHandler := middleware.NewStaffMiddleware(config.Envs.Fraud.StaffApiKey)
+
+	// repository
+	fraudRepository := fraudRepository.NewFraudRepository(adapter.Adapters.MultifinanceDB)
+	customerRepository := customerRepository.NewCustomerRepository(adapter.Adapters.MultifinanceDB)
+
+	// service
+	fraudService := service.NewFraudService(
+		adapter.Adapters.MultifinanceDB,
+		fraudRepository,
+		customerRepository,
+		config.Envs.App.LocalStoragePrivatePath,
+		config.Envs.Fraud.PhotoHashMaxDistance,
+	)
+
+	// handler
+	handler.service = fraudService
+	handler.middleware = *middlewareHandler
+	handler.validator = validator
+
+	return handler
+}
+
+func (h *fraudHandler) FraudRoute(router fiber.Router) {
+	router.Post("/watchlist", h.middleware.StaffKey, h.addWatchlistEntry)
+	router.Get("/watchlist", h.middleware.StaffKey, h.getWatchlistEntries)
+	router.Delete("/watchlist/:id", h.middleware.StaffKey, h.removeWatchlistEntry)
+	router.Get("/reviews", h.middleware.StaffKey, h.getFraudReviews)
+	router.Post("/reviews/:id/decision", h.middleware.StaffKey, h.decideFraudReview)
+	router.Get("/events", h.middleware.StaffKey, h.getFraudEvents)
+}
+
+func (h *fraudHandler) addWatchlistEntry(c *fiber.Ctx) error {
+	var (
+		ctx = c.UserContext()
+		req = new(dto.AddWatchlistEntryRequest)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::addWatchlistEntry - Failed to parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::addWatchlistEntry - Invalid request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.CreatedBy = middleware.GetStaffID(c)
+
+	res, err := h.service.AddWatchlistEntry(ctx, req)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("handler::addWatchlistEntry - Failed to add watchlist entry")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(res, ""))
+}
+
+func (h *fraudHandler) getWatchlistEntries(c *fiber.Ctx) error {
+	var (
+		ctx = c.UserContext()
+		req = new(dto.GetWatchlistEntriesRequest)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getWatchlistEntries - Failed to parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := h.validator.Validate(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getWatchlistEntries - Invalid request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	res, err := h.service.GetWatchlistEntries(ctx, req)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("handler::getWatchlistEntries - Failed to get watchlist entries")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
+
+func (h *fraudHandler) removeWatchlistEntry(c *fiber.Ctx) error {
+	var ctx = c.UserContext()
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id < 1 {
+		log.Ctx(ctx).Warn().Str("id", c.Params("id")).Msg("handler::removeWatchlistEntry - Invalid id")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(constants.ErrParamIdIsRequired))
+	}
+
+	if err := h.service.RemoveWatchlistEntry(ctx, id); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("handler::removeWatchlistEntry - Failed to remove watchlist entry")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+func (h *fraudHandler) getFraudReviews(c *fiber.Ctx) error {
+	var (
+		ctx = c.UserContext()
+		req = new(dto.GetFraudReviewsRequest)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getFraudReviews - Failed to parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := h.validator.Validate(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getFraudReviews - Invalid request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	res, err := h.service.GetFraudReviews(ctx, req)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("handler::getFraudReviews - Failed to get fraud reviews")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
+
+func (h *fraudHandler) decideFraudReview(c *fiber.Ctx) error {
+	var (
+		ctx = c.UserContext()
+		req = new(dto.DecideFraudReviewRequest)
+	)
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id < 1 {
+		log.Ctx(ctx).Warn().Str("id", c.Params("id")).Msg("handler::decideFraudReview - Invalid id")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(constants.ErrParamIdIsRequired))
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::decideFraudReview - Failed to parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::decideFraudReview - Invalid request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ReviewedBy = middleware.GetStaffID(c)
+
+	res, err := h.service.DecideFraudReview(ctx, id, req)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Any("payload", req).Msg("handler::decideFraudReview - Failed to decide fraud review")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
+
+func (h *fraudHandler) getFraudEvents(c *fiber.Ctx) error {
+	var (
+		ctx = c.UserContext()
+		req = new(dto.GetFraudEventsRequest)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getFraudEvents - Failed to parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := h.validator.Validate(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getFraudEvents - Invalid request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	res, err := h.service.GetFraudEvents(ctx, req)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("handler::getFraudEvents - Failed to get fraud events")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_fraudHandler_decideFraudReview(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockFraudService(ctrlMock)
+	mockValidator := NewMockValidator(ctrlMock)
+
+	type args struct {
+		path       string
+		staffKey   string
+		body       string
+		statusCode int
+		mockFn     func()
+	}
+
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Success",
+			args: args{
+				path:       "/reviews/1/decision",
+				staffKey:   "secret",
+				body:       `{"decision": "approved", "note": "Same person, new KTP"}`,
+				statusCode: http.StatusOK,
+				mockFn: func() {
+					mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+					mockSvc.EXPECT().DecideFraudReview(gomock.Any(), int64(1), &dto.DecideFraudReviewRequest{
+						Decision:   constants.FraudReviewStatusApproved,
+						Note:       "Same person, new KTP",
+						ReviewedBy: "staff-1",
+					}).Return(&dto.FraudReviewResponse{ID: 1, Status: constants.FraudReviewStatusApproved}, nil)
+				},
+			},
+		},
+		{
+			name: "Failure - Invalid Staff Key",
+			args: args{
+				path:       "/reviews/1/decision",
+				staffKey:   "wrong",
+				body:       `{"decision": "approved"}`,
+				statusCode: http.StatusUnauthorized,
+				mockFn:     func() {},
+			},
+		},
+		{
+			name: "Failure - Invalid ID",
+			args: args{
+				path:       "/reviews/abc/decision",
+				staffKey:   "secret",
+				body:       `{"decision": "approved"}`,
+				statusCode: http.StatusBadRequest,
+				mockFn:     func() {},
+			},
+		},
+		{
+			name: "Failure - Validation Error",
+			args: args{
+				path:       "/reviews/1/decision",
+				staffKey:   "secret",
+				body:       `{"decision": "maybe"}`,
+				statusCode: http.StatusBadRequest,
+				mockFn: func() {
+					mockValidator.EXPECT().Validate(gomock.Any()).Return(errors.New("validation error"))
+				},
+			},
+		},
+		{
+			name: "Failure - Already Decided",
+			args: args{
+				path:       "/reviews/1/decision",
+				staffKey:   "secret",
+				body:       `{"decision": "rejected"}`,
+				statusCode: http.StatusConflict,
+				mockFn: func() {
+					mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+					mockSvc.EXPECT().DecideFraudReview(gomock.Any(), int64(1), gomock.Any()).
+						Return(nil, err_msg.NewCustomErrors(fiber.StatusConflict, err_msg.WithMessage(constants.ErrFraudReviewAlreadyDecided)))
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			handler := &fraudHandler{
+				service:    mockSvc,
+				middleware: *middleware.NewStaffMiddleware("secret"),
+				validator:  mockValidator,
+			}
+			handler.FraudRoute(app)
+
+			tt.args.mockFn()
+
+			req := httptest.NewRequest(http.MethodPost, tt.args.path, bytes.NewBufferString(tt.args.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(constants.HeaderStaffKey, tt.args.staffKey)
+			req.Header.Set(constants.HeaderStaffID, "staff-1")
+			resp, _ := app.Test(req)
+
+			assert.Equal(t, tt.args.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_fraudHandler_getWatchlistEntries(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockFraudService(ctrlMock)
+	mockValidator := NewMockValidator(ctrlMock)
+
+	app := fiber.New()
+	handler := &fraudHandler{
+		service:    mockSvc,
+		middleware: *middleware.NewStaffMiddleware("secret"),
+		validator:  mockValidator,
+	}
+	handler.FraudRoute(app)
+
+	mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+	mockSvc.EXPECT().GetWatchlistEntries(gomock.Any(), &dto.GetWatchlistEntriesRequest{
+		Page:      1,
+		Paginate:  10,
+		EntryType: constants.WatchlistTypeEmail,
+	}).Return(&dto.GetWatchlistEntriesResponse{Items: []dto.WatchlistEntryResponse{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/watchlist?entry_type=email", nil)
+	req.Header.Set(constants.HeaderStaffKey, "secret")
+	req.Header.Set(constants.HeaderStaffID, "staff-1")
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// without a staff ID nothing reaches the service
+	req = httptest.NewRequest(http.MethodGet, "/watchlist", nil)
+	req.Header.Set(constants.HeaderStaffKey, "secret")
+	resp, _ = app.Test(req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,50 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/imagehash"
+)
+
+//go:generate mockgen -source=ports.go -destination=../service/service_mock_test.go -package=service
+type FraudRepository interface {
+	InsertNewWatchlistEntry(ctx context.Context, data *entity.WatchlistEntry) (int64, error)
+	DeleteWatchlistEntry(ctx context.Context, id int64) error
+	FindWatchlistEntries(ctx context.Context, req *dto.GetWatchlistEntriesRequest) (*dto.GetWatchlistEntriesResponse, error)
+	FindWatchlistMatches(ctx context.Context, candidates []entity.WatchlistEntry) ([]entity.WatchlistEntry, error)
+	FindCustomerIDsByPhotoHash(ctx context.Context, photo string, hashes *imagehash.Hashes, excludeCustomerID int64, maxDistance int) ([]int64, error)
+	InsertNewFraudReview(ctx context.Context, tx *sql.Tx, data *entity.FraudReview) error
+	FindFraudReviews(ctx context.Context, req *dto.GetFraudReviewsRequest) ([]entity.FraudReview, int, error)
+	LockFraudReviewByID(ctx context.Context, tx *sql.Tx, id int64) (*entity.FraudReview, error)
+	FindFraudReviewsByCustomerID(ctx context.Context, tx *sql.Tx, customerID int64) ([]entity.FraudReview, error)
+	UpdateFraudReviewDecision(ctx context.Context, tx *sql.Tx, data *entity.FraudReview) error
+	CountPendingFraudReviewsByCustomerID(ctx context.Context, tx *sql.Tx, customerID int64) (int, error)
+	InsertNewFraudEvent(ctx context.Context, data *entity.FraudEvent) error
+	FindFraudEvents(ctx context.Context, req *dto.GetFraudEventsRequest) ([]entity.FraudEvent, int, error)
+}
+
+// FraudScreener is used by registration and booking to check a customer
+// against the watchlist and other customers' photos, and to open review cases.
+// OpenReview reports whether the customer is under review afterwards; reasons
+// an earlier case already raised do not open another one.
+//
+//go:generate mockgen -source=ports.go -destination=../../auth/service/service_fraud_mock_test.go -package=service
+//go:generate mockgen -source=ports.go -destination=../../transaction/service/service_fraud_mock_test.go -package=service
+type FraudScreener interface {
+	Screen(ctx context.Context, subject *dto.ScreeningSubject) (*dto.ScreeningResult, error)
+	OpenReview(ctx context.Context, tx *sql.Tx, customerID int64, triggerEvent string, reasons []dto.Reason) (bool, error)
+	RecordEvent(ctx context.Context, customerID int64, eventType, channel string, detail map[string]any) error
+}
+
+//go:generate mockgen -source=ports.go -destination=../handler/rest/handler_mock_test.go -package=rest
+type FraudService interface {
+	AddWatchlistEntry(ctx context.Context, req *dto.AddWatchlistEntryRequest) (*dto.WatchlistEntryResponse, error)
+	GetWatchlistEntries(ctx context.Context, req *dto.GetWatchlistEntriesRequest) (*dto.GetWatchlistEntriesResponse, error)
+	RemoveWatchlistEntry(ctx context.Context, id int64) error
+	GetFraudReviews(ctx context.Context, req *dto.GetFraudReviewsRequest) (*dto.GetFraudReviewsResponse, error)
+	DecideFraudReview(ctx context.Context, id int64, req *dto.DecideFraudReviewRequest) (*dto.FraudReviewResponse, error)
+	GetFraudEvents(ctx context.Context, req *dto.GetFraudEventsRequest) (*dto.GetFraudEventsResponse, error)
+}
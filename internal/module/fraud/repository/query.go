@@ -0,0 +1,161 @@
+package repository
+
+import "github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+
+const (
+	queryInsertNewWatchlistEntry = `
+		INSERT INTO watchlist_entries (entry_type, value, reason, created_by) VALUES (?, ?, ?, ?)
+	`
+
+	queryDeleteWatchlistEntry = `
+		DELETE FROM watchlist_entries WHERE id = ?
+	`
+
+	queryFindWatchlistEntries = `
+		SELECT
+			id,
+			entry_type,
+			value,
+			reason,
+			created_by,
+			created_at
+		FROM watchlist_entries
+		WHERE (:entry_type = '' OR entry_type = :entry_type)
+		ORDER BY created_at DESC, id DESC
+		LIMIT :limit OFFSET :offset
+	`
+
+	queryCountWatchlistEntries = `
+		SELECT COUNT(*) AS total_data
+		FROM watchlist_entries
+		WHERE (:entry_type = '' OR entry_type = :entry_type)
+	`
+
+	// the match condition is repeated once per candidate
+	queryFindWatchlistMatches = `
+		SELECT
+			id,
+			entry_type,
+			value,
+			reason,
+			created_by,
+			created_at
+		FROM watchlist_entries
+		WHERE %s
+	`
+
+	queryInsertNewFraudReview = `
+		INSERT INTO fraud_reviews (customer_id, trigger_event, reasons, status) VALUES (?, ?, ?, ?)
+	`
+
+	queryFindFraudReviews = `
+		SELECT
+			id,
+			customer_id,
+			trigger_event,
+			reasons,
+			status,
+			decision_note,
+			reviewed_by,
+			reviewed_at,
+			created_at
+		FROM fraud_reviews
+		WHERE (:status = '' OR status = :status)
+		ORDER BY created_at ASC, id ASC
+		LIMIT :limit OFFSET :offset
+	`
+
+	queryCountFraudReviews = `
+		SELECT COUNT(*) AS total_data
+		FROM fraud_reviews
+		WHERE (:status = '' OR status = :status)
+	`
+
+	queryLockFraudReviewByID = `
+		SELECT
+			id,
+			customer_id,
+			trigger_event,
+			reasons,
+			status,
+			decision_note,
+			reviewed_by,
+			reviewed_at,
+			created_at
+		FROM fraud_reviews
+		WHERE id = ?
+		FOR UPDATE
+	`
+
+	queryFindFraudReviewsByCustomerID = `
+		SELECT
+			id,
+			customer_id,
+			trigger_event,
+			reasons,
+			status,
+			decision_note,
+			reviewed_by,
+			reviewed_at,
+			created_at
+		FROM fraud_reviews
+		WHERE customer_id = ?
+		ORDER BY created_at ASC, id ASC
+	`
+
+	queryUpdateFraudReviewDecision = `
+		UPDATE fraud_reviews
+		SET status = ?, decision_note = ?, reviewed_by = ?, reviewed_at = ?
+		WHERE id = ?
+	`
+
+	queryCountPendingFraudReviewsByCustomerID = `
+		SELECT COUNT(*) FROM fraud_reviews WHERE customer_id = ? AND status = ?
+	`
+
+	queryInsertNewFraudEvent = `
+		INSERT INTO fraud_events (customer_id, event_type, channel, detail) VALUES (?, ?, ?, ?)
+	`
+
+	queryFindFraudEvents = `
+		SELECT
+			id,
+			customer_id,
+			event_type,
+			channel,
+			detail,
+			created_at
+		FROM fraud_events
+		WHERE (:customer_id = 0 OR customer_id = :customer_id)
+			AND (:event_type = '' OR event_type = :event_type)
+		ORDER BY created_at DESC, id DESC
+		LIMIT :limit OFFSET :offset
+	`
+
+	queryCountFraudEvents = `
+		SELECT COUNT(*) AS total_data
+		FROM fraud_events
+		WHERE (:customer_id = 0 OR customer_id = :customer_id)
+			AND (:event_type = '' OR event_type = :event_type)
+	`
+)
+
+var (
+	// %[1]s is the photo column prefix; a negative distance disables the perceptual match
+	queryFindCustomerIDsByPhotoHash = dialect.Query{
+		MySQL: `
+			SELECT id
+			FROM customers
+			WHERE id <> ?
+				AND (%[1]s_photo_hash = ? OR BIT_COUNT(%[1]s_photo_phash ^ ?) <= ?)
+			LIMIT 10
+		`,
+		Postgres: `
+			SELECT id
+			FROM customers
+			WHERE id <> ?
+				AND (%[1]s_photo_hash = ? OR BIT_COUNT(CAST(%[1]s_photo_phash # ? AS BIT(64))) <= ?)
+			LIMIT 10
+		`,
+	}
+)
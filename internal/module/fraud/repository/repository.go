@@ -0,0 +1,419 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/entity"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/imagehash"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.FraudRepository = &fraudRepository{}
+
+var photoColumns = map[string]string{
+	constants.PhotoKtp:    "ktp",
+	constants.PhotoSelfie: "selfie",
+}
+
+type fraudRepository struct {
+	db *sqlx.DB
+}
+
+func NewFraudRepository(db *sqlx.DB) *fraudRepository {
+	return &fraudRepository{
+		db: db,
+	}
+}
+
+func (r *fraudRepository) InsertNewWatchlistEntry(ctx context.Context, data *entity.WatchlistEntry) (int64, error) {
+	ctx, span := tracing.Start(ctx, "fraudRepository.InsertNewWatchlistEntry")
+	defer span.End()
+
+	id, err := dialect.InsertID(ctx, r.db, r.db.DriverName(), r.db.Rebind(queryInsertNewWatchlistEntry),
+		data.EntryType,
+		data.Value,
+		data.Reason,
+		data.CreatedBy,
+	)
+	if err != nil {
+		if err_msg.IsDuplicateEntry(err) {
+			log.Ctx(ctx).Warn().Err(err).Any("payload", data).Msg("repository::InsertNewWatchlistEntry - Watchlist entry already exists")
+			return 0, err_msg.NewCustomErrors(fiber.StatusConflict, err_msg.WithMessage(constants.ErrWatchlistEntryExists))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Any("payload", data).Msg("repository::InsertNewWatchlistEntry - Failed to insert watchlist entry")
+		return 0, err_msg.NewDatabaseErrors(err)
+	}
+
+	return id, nil
+}
+
+func (r *fraudRepository) DeleteWatchlistEntry(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "fraudRepository.DeleteWatchlistEntry")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(queryDeleteWatchlistEntry), id)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("repository::DeleteWatchlistEntry - Failed to delete watchlist entry")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("repository::DeleteWatchlistEntry - Failed to read affected rows")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	if affected == 0 {
+		return err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrWatchlistEntryNotFound))
+	}
+
+	return nil
+}
+
+func (r *fraudRepository) FindWatchlistEntries(ctx context.Context, req *dto.GetWatchlistEntriesRequest) (*dto.GetWatchlistEntriesResponse, error) {
+	ctx, span := tracing.Start(ctx, "fraudRepository.FindWatchlistEntries")
+	defer span.End()
+
+	var (
+		resp = new(dto.GetWatchlistEntriesResponse)
+		data = make([]dto.WatchlistEntryResponse, 0, req.Paginate)
+	)
+
+	var totalData int
+	countQuery, countArgs, err := sqlx.Named(queryCountWatchlistEntries, map[string]interface{}{
+		"entry_type": req.EntryType,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindWatchlistEntries - Failed to bind named query for count")
+		return nil, err
+	}
+
+	err = r.db.GetContext(ctx, &totalData, r.db.Rebind(countQuery), countArgs...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindWatchlistEntries - Failed to count watchlist entries")
+		return nil, err
+	}
+
+	query, args, err := sqlx.Named(queryFindWatchlistEntries, map[string]interface{}{
+		"entry_type": req.EntryType,
+		"limit":      req.Paginate,
+		"offset":     req.Paginate * (req.Page - 1),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindWatchlistEntries - Failed to bind named query")
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("repository::FindWatchlistEntries - Failed to find watchlist entries")
+		return nil, err
+	}
+
+	resp.Items = data
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, totalData)
+
+	return resp, nil
+}
+
+func (r *fraudRepository) FindWatchlistMatches(ctx context.Context, candidates []entity.WatchlistEntry) ([]entity.WatchlistEntry, error) {
+	ctx, span := tracing.Start(ctx, "fraudRepository.FindWatchlistMatches")
+	defer span.End()
+
+	var res []entity.WatchlistEntry
+
+	if len(candidates) == 0 {
+		return res, nil
+	}
+
+	var (
+		conditions = make([]string, 0, len(candidates))
+		args       = make([]interface{}, 0, len(candidates)*2)
+	)
+
+	for _, candidate := range candidates {
+		conditions = append(conditions, "(entry_type = ? AND value = ?)")
+		args = append(args, candidate.EntryType, candidate.Value)
+	}
+
+	query := fmt.Sprintf(queryFindWatchlistMatches, strings.Join(conditions, " OR "))
+
+	err := r.db.SelectContext(ctx, &res, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("candidates", candidates).Msg("repository::FindWatchlistMatches - Failed to find watchlist matches")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *fraudRepository) FindCustomerIDsByPhotoHash(ctx context.Context, photo string, hashes *imagehash.Hashes, excludeCustomerID int64, maxDistance int) ([]int64, error) {
+	ctx, span := tracing.Start(ctx, "fraudRepository.FindCustomerIDsByPhotoHash")
+	defer span.End()
+
+	var res []int64
+
+	column, ok := photoColumns[photo]
+	if !ok {
+		return nil, fmt.Errorf("unknown photo %q", photo)
+	}
+
+	if !hashes.HasPerceptual {
+		maxDistance = -1
+	}
+
+	query := fmt.Sprintf(queryFindCustomerIDsByPhotoHash.For(r.db.DriverName()), column)
+
+	// the perceptual hash is stored in a signed BIGINT, BIT_COUNT only looks at the bits
+	err := r.db.SelectContext(ctx, &res, r.db.Rebind(query),
+		excludeCustomerID,
+		hashes.SHA256,
+		int64(hashes.Perceptual),
+		maxDistance,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("photo", photo).Msg("repository::FindCustomerIDsByPhotoHash - Failed to find customers by photo hash")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *fraudRepository) InsertNewFraudReview(ctx context.Context, tx *sql.Tx, data *entity.FraudReview) error {
+	ctx, span := tracing.Start(ctx, "fraudRepository.InsertNewFraudReview")
+	defer span.End()
+
+	_, err := tx.ExecContext(ctx, r.db.Rebind(queryInsertNewFraudReview),
+		data.CustomerID,
+		data.TriggerEvent,
+		data.Reasons,
+		data.Status,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", data).Msg("repository::InsertNewFraudReview - Failed to insert fraud review")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *fraudRepository) FindFraudReviews(ctx context.Context, req *dto.GetFraudReviewsRequest) ([]entity.FraudReview, int, error) {
+	ctx, span := tracing.Start(ctx, "fraudRepository.FindFraudReviews")
+	defer span.End()
+
+	var (
+		data      = make([]entity.FraudReview, 0, req.Paginate)
+		totalData int
+	)
+
+	countQuery, countArgs, err := sqlx.Named(queryCountFraudReviews, map[string]interface{}{
+		"status": req.Status,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindFraudReviews - Failed to bind named query for count")
+		return nil, 0, err
+	}
+
+	err = r.db.GetContext(ctx, &totalData, r.db.Rebind(countQuery), countArgs...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindFraudReviews - Failed to count fraud reviews")
+		return nil, 0, err
+	}
+
+	query, args, err := sqlx.Named(queryFindFraudReviews, map[string]interface{}{
+		"status": req.Status,
+		"limit":  req.Paginate,
+		"offset": req.Paginate * (req.Page - 1),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindFraudReviews - Failed to bind named query")
+		return nil, 0, err
+	}
+
+	err = r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("repository::FindFraudReviews - Failed to find fraud reviews")
+		return nil, 0, err
+	}
+
+	return data, totalData, nil
+}
+
+func (r *fraudRepository) LockFraudReviewByID(ctx context.Context, tx *sql.Tx, id int64) (*entity.FraudReview, error) {
+	ctx, span := tracing.Start(ctx, "fraudRepository.LockFraudReviewByID")
+	defer span.End()
+
+	var res = new(entity.FraudReview)
+
+	err := tx.QueryRowContext(ctx, r.db.Rebind(queryLockFraudReviewByID), id).Scan(
+		&res.ID,
+		&res.CustomerID,
+		&res.TriggerEvent,
+		&res.Reasons,
+		&res.Status,
+		&res.DecisionNote,
+		&res.ReviewedBy,
+		&res.ReviewedAt,
+		&res.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Ctx(ctx).Warn().Int64("id", id).Msg("repository::LockFraudReviewByID - Fraud review not found")
+			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrFraudReviewNotFound))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("repository::LockFraudReviewByID - Failed to lock fraud review")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *fraudRepository) FindFraudReviewsByCustomerID(ctx context.Context, tx *sql.Tx, customerID int64) ([]entity.FraudReview, error) {
+	ctx, span := tracing.Start(ctx, "fraudRepository.FindFraudReviewsByCustomerID")
+	defer span.End()
+
+	rows, err := tx.QueryContext(ctx, r.db.Rebind(queryFindFraudReviewsByCustomerID), customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Msg("repository::FindFraudReviewsByCustomerID - Failed to find fraud reviews")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+	defer rows.Close()
+
+	var res []entity.FraudReview
+	for rows.Next() {
+		var review entity.FraudReview
+		err = rows.Scan(
+			&review.ID,
+			&review.CustomerID,
+			&review.TriggerEvent,
+			&review.Reasons,
+			&review.Status,
+			&review.DecisionNote,
+			&review.ReviewedBy,
+			&review.ReviewedAt,
+			&review.CreatedAt,
+		)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Msg("repository::FindFraudReviewsByCustomerID - Failed to scan fraud review")
+			return nil, err_msg.NewDatabaseErrors(err)
+		}
+
+		res = append(res, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Msg("repository::FindFraudReviewsByCustomerID - Failed to read fraud reviews")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *fraudRepository) UpdateFraudReviewDecision(ctx context.Context, tx *sql.Tx, data *entity.FraudReview) error {
+	ctx, span := tracing.Start(ctx, "fraudRepository.UpdateFraudReviewDecision")
+	defer span.End()
+
+	_, err := tx.ExecContext(ctx, r.db.Rebind(queryUpdateFraudReviewDecision),
+		data.Status,
+		data.DecisionNote,
+		data.ReviewedBy,
+		data.ReviewedAt,
+		data.ID,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", data).Msg("repository::UpdateFraudReviewDecision - Failed to update fraud review")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *fraudRepository) CountPendingFraudReviewsByCustomerID(ctx context.Context, tx *sql.Tx, customerID int64) (int, error) {
+	ctx, span := tracing.Start(ctx, "fraudRepository.CountPendingFraudReviewsByCustomerID")
+	defer span.End()
+
+	var total int
+
+	err := tx.QueryRowContext(ctx, r.db.Rebind(queryCountPendingFraudReviewsByCustomerID), customerID, constants.FraudReviewStatusPending).Scan(&total)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Msg("repository::CountPendingFraudReviewsByCustomerID - Failed to count pending fraud reviews")
+		return 0, err_msg.NewDatabaseErrors(err)
+	}
+
+	return total, nil
+}
+
+func (r *fraudRepository) InsertNewFraudEvent(ctx context.Context, data *entity.FraudEvent) error {
+	ctx, span := tracing.Start(ctx, "fraudRepository.InsertNewFraudEvent")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(queryInsertNewFraudEvent),
+		data.CustomerID,
+		data.EventType,
+		data.Channel,
+		data.Detail,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", data).Msg("repository::InsertNewFraudEvent - Failed to insert fraud event")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *fraudRepository) FindFraudEvents(ctx context.Context, req *dto.GetFraudEventsRequest) ([]entity.FraudEvent, int, error) {
+	ctx, span := tracing.Start(ctx, "fraudRepository.FindFraudEvents")
+	defer span.End()
+
+	var (
+		data      = make([]entity.FraudEvent, 0, req.Paginate)
+		totalData int
+	)
+
+	countQuery, countArgs, err := sqlx.Named(queryCountFraudEvents, map[string]interface{}{
+		"customer_id": req.CustomerID,
+		"event_type":  req.EventType,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindFraudEvents - Failed to bind named query for count")
+		return nil, 0, err
+	}
+
+	err = r.db.GetContext(ctx, &totalData, r.db.Rebind(countQuery), countArgs...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindFraudEvents - Failed to count fraud events")
+		return nil, 0, err
+	}
+
+	query, args, err := sqlx.Named(queryFindFraudEvents, map[string]interface{}{
+		"customer_id": req.CustomerID,
+		"event_type":  req.EventType,
+		"limit":       req.Paginate,
+		"offset":      req.Paginate * (req.Page - 1),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindFraudEvents - Failed to bind named query")
+		return nil, 0, err
+	}
+
+	err = r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("repository::FindFraudEvents - Failed to find fraud events")
+		return nil, 0, err
+	}
+
+	return data, totalData, nil
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect/dialecttest"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/imagehash"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_fraudRepository_InsertNewWatchlistEntry(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		repo := NewFraudRepository(db)
+
+		data := &entity.WatchlistEntry{
+			EntryType: constants.WatchlistTypePhone,
+			Value:     "081234567890",
+			Reason:    "Reported stolen identity",
+			CreatedBy: "staff-1",
+		}
+
+		tests := []struct {
+			name     string
+			mockFn   func()
+			want     int64
+			wantCode int
+		}{
+			{
+				name: "Insert Watchlist Entry Successfully",
+				mockFn: func() {
+					dialecttest.ExpectInsert(mock, db, "INSERT INTO watchlist_entries").
+						WithArgs(data.EntryType, data.Value, data.Reason, data.CreatedBy).
+						WillReturnID(7)
+				},
+				want: 7,
+			},
+			{
+				name: "Duplicate Watchlist Entry",
+				mockFn: func() {
+					dialecttest.ExpectInsert(mock, db, "INSERT INTO watchlist_entries").
+						WithArgs(data.EntryType, data.Value, data.Reason, data.CreatedBy).
+						WillReturnError(dialecttest.DuplicateKey(db, "watchlist_entries", "unique_watchlist_type_value", "nik-3171011501900001"))
+				},
+				wantCode: fiber.StatusConflict,
+			},
+			{
+				name: "Database Error",
+				mockFn: func() {
+					dialecttest.ExpectInsert(mock, db, "INSERT INTO watchlist_entries").
+						WithArgs(data.EntryType, data.Value, data.Reason, data.CreatedBy).
+						WillReturnError(errors.New("connection refused"))
+				},
+				wantCode: fiber.StatusInternalServerError,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn()
+
+				got, err := repo.InsertNewWatchlistEntry(context.Background(), data)
+				if tt.wantCode != 0 {
+					assert.Equal(t, tt.wantCode, err.(*err_msg.CustomError).Code)
+				} else {
+					assert.NoError(t, err)
+				}
+
+				assert.Equal(t, tt.want, got)
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_fraudRepository_DeleteWatchlistEntry(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		repo := NewFraudRepository(db)
+
+		mock.ExpectExec("DELETE FROM watchlist_entries").WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		assert.NoError(t, repo.DeleteWatchlistEntry(context.Background(), 1))
+
+		mock.ExpectExec("DELETE FROM watchlist_entries").WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 0))
+		err := repo.DeleteWatchlistEntry(context.Background(), 2)
+		assert.Equal(t, fiber.StatusNotFound, err.(*err_msg.CustomError).Code)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_fraudRepository_FindWatchlistMatches(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		repo := NewFraudRepository(db)
+
+		got, err := repo.FindWatchlistMatches(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+
+		createdAt := time.Now()
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE (entry_type = ? AND value = ?) OR (entry_type = ? AND value = ?)")).
+			WithArgs(constants.WatchlistTypeNik, "3174010101900001", constants.WatchlistTypeEmail, "bad@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "entry_type", "value", "reason", "created_by", "created_at"}).
+				AddRow(3, constants.WatchlistTypeEmail, "bad@example.com", "Chargeback", "staff-1", createdAt))
+
+		got, err = repo.FindWatchlistMatches(context.Background(), []entity.WatchlistEntry{
+			{EntryType: constants.WatchlistTypeNik, Value: "3174010101900001"},
+			{EntryType: constants.WatchlistTypeEmail, Value: "bad@example.com"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.WatchlistEntry{{
+			ID:        3,
+			EntryType: constants.WatchlistTypeEmail,
+			Value:     "bad@example.com",
+			Reason:    "Chargeback",
+			CreatedBy: "staff-1",
+			CreatedAt: createdAt,
+		}}, got)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_fraudRepository_FindCustomerIDsByPhotoHash(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		repo := NewFraudRepository(db)
+
+		photoMatch := dialect.Query{
+			MySQL:    "%[1]s_photo_hash = ? OR BIT_COUNT(%[1]s_photo_phash ^ ?) <= ?",
+			Postgres: "%[1]s_photo_hash = ? OR BIT_COUNT(CAST(%[1]s_photo_phash # ? AS BIT(64))) <= ?",
+		}.For(db.DriverName())
+
+		tests := []struct {
+			name    string
+			photo   string
+			hashes  *imagehash.Hashes
+			mockFn  func()
+			want    []int64
+			wantErr bool
+		}{
+			{
+				name:   "Perceptual Match Within Distance",
+				photo:  constants.PhotoKtp,
+				hashes: &imagehash.Hashes{SHA256: "abc", Perceptual: 1<<63 | 1, HasPerceptual: true},
+				mockFn: func() {
+					mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(photoMatch, "ktp"))).
+						WithArgs(int64(5), "abc", int64(-9223372036854775807), 6).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(4))
+				},
+				want: []int64{2, 4},
+			},
+			{
+				name:   "Exact Match Only Without Perceptual Hash",
+				photo:  constants.PhotoSelfie,
+				hashes: &imagehash.Hashes{SHA256: "def"},
+				mockFn: func() {
+					mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(photoMatch, "selfie"))).
+						WithArgs(int64(5), "def", int64(0), -1).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				},
+			},
+			{
+				name:    "Unknown Photo",
+				photo:   "passport",
+				hashes:  &imagehash.Hashes{SHA256: "abc"},
+				mockFn:  func() {},
+				wantErr: true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn()
+
+				got, err := repo.FindCustomerIDsByPhotoHash(context.Background(), tt.photo, tt.hashes, 5, 6)
+
+				assert.Equal(t, tt.wantErr, err != nil)
+				assert.Equal(t, tt.want, got)
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_fraudRepository_LockFraudReviewByID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		repo := NewFraudRepository(db)
+		createdAt := time.Now()
+
+		mock.ExpectBegin()
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		mock.ExpectQuery(regexp.QuoteMeta("FROM fraud_reviews")).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "trigger_event", "reasons", "status", "decision_note", "reviewed_by", "reviewed_at", "created_at"}).
+				AddRow(1, 9, constants.FraudTriggerRegistration, `[]`, constants.FraudReviewStatusPending, nil, nil, nil, createdAt))
+
+		got, err := repo.LockFraudReviewByID(context.Background(), tx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.FraudReview{
+			ID:           1,
+			CustomerID:   9,
+			TriggerEvent: constants.FraudTriggerRegistration,
+			Reasons:      `[]`,
+			Status:       constants.FraudReviewStatusPending,
+			CreatedAt:    createdAt,
+		}, got)
+
+		mock.ExpectQuery(regexp.QuoteMeta("FROM fraud_reviews")).WithArgs(int64(2)).WillReturnError(sql.ErrNoRows)
+
+		_, err = repo.LockFraudReviewByID(context.Background(), tx, 2)
+		assert.Equal(t, fiber.StatusNotFound, err.(*err_msg.CustomError).Code)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_fraudRepository_FindFraudReviewsByCustomerID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		repo := NewFraudRepository(db)
+		createdAt := time.Date(2024, 12, 23, 9, 0, 0, 0, time.UTC)
+		reviewedAt := time.Date(2024, 12, 24, 9, 0, 0, 0, time.UTC)
+
+		mock.ExpectBegin()
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		mock.ExpectQuery(regexp.QuoteMeta("FROM fraud_reviews WHERE customer_id = ?")).
+			WithArgs(int64(9)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "trigger_event", "reasons", "status", "decision_note", "reviewed_by", "reviewed_at", "created_at"}).
+				AddRow(1, 9, constants.FraudTriggerTransaction, `[{"code":"watchlist_device_id","detail":"Chargeback"}]`, constants.FraudReviewStatusApproved, "known device", "staff-1", reviewedAt, createdAt))
+
+		got, err := repo.FindFraudReviewsByCustomerID(context.Background(), tx, 9)
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.FraudReview{{
+			ID:           1,
+			CustomerID:   9,
+			TriggerEvent: constants.FraudTriggerTransaction,
+			Reasons:      `[{"code":"watchlist_device_id","detail":"Chargeback"}]`,
+			Status:       constants.FraudReviewStatusApproved,
+			DecisionNote: sql.NullString{String: "known device", Valid: true},
+			ReviewedBy:   sql.NullString{String: "staff-1", Valid: true},
+			ReviewedAt:   sql.NullTime{Time: reviewedAt, Valid: true},
+			CreatedAt:    createdAt,
+		}}, got)
+
+		mock.ExpectQuery(regexp.QuoteMeta("FROM fraud_reviews WHERE customer_id = ?")).WithArgs(int64(10)).WillReturnError(errors.New("connection refused"))
+
+		_, err = repo.FindFraudReviewsByCustomerID(context.Background(), tx, 10)
+		assert.Error(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,449 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	customerPorts "github.com/hilmiikhsan/multifinance-service/internal/module/customer/ports"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/entity"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/imagehash"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	_ ports.FraudService  = &fraudService{}
+	_ ports.FraudScreener = &fraudService{}
+
+	nonDigitRegex = regexp.MustCompile(`\D`)
+)
+
+type fraudService struct {
+	db                   *sqlx.DB
+	fraudRepository      ports.FraudRepository
+	customerRepository   customerPorts.CustomerRepository
+	photoStoragePath     string
+	photoHashMaxDistance int
+}
+
+func NewFraudService(db *sqlx.DB, fraudRepository ports.FraudRepository, customerRepository customerPorts.CustomerRepository, photoStoragePath string, photoHashMaxDistance int) *fraudService {
+	return &fraudService{
+		db:                   db,
+		fraudRepository:      fraudRepository,
+		customerRepository:   customerRepository,
+		photoStoragePath:     photoStoragePath,
+		photoHashMaxDistance: photoHashMaxDistance,
+	}
+}
+
+func (s *fraudService) Screen(ctx context.Context, subject *dto.ScreeningSubject) (*dto.ScreeningResult, error) {
+	ctx, span := tracing.Start(ctx, "fraudService.Screen")
+	defer span.End()
+
+	var res = new(dto.ScreeningResult)
+
+	candidates := make([]entity.WatchlistEntry, 0, 4)
+	for _, c := range []entity.WatchlistEntry{
+		{EntryType: constants.WatchlistTypeNik, Value: subject.Nik},
+		{EntryType: constants.WatchlistTypeEmail, Value: subject.Email},
+		{EntryType: constants.WatchlistTypePhone, Value: subject.PhoneNumber},
+		{EntryType: constants.WatchlistTypeDeviceID, Value: subject.DeviceID},
+	} {
+		if c.Value = normalizeWatchlistValue(c.EntryType, c.Value); c.Value != "" {
+			candidates = append(candidates, c)
+		}
+	}
+
+	matches, err := s.fraudRepository.FindWatchlistMatches(ctx, candidates)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", subject.CustomerID).Msg("service::Screen - Failed to check watchlist")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	for _, match := range matches {
+		res.Reasons = append(res.Reasons, dto.Reason{
+			Code:   constants.FraudReasonWatchlistPrefix + match.EntryType,
+			Detail: match.Reason,
+		})
+	}
+
+	photos := []struct {
+		photo  string
+		path   string
+		reason string
+		hashes **imagehash.Hashes
+	}{
+		{constants.PhotoKtp, subject.KtpPhotoPath, constants.FraudReasonDuplicateKtpPhoto, &res.KtpPhoto},
+		{constants.PhotoSelfie, subject.SelfiePhotoPath, constants.FraudReasonDuplicateSelfiePhoto, &res.SelfiePhoto},
+	}
+
+	for _, p := range photos {
+		if p.path == "" {
+			continue
+		}
+
+		// a photo that cannot be read is not a fraud signal on its own
+		hashes, err := imagehash.HashFile(s.resolvePhotoPath(p.path))
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("photo", p.photo).Str("path", p.path).Msg("service::Screen - Failed to hash photo")
+			continue
+		}
+		*p.hashes = hashes
+
+		customerIDs, err := s.fraudRepository.FindCustomerIDsByPhotoHash(ctx, p.photo, hashes, subject.CustomerID, s.photoHashMaxDistance)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("photo", p.photo).Msg("service::Screen - Failed to check photo hash")
+			return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		}
+
+		for _, customerID := range customerIDs {
+			res.Reasons = append(res.Reasons, dto.Reason{
+				Code:   p.reason,
+				Detail: fmt.Sprintf("matches customer %d", customerID),
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// OpenReview opens a case for the reasons no earlier case of the customer
+// raised. A pending case already holds the customer, and a decided one is not
+// reopened for the same signal, otherwise every booking of a customer staff
+// approved would be refused again.
+func (s *fraudService) OpenReview(ctx context.Context, tx *sql.Tx, customerID int64, triggerEvent string, reasons []dto.Reason) (bool, error) {
+	ctx, span := tracing.Start(ctx, "fraudService.OpenReview")
+	defer span.End()
+
+	reviews, err := s.fraudRepository.FindFraudReviewsByCustomerID(ctx, tx, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Msg("service::OpenReview - Failed to find fraud reviews")
+		return false, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	raised := make(map[dto.Reason]bool)
+	for _, review := range reviews {
+		if review.Status == constants.FraudReviewStatusPending {
+			log.Ctx(ctx).Warn().Int64("customer_id", customerID).Int64("review_id", review.ID).Msg("service::OpenReview - Customer already has a pending fraud review")
+			return true, nil
+		}
+
+		var reviewReasons []dto.Reason
+		if err := json.Unmarshal([]byte(review.Reasons), &reviewReasons); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("review_id", review.ID).Msg("service::OpenReview - Failed to decode fraud review reasons")
+			continue
+		}
+
+		for _, reason := range reviewReasons {
+			raised[reason] = true
+		}
+	}
+
+	newReasons := make([]dto.Reason, 0, len(reasons))
+	for _, reason := range reasons {
+		if !raised[reason] {
+			newReasons = append(newReasons, reason)
+		}
+	}
+
+	if len(newReasons) == 0 {
+		log.Ctx(ctx).Info().Int64("customer_id", customerID).Any("reasons", reasons).Msg("service::OpenReview - Fraud reasons were already decided")
+		return false, nil
+	}
+
+	encodedReasons, err := json.Marshal(newReasons)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("reasons", newReasons).Msg("service::OpenReview - Failed to encode reasons")
+		return false, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	err = s.fraudRepository.InsertNewFraudReview(ctx, tx, &entity.FraudReview{
+		CustomerID:   customerID,
+		TriggerEvent: triggerEvent,
+		Reasons:      string(encodedReasons),
+		Status:       constants.FraudReviewStatusPending,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Msg("service::OpenReview - Failed to insert fraud review")
+		return false, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	err = s.customerRepository.UpdateReviewStatus(ctx, tx, customerID, constants.CustomerReviewStatusPendingReview)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Msg("service::OpenReview - Failed to update customer review status")
+		return false, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	log.Ctx(ctx).Warn().Int64("customer_id", customerID).Str("trigger_event", triggerEvent).Any("reasons", newReasons).Msg("service::OpenReview - Customer flagged for manual review")
+	return true, nil
+}
+
+func (s *fraudService) RecordEvent(ctx context.Context, customerID int64, eventType, channel string, detail map[string]any) error {
+	ctx, span := tracing.Start(ctx, "fraudService.RecordEvent")
+	defer span.End()
+
+	encodedDetail, err := json.Marshal(detail)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("detail", detail).Msg("service::RecordEvent - Failed to encode detail")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	err = s.fraudRepository.InsertNewFraudEvent(ctx, &entity.FraudEvent{
+		CustomerID: customerID,
+		EventType:  eventType,
+		Channel:    channel,
+		Detail:     string(encodedDetail),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Str("event_type", eventType).Msg("service::RecordEvent - Failed to insert fraud event")
+		return err
+	}
+
+	log.Ctx(ctx).Warn().Int64("customer_id", customerID).Str("event_type", eventType).Str("channel", channel).Any("detail", detail).Msg("service::RecordEvent - Fraud event recorded")
+	return nil
+}
+
+func (s *fraudService) AddWatchlistEntry(ctx context.Context, req *dto.AddWatchlistEntryRequest) (*dto.WatchlistEntryResponse, error) {
+	ctx, span := tracing.Start(ctx, "fraudService.AddWatchlistEntry")
+	defer span.End()
+
+	data := &entity.WatchlistEntry{
+		EntryType: req.EntryType,
+		Value:     normalizeWatchlistValue(req.EntryType, req.Value),
+		Reason:    req.Reason,
+		CreatedBy: req.CreatedBy,
+	}
+
+	if data.Value == "" {
+		return nil, err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage("Watchlist value is not valid"), err_msg.WithErrors("value", "Watchlist value is not valid"))
+	}
+
+	id, err := s.fraudRepository.InsertNewWatchlistEntry(ctx, data)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::AddWatchlistEntry - Failed to insert watchlist entry")
+		return nil, err
+	}
+
+	return &dto.WatchlistEntryResponse{
+		ID:        id,
+		EntryType: data.EntryType,
+		Value:     data.Value,
+		Reason:    data.Reason,
+		CreatedBy: data.CreatedBy,
+		CreatedAt: time.Now().Format(constants.DateTimeFormat),
+	}, nil
+}
+
+func (s *fraudService) GetWatchlistEntries(ctx context.Context, req *dto.GetWatchlistEntriesRequest) (*dto.GetWatchlistEntriesResponse, error) {
+	ctx, span := tracing.Start(ctx, "fraudService.GetWatchlistEntries")
+	defer span.End()
+
+	res, err := s.fraudRepository.FindWatchlistEntries(ctx, req)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::GetWatchlistEntries - Failed to find watchlist entries")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	return res, nil
+}
+
+func (s *fraudService) RemoveWatchlistEntry(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "fraudService.RemoveWatchlistEntry")
+	defer span.End()
+
+	if err := s.fraudRepository.DeleteWatchlistEntry(ctx, id); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("service::RemoveWatchlistEntry - Failed to delete watchlist entry")
+		return err
+	}
+
+	return nil
+}
+
+func (s *fraudService) GetFraudReviews(ctx context.Context, req *dto.GetFraudReviewsRequest) (*dto.GetFraudReviewsResponse, error) {
+	ctx, span := tracing.Start(ctx, "fraudService.GetFraudReviews")
+	defer span.End()
+
+	reviews, totalData, err := s.fraudRepository.FindFraudReviews(ctx, req)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::GetFraudReviews - Failed to find fraud reviews")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	res := &dto.GetFraudReviewsResponse{
+		Items: make([]dto.FraudReviewResponse, 0, len(reviews)),
+	}
+
+	for i := range reviews {
+		res.Items = append(res.Items, *toFraudReviewResponse(&reviews[i]))
+	}
+
+	res.Meta.CountTotalPage(req.Page, req.Paginate, totalData)
+
+	return res, nil
+}
+
+func (s *fraudService) DecideFraudReview(ctx context.Context, id int64, req *dto.DecideFraudReviewRequest) (*dto.FraudReviewResponse, error) {
+	ctx, span := tracing.Start(ctx, "fraudService.DecideFraudReview")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("service::DecideFraudReview - Failed to begin transaction")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Ctx(ctx).Error().Err(rollbackErr).Int64("id", id).Msg("service::DecideFraudReview - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	review, err := s.fraudRepository.LockFraudReviewByID(ctx, tx, id)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("service::DecideFraudReview - Failed to lock fraud review")
+		return nil, err
+	}
+
+	if review.Status != constants.FraudReviewStatusPending {
+		log.Ctx(ctx).Warn().Int64("id", id).Str("status", review.Status).Msg("service::DecideFraudReview - Fraud review already decided")
+		err = err_msg.NewCustomErrors(fiber.StatusConflict, err_msg.WithMessage(constants.ErrFraudReviewAlreadyDecided))
+		return nil, err
+	}
+
+	review.Status = req.Decision
+	review.DecisionNote = sql.NullString{String: req.Note, Valid: req.Note != ""}
+	review.ReviewedBy = sql.NullString{String: req.ReviewedBy, Valid: true}
+	review.ReviewedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	err = s.fraudRepository.UpdateFraudReviewDecision(ctx, tx, review)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("service::DecideFraudReview - Failed to update fraud review")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	// a rejection closes the account, an approval only clears it once no other case is open
+	customerStatus := constants.CustomerReviewStatusRejected
+	if req.Decision == constants.FraudReviewStatusApproved {
+		pending, countErr := s.fraudRepository.CountPendingFraudReviewsByCustomerID(ctx, tx, review.CustomerID)
+		if countErr != nil {
+			err = countErr
+			log.Ctx(ctx).Error().Err(err).Int64("customer_id", review.CustomerID).Msg("service::DecideFraudReview - Failed to count pending fraud reviews")
+			return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		}
+
+		customerStatus = constants.CustomerReviewStatusPendingReview
+		if pending == 0 {
+			customerStatus = constants.CustomerReviewStatusClear
+		}
+	}
+
+	err = s.customerRepository.UpdateReviewStatus(ctx, tx, review.CustomerID, customerStatus)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", review.CustomerID).Msg("service::DecideFraudReview - Failed to update customer review status")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("service::DecideFraudReview - Failed to commit transaction")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	return toFraudReviewResponse(review), nil
+}
+
+func (s *fraudService) GetFraudEvents(ctx context.Context, req *dto.GetFraudEventsRequest) (*dto.GetFraudEventsResponse, error) {
+	ctx, span := tracing.Start(ctx, "fraudService.GetFraudEvents")
+	defer span.End()
+
+	events, totalData, err := s.fraudRepository.FindFraudEvents(ctx, req)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::GetFraudEvents - Failed to find fraud events")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	res := &dto.GetFraudEventsResponse{
+		Items: make([]dto.FraudEventResponse, 0, len(events)),
+	}
+
+	for _, event := range events {
+		item := dto.FraudEventResponse{
+			ID:         event.ID,
+			CustomerID: event.CustomerID,
+			EventType:  event.EventType,
+			Channel:    event.Channel,
+			Detail:     map[string]any{},
+			CreatedAt:  event.CreatedAt.Format(constants.DateTimeFormat),
+		}
+
+		if err := json.Unmarshal([]byte(event.Detail), &item.Detail); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("id", event.ID).Msg("service::GetFraudEvents - Failed to decode detail")
+		}
+
+		res.Items = append(res.Items, item)
+	}
+
+	res.Meta.CountTotalPage(req.Page, req.Paginate, totalData)
+
+	return res, nil
+}
+
+// resolvePhotoPath keeps uploaded photo paths inside the private storage directory.
+func (s *fraudService) resolvePhotoPath(path string) string {
+	return filepath.Join(s.photoStoragePath, filepath.Clean("/"+path))
+}
+
+// normalizeWatchlistValue brings values to the form they are stored in so a
+// listed phone number matches whether it is written as +62, 62 or 0.
+func normalizeWatchlistValue(entryType, value string) string {
+	value = strings.TrimSpace(value)
+
+	switch entryType {
+	case constants.WatchlistTypeEmail:
+		return strings.ToLower(value)
+	case constants.WatchlistTypePhone:
+		digits := nonDigitRegex.ReplaceAllString(value, "")
+		if strings.HasPrefix(digits, "62") {
+			digits = "0" + digits[2:]
+		}
+		return digits
+	}
+
+	return value
+}
+
+func toFraudReviewResponse(review *entity.FraudReview) *dto.FraudReviewResponse {
+	res := &dto.FraudReviewResponse{
+		ID:           review.ID,
+		CustomerID:   review.CustomerID,
+		TriggerEvent: review.TriggerEvent,
+		Reasons:      []dto.Reason{},
+		Status:       review.Status,
+		DecisionNote: review.DecisionNote.String,
+		ReviewedBy:   review.ReviewedBy.String,
+		CreatedAt:    review.CreatedAt.Format(constants.DateTimeFormat),
+	}
+
+	if review.ReviewedAt.Valid {
+		res.ReviewedAt = review.ReviewedAt.Time.Format(constants.DateTimeFormat)
+	}
+
+	if err := json.Unmarshal([]byte(review.Reasons), &res.Reasons); err != nil {
+		log.Warn().Err(err).Int64("id", review.ID).Msg("service::toFraudReviewResponse - Failed to decode reasons")
+	}
+
+	return res
+}
@@ -0,0 +1,419 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ports.go -destination=../service/service_mock_test.go -package=service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	dto "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/dto"
+	entity "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/entity"
+	imagehash "github.com/hilmiikhsan/multifinance-service/pkg/imagehash"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFraudRepository is a mock of FraudRepository interface.
+type MockFraudRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFraudRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFraudRepositoryMockRecorder is the mock recorder for MockFraudRepository.
+type MockFraudRepositoryMockRecorder struct {
+	mock *MockFraudRepository
+}
+
+// NewMockFraudRepository creates a new mock instance.
+func NewMockFraudRepository(ctrl *gomock.Controller) *MockFraudRepository {
+	mock := &MockFraudRepository{ctrl: ctrl}
+	mock.recorder = &MockFraudRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFraudRepository) EXPECT() *MockFraudRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountPendingFraudReviewsByCustomerID mocks base method.
+func (m *MockFraudRepository) CountPendingFraudReviewsByCustomerID(ctx context.Context, tx *sql.Tx, customerID int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPendingFraudReviewsByCustomerID", ctx, tx, customerID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPendingFraudReviewsByCustomerID indicates an expected call of CountPendingFraudReviewsByCustomerID.
+func (mr *MockFraudRepositoryMockRecorder) CountPendingFraudReviewsByCustomerID(ctx, tx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPendingFraudReviewsByCustomerID", reflect.TypeOf((*MockFraudRepository)(nil).CountPendingFraudReviewsByCustomerID), ctx, tx, customerID)
+}
+
+// DeleteWatchlistEntry mocks base method.
+func (m *MockFraudRepository) DeleteWatchlistEntry(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWatchlistEntry", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWatchlistEntry indicates an expected call of DeleteWatchlistEntry.
+func (mr *MockFraudRepositoryMockRecorder) DeleteWatchlistEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWatchlistEntry", reflect.TypeOf((*MockFraudRepository)(nil).DeleteWatchlistEntry), ctx, id)
+}
+
+// FindCustomerIDsByPhotoHash mocks base method.
+func (m *MockFraudRepository) FindCustomerIDsByPhotoHash(ctx context.Context, photo string, hashes *imagehash.Hashes, excludeCustomerID int64, maxDistance int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCustomerIDsByPhotoHash", ctx, photo, hashes, excludeCustomerID, maxDistance)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCustomerIDsByPhotoHash indicates an expected call of FindCustomerIDsByPhotoHash.
+func (mr *MockFraudRepositoryMockRecorder) FindCustomerIDsByPhotoHash(ctx, photo, hashes, excludeCustomerID, maxDistance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCustomerIDsByPhotoHash", reflect.TypeOf((*MockFraudRepository)(nil).FindCustomerIDsByPhotoHash), ctx, photo, hashes, excludeCustomerID, maxDistance)
+}
+
+// FindFraudEvents mocks base method.
+func (m *MockFraudRepository) FindFraudEvents(ctx context.Context, req *dto.GetFraudEventsRequest) ([]entity.FraudEvent, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFraudEvents", ctx, req)
+	ret0, _ := ret[0].([]entity.FraudEvent)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindFraudEvents indicates an expected call of FindFraudEvents.
+func (mr *MockFraudRepositoryMockRecorder) FindFraudEvents(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFraudEvents", reflect.TypeOf((*MockFraudRepository)(nil).FindFraudEvents), ctx, req)
+}
+
+// FindFraudReviews mocks base method.
+func (m *MockFraudRepository) FindFraudReviews(ctx context.Context, req *dto.GetFraudReviewsRequest) ([]entity.FraudReview, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFraudReviews", ctx, req)
+	ret0, _ := ret[0].([]entity.FraudReview)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindFraudReviews indicates an expected call of FindFraudReviews.
+func (mr *MockFraudRepositoryMockRecorder) FindFraudReviews(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFraudReviews", reflect.TypeOf((*MockFraudRepository)(nil).FindFraudReviews), ctx, req)
+}
+
+// FindFraudReviewsByCustomerID mocks base method.
+func (m *MockFraudRepository) FindFraudReviewsByCustomerID(ctx context.Context, tx *sql.Tx, customerID int64) ([]entity.FraudReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFraudReviewsByCustomerID", ctx, tx, customerID)
+	ret0, _ := ret[0].([]entity.FraudReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFraudReviewsByCustomerID indicates an expected call of FindFraudReviewsByCustomerID.
+func (mr *MockFraudRepositoryMockRecorder) FindFraudReviewsByCustomerID(ctx, tx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFraudReviewsByCustomerID", reflect.TypeOf((*MockFraudRepository)(nil).FindFraudReviewsByCustomerID), ctx, tx, customerID)
+}
+
+// FindWatchlistEntries mocks base method.
+func (m *MockFraudRepository) FindWatchlistEntries(ctx context.Context, req *dto.GetWatchlistEntriesRequest) (*dto.GetWatchlistEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWatchlistEntries", ctx, req)
+	ret0, _ := ret[0].(*dto.GetWatchlistEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWatchlistEntries indicates an expected call of FindWatchlistEntries.
+func (mr *MockFraudRepositoryMockRecorder) FindWatchlistEntries(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWatchlistEntries", reflect.TypeOf((*MockFraudRepository)(nil).FindWatchlistEntries), ctx, req)
+}
+
+// FindWatchlistMatches mocks base method.
+func (m *MockFraudRepository) FindWatchlistMatches(ctx context.Context, candidates []entity.WatchlistEntry) ([]entity.WatchlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWatchlistMatches", ctx, candidates)
+	ret0, _ := ret[0].([]entity.WatchlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWatchlistMatches indicates an expected call of FindWatchlistMatches.
+func (mr *MockFraudRepositoryMockRecorder) FindWatchlistMatches(ctx, candidates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWatchlistMatches", reflect.TypeOf((*MockFraudRepository)(nil).FindWatchlistMatches), ctx, candidates)
+}
+
+// InsertNewFraudEvent mocks base method.
+func (m *MockFraudRepository) InsertNewFraudEvent(ctx context.Context, data *entity.FraudEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewFraudEvent", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNewFraudEvent indicates an expected call of InsertNewFraudEvent.
+func (mr *MockFraudRepositoryMockRecorder) InsertNewFraudEvent(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewFraudEvent", reflect.TypeOf((*MockFraudRepository)(nil).InsertNewFraudEvent), ctx, data)
+}
+
+// InsertNewFraudReview mocks base method.
+func (m *MockFraudRepository) InsertNewFraudReview(ctx context.Context, tx *sql.Tx, data *entity.FraudReview) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewFraudReview", ctx, tx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNewFraudReview indicates an expected call of InsertNewFraudReview.
+func (mr *MockFraudRepositoryMockRecorder) InsertNewFraudReview(ctx, tx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewFraudReview", reflect.TypeOf((*MockFraudRepository)(nil).InsertNewFraudReview), ctx, tx, data)
+}
+
+// InsertNewWatchlistEntry mocks base method.
+func (m *MockFraudRepository) InsertNewWatchlistEntry(ctx context.Context, data *entity.WatchlistEntry) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewWatchlistEntry", ctx, data)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertNewWatchlistEntry indicates an expected call of InsertNewWatchlistEntry.
+func (mr *MockFraudRepositoryMockRecorder) InsertNewWatchlistEntry(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewWatchlistEntry", reflect.TypeOf((*MockFraudRepository)(nil).InsertNewWatchlistEntry), ctx, data)
+}
+
+// LockFraudReviewByID mocks base method.
+func (m *MockFraudRepository) LockFraudReviewByID(ctx context.Context, tx *sql.Tx, id int64) (*entity.FraudReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockFraudReviewByID", ctx, tx, id)
+	ret0, _ := ret[0].(*entity.FraudReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockFraudReviewByID indicates an expected call of LockFraudReviewByID.
+func (mr *MockFraudRepositoryMockRecorder) LockFraudReviewByID(ctx, tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockFraudReviewByID", reflect.TypeOf((*MockFraudRepository)(nil).LockFraudReviewByID), ctx, tx, id)
+}
+
+// UpdateFraudReviewDecision mocks base method.
+func (m *MockFraudRepository) UpdateFraudReviewDecision(ctx context.Context, tx *sql.Tx, data *entity.FraudReview) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFraudReviewDecision", ctx, tx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFraudReviewDecision indicates an expected call of UpdateFraudReviewDecision.
+func (mr *MockFraudRepositoryMockRecorder) UpdateFraudReviewDecision(ctx, tx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFraudReviewDecision", reflect.TypeOf((*MockFraudRepository)(nil).UpdateFraudReviewDecision), ctx, tx, data)
+}
+
+// MockFraudScreener is a mock of FraudScreener interface.
+type MockFraudScreener struct {
+	ctrl     *gomock.Controller
+	recorder *MockFraudScreenerMockRecorder
+	isgomock struct{}
+}
+
+// MockFraudScreenerMockRecorder is the mock recorder for MockFraudScreener.
+type MockFraudScreenerMockRecorder struct {
+	mock *MockFraudScreener
+}
+
+// NewMockFraudScreener creates a new mock instance.
+func NewMockFraudScreener(ctrl *gomock.Controller) *MockFraudScreener {
+	mock := &MockFraudScreener{ctrl: ctrl}
+	mock.recorder = &MockFraudScreenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFraudScreener) EXPECT() *MockFraudScreenerMockRecorder {
+	return m.recorder
+}
+
+// OpenReview mocks base method.
+func (m *MockFraudScreener) OpenReview(ctx context.Context, tx *sql.Tx, customerID int64, triggerEvent string, reasons []dto.Reason) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenReview", ctx, tx, customerID, triggerEvent, reasons)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenReview indicates an expected call of OpenReview.
+func (mr *MockFraudScreenerMockRecorder) OpenReview(ctx, tx, customerID, triggerEvent, reasons any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenReview", reflect.TypeOf((*MockFraudScreener)(nil).OpenReview), ctx, tx, customerID, triggerEvent, reasons)
+}
+
+// RecordEvent mocks base method.
+func (m *MockFraudScreener) RecordEvent(ctx context.Context, customerID int64, eventType, channel string, detail map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordEvent", ctx, customerID, eventType, channel, detail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordEvent indicates an expected call of RecordEvent.
+func (mr *MockFraudScreenerMockRecorder) RecordEvent(ctx, customerID, eventType, channel, detail any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordEvent", reflect.TypeOf((*MockFraudScreener)(nil).RecordEvent), ctx, customerID, eventType, channel, detail)
+}
+
+// Screen mocks base method.
+func (m *MockFraudScreener) Screen(ctx context.Context, subject *dto.ScreeningSubject) (*dto.ScreeningResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Screen", ctx, subject)
+	ret0, _ := ret[0].(*dto.ScreeningResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Screen indicates an expected call of Screen.
+func (mr *MockFraudScreenerMockRecorder) Screen(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Screen", reflect.TypeOf((*MockFraudScreener)(nil).Screen), ctx, subject)
+}
+
+// MockFraudService is a mock of FraudService interface.
+type MockFraudService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFraudServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockFraudServiceMockRecorder is the mock recorder for MockFraudService.
+type MockFraudServiceMockRecorder struct {
+	mock *MockFraudService
+}
+
+// NewMockFraudService creates a new mock instance.
+func NewMockFraudService(ctrl *gomock.Controller) *MockFraudService {
+	mock := &MockFraudService{ctrl: ctrl}
+	mock.recorder = &MockFraudServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFraudService) EXPECT() *MockFraudServiceMockRecorder {
+	return m.recorder
+}
+
+// AddWatchlistEntry mocks base method.
+func (m *MockFraudService) AddWatchlistEntry(ctx context.Context, req *dto.AddWatchlistEntryRequest) (*dto.WatchlistEntryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWatchlistEntry", ctx, req)
+	ret0, _ := ret[0].(*dto.WatchlistEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWatchlistEntry indicates an expected call of AddWatchlistEntry.
+func (mr *MockFraudServiceMockRecorder) AddWatchlistEntry(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWatchlistEntry", reflect.TypeOf((*MockFraudService)(nil).AddWatchlistEntry), ctx, req)
+}
+
+// DecideFraudReview mocks base method.
+func (m *MockFraudService) DecideFraudReview(ctx context.Context, id int64, req *dto.DecideFraudReviewRequest) (*dto.FraudReviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideFraudReview", ctx, id, req)
+	ret0, _ := ret[0].(*dto.FraudReviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideFraudReview indicates an expected call of DecideFraudReview.
+func (mr *MockFraudServiceMockRecorder) DecideFraudReview(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideFraudReview", reflect.TypeOf((*MockFraudService)(nil).DecideFraudReview), ctx, id, req)
+}
+
+// GetFraudEvents mocks base method.
+func (m *MockFraudService) GetFraudEvents(ctx context.Context, req *dto.GetFraudEventsRequest) (*dto.GetFraudEventsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFraudEvents", ctx, req)
+	ret0, _ := ret[0].(*dto.GetFraudEventsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFraudEvents indicates an expected call of GetFraudEvents.
+func (mr *MockFraudServiceMockRecorder) GetFraudEvents(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFraudEvents", reflect.TypeOf((*MockFraudService)(nil).GetFraudEvents), ctx, req)
+}
+
+// GetFraudReviews mocks base method.
+func (m *MockFraudService) GetFraudReviews(ctx context.Context, req *dto.GetFraudReviewsRequest) (*dto.GetFraudReviewsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFraudReviews", ctx, req)
+	ret0, _ := ret[0].(*dto.GetFraudReviewsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFraudReviews indicates an expected call of GetFraudReviews.
+func (mr *MockFraudServiceMockRecorder) GetFraudReviews(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFraudReviews", reflect.TypeOf((*MockFraudService)(nil).GetFraudReviews), ctx, req)
+}
+
+// GetWatchlistEntries mocks base method.
+func (m *MockFraudService) GetWatchlistEntries(ctx context.Context, req *dto.GetWatchlistEntriesRequest) (*dto.GetWatchlistEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchlistEntries", ctx, req)
+	ret0, _ := ret[0].(*dto.GetWatchlistEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchlistEntries indicates an expected call of GetWatchlistEntries.
+func (mr *MockFraudServiceMockRecorder) GetWatchlistEntries(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchlistEntries", reflect.TypeOf((*MockFraudService)(nil).GetWatchlistEntries), ctx, req)
+}
+
+// RemoveWatchlistEntry mocks base method.
+func (m *MockFraudService) RemoveWatchlistEntry(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveWatchlistEntry", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveWatchlistEntry indicates an expected call of RemoveWatchlistEntry.
+func (mr *MockFraudServiceMockRecorder) RemoveWatchlistEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveWatchlistEntry", reflect.TypeOf((*MockFraudService)(nil).RemoveWatchlistEntry), ctx, id)
+}
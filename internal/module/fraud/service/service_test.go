@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/fraud/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/imagehash"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_fraudService_Screen(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockFraudRepo := NewMockFraudRepository(ctrlMock)
+
+	storage := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(storage, "ktp.pdf"), []byte("scanned ktp"), 0o600))
+
+	ktpHashes, err := imagehash.HashFile(filepath.Join(storage, "ktp.pdf"))
+	assert.NoError(t, err)
+
+	s := &fraudService{
+		fraudRepository:      mockFraudRepo,
+		photoStoragePath:     storage,
+		photoHashMaxDistance: 6,
+	}
+
+	tests := []struct {
+		name    string
+		subject *dto.ScreeningSubject
+		mockFn  func()
+		want    *dto.ScreeningResult
+		wantErr bool
+	}{
+		{
+			name: "Clear Customer",
+			subject: &dto.ScreeningSubject{
+				Nik:   "3174010101900001",
+				Email: "Clear@Example.com",
+			},
+			mockFn: func() {
+				mockFraudRepo.EXPECT().FindWatchlistMatches(gomock.Any(), []entity.WatchlistEntry{
+					{EntryType: constants.WatchlistTypeNik, Value: "3174010101900001"},
+					{EntryType: constants.WatchlistTypeEmail, Value: "clear@example.com"},
+				}).Return(nil, nil)
+			},
+			want: &dto.ScreeningResult{},
+		},
+		{
+			name: "Watchlist Hit And Duplicate KTP Photo",
+			subject: &dto.ScreeningSubject{
+				CustomerID:      9,
+				Nik:             "3174010101900001",
+				Email:           "flagged@example.com",
+				PhoneNumber:     "+62 812-3456-7890",
+				DeviceID:        "device-1",
+				KtpPhotoPath:    "/ktp/../../ktp.pdf",
+				SelfiePhotoPath: "/missing/selfie.jpg",
+			},
+			mockFn: func() {
+				mockFraudRepo.EXPECT().FindWatchlistMatches(gomock.Any(), []entity.WatchlistEntry{
+					{EntryType: constants.WatchlistTypeNik, Value: "3174010101900001"},
+					{EntryType: constants.WatchlistTypeEmail, Value: "flagged@example.com"},
+					{EntryType: constants.WatchlistTypePhone, Value: "081234567890"},
+					{EntryType: constants.WatchlistTypeDeviceID, Value: "device-1"},
+				}).Return([]entity.WatchlistEntry{
+					{EntryType: constants.WatchlistTypePhone, Value: "081234567890", Reason: "Chargeback"},
+				}, nil)
+
+				mockFraudRepo.EXPECT().FindCustomerIDsByPhotoHash(gomock.Any(), constants.PhotoKtp, ktpHashes, int64(9), 6).Return([]int64{3}, nil)
+			},
+			want: &dto.ScreeningResult{
+				Reasons: []dto.Reason{
+					{Code: "watchlist_phone", Detail: "Chargeback"},
+					{Code: constants.FraudReasonDuplicateKtpPhoto, Detail: "matches customer 3"},
+				},
+				KtpPhoto: ktpHashes,
+			},
+		},
+		{
+			name:    "Watchlist Lookup Error",
+			subject: &dto.ScreeningSubject{Nik: "3174010101900001"},
+			mockFn: func() {
+				mockFraudRepo.EXPECT().FindWatchlistMatches(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			got, err := s.Screen(context.Background(), tt.subject)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_fraudService_OpenReview(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockFraudRepo := NewMockFraudRepository(ctrlMock)
+	mockCustomerRepo := NewMockCustomerRepository(ctrlMock)
+
+	s := &fraudService{
+		fraudRepository:    mockFraudRepo,
+		customerRepository: mockCustomerRepo,
+	}
+
+	reasons := []dto.Reason{
+		{Code: "watchlist_device_id", Detail: "Chargeback"},
+		{Code: "watchlist_email", Detail: "Disposable address"},
+	}
+
+	tests := []struct {
+		name            string
+		mockFn          func()
+		wantUnderReview bool
+		wantErr         bool
+	}{
+		{
+			name: "Open Review For New Reasons",
+			mockFn: func() {
+				mockFraudRepo.EXPECT().FindFraudReviewsByCustomerID(gomock.Any(), gomock.Any(), int64(9)).Return(nil, nil)
+				mockFraudRepo.EXPECT().InsertNewFraudReview(gomock.Any(), gomock.Any(), &entity.FraudReview{
+					CustomerID:   9,
+					TriggerEvent: constants.FraudTriggerTransaction,
+					Reasons:      `[{"code":"watchlist_device_id","detail":"Chargeback"},{"code":"watchlist_email","detail":"Disposable address"}]`,
+					Status:       constants.FraudReviewStatusPending,
+				}).Return(nil)
+				mockCustomerRepo.EXPECT().UpdateReviewStatus(gomock.Any(), gomock.Any(), int64(9), constants.CustomerReviewStatusPendingReview).Return(nil)
+			},
+			wantUnderReview: true,
+		},
+		{
+			name: "Open Review Only For Reasons Not Decided Yet",
+			mockFn: func() {
+				mockFraudRepo.EXPECT().FindFraudReviewsByCustomerID(gomock.Any(), gomock.Any(), int64(9)).Return([]entity.FraudReview{
+					{ID: 1, Reasons: `[{"code":"watchlist_device_id","detail":"Chargeback"}]`, Status: constants.FraudReviewStatusApproved},
+				}, nil)
+				mockFraudRepo.EXPECT().InsertNewFraudReview(gomock.Any(), gomock.Any(), &entity.FraudReview{
+					CustomerID:   9,
+					TriggerEvent: constants.FraudTriggerTransaction,
+					Reasons:      `[{"code":"watchlist_email","detail":"Disposable address"}]`,
+					Status:       constants.FraudReviewStatusPending,
+				}).Return(nil)
+				mockCustomerRepo.EXPECT().UpdateReviewStatus(gomock.Any(), gomock.Any(), int64(9), constants.CustomerReviewStatusPendingReview).Return(nil)
+			},
+			wantUnderReview: true,
+		},
+		{
+			name: "Reasons Already Approved",
+			mockFn: func() {
+				mockFraudRepo.EXPECT().FindFraudReviewsByCustomerID(gomock.Any(), gomock.Any(), int64(9)).Return([]entity.FraudReview{
+					{ID: 1, Reasons: `[{"code":"watchlist_device_id","detail":"Chargeback"}]`, Status: constants.FraudReviewStatusApproved},
+					{ID: 2, Reasons: `[{"code":"watchlist_email","detail":"Disposable address"}]`, Status: constants.FraudReviewStatusApproved},
+				}, nil)
+			},
+			wantUnderReview: false,
+		},
+		{
+			name: "Review Already Pending",
+			mockFn: func() {
+				mockFraudRepo.EXPECT().FindFraudReviewsByCustomerID(gomock.Any(), gomock.Any(), int64(9)).Return([]entity.FraudReview{
+					{ID: 3, Reasons: `[{"code":"duplicate_ktp_photo","detail":"matches customer 4"}]`, Status: constants.FraudReviewStatusPending},
+				}, nil)
+			},
+			wantUnderReview: true,
+		},
+		{
+			name: "Failed To Find Reviews",
+			mockFn: func() {
+				mockFraudRepo.EXPECT().FindFraudReviewsByCustomerID(gomock.Any(), gomock.Any(), int64(9)).Return(nil, errors.New("database error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			underReview, err := s.OpenReview(context.Background(), nil, 9, constants.FraudTriggerTransaction, reasons)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantUnderReview, underReview)
+		})
+	}
+}
+
+func Test_fraudService_RecordEvent(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockFraudRepo := NewMockFraudRepository(ctrlMock)
+
+	s := &fraudService{
+		fraudRepository: mockFraudRepo,
+	}
+
+	mockFraudRepo.EXPECT().InsertNewFraudEvent(gomock.Any(), &entity.FraudEvent{
+		CustomerID: 9,
+		EventType:  constants.FraudEventVelocityBreach,
+		Channel:    "web",
+		Detail:     `{"limit":"count","window":"1h0m0s"}`,
+	}).Return(nil)
+
+	err := s.RecordEvent(context.Background(), 9, constants.FraudEventVelocityBreach, "web", map[string]any{
+		"window": "1h0m0s",
+		"limit":  "count",
+	})
+	assert.NoError(t, err)
+}
+
+func Test_fraudService_DecideFraudReview(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockFraudRepo := NewMockFraudRepository(ctrlMock)
+	mockCustomerRepo := NewMockCustomerRepository(ctrlMock)
+
+	pendingReview := func() *entity.FraudReview {
+		return &entity.FraudReview{
+			ID:           1,
+			CustomerID:   9,
+			TriggerEvent: constants.FraudTriggerRegistration,
+			Reasons:      `[{"code":"duplicate_ktp_photo","detail":"matches customer 3"}]`,
+			Status:       constants.FraudReviewStatusPending,
+			CreatedAt:    time.Now(),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		req      *dto.DecideFraudReviewRequest
+		mockFn   func(dbMock sqlmock.Sqlmock)
+		wantCode int
+	}{
+		{
+			name: "Approve Last Pending Review Clears Customer",
+			req:  &dto.DecideFraudReviewRequest{Decision: constants.FraudReviewStatusApproved, ReviewedBy: "staff-1"},
+			mockFn: func(dbMock sqlmock.Sqlmock) {
+				dbMock.ExpectBegin()
+				mockFraudRepo.EXPECT().LockFraudReviewByID(gomock.Any(), gomock.Any(), int64(1)).Return(pendingReview(), nil)
+				mockFraudRepo.EXPECT().UpdateFraudReviewDecision(gomock.Any(), gomock.Any(), gomock.Cond(func(data *entity.FraudReview) bool {
+					return data.Status == constants.FraudReviewStatusApproved && data.ReviewedBy.String == "staff-1" && data.ReviewedAt.Valid
+				})).Return(nil)
+				mockFraudRepo.EXPECT().CountPendingFraudReviewsByCustomerID(gomock.Any(), gomock.Any(), int64(9)).Return(0, nil)
+				mockCustomerRepo.EXPECT().UpdateReviewStatus(gomock.Any(), gomock.Any(), int64(9), constants.CustomerReviewStatusClear).Return(nil)
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name: "Approve With Other Open Review Keeps Customer Pending",
+			req:  &dto.DecideFraudReviewRequest{Decision: constants.FraudReviewStatusApproved, ReviewedBy: "staff-1"},
+			mockFn: func(dbMock sqlmock.Sqlmock) {
+				dbMock.ExpectBegin()
+				mockFraudRepo.EXPECT().LockFraudReviewByID(gomock.Any(), gomock.Any(), int64(1)).Return(pendingReview(), nil)
+				mockFraudRepo.EXPECT().UpdateFraudReviewDecision(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockFraudRepo.EXPECT().CountPendingFraudReviewsByCustomerID(gomock.Any(), gomock.Any(), int64(9)).Return(1, nil)
+				mockCustomerRepo.EXPECT().UpdateReviewStatus(gomock.Any(), gomock.Any(), int64(9), constants.CustomerReviewStatusPendingReview).Return(nil)
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name: "Reject Closes Customer Account",
+			req:  &dto.DecideFraudReviewRequest{Decision: constants.FraudReviewStatusRejected, Note: "Forged KTP", ReviewedBy: "staff-1"},
+			mockFn: func(dbMock sqlmock.Sqlmock) {
+				dbMock.ExpectBegin()
+				mockFraudRepo.EXPECT().LockFraudReviewByID(gomock.Any(), gomock.Any(), int64(1)).Return(pendingReview(), nil)
+				mockFraudRepo.EXPECT().UpdateFraudReviewDecision(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockCustomerRepo.EXPECT().UpdateReviewStatus(gomock.Any(), gomock.Any(), int64(9), constants.CustomerReviewStatusRejected).Return(nil)
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name: "Review Already Decided",
+			req:  &dto.DecideFraudReviewRequest{Decision: constants.FraudReviewStatusApproved, ReviewedBy: "staff-1"},
+			mockFn: func(dbMock sqlmock.Sqlmock) {
+				review := pendingReview()
+				review.Status = constants.FraudReviewStatusRejected
+
+				dbMock.ExpectBegin()
+				mockFraudRepo.EXPECT().LockFraudReviewByID(gomock.Any(), gomock.Any(), int64(1)).Return(review, nil)
+				dbMock.ExpectRollback()
+			},
+			wantCode: fiber.StatusConflict,
+		},
+		{
+			name: "Review Not Found",
+			req:  &dto.DecideFraudReviewRequest{Decision: constants.FraudReviewStatusApproved, ReviewedBy: "staff-1"},
+			mockFn: func(dbMock sqlmock.Sqlmock) {
+				dbMock.ExpectBegin()
+				mockFraudRepo.EXPECT().LockFraudReviewByID(gomock.Any(), gomock.Any(), int64(1)).
+					Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrFraudReviewNotFound)))
+				dbMock.ExpectRollback()
+			},
+			wantCode: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, dbMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create sqlmock: %v", err)
+			}
+			defer db.Close()
+
+			tt.mockFn(dbMock)
+
+			s := &fraudService{
+				db:                 sqlx.NewDb(db, "mysql"),
+				fraudRepository:    mockFraudRepo,
+				customerRepository: mockCustomerRepo,
+			}
+
+			got, err := s.DecideFraudReview(context.Background(), 1, tt.req)
+			if tt.wantCode != 0 {
+				assert.Equal(t, tt.wantCode, err.(*err_msg.CustomError).Code)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.req.Decision, got.Status)
+				assert.Equal(t, []dto.Reason{{Code: constants.FraudReasonDuplicateKtpPhoto, Detail: "matches customer 3"}}, got.Reasons)
+			}
+
+			assert.NoError(t, dbMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package dto
+
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+type CheckResult struct {
+	Status     string `json:"status"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// ReadinessResponse names the database dialect the database check ran on.
+type ReadinessResponse struct {
+	Status  string                 `json:"status"`
+	Dialect string                 `json:"dialect"`
+	Checks  map[string]CheckResult `json:"checks"`
+}
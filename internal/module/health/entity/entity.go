@@ -0,0 +1,7 @@
+package entity
+
+// MigrationVersion is a row of the goose version table.
+type MigrationVersion struct {
+	VersionID int64 `db:"version_id"`
+	IsApplied bool  `db:"is_applied"`
+}
@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/db"
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/health/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/health/ports"
+	healthRepository "github.com/hilmiikhsan/multifinance-service/internal/module/health/repository"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/health/service"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/response"
+	"github.com/rs/zerolog/log"
+)
+
+type healthHandler struct {
+	service ports.HealthService
+}
+
+func NewHealthHandler() *healthHandler {
+	var handler = new(healthHandler)
+
+	// migrations
+	migrations, err := db.MigrationVersions(config.Envs.DB.Driver)
+	if err != nil {
+		log.Fatal().Err(err).Msg("handler::NewHealthHandler - Failed to read migration versions")
+	}
+
+	// repository
+	healthRepository := healthRepository.NewHealthRepository(adapter.Adapters.MultifinanceDB, adapter.Adapters.MultifinanceRedis)
+
+	// service
+	healthService := service.NewHealthService(
+		healthRepository,
+		config.Envs.DB.Driver,
+		migrations,
+		time.Duration(config.Envs.Health.CheckTimeoutMs)*time.Millisecond,
+		adapter.Adapters.Draining,
+	)
+
+	// handler
+	handler.service = healthService
+
+	return handler
+}
+
+func (h *healthHandler) HealthRoute(router fiber.Router) {
+	router.Get("/healthz", h.liveness)
+	router.Get("/readyz", h.readiness)
+}
+
+// liveness only tells the process is serving, a dependency outage must not
+// get the instance restarted.
+func (h *healthHandler) liveness(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(response.Success(dto.LivenessResponse{Status: constants.HealthStatusOK}, ""))
+}
+
+func (h *healthHandler) readiness(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	res, err := h.service.Readiness(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("handler::readiness - Service is not ready")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/health/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_healthHandler_HealthRoute(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockHealthService(ctrlMock)
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		mockFn func()
+	}{
+		{
+			name:   "Liveness",
+			path:   "/healthz",
+			status: http.StatusOK,
+			mockFn: func() {},
+		},
+		{
+			name:   "Ready",
+			path:   "/readyz",
+			status: http.StatusOK,
+			mockFn: func() {
+				mockSvc.EXPECT().Readiness(gomock.Any()).Return(&dto.ReadinessResponse{
+					Status: constants.HealthStatusOK,
+					Checks: map[string]dto.CheckResult{constants.HealthCheckDB: {Status: constants.HealthStatusOK}},
+				}, nil)
+			},
+		},
+		{
+			name:   "Not Ready",
+			path:   "/readyz",
+			status: http.StatusServiceUnavailable,
+			mockFn: func() {
+				mockSvc.EXPECT().Readiness(gomock.Any()).Return(nil, err_msg.NewCustomErrors(fiber.StatusServiceUnavailable,
+					err_msg.WithMessage(constants.ErrServiceNotReady),
+					err_msg.WithErrors(constants.HealthCheckShutdown, "server is shutting down"),
+				))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			handler := &healthHandler{service: mockSvc}
+			handler.HealthRoute(app)
+
+			tt.mockFn()
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/module/health/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/health/entity"
+)
+
+//go:generate mockgen -source=ports.go -destination=../service/service_mock_test.go -package=service
+type HealthRepository interface {
+	PingDB(ctx context.Context) error
+	PingRedis(ctx context.Context) error
+	FindMigrationVersions(ctx context.Context) ([]entity.MigrationVersion, error)
+}
+
+//go:generate mockgen -source=ports.go -destination=../handler/rest/handler_mock_test.go -package=rest
+type HealthService interface {
+	Readiness(ctx context.Context) (*dto.ReadinessResponse, error)
+}
@@ -0,0 +1,8 @@
+package repository
+
+// newest rows first, goose keeps the history of a version when it is rolled back
+const queryFindMigrationVersions = `
+	SELECT version_id, is_applied
+	FROM goose_db_version
+	ORDER BY id DESC
+`
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/health/entity"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/health/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.HealthRepository = &healthRepository{}
+
+type healthRepository struct {
+	db    *sqlx.DB
+	redis *redis.Client
+}
+
+func NewHealthRepository(db *sqlx.DB, redis *redis.Client) *healthRepository {
+	return &healthRepository{
+		db:    db,
+		redis: redis,
+	}
+}
+
+func (r *healthRepository) PingDB(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "healthRepository.PingDB")
+	defer span.End()
+
+	if err := r.db.PingContext(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("dialect", r.db.DriverName()).Msg("repository::PingDB - Failed to ping database")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *healthRepository) PingRedis(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "healthRepository.PingRedis")
+	defer span.End()
+
+	if err := r.redis.Ping(ctx).Err(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::PingRedis - Failed to ping redis")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *healthRepository) FindMigrationVersions(ctx context.Context) ([]entity.MigrationVersion, error) {
+	ctx, span := tracing.Start(ctx, "healthRepository.FindMigrationVersions")
+	defer span.End()
+
+	var versions []entity.MigrationVersion
+
+	if err := r.db.SelectContext(ctx, &versions, queryFindMigrationVersions); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindMigrationVersions - Failed to find migration versions")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return versions, nil
+}
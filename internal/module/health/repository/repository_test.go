@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/health/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_healthRepository_PingDB(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewHealthRepository(sqlx.NewDb(db, "mysql"), nil)
+
+	mock.ExpectPing()
+	assert.NoError(t, r.PingDB(context.Background()))
+
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	assert.Error(t, r.PingDB(context.Background()))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_healthRepository_PingRedis(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	r := NewHealthRepository(nil, rdb)
+
+	assert.NoError(t, r.PingRedis(context.Background()))
+
+	mr.Close()
+	assert.Error(t, r.PingRedis(context.Background()))
+}
+
+func Test_healthRepository_FindMigrationVersions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewHealthRepository(sqlx.NewDb(db, "mysql"), nil)
+
+	tests := []struct {
+		name    string
+		want    []entity.MigrationVersion
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "Find Migration Versions Successfully",
+			want: []entity.MigrationVersion{
+				{VersionID: 2, IsApplied: true},
+				{VersionID: 1, IsApplied: true},
+			},
+			mockFn: func() {
+				mock.ExpectQuery("SELECT version_id, is_applied FROM goose_db_version").WillReturnRows(
+					sqlmock.NewRows([]string{"version_id", "is_applied"}).AddRow(2, true).AddRow(1, true),
+				)
+			},
+		},
+		{
+			name:    "Find Migration Versions Without Version Table",
+			wantErr: true,
+			mockFn: func() {
+				mock.ExpectQuery("SELECT version_id, is_applied FROM goose_db_version").WillReturnError(errors.New("table doesn't exist"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			got, err := r.FindMigrationVersions(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/health/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/health/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.HealthService = &healthService{}
+
+type healthService struct {
+	healthRepository ports.HealthRepository
+	dialect          string
+	migrations       []int64
+	timeout          time.Duration
+	draining         func() bool
+}
+
+// NewHealthService checks the dependencies of the API. dialect is the database
+// the repositories run on, migrations are the versions it should be at and
+// draining reports a graceful shutdown.
+func NewHealthService(healthRepository ports.HealthRepository, dialect string, migrations []int64, timeout time.Duration, draining func() bool) *healthService {
+	return &healthService{
+		healthRepository: healthRepository,
+		dialect:          dialect,
+		migrations:       migrations,
+		timeout:          timeout,
+		draining:         draining,
+	}
+}
+
+func (s *healthService) Readiness(ctx context.Context) (*dto.ReadinessResponse, error) {
+	ctx, span := tracing.Start(ctx, "healthService.Readiness")
+	defer span.End()
+
+	// no need to reach the dependencies once the instance is going away
+	if s.draining() {
+		log.Ctx(ctx).Warn().Msg("service::Readiness - Not ready, the server is shutting down")
+		return nil, err_msg.NewCustomErrors(fiber.StatusServiceUnavailable,
+			err_msg.WithMessage(constants.ErrServiceNotReady),
+			err_msg.WithErrors(constants.HealthCheckShutdown, "server is shutting down"),
+		)
+	}
+
+	checks := map[string]func(ctx context.Context) error{
+		constants.HealthCheckDB:         s.healthRepository.PingDB,
+		constants.HealthCheckRedis:      s.healthRepository.PingRedis,
+		constants.HealthCheckMigrations: s.checkMigrations,
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		res      = &dto.ReadinessResponse{Status: constants.HealthStatusOK, Dialect: s.dialect, Checks: make(map[string]dto.CheckResult, len(checks))}
+		notReady = err_msg.NewCustomErrors(fiber.StatusServiceUnavailable, err_msg.WithMessage(constants.ErrServiceNotReady))
+	)
+
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(ctx context.Context) error) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, s.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check(checkCtx)
+			result := dto.CheckResult{Status: constants.HealthStatusOK, DurationMs: time.Since(start).Milliseconds()}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				log.Ctx(ctx).Error().Err(err).Str("check", name).Str("dialect", s.dialect).Msg("service::Readiness - Check failed")
+				msg := err.Error()
+				if errors.Is(checkCtx.Err(), context.DeadlineExceeded) {
+					msg = fmt.Sprintf("timed out after %s", s.timeout)
+				}
+
+				result.Status = constants.HealthStatusNotReady
+				notReady.Add(name, msg)
+			}
+			res.Checks[name] = result
+		}(name, check)
+	}
+
+	wg.Wait()
+
+	if notReady.HasErrors() {
+		return nil, notReady
+	}
+
+	return res, nil
+}
+
+// checkMigrations fails when a migration of the binary is not applied yet.
+func (s *healthService) checkMigrations(ctx context.Context) error {
+	versions, err := s.healthRepository.FindMigrationVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the newest row of a version tells whether it is applied
+	applied := make(map[int64]bool, len(versions))
+	for _, version := range versions {
+		if _, seen := applied[version.VersionID]; !seen {
+			applied[version.VersionID] = version.IsApplied
+		}
+	}
+
+	var pending []int64
+	for _, version := range s.migrations {
+		if !applied[version] {
+			pending = append(pending, version)
+		}
+	}
+
+	if len(pending) > 0 {
+		log.Ctx(ctx).Warn().Ints64("pending", pending).Msg("service::checkMigrations - Migrations are pending")
+		return fmt.Errorf("migrations not applied: %v", pending)
+	}
+
+	return nil
+}
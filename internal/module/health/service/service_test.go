@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/health/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_healthService_Readiness(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockHealthRepository(ctrlMock)
+
+	applied := []entity.MigrationVersion{
+		{VersionID: 2, IsApplied: true},
+		{VersionID: 1, IsApplied: true},
+		{VersionID: 0, IsApplied: true},
+	}
+
+	tests := []struct {
+		name       string
+		draining   bool
+		wantErr    bool
+		wantChecks []string
+		mockFn     func()
+	}{
+		{
+			name: "Ready",
+			mockFn: func() {
+				mockRepo.EXPECT().PingDB(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().PingRedis(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().FindMigrationVersions(gomock.Any()).Return(applied, nil)
+			},
+		},
+		{
+			name:       "Draining",
+			draining:   true,
+			wantErr:    true,
+			wantChecks: []string{constants.HealthCheckShutdown},
+			mockFn:     func() {},
+		},
+		{
+			name:       "Database Unreachable",
+			wantErr:    true,
+			wantChecks: []string{constants.HealthCheckDB, constants.HealthCheckMigrations},
+			mockFn: func() {
+				mockRepo.EXPECT().PingDB(gomock.Any()).Return(errors.New("connection refused"))
+				mockRepo.EXPECT().PingRedis(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().FindMigrationVersions(gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+		},
+		{
+			name:       "Redis Timeout",
+			wantErr:    true,
+			wantChecks: []string{constants.HealthCheckRedis},
+			mockFn: func() {
+				mockRepo.EXPECT().PingDB(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().PingRedis(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				})
+				mockRepo.EXPECT().FindMigrationVersions(gomock.Any()).Return(applied, nil)
+			},
+		},
+		{
+			name:       "Migration Pending",
+			wantErr:    true,
+			wantChecks: []string{constants.HealthCheckMigrations},
+			mockFn: func() {
+				mockRepo.EXPECT().PingDB(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().PingRedis(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().FindMigrationVersions(gomock.Any()).Return(applied[1:], nil)
+			},
+		},
+		{
+			name:       "Migration Rolled Back",
+			wantErr:    true,
+			wantChecks: []string{constants.HealthCheckMigrations},
+			mockFn: func() {
+				mockRepo.EXPECT().PingDB(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().PingRedis(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().FindMigrationVersions(gomock.Any()).Return(append([]entity.MigrationVersion{{VersionID: 2, IsApplied: false}}, applied...), nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			s := NewHealthService(mockRepo, dialect.Postgres, []int64{1, 2}, 50*time.Millisecond, func() bool { return tt.draining })
+
+			got, err := s.Readiness(context.Background())
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, constants.HealthStatusOK, got.Status)
+				assert.Equal(t, dialect.Postgres, got.Dialect)
+				assert.Len(t, got.Checks, 3)
+				return
+			}
+
+			assert.Nil(t, got)
+
+			customErr, ok := err.(*err_msg.CustomError)
+			assert.True(t, ok)
+			assert.Equal(t, 503, customErr.Code)
+			assert.Len(t, customErr.Errors, len(tt.wantChecks))
+			for _, check := range tt.wantChecks {
+				assert.Contains(t, customErr.Errors, check)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// JobRun is one run of a scheduled job. A run left running after its worker
+// stopped without finishing it keeps that status.
+type JobRun struct {
+	ID           int64          `db:"id"`
+	JobName      string         `db:"job_name"`
+	Instance     string         `db:"instance"`
+	ScheduledAt  time.Time      `db:"scheduled_at"`
+	Status       string         `db:"status"`
+	Result       sql.NullString `db:"result"`
+	ErrorMessage sql.NullString `db:"error_message"`
+	StartedAt    time.Time      `db:"started_at"`
+	FinishedAt   sql.NullTime   `db:"finished_at"`
+	DurationMs   sql.NullInt64  `db:"duration_ms"`
+}
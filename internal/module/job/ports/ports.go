@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/module/job/entity"
+)
+
+//go:generate mockgen -source=ports.go -destination=../service/service_mock_test.go -package=service
+type JobRunRepository interface {
+	InsertNewJobRun(ctx context.Context, data *entity.JobRun) (int64, error)
+	UpdateJobRunResult(ctx context.Context, data *entity.JobRun) error
+}
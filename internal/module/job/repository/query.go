@@ -0,0 +1,25 @@
+package repository
+
+const (
+	queryInsertNewJobRun = `
+		INSERT INTO job_runs
+		(
+			job_name,
+			instance,
+			scheduled_at,
+			status,
+			started_at
+		) VALUES (?, ?, ?, ?, ?)
+	`
+
+	queryUpdateJobRunResult = `
+		UPDATE job_runs
+		SET
+			status = ?,
+			result = ?,
+			error_message = ?,
+			finished_at = ?,
+			duration_ms = ?
+		WHERE id = ?
+	`
+)
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/module/job/entity"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/job/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.JobRunRepository = &jobRunRepository{}
+
+type jobRunRepository struct {
+	db *sqlx.DB
+}
+
+func NewJobRunRepository(db *sqlx.DB) *jobRunRepository {
+	return &jobRunRepository{
+		db: db,
+	}
+}
+
+func (r *jobRunRepository) InsertNewJobRun(ctx context.Context, data *entity.JobRun) (int64, error) {
+	ctx, span := tracing.Start(ctx, "jobRunRepository.InsertNewJobRun")
+	defer span.End()
+
+	id, err := dialect.InsertID(ctx, r.db, r.db.DriverName(), r.db.Rebind(queryInsertNewJobRun),
+		data.JobName,
+		data.Instance,
+		data.ScheduledAt,
+		data.Status,
+		data.StartedAt,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("job_name", data.JobName).Msg("repository::InsertNewJobRun - Failed to insert job run")
+		return 0, err_msg.NewDatabaseErrors(err)
+	}
+
+	return id, nil
+}
+
+func (r *jobRunRepository) UpdateJobRunResult(ctx context.Context, data *entity.JobRun) error {
+	ctx, span := tracing.Start(ctx, "jobRunRepository.UpdateJobRunResult")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(queryUpdateJobRunResult),
+		data.Status,
+		data.Result,
+		data.ErrorMessage,
+		data.FinishedAt,
+		data.DurationMs,
+		data.ID,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", data.ID).Msg("repository::UpdateJobRunResult - Failed to update job run")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
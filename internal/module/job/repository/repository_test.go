@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/job/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect/dialecttest"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_jobRunRepository_InsertNewJobRun(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		now := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+		run := &entity.JobRun{JobName: "installment-reminder", Instance: "worker-1", ScheduledAt: now, Status: "running", StartedAt: now}
+
+		tests := []struct {
+			name    string
+			wantID  int64
+			wantErr bool
+			mockFn  func()
+		}{
+			{
+				name:   "Insert New Job Run Successfully",
+				wantID: 3,
+				mockFn: func() {
+					dialecttest.ExpectInsert(mock, db, "INSERT INTO job_runs").WithArgs("installment-reminder", "worker-1", now, "running", now).
+						WillReturnID(3)
+				},
+			},
+			{
+				name:    "Insert New Job Run Failed",
+				wantErr: true,
+				mockFn: func() {
+					dialecttest.ExpectInsert(mock, db, "INSERT INTO job_runs").WillReturnError(fmt.Errorf("database error"))
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn()
+
+				id, err := NewJobRunRepository(db).InsertNewJobRun(context.Background(), run)
+				assert.Equal(t, tt.wantErr, err != nil)
+				assert.Equal(t, tt.wantID, id)
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_jobRunRepository_UpdateJobRunResult(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		now := time.Date(2025, 1, 1, 1, 0, 5, 0, time.UTC)
+		run := &entity.JobRun{
+			ID:         3,
+			Status:     "succeeded",
+			Result:     sql.NullString{String: `{"sent":3}`, Valid: true},
+			FinishedAt: sql.NullTime{Time: now, Valid: true},
+			DurationMs: sql.NullInt64{Int64: 5000, Valid: true},
+		}
+
+		mock.ExpectExec("UPDATE job_runs").WithArgs("succeeded", run.Result, run.ErrorMessage, run.FinishedAt, run.DurationMs, int64(3)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, NewJobRunRepository(db).UpdateJobRunResult(context.Background(), run))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
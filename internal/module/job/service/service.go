@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/job/entity"
+	jobPorts "github.com/hilmiikhsan/multifinance-service/internal/module/job/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/scheduler"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+var _ scheduler.Recorder = &jobRunRecorder{}
+
+// maxErrorLength is the size of job_runs.error_message.
+const maxErrorLength = 255
+
+// jobRunRecorder keeps the history of scheduled job runs in job_runs.
+type jobRunRecorder struct {
+	jobRunRepository jobPorts.JobRunRepository
+}
+
+func NewJobRunRecorder(jobRunRepository jobPorts.JobRunRepository) *jobRunRecorder {
+	return &jobRunRecorder{
+		jobRunRepository: jobRunRepository,
+	}
+}
+
+func (s *jobRunRecorder) Started(ctx context.Context, run *scheduler.Run) error {
+	ctx, span := tracing.Start(ctx, "jobRunRecorder.Started")
+	defer span.End()
+
+	id, err := s.jobRunRepository.InsertNewJobRun(ctx, &entity.JobRun{
+		JobName:     run.Job,
+		Instance:    run.Instance,
+		ScheduledAt: run.ScheduledAt,
+		Status:      constants.JobRunStatusRunning,
+		StartedAt:   run.StartedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	run.ID = id
+	return nil
+}
+
+// Finished does nothing for a run whose start could not be recorded.
+func (s *jobRunRecorder) Finished(ctx context.Context, run *scheduler.Run) error {
+	ctx, span := tracing.Start(ctx, "jobRunRecorder.Finished")
+	defer span.End()
+
+	if run.ID == 0 {
+		return nil
+	}
+
+	data := &entity.JobRun{
+		ID:         run.ID,
+		Status:     constants.JobRunStatusSucceeded,
+		FinishedAt: sql.NullTime{Time: run.FinishedAt, Valid: true},
+		DurationMs: sql.NullInt64{Int64: run.FinishedAt.Sub(run.StartedAt).Milliseconds(), Valid: true},
+	}
+
+	if run.Err != nil {
+		data.Status = constants.JobRunStatusFailed
+		data.ErrorMessage = sql.NullString{String: truncate(run.Err.Error()), Valid: true}
+	}
+
+	if run.Result != nil {
+		result, err := json.Marshal(run.Result)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("job", run.Job).Msg("service::Finished - Failed to encode job result")
+		} else {
+			data.Result = sql.NullString{String: string(result), Valid: true}
+		}
+	}
+
+	return s.jobRunRepository.UpdateJobRunResult(ctx, data)
+}
+
+func truncate(message string) string {
+	if len(message) > maxErrorLength {
+		return message[:maxErrorLength]
+	}
+
+	return message
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ports.go -destination=../service/service_mock_test.go -package=service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/hilmiikhsan/multifinance-service/internal/module/job/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobRunRepository is a mock of JobRunRepository interface.
+type MockJobRunRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRunRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockJobRunRepositoryMockRecorder is the mock recorder for MockJobRunRepository.
+type MockJobRunRepositoryMockRecorder struct {
+	mock *MockJobRunRepository
+}
+
+// NewMockJobRunRepository creates a new mock instance.
+func NewMockJobRunRepository(ctrl *gomock.Controller) *MockJobRunRepository {
+	mock := &MockJobRunRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRunRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRunRepository) EXPECT() *MockJobRunRepositoryMockRecorder {
+	return m.recorder
+}
+
+// InsertNewJobRun mocks base method.
+func (m *MockJobRunRepository) InsertNewJobRun(ctx context.Context, data *entity.JobRun) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewJobRun", ctx, data)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertNewJobRun indicates an expected call of InsertNewJobRun.
+func (mr *MockJobRunRepositoryMockRecorder) InsertNewJobRun(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewJobRun", reflect.TypeOf((*MockJobRunRepository)(nil).InsertNewJobRun), ctx, data)
+}
+
+// UpdateJobRunResult mocks base method.
+func (m *MockJobRunRepository) UpdateJobRunResult(ctx context.Context, data *entity.JobRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobRunResult", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobRunResult indicates an expected call of UpdateJobRunResult.
+func (mr *MockJobRunRepositoryMockRecorder) UpdateJobRunResult(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobRunResult", reflect.TypeOf((*MockJobRunRepository)(nil).UpdateJobRunResult), ctx, data)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/job/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/scheduler"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_jobRunRecorder_Finished(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockJobRunRepository(ctrlMock)
+	startedAt := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		run     *scheduler.Run
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "Succeeded With Result",
+			run:  &scheduler.Run{ID: 3, Job: "installment-reminder", StartedAt: startedAt, FinishedAt: startedAt.Add(1500 * time.Millisecond), Result: map[string]int{"sent": 3}},
+			mockFn: func() {
+				mockRepo.EXPECT().UpdateJobRunResult(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, data *entity.JobRun) error {
+						assert.Equal(t, int64(3), data.ID)
+						assert.Equal(t, constants.JobRunStatusSucceeded, data.Status)
+						assert.Equal(t, `{"sent":3}`, data.Result.String)
+						assert.Equal(t, int64(1500), data.DurationMs.Int64)
+						assert.False(t, data.ErrorMessage.Valid)
+						return nil
+					})
+			},
+		},
+		{
+			name: "Failed",
+			run:  &scheduler.Run{ID: 4, Job: "monthly-statement", StartedAt: startedAt, FinishedAt: startedAt, Err: errors.New("database is down")},
+			mockFn: func() {
+				mockRepo.EXPECT().UpdateJobRunResult(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, data *entity.JobRun) error {
+						assert.Equal(t, constants.JobRunStatusFailed, data.Status)
+						assert.Equal(t, "database is down", data.ErrorMessage.String)
+						assert.False(t, data.Result.Valid)
+						return nil
+					})
+			},
+		},
+		{
+			name:   "Start Was Not Recorded",
+			run:    &scheduler.Run{Job: "monthly-statement", StartedAt: startedAt, FinishedAt: startedAt},
+			mockFn: func() {},
+		},
+		{
+			name:    "Update Error",
+			run:     &scheduler.Run{ID: 5, Job: "monthly-statement", StartedAt: startedAt, FinishedAt: startedAt},
+			wantErr: true,
+			mockFn: func() {
+				mockRepo.EXPECT().UpdateJobRunResult(gomock.Any(), gomock.Any()).
+					Return(err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError)))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			err := NewJobRunRecorder(mockRepo).Finished(context.Background(), tt.run)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
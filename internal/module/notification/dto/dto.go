@@ -0,0 +1,71 @@
+package dto
+
+import "github.com/hilmiikhsan/multifinance-service/pkg/types"
+
+type PreferencesResponse struct {
+	Locale string `json:"locale"`
+	Email  bool   `json:"email"`
+	SMS    bool   `json:"sms"`
+	InApp  bool   `json:"in_app"`
+}
+
+// UpdatePreferencesRequest changes only the fields that are sent.
+type UpdatePreferencesRequest struct {
+	Locale string `json:"locale" validate:"omitempty,oneof=id en"`
+	Email  *bool  `json:"email"`
+	SMS    *bool  `json:"sms"`
+	InApp  *bool  `json:"in_app"`
+}
+
+type GetInboxRequest struct {
+	Page     int  `query:"page" validate:"required,min=1"`
+	Paginate int  `query:"paginate" validate:"required,min=1,max=100"`
+	Unread   bool `query:"unread"`
+}
+
+func (r *GetInboxRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type InboxMessageResponse struct {
+	ID        int64  `json:"id"`
+	Subject   string `json:"subject"`
+	Body      string `json:"body"`
+	Read      bool   `json:"read"`
+	ReadAt    string `json:"read_at,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+type GetInboxResponse struct {
+	Items []InboxMessageResponse `json:"items"`
+	Meta  types.Meta             `json:"meta"`
+}
+
+// NotifyResult counts the channels of one or more notifications. Skipped
+// channels are opted out, have no address or no configured sender, or were
+// already sent before.
+type NotifyResult struct {
+	Sent    int `json:"sent"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+func (r *NotifyResult) Add(other NotifyResult) {
+	r.Sent += other.Sent
+	r.Failed += other.Failed
+	r.Skipped += other.Skipped
+}
+
+// ReminderResult counts the contracts with an installment due on the target
+// date and what happened to their reminders.
+type ReminderResult struct {
+	DueDate   string `json:"due_date"`
+	Contracts int    `json:"contracts"`
+	NotifyResult
+}
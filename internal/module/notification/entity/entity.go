@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Recipient is a customer with where and how to reach them. A customer who
+// never saved preferences gets Indonesian on every channel.
+type Recipient struct {
+	CustomerID   int64          `db:"customer_id"`
+	FullName     string         `db:"full_name"`
+	Email        string         `db:"email" log:"mask"`
+	PhoneNumber  sql.NullString `db:"phone_number" log:"mask"`
+	Locale       string         `db:"locale"`
+	EmailEnabled bool           `db:"email_enabled"`
+	SMSEnabled   bool           `db:"sms_enabled"`
+	InAppEnabled bool           `db:"in_app_enabled"`
+}
+
+type Preference struct {
+	CustomerID   int64  `db:"customer_id"`
+	Locale       string `db:"locale"`
+	EmailEnabled bool   `db:"email_enabled"`
+	SMSEnabled   bool   `db:"sms_enabled"`
+	InAppEnabled bool   `db:"in_app_enabled"`
+}
+
+// Log is one notification on one channel. Template, reference and channel
+// identify it per customer, so a notification is never sent twice.
+type Log struct {
+	ID           int64          `db:"id"`
+	CustomerID   int64          `db:"customer_id"`
+	Template     string         `db:"template"`
+	Reference    string         `db:"reference"`
+	Channel      string         `db:"channel"`
+	Locale       string         `db:"locale"`
+	Recipient    sql.NullString `db:"recipient"`
+	Subject      sql.NullString `db:"subject"`
+	Body         string         `db:"body"`
+	Status       string         `db:"status"`
+	ErrorMessage sql.NullString `db:"error_message"`
+	CreatedAt    time.Time      `db:"created_at"`
+	SentAt       sql.NullTime   `db:"sent_at"`
+}
+
+type InboxMessage struct {
+	ID         int64        `db:"id"`
+	CustomerID int64        `db:"customer_id"`
+	Subject    string       `db:"subject"`
+	Body       string       `db:"body"`
+	ReadAt     sql.NullTime `db:"read_at"`
+	CreatedAt  time.Time    `db:"created_at"`
+}
+
+// DueContract is an active contract with what has been paid towards it, as
+// read for installment reminders.
+type DueContract struct {
+	ID                int64     `db:"id"`
+	CustomerID        int64     `db:"customer_id"`
+	ContractNumber    string    `db:"contract_number"`
+	OnTheRoadPrice    float64   `db:"on_the_road_price"`
+	AdminFee          float64   `db:"admin_fee"`
+	InstallmentAmount float64   `db:"installment_amount"`
+	InterestAmount    float64   `db:"interest_amount"`
+	TenorMonth        int       `db:"tenor_month"`
+	PaidAmount        float64   `db:"paid_amount"`
+	CreatedAt         time.Time `db:"created_at"`
+}
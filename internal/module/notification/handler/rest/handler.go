@@ -0,0 +1,155 @@
+package rest
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	redisRepository "github.com/hilmiikhsan/multifinance-service/internal/infrastructure/redis"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/ports"
+	notificationRepository "github.com/hilmiikhsan/multifinance-service/internal/module/notification/repository"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/service"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	jwtHandler "github.com/hilmiikhsan/multifinance-service/pkg/jwt_handler"
+	"github.com/hilmiikhsan/multifinance-service/pkg/response"
+	"github.com/rs/zerolog/log"
+)
+
+type notificationHandler struct {
+	service    ports.NotificationService
+	middleware middleware.AuthMiddleware
+	validator  adapter.Validator
+}
+
+func NewNotificationHandler() *notificationHandler {
+	var handler = new(notificationHandler)
+
+	// validator
+	validator := adapter.Adapters.Validator
+
+	// redis
+	redisRepository := redisRepository.NewRedisRepository(adapter.Adapters.MultifinanceRedis)
+
+	// jwt
+	jwt := jwtHandler.NewJWT(redisRepository)
+
+	// middleware
+	middlewareHandler := middleware.NewAuthMiddleware(jwt)
+
+	// repository
+	notificationRepository := notificationRepository.NewNotificationRepository(adapter.Adapters.MultifinanceDB)
+
+	// service, the API only reads and writes the inbox and preferences
+	notificationService := service.NewNotificationService(notificationRepository, nil, nil)
+
+	// handler
+	handler.service = notificationService
+	handler.middleware = *middlewareHandler
+	handler.validator = validator
+
+	return handler
+}
+
+func (h *notificationHandler) NotificationRoute(router fiber.Router) {
+	router.Get("/preferences", h.middleware.AuthBearer, h.getPreferences)
+	router.Put("/preferences", h.middleware.AuthBearer, h.updatePreferences)
+	router.Get("/inbox", h.middleware.AuthBearer, h.getInbox)
+	router.Post("/inbox/:id/read", h.middleware.AuthBearer, h.markInboxRead)
+}
+
+func (h *notificationHandler) getPreferences(c *fiber.Ctx) error {
+	var (
+		ctx        = c.UserContext()
+		customerID = int64(middleware.GetLocals(c).GetCustomerID())
+	)
+
+	res, err := h.service.GetPreferences(ctx, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Msg("handler::getPreferences - Failed to get preferences")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
+
+func (h *notificationHandler) updatePreferences(c *fiber.Ctx) error {
+	var (
+		ctx        = c.UserContext()
+		req        = new(dto.UpdatePreferencesRequest)
+		customerID = int64(middleware.GetLocals(c).GetCustomerID())
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::updatePreferences - Failed to parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::updatePreferences - Invalid request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	res, err := h.service.UpdatePreferences(ctx, req, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("handler::updatePreferences - Failed to update preferences")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
+
+func (h *notificationHandler) getInbox(c *fiber.Ctx) error {
+	var (
+		ctx        = c.UserContext()
+		req        = new(dto.GetInboxRequest)
+		customerID = int64(middleware.GetLocals(c).GetCustomerID())
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getInbox - Failed to parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := h.validator.Validate(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getInbox - Invalid request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	res, err := h.service.GetInbox(ctx, req, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("handler::getInbox - Failed to get inbox")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
+
+func (h *notificationHandler) markInboxRead(c *fiber.Ctx) error {
+	var (
+		ctx        = c.UserContext()
+		customerID = int64(middleware.GetLocals(c).GetCustomerID())
+	)
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id < 1 {
+		log.Ctx(ctx).Warn().Str("id", c.Params("id")).Msg("handler::markInboxRead - Invalid id")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(constants.ErrParamIdIsRequired))
+	}
+
+	res, err := h.service.MarkInboxRead(ctx, id, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("handler::markInboxRead - Failed to mark inbox message read")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
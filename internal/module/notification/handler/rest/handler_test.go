@@ -0,0 +1,158 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_notificationHandler_updatePreferences(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockNotificationService(ctrlMock)
+	mockValidator := NewMockValidator(ctrlMock)
+
+	type args struct {
+		body       string
+		statusCode int
+		mockFn     func()
+	}
+
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Success",
+			args: args{
+				body:       `{"locale":"en","sms":false}`,
+				statusCode: http.StatusOK,
+				mockFn: func() {
+					mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+					mockSvc.EXPECT().UpdatePreferences(gomock.Any(), gomock.Any(), int64(1)).DoAndReturn(
+						func(_ any, req *dto.UpdatePreferencesRequest, _ int64) (*dto.PreferencesResponse, error) {
+							assert.Equal(t, "en", req.Locale)
+							assert.NotNil(t, req.SMS)
+							assert.False(t, *req.SMS)
+							assert.Nil(t, req.Email)
+							return &dto.PreferencesResponse{Locale: "en", Email: true, InApp: true}, nil
+						})
+				},
+			},
+		},
+		{
+			name: "Failure - Invalid Locale",
+			args: args{
+				body:       `{"locale":"fr"}`,
+				statusCode: http.StatusBadRequest,
+				mockFn: func() {
+					mockValidator.EXPECT().Validate(gomock.Any()).Return(errors.New("locale must be one of [id en]"))
+				},
+			},
+		},
+		{
+			name: "Failure - Invalid Body",
+			args: args{
+				body:       `{"email":"yes"}`,
+				statusCode: http.StatusBadRequest,
+				mockFn:     func() {},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			handler := &notificationHandler{service: mockSvc, validator: mockValidator}
+			app.Put("/preferences", func(c *fiber.Ctx) error {
+				c.Locals("customer_id", 1)
+				return handler.updatePreferences(c)
+			})
+
+			tt.args.mockFn()
+
+			req := httptest.NewRequest(http.MethodPut, "/preferences", strings.NewReader(tt.args.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.args.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_notificationHandler_markInboxRead(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockNotificationService(ctrlMock)
+
+	type args struct {
+		path       string
+		statusCode int
+		mockFn     func()
+	}
+
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Success",
+			args: args{
+				path:       "/inbox/5/read",
+				statusCode: http.StatusOK,
+				mockFn: func() {
+					mockSvc.EXPECT().MarkInboxRead(gomock.Any(), int64(5), int64(1)).
+						Return(&dto.InboxMessageResponse{ID: 5, Read: true}, nil)
+				},
+			},
+		},
+		{
+			name: "Failure - Invalid ID",
+			args: args{
+				path:       "/inbox/abc/read",
+				statusCode: http.StatusBadRequest,
+				mockFn:     func() {},
+			},
+		},
+		{
+			name: "Failure - Not Found",
+			args: args{
+				path:       "/inbox/5/read",
+				statusCode: http.StatusNotFound,
+				mockFn: func() {
+					mockSvc.EXPECT().MarkInboxRead(gomock.Any(), int64(5), int64(1)).
+						Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrNotificationNotFound)))
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			handler := &notificationHandler{service: mockSvc}
+			app.Post("/inbox/:id/read", func(c *fiber.Ctx) error {
+				c.Locals("customer_id", 1)
+				return handler.markInboxRead(c)
+			})
+
+			tt.args.mockFn()
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodPost, tt.args.path, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.args.statusCode, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/outbox"
+)
+
+//go:generate mockgen -source=ports.go -destination=../service/service_mock_test.go -package=service
+type NotificationRepository interface {
+	FindRecipientByCustomerID(ctx context.Context, customerID int64) (*entity.Recipient, error)
+	UpsertPreference(ctx context.Context, data *entity.Preference) error
+	InsertNewLog(ctx context.Context, data *entity.Log) (int64, bool, error)
+	UpdateLogResult(ctx context.Context, data *entity.Log) error
+	InsertNewInboxMessage(ctx context.Context, data *entity.InboxMessage) error
+	FindInboxByCustomerID(ctx context.Context, req *dto.GetInboxRequest, customerID int64) ([]entity.InboxMessage, int, error)
+	FindInboxMessageByIDAndCustomerID(ctx context.Context, id, customerID int64) (*entity.InboxMessage, error)
+	MarkInboxMessageRead(ctx context.Context, id int64, readAt time.Time) error
+	FindActiveContractsAfterID(ctx context.Context, afterID int64, limit int) ([]entity.DueContract, error)
+}
+
+//go:generate mockgen -source=ports.go -destination=../handler/rest/handler_mock_test.go -package=rest
+type NotificationService interface {
+	GetPreferences(ctx context.Context, customerID int64) (*dto.PreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, req *dto.UpdatePreferencesRequest, customerID int64) (*dto.PreferencesResponse, error)
+	GetInbox(ctx context.Context, req *dto.GetInboxRequest, customerID int64) (*dto.GetInboxResponse, error)
+	MarkInboxRead(ctx context.Context, id, customerID int64) (*dto.InboxMessageResponse, error)
+}
+
+// EventHandler turns the domain events read from the outbox stream into
+// notifications. Events it has no notification for are ignored.
+type EventHandler interface {
+	HandleEvent(ctx context.Context, event *outbox.Event) error
+}
+
+type ReminderService interface {
+	SendDueReminders(ctx context.Context, daysAhead int) (*dto.ReminderResult, error)
+}
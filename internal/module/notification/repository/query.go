@@ -0,0 +1,146 @@
+package repository
+
+import "github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+
+const (
+	queryFindRecipientByCustomerID = `
+		SELECT
+			c.id AS customer_id,
+			c.full_name,
+			c.email,
+			c.phone_number,
+			COALESCE(p.locale, 'id') AS locale,
+			COALESCE(p.email_enabled, TRUE) AS email_enabled,
+			COALESCE(p.sms_enabled, TRUE) AS sms_enabled,
+			COALESCE(p.in_app_enabled, TRUE) AS in_app_enabled
+		FROM customers c
+		LEFT JOIN notification_preferences p ON p.customer_id = c.id
+		WHERE c.id = ? AND c.deleted_at IS NULL
+	`
+
+	queryInsertNewLog = `
+		INSERT INTO notification_logs
+		(
+			customer_id,
+			template,
+			reference,
+			channel,
+			locale,
+			recipient,
+			subject,
+			body,
+			status
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	queryUpdateLogResult = `
+		UPDATE notification_logs
+		SET
+			status = ?,
+			error_message = ?,
+			sent_at = ?
+		WHERE id = ?
+	`
+
+	queryInsertNewInboxMessage = `
+		INSERT INTO notification_inbox
+		(
+			customer_id,
+			subject,
+			body
+		) VALUES (?, ?, ?)
+	`
+
+	queryCountInboxByCustomerID = `
+		SELECT COUNT(*)
+		FROM notification_inbox
+		WHERE customer_id = :customer_id
+			AND (:unread = FALSE OR read_at IS NULL)
+	`
+
+	queryFindInboxByCustomerID = `
+		SELECT
+			id,
+			customer_id,
+			subject,
+			body,
+			read_at,
+			created_at
+		FROM notification_inbox
+		WHERE customer_id = :customer_id
+			AND (:unread = FALSE OR read_at IS NULL)
+		ORDER BY id DESC
+		LIMIT :limit OFFSET :offset
+	`
+
+	queryFindInboxMessageByIDAndCustomerID = `
+		SELECT
+			id,
+			customer_id,
+			subject,
+			body,
+			read_at,
+			created_at
+		FROM notification_inbox
+		WHERE id = ? AND customer_id = ?
+	`
+
+	queryMarkInboxMessageRead = `
+		UPDATE notification_inbox
+		SET read_at = ?
+		WHERE id = ? AND read_at IS NULL
+	`
+
+	queryFindActiveContractsAfterID = `
+		SELECT
+			t.id,
+			t.customer_id,
+			t.contract_number,
+			t.on_the_road_price,
+			t.admin_fee,
+			t.installment_amount,
+			t.interest_amount,
+			t.tenor_month,
+			COALESCE((SELECT SUM(p.amount) FROM transaction_payments p WHERE p.transaction_id = t.id), 0) AS paid_amount,
+			t.created_at
+		FROM transactions t
+		WHERE t.id > ? AND t.status = ? AND t.deleted_at IS NULL
+		ORDER BY t.id ASC
+		LIMIT ?
+	`
+)
+
+var (
+	queryUpsertPreference = dialect.Query{
+		MySQL: `
+			INSERT INTO notification_preferences
+			(
+				customer_id,
+				locale,
+				email_enabled,
+				sms_enabled,
+				in_app_enabled
+			) VALUES (?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE
+				locale = VALUES(locale),
+				email_enabled = VALUES(email_enabled),
+				sms_enabled = VALUES(sms_enabled),
+				in_app_enabled = VALUES(in_app_enabled)
+		`,
+		Postgres: `
+			INSERT INTO notification_preferences
+			(
+				customer_id,
+				locale,
+				email_enabled,
+				sms_enabled,
+				in_app_enabled
+			) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (customer_id) DO UPDATE SET
+				locale = EXCLUDED.locale,
+				email_enabled = EXCLUDED.email_enabled,
+				sms_enabled = EXCLUDED.sms_enabled,
+				in_app_enabled = EXCLUDED.in_app_enabled
+		`,
+	}
+)
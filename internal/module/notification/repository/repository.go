@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/entity"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.NotificationRepository = &notificationRepository{}
+
+type notificationRepository struct {
+	db *sqlx.DB
+}
+
+func NewNotificationRepository(db *sqlx.DB) *notificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+func (r *notificationRepository) FindRecipientByCustomerID(ctx context.Context, customerID int64) (*entity.Recipient, error) {
+	ctx, span := tracing.Start(ctx, "notificationRepository.FindRecipientByCustomerID")
+	defer span.End()
+
+	var res = new(entity.Recipient)
+
+	err := r.db.GetContext(ctx, res, r.db.Rebind(queryFindRecipientByCustomerID), customerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Msg("repository::FindRecipientByCustomerID - Failed to find recipient")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *notificationRepository) UpsertPreference(ctx context.Context, data *entity.Preference) error {
+	ctx, span := tracing.Start(ctx, "notificationRepository.UpsertPreference")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(queryUpsertPreference.For(r.db.DriverName())),
+		data.CustomerID,
+		data.Locale,
+		data.EmailEnabled,
+		data.SMSEnabled,
+		data.InAppEnabled,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", data.CustomerID).Msg("repository::UpsertPreference - Failed to save preference")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+// InsertNewLog reports false, and no error, when the notification was logged
+// before for the same channel.
+func (r *notificationRepository) InsertNewLog(ctx context.Context, data *entity.Log) (int64, bool, error) {
+	ctx, span := tracing.Start(ctx, "notificationRepository.InsertNewLog")
+	defer span.End()
+
+	id, err := dialect.InsertID(ctx, r.db, r.db.DriverName(), r.db.Rebind(queryInsertNewLog),
+		data.CustomerID,
+		data.Template,
+		data.Reference,
+		data.Channel,
+		data.Locale,
+		data.Recipient,
+		data.Subject,
+		data.Body,
+		data.Status,
+	)
+	if err != nil {
+		if err_msg.IsDuplicateEntry(err) {
+			return 0, false, nil
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", data.CustomerID).Str("template", data.Template).Str("reference", data.Reference).Msg("repository::InsertNewLog - Failed to insert notification log")
+		return 0, false, err_msg.NewDatabaseErrors(err)
+	}
+
+	return id, true, nil
+}
+
+func (r *notificationRepository) UpdateLogResult(ctx context.Context, data *entity.Log) error {
+	ctx, span := tracing.Start(ctx, "notificationRepository.UpdateLogResult")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(queryUpdateLogResult),
+		data.Status,
+		data.ErrorMessage,
+		data.SentAt,
+		data.ID,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", data.ID).Msg("repository::UpdateLogResult - Failed to update notification log")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) InsertNewInboxMessage(ctx context.Context, data *entity.InboxMessage) error {
+	ctx, span := tracing.Start(ctx, "notificationRepository.InsertNewInboxMessage")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(queryInsertNewInboxMessage),
+		data.CustomerID,
+		data.Subject,
+		data.Body,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", data.CustomerID).Msg("repository::InsertNewInboxMessage - Failed to insert inbox message")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) FindInboxByCustomerID(ctx context.Context, req *dto.GetInboxRequest, customerID int64) ([]entity.InboxMessage, int, error) {
+	ctx, span := tracing.Start(ctx, "notificationRepository.FindInboxByCustomerID")
+	defer span.End()
+
+	var (
+		data      = make([]entity.InboxMessage, 0, req.Paginate)
+		totalData int
+	)
+
+	countQuery, countArgs, err := sqlx.Named(queryCountInboxByCustomerID, map[string]interface{}{
+		"customer_id": customerID,
+		"unread":      req.Unread,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindInboxByCustomerID - Failed to bind named query for count")
+		return nil, 0, err
+	}
+
+	err = r.db.GetContext(ctx, &totalData, r.db.Rebind(countQuery), countArgs...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindInboxByCustomerID - Failed to count inbox messages")
+		return nil, 0, err
+	}
+
+	query, args, err := sqlx.Named(queryFindInboxByCustomerID, map[string]interface{}{
+		"customer_id": customerID,
+		"unread":      req.Unread,
+		"limit":       req.Paginate,
+		"offset":      req.Paginate * (req.Page - 1),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindInboxByCustomerID - Failed to bind named query")
+		return nil, 0, err
+	}
+
+	err = r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("repository::FindInboxByCustomerID - Failed to find inbox messages")
+		return nil, 0, err
+	}
+
+	return data, totalData, nil
+}
+
+func (r *notificationRepository) FindInboxMessageByIDAndCustomerID(ctx context.Context, id, customerID int64) (*entity.InboxMessage, error) {
+	ctx, span := tracing.Start(ctx, "notificationRepository.FindInboxMessageByIDAndCustomerID")
+	defer span.End()
+
+	var res = new(entity.InboxMessage)
+
+	err := r.db.GetContext(ctx, res, r.db.Rebind(queryFindInboxMessageByIDAndCustomerID), id, customerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrNotificationNotFound))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("repository::FindInboxMessageByIDAndCustomerID - Failed to find inbox message")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *notificationRepository) MarkInboxMessageRead(ctx context.Context, id int64, readAt time.Time) error {
+	ctx, span := tracing.Start(ctx, "notificationRepository.MarkInboxMessageRead")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(queryMarkInboxMessageRead), readAt, id)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("repository::MarkInboxMessageRead - Failed to mark inbox message read")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) FindActiveContractsAfterID(ctx context.Context, afterID int64, limit int) ([]entity.DueContract, error) {
+	ctx, span := tracing.Start(ctx, "notificationRepository.FindActiveContractsAfterID")
+	defer span.End()
+
+	var res = make([]entity.DueContract, 0, limit)
+
+	err := r.db.SelectContext(ctx, &res, r.db.Rebind(queryFindActiveContractsAfterID), afterID, constants.TransactionStatusActive, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("after_id", afterID).Msg("repository::FindActiveContractsAfterID - Failed to find active contracts")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
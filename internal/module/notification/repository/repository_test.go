@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect/dialecttest"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_notificationRepository_FindRecipientByCustomerID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		columns := []string{"customer_id", "full_name", "email", "phone_number", "locale", "email_enabled", "sms_enabled", "in_app_enabled"}
+
+		tests := []struct {
+			name    string
+			wantErr bool
+			mockFn  func()
+		}{
+			{
+				name: "Find Recipient Successfully",
+				mockFn: func() {
+					mock.ExpectQuery("SELECT (.+) FROM customers c LEFT JOIN notification_preferences p").WithArgs(int64(1)).
+						WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "Budi", "budi@example.com", "081234567890", "en", true, false, true))
+				},
+			},
+			{
+				name:    "Customer Not Found",
+				wantErr: true,
+				mockFn: func() {
+					mock.ExpectQuery("SELECT (.+) FROM customers c").WithArgs(int64(1)).WillReturnError(sql.ErrNoRows)
+				},
+			},
+			{
+				name:    "Find Recipient Failed",
+				wantErr: true,
+				mockFn: func() {
+					mock.ExpectQuery("SELECT (.+) FROM customers c").WithArgs(int64(1)).WillReturnError(fmt.Errorf("database error"))
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn()
+
+				got, err := NewNotificationRepository(db).FindRecipientByCustomerID(context.Background(), 1)
+				assert.Equal(t, tt.wantErr, err != nil)
+				if !tt.wantErr {
+					assert.Equal(t, "en", got.Locale)
+					assert.False(t, got.SMSEnabled)
+					assert.Equal(t, "081234567890", got.PhoneNumber.String)
+				}
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_notificationRepository_InsertNewLog(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		entry := &entity.Log{
+			CustomerID: 1,
+			Template:   "booking_confirmed",
+			Reference:  "KTR-1",
+			Channel:    "email",
+			Locale:     "id",
+			Recipient:  sql.NullString{String: "budi@example.com", Valid: true},
+			Subject:    sql.NullString{String: "Pembiayaan KTR-1 telah aktif", Valid: true},
+			Body:       "body",
+			Status:     "pending",
+		}
+
+		tests := []struct {
+			name        string
+			wantID      int64
+			wantCreated bool
+			wantErr     bool
+			mockFn      func()
+		}{
+			{
+				name:        "Insert New Log Successfully",
+				wantID:      9,
+				wantCreated: true,
+				mockFn: func() {
+					dialecttest.ExpectInsert(mock, db, "INSERT INTO notification_logs").
+						WithArgs(int64(1), "booking_confirmed", "KTR-1", "email", "id", entry.Recipient, entry.Subject, "body", "pending").
+						WillReturnID(9)
+				},
+			},
+			{
+				name: "Already Logged",
+				mockFn: func() {
+					dialecttest.ExpectInsert(mock, db, "INSERT INTO notification_logs").WillReturnError(dialecttest.DuplicateKey(db, "notification_logs", "uq_notification_logs_customer_id_template_reference_channel", "1-booking_confirmed-KTR-1-email"))
+				},
+			},
+			{
+				name:    "Insert New Log Failed",
+				wantErr: true,
+				mockFn: func() {
+					dialecttest.ExpectInsert(mock, db, "INSERT INTO notification_logs").WillReturnError(fmt.Errorf("database error"))
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn()
+
+				id, created, err := NewNotificationRepository(db).InsertNewLog(context.Background(), entry)
+				assert.Equal(t, tt.wantErr, err != nil)
+				assert.Equal(t, tt.wantID, id)
+				assert.Equal(t, tt.wantCreated, created)
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_notificationRepository_FindInboxByCustomerID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		now := time.Date(2024, 12, 31, 9, 0, 0, 0, time.UTC)
+		req := &dto.GetInboxRequest{Page: 2, Paginate: 10, Unread: true}
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM notification_inbox").WithArgs(int64(1), true).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+		mock.ExpectQuery("SELECT (.+) FROM notification_inbox (.+) LIMIT").WithArgs(int64(1), true, 10, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "subject", "body", "read_at", "created_at"}).
+				AddRow(1, 1, "Pembiayaan telah aktif", "body", nil, now))
+
+		got, total, err := NewNotificationRepository(db).FindInboxByCustomerID(context.Background(), req, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 11, total)
+		assert.Len(t, got, 1)
+		assert.False(t, got[0].ReadAt.Valid)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_notificationRepository_FindActiveContractsAfterID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		now := time.Date(2024, 12, 1, 9, 0, 0, 0, time.UTC)
+
+		mock.ExpectQuery("SELECT (.+) FROM transactions t WHERE t.id > (.+) LIMIT").WithArgs(int64(40), "active", 500).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "contract_number", "on_the_road_price", "admin_fee", "installment_amount", "interest_amount", "tenor_month", "paid_amount", "created_at"}).
+				AddRow(41, 1, "KTR-41", 12000000, 250000, 1100000, 1200000, 12, 0, now))
+
+		got, err := NewNotificationRepository(db).FindActiveContractsAfterID(context.Background(), 40, 500)
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, "KTR-41", got[0].ContractNumber)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
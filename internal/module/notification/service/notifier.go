@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/entity"
+	notificationPorts "github.com/hilmiikhsan/multifinance-service/internal/module/notification/ports"
+	outboxDto "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/document"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/export"
+	"github.com/hilmiikhsan/multifinance-service/pkg/notification"
+	"github.com/hilmiikhsan/multifinance-service/pkg/outbox"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+// errorMessageLength is the size of notification_logs.error_message.
+const errorMessageLength = 255
+
+var _ notification.Sender = &inboxSender{}
+
+// inboxSender is the in-app channel, it stores the message in the inbox of
+// the customer.
+type inboxSender struct {
+	notificationRepository notificationPorts.NotificationRepository
+}
+
+func newInboxSender(notificationRepository notificationPorts.NotificationRepository) *inboxSender {
+	return &inboxSender{
+		notificationRepository: notificationRepository,
+	}
+}
+
+func (s *inboxSender) Send(ctx context.Context, msg *notification.Message) error {
+	ctx, span := tracing.Start(ctx, "inboxSender.Send")
+	defer span.End()
+
+	return s.notificationRepository.InsertNewInboxMessage(ctx, &entity.InboxMessage{
+		CustomerID: msg.CustomerID,
+		Subject:    msg.Subject,
+		Body:       msg.Body,
+	})
+}
+
+// HandleEvent is called for every event on the customer stream. Events come
+// at least once; the send log makes a repeated event a no-op.
+func (s *notificationService) HandleEvent(ctx context.Context, event *outbox.Event) error {
+	ctx, span := tracing.Start(ctx, "notificationService.HandleEvent")
+	defer span.End()
+
+	switch event.Type {
+	case constants.EventTransactionBooked:
+		return s.handleTransactionBooked(ctx, event)
+	default:
+		return nil
+	}
+}
+
+func (s *notificationService) handleTransactionBooked(ctx context.Context, event *outbox.Event) error {
+	var payload outboxDto.TransactionBookedV1
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		// retrying cannot fix the payload, so the event is let go
+		log.Ctx(ctx).Error().Err(err).Str("event_id", event.ID).Msg("service::HandleEvent - Failed to decode transaction booked payload")
+		return nil
+	}
+
+	recipient, found, err := s.findRecipient(ctx, int64(payload.CustomerID))
+	if err != nil || !found {
+		return err
+	}
+
+	data := &notification.BookingConfirmed{
+		FullName:          recipient.FullName,
+		ContractNumber:    payload.ContractNumber,
+		AssetName:         payload.AssetName,
+		OnTheRoadPrice:    payload.OnTheRoadPrice,
+		InstallmentAmount: payload.InstallmentAmount,
+		TenorMonth:        payload.TenorMonth,
+		FirstDueDate:      event.OccurredAt.AddDate(0, 1, 0),
+	}
+
+	if schedule := document.BuildSchedule(event.OccurredAt, payload.OnTheRoadPrice, payload.InterestAmount, payload.InstallmentAmount, payload.TenorMonth); len(schedule) > 0 {
+		data.FirstDueDate = schedule[0].DueDate
+	}
+
+	res, err := s.notify(ctx, recipient, notification.TemplateBookingConfirmed, payload.ContractNumber, data)
+	if err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Info().Str("event_id", event.ID).Str("contract_number", payload.ContractNumber).Any("result", res).Msg("service::HandleEvent - Booking confirmation sent")
+
+	return nil
+}
+
+// findRecipient reports false for a customer that no longer exists, there is
+// nobody left to notify.
+func (s *notificationService) findRecipient(ctx context.Context, customerID int64) (*entity.Recipient, bool, error) {
+	recipient, err := s.notificationRepository.FindRecipientByCustomerID(ctx, customerID)
+	if err != nil {
+		if err_msg.HasCode(err, fiber.StatusNotFound) {
+			log.Ctx(ctx).Warn().Int64("customer_id", customerID).Msg("service::findRecipient - Customer not found, skipping notification")
+			return nil, false, nil
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Msg("service::findRecipient - Failed to find recipient")
+		return nil, false, err
+	}
+
+	return recipient, true, nil
+}
+
+// notify sends one notification on every channel the customer has on. A
+// channel is logged before it is sent, so it goes out at most once per
+// reference: a crash between the two leaves the log pending instead of
+// sending it again. A failed send is logged and not retried.
+func (s *notificationService) notify(ctx context.Context, recipient *entity.Recipient, template, reference string, data any) (dto.NotifyResult, error) {
+	var (
+		res    dto.NotifyResult
+		locale = export.LookupLocale(recipient.Locale).Code
+	)
+
+	for _, channel := range notification.Channels {
+		sender := s.senders[channel]
+		to, enabled := channelAddress(recipient, channel)
+		if sender == nil || !enabled {
+			res.Skipped++
+			continue
+		}
+
+		content, err := notification.Render(template, locale, channel, data)
+		if err != nil {
+			// a template that does not render now will not on a retry either
+			log.Ctx(ctx).Error().Err(err).Str("template", template).Str("channel", channel).Msg("service::notify - Failed to render notification")
+			return res, outbox.Permanent(err)
+		}
+
+		entry := &entity.Log{
+			CustomerID: recipient.CustomerID,
+			Template:   template,
+			Reference:  reference,
+			Channel:    channel,
+			Locale:     locale,
+			Recipient:  sql.NullString{String: to, Valid: to != ""},
+			Subject:    sql.NullString{String: content.Subject, Valid: content.Subject != ""},
+			Body:       content.Body,
+			Status:     constants.NotificationStatusPending,
+		}
+
+		id, created, err := s.notificationRepository.InsertNewLog(ctx, entry)
+		if err != nil {
+			return res, err
+		}
+
+		if !created {
+			res.Skipped++
+			continue
+		}
+
+		entry.ID = id
+
+		err = sender.Send(ctx, &notification.Message{
+			CustomerID: recipient.CustomerID,
+			Channel:    channel,
+			To:         to,
+			Subject:    content.Subject,
+			Body:       content.Body,
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("log_id", id).Str("channel", channel).Msg("service::notify - Failed to send notification")
+			entry.Status = constants.NotificationStatusFailed
+			entry.ErrorMessage = sql.NullString{String: truncate(err.Error(), errorMessageLength), Valid: true}
+			res.Failed++
+		} else {
+			entry.Status = constants.NotificationStatusSent
+			entry.SentAt = sql.NullTime{Time: s.now(), Valid: true}
+			res.Sent++
+		}
+
+		if err := s.notificationRepository.UpdateLogResult(ctx, entry); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// channelAddress tells where a channel reaches the customer and whether the
+// customer can be reached on it at all.
+func channelAddress(recipient *entity.Recipient, channel string) (string, bool) {
+	switch channel {
+	case notification.ChannelEmail:
+		return recipient.Email, recipient.EmailEnabled && recipient.Email != ""
+	case notification.ChannelSMS:
+		return recipient.PhoneNumber.String, recipient.SMSEnabled && recipient.PhoneNumber.String != ""
+	case notification.ChannelInApp:
+		return "", recipient.InAppEnabled
+	default:
+		return "", false
+	}
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	return string(runes[:n])
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/document"
+	"github.com/hilmiikhsan/multifinance-service/pkg/export"
+	"github.com/hilmiikhsan/multifinance-service/pkg/notification"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+// SendDueReminders reminds every customer whose next unpaid installment falls
+// daysAhead days from today, Jakarta time. Running it again on the same day
+// sends nothing new.
+func (s *notificationService) SendDueReminders(ctx context.Context, daysAhead int) (*dto.ReminderResult, error) {
+	ctx, span := tracing.Start(ctx, "notificationService.SendDueReminders")
+	defer span.End()
+
+	var (
+		location = export.LookupLocale(export.LocaleID).Location
+		res      = &dto.ReminderResult{
+			DueDate: s.now().In(location).AddDate(0, 0, daysAhead).Format(constants.DateFormat),
+		}
+		afterID int64
+	)
+
+	for {
+		contracts, err := s.notificationRepository.FindActiveContractsAfterID(ctx, afterID, reminderBatchSize)
+		if err != nil {
+			return res, err
+		}
+
+		for _, contract := range contracts {
+			afterID = contract.ID
+
+			schedule := document.BuildSchedule(contract.CreatedAt, contract.OnTheRoadPrice, contract.InterestAmount, contract.InstallmentAmount, contract.TenorMonth)
+
+			installment, amount, ok := document.NextDue(schedule, contract.AdminFee, contract.PaidAmount)
+			if !ok || installment.DueDate.In(location).Format(constants.DateFormat) != res.DueDate {
+				continue
+			}
+
+			res.Contracts++
+
+			recipient, found, err := s.findRecipient(ctx, contract.CustomerID)
+			if err != nil {
+				return res, err
+			}
+
+			if !found {
+				continue
+			}
+
+			sent, err := s.notify(ctx, recipient, notification.TemplateInstallmentDue, fmt.Sprintf("%s:%s", contract.ContractNumber, res.DueDate), &notification.InstallmentDue{
+				FullName:          recipient.FullName,
+				ContractNumber:    contract.ContractNumber,
+				InstallmentNumber: installment.Number,
+				Amount:            amount,
+				DueDate:           installment.DueDate,
+			})
+			res.Add(sent)
+			if err != nil {
+				log.Ctx(ctx).Error().Err(err).Str("contract_number", contract.ContractNumber).Msg("service::SendDueReminders - Failed to send reminder")
+				return res, err
+			}
+		}
+
+		if len(contracts) < reminderBatchSize {
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/notification"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_notificationService_SendDueReminders(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockNotificationRepository(ctrlMock)
+
+	// 2025-01-28 20:00 in Jakarta, three days ahead is 2025-01-31
+	now := time.Date(2025, 1, 28, 13, 0, 0, 0, time.UTC)
+	bookedAt := time.Date(2024, 12, 31, 2, 0, 0, 0, time.UTC)
+
+	contracts := []entity.DueContract{
+		// first installment unpaid, due 2025-01-31
+		{ID: 1, CustomerID: 1, ContractNumber: "KTR-1", OnTheRoadPrice: 12000000, AdminFee: 250000, InstallmentAmount: 1100000, InterestAmount: 1200000, TenorMonth: 12, CreatedAt: bookedAt},
+		// first installment paid, the next one is a month later
+		{ID: 2, CustomerID: 2, ContractNumber: "KTR-2", OnTheRoadPrice: 12000000, AdminFee: 250000, InstallmentAmount: 1100000, InterestAmount: 1200000, TenorMonth: 12, PaidAmount: 1350000, CreatedAt: bookedAt},
+		// booked on another day
+		{ID: 3, CustomerID: 3, ContractNumber: "KTR-3", OnTheRoadPrice: 6000000, AdminFee: 250000, InstallmentAmount: 1100000, InterestAmount: 600000, TenorMonth: 6, CreatedAt: bookedAt.AddDate(0, 0, -5)},
+	}
+
+	mockRepo.EXPECT().FindActiveContractsAfterID(gomock.Any(), int64(0), reminderBatchSize).Return(contracts, nil)
+	mockRepo.EXPECT().FindRecipientByCustomerID(gomock.Any(), int64(1)).Return(testRecipient(), nil)
+	mockRepo.EXPECT().InsertNewLog(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, data *entity.Log) (int64, bool, error) {
+			assert.Equal(t, notification.TemplateInstallmentDue, data.Template)
+			assert.Equal(t, "KTR-1:2025-01-31", data.Reference)
+			return 1, true, nil
+		}).Times(3)
+	mockRepo.EXPECT().InsertNewInboxMessage(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().UpdateLogResult(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+
+	email, sms := notification.NewMemorySender(), notification.NewMemorySender()
+
+	s := NewNotificationService(mockRepo, email, sms)
+	s.now = func() time.Time { return now }
+
+	got, err := s.SendDueReminders(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01-31", got.DueDate)
+	assert.Equal(t, 1, got.Contracts)
+	assert.Equal(t, 3, got.Sent)
+
+	assert.Len(t, sms.Messages(), 1)
+	assert.Contains(t, sms.Messages()[0].Body, "Rp 1.350.000")
+	assert.Contains(t, sms.Messages()[0].Body, "31 Januari 2025")
+}
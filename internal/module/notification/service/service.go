@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/entity"
+	notificationPorts "github.com/hilmiikhsan/multifinance-service/internal/module/notification/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/notification"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	_ notificationPorts.NotificationService = &notificationService{}
+	_ notificationPorts.EventHandler        = &notificationService{}
+	_ notificationPorts.ReminderService     = &notificationService{}
+)
+
+// reminderBatchSize is how many active contracts are read at a time while
+// looking for installments to remind of.
+const reminderBatchSize = 500
+
+type notificationService struct {
+	notificationRepository notificationPorts.NotificationRepository
+	senders                map[string]notification.Sender
+	now                    func() time.Time
+}
+
+// NewNotificationService sends email and SMS through the given senders, a nil
+// sender leaves that channel off. In-app messages always go to the inbox.
+func NewNotificationService(notificationRepository notificationPorts.NotificationRepository, email, sms notification.Sender) *notificationService {
+	senders := map[string]notification.Sender{
+		notification.ChannelInApp: newInboxSender(notificationRepository),
+	}
+
+	if email != nil {
+		senders[notification.ChannelEmail] = email
+	}
+
+	if sms != nil {
+		senders[notification.ChannelSMS] = sms
+	}
+
+	return &notificationService{
+		notificationRepository: notificationRepository,
+		senders:                senders,
+		now:                    time.Now,
+	}
+}
+
+func (s *notificationService) GetPreferences(ctx context.Context, customerID int64) (*dto.PreferencesResponse, error) {
+	ctx, span := tracing.Start(ctx, "notificationService.GetPreferences")
+	defer span.End()
+
+	recipient, err := s.notificationRepository.FindRecipientByCustomerID(ctx, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Msg("service::GetPreferences - Failed to find recipient")
+		return nil, err
+	}
+
+	return &dto.PreferencesResponse{
+		Locale: recipient.Locale,
+		Email:  recipient.EmailEnabled,
+		SMS:    recipient.SMSEnabled,
+		InApp:  recipient.InAppEnabled,
+	}, nil
+}
+
+func (s *notificationService) UpdatePreferences(ctx context.Context, req *dto.UpdatePreferencesRequest, customerID int64) (*dto.PreferencesResponse, error) {
+	ctx, span := tracing.Start(ctx, "notificationService.UpdatePreferences")
+	defer span.End()
+
+	recipient, err := s.notificationRepository.FindRecipientByCustomerID(ctx, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Msg("service::UpdatePreferences - Failed to find recipient")
+		return nil, err
+	}
+
+	preference := &entity.Preference{
+		CustomerID:   customerID,
+		Locale:       recipient.Locale,
+		EmailEnabled: recipient.EmailEnabled,
+		SMSEnabled:   recipient.SMSEnabled,
+		InAppEnabled: recipient.InAppEnabled,
+	}
+
+	if req.Locale != "" {
+		preference.Locale = req.Locale
+	}
+
+	if req.Email != nil {
+		preference.EmailEnabled = *req.Email
+	}
+
+	if req.SMS != nil {
+		preference.SMSEnabled = *req.SMS
+	}
+
+	if req.InApp != nil {
+		preference.InAppEnabled = *req.InApp
+	}
+
+	if err := s.notificationRepository.UpsertPreference(ctx, preference); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Msg("service::UpdatePreferences - Failed to save preference")
+		return nil, err
+	}
+
+	return &dto.PreferencesResponse{
+		Locale: preference.Locale,
+		Email:  preference.EmailEnabled,
+		SMS:    preference.SMSEnabled,
+		InApp:  preference.InAppEnabled,
+	}, nil
+}
+
+func (s *notificationService) GetInbox(ctx context.Context, req *dto.GetInboxRequest, customerID int64) (*dto.GetInboxResponse, error) {
+	ctx, span := tracing.Start(ctx, "notificationService.GetInbox")
+	defer span.End()
+
+	messages, totalData, err := s.notificationRepository.FindInboxByCustomerID(ctx, req, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("customer_id", customerID).Any("payload", req).Msg("service::GetInbox - Failed to find inbox messages")
+		return nil, err
+	}
+
+	res := &dto.GetInboxResponse{
+		Items: make([]dto.InboxMessageResponse, 0, len(messages)),
+	}
+
+	for i := range messages {
+		res.Items = append(res.Items, *inboxMessageResponse(&messages[i]))
+	}
+
+	res.Meta.CountTotalPage(req.Page, req.Paginate, totalData)
+
+	return res, nil
+}
+
+// MarkInboxRead keeps the first read time when a message is read again.
+func (s *notificationService) MarkInboxRead(ctx context.Context, id, customerID int64) (*dto.InboxMessageResponse, error) {
+	ctx, span := tracing.Start(ctx, "notificationService.MarkInboxRead")
+	defer span.End()
+
+	message, err := s.notificationRepository.FindInboxMessageByIDAndCustomerID(ctx, id, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !message.ReadAt.Valid {
+		now := s.now()
+		if err := s.notificationRepository.MarkInboxMessageRead(ctx, id, now); err != nil {
+			log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("service::MarkInboxRead - Failed to mark inbox message read")
+			return nil, err
+		}
+
+		message.ReadAt.Time, message.ReadAt.Valid = now, true
+	}
+
+	return inboxMessageResponse(message), nil
+}
+
+func inboxMessageResponse(message *entity.InboxMessage) *dto.InboxMessageResponse {
+	res := &dto.InboxMessageResponse{
+		ID:        message.ID,
+		Subject:   message.Subject,
+		Body:      message.Body,
+		Read:      message.ReadAt.Valid,
+		CreatedAt: message.CreatedAt.Format(constants.DateTimeFormat),
+	}
+
+	if message.ReadAt.Valid {
+		res.ReadAt = message.ReadAt.Time.Format(constants.DateTimeFormat)
+	}
+
+	return res
+}
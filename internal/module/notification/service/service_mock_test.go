@@ -0,0 +1,339 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ports.go -destination=../service/service_mock_test.go -package=service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	dto "github.com/hilmiikhsan/multifinance-service/internal/module/notification/dto"
+	entity "github.com/hilmiikhsan/multifinance-service/internal/module/notification/entity"
+	outbox "github.com/hilmiikhsan/multifinance-service/pkg/outbox"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationRepository is a mock of NotificationRepository interface.
+type MockNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationRepositoryMockRecorder is the mock recorder for MockNotificationRepository.
+type MockNotificationRepositoryMockRecorder struct {
+	mock *MockNotificationRepository
+}
+
+// NewMockNotificationRepository creates a new mock instance.
+func NewMockNotificationRepository(ctrl *gomock.Controller) *MockNotificationRepository {
+	mock := &MockNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindActiveContractsAfterID mocks base method.
+func (m *MockNotificationRepository) FindActiveContractsAfterID(ctx context.Context, afterID int64, limit int) ([]entity.DueContract, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveContractsAfterID", ctx, afterID, limit)
+	ret0, _ := ret[0].([]entity.DueContract)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveContractsAfterID indicates an expected call of FindActiveContractsAfterID.
+func (mr *MockNotificationRepositoryMockRecorder) FindActiveContractsAfterID(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveContractsAfterID", reflect.TypeOf((*MockNotificationRepository)(nil).FindActiveContractsAfterID), ctx, afterID, limit)
+}
+
+// FindInboxByCustomerID mocks base method.
+func (m *MockNotificationRepository) FindInboxByCustomerID(ctx context.Context, req *dto.GetInboxRequest, customerID int64) ([]entity.InboxMessage, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInboxByCustomerID", ctx, req, customerID)
+	ret0, _ := ret[0].([]entity.InboxMessage)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindInboxByCustomerID indicates an expected call of FindInboxByCustomerID.
+func (mr *MockNotificationRepositoryMockRecorder) FindInboxByCustomerID(ctx, req, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInboxByCustomerID", reflect.TypeOf((*MockNotificationRepository)(nil).FindInboxByCustomerID), ctx, req, customerID)
+}
+
+// FindInboxMessageByIDAndCustomerID mocks base method.
+func (m *MockNotificationRepository) FindInboxMessageByIDAndCustomerID(ctx context.Context, id, customerID int64) (*entity.InboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInboxMessageByIDAndCustomerID", ctx, id, customerID)
+	ret0, _ := ret[0].(*entity.InboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindInboxMessageByIDAndCustomerID indicates an expected call of FindInboxMessageByIDAndCustomerID.
+func (mr *MockNotificationRepositoryMockRecorder) FindInboxMessageByIDAndCustomerID(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInboxMessageByIDAndCustomerID", reflect.TypeOf((*MockNotificationRepository)(nil).FindInboxMessageByIDAndCustomerID), ctx, id, customerID)
+}
+
+// FindRecipientByCustomerID mocks base method.
+func (m *MockNotificationRepository) FindRecipientByCustomerID(ctx context.Context, customerID int64) (*entity.Recipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRecipientByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].(*entity.Recipient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRecipientByCustomerID indicates an expected call of FindRecipientByCustomerID.
+func (mr *MockNotificationRepositoryMockRecorder) FindRecipientByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecipientByCustomerID", reflect.TypeOf((*MockNotificationRepository)(nil).FindRecipientByCustomerID), ctx, customerID)
+}
+
+// InsertNewInboxMessage mocks base method.
+func (m *MockNotificationRepository) InsertNewInboxMessage(ctx context.Context, data *entity.InboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewInboxMessage", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNewInboxMessage indicates an expected call of InsertNewInboxMessage.
+func (mr *MockNotificationRepositoryMockRecorder) InsertNewInboxMessage(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewInboxMessage", reflect.TypeOf((*MockNotificationRepository)(nil).InsertNewInboxMessage), ctx, data)
+}
+
+// InsertNewLog mocks base method.
+func (m *MockNotificationRepository) InsertNewLog(ctx context.Context, data *entity.Log) (int64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewLog", ctx, data)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// InsertNewLog indicates an expected call of InsertNewLog.
+func (mr *MockNotificationRepositoryMockRecorder) InsertNewLog(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewLog", reflect.TypeOf((*MockNotificationRepository)(nil).InsertNewLog), ctx, data)
+}
+
+// MarkInboxMessageRead mocks base method.
+func (m *MockNotificationRepository) MarkInboxMessageRead(ctx context.Context, id int64, readAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkInboxMessageRead", ctx, id, readAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkInboxMessageRead indicates an expected call of MarkInboxMessageRead.
+func (mr *MockNotificationRepositoryMockRecorder) MarkInboxMessageRead(ctx, id, readAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkInboxMessageRead", reflect.TypeOf((*MockNotificationRepository)(nil).MarkInboxMessageRead), ctx, id, readAt)
+}
+
+// UpdateLogResult mocks base method.
+func (m *MockNotificationRepository) UpdateLogResult(ctx context.Context, data *entity.Log) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLogResult", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLogResult indicates an expected call of UpdateLogResult.
+func (mr *MockNotificationRepositoryMockRecorder) UpdateLogResult(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogResult", reflect.TypeOf((*MockNotificationRepository)(nil).UpdateLogResult), ctx, data)
+}
+
+// UpsertPreference mocks base method.
+func (m *MockNotificationRepository) UpsertPreference(ctx context.Context, data *entity.Preference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPreference", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertPreference indicates an expected call of UpsertPreference.
+func (mr *MockNotificationRepositoryMockRecorder) UpsertPreference(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPreference", reflect.TypeOf((*MockNotificationRepository)(nil).UpsertPreference), ctx, data)
+}
+
+// MockNotificationService is a mock of NotificationService interface.
+type MockNotificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationServiceMockRecorder is the mock recorder for MockNotificationService.
+type MockNotificationServiceMockRecorder struct {
+	mock *MockNotificationService
+}
+
+// NewMockNotificationService creates a new mock instance.
+func NewMockNotificationService(ctrl *gomock.Controller) *MockNotificationService {
+	mock := &MockNotificationService{ctrl: ctrl}
+	mock.recorder = &MockNotificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationService) EXPECT() *MockNotificationServiceMockRecorder {
+	return m.recorder
+}
+
+// GetInbox mocks base method.
+func (m *MockNotificationService) GetInbox(ctx context.Context, req *dto.GetInboxRequest, customerID int64) (*dto.GetInboxResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInbox", ctx, req, customerID)
+	ret0, _ := ret[0].(*dto.GetInboxResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInbox indicates an expected call of GetInbox.
+func (mr *MockNotificationServiceMockRecorder) GetInbox(ctx, req, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockNotificationService)(nil).GetInbox), ctx, req, customerID)
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotificationService) GetPreferences(ctx context.Context, customerID int64) (*dto.PreferencesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, customerID)
+	ret0, _ := ret[0].(*dto.PreferencesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationServiceMockRecorder) GetPreferences(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotificationService)(nil).GetPreferences), ctx, customerID)
+}
+
+// MarkInboxRead mocks base method.
+func (m *MockNotificationService) MarkInboxRead(ctx context.Context, id, customerID int64) (*dto.InboxMessageResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkInboxRead", ctx, id, customerID)
+	ret0, _ := ret[0].(*dto.InboxMessageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkInboxRead indicates an expected call of MarkInboxRead.
+func (mr *MockNotificationServiceMockRecorder) MarkInboxRead(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkInboxRead", reflect.TypeOf((*MockNotificationService)(nil).MarkInboxRead), ctx, id, customerID)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockNotificationService) UpdatePreferences(ctx context.Context, req *dto.UpdatePreferencesRequest, customerID int64) (*dto.PreferencesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", ctx, req, customerID)
+	ret0, _ := ret[0].(*dto.PreferencesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockNotificationServiceMockRecorder) UpdatePreferences(ctx, req, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockNotificationService)(nil).UpdatePreferences), ctx, req, customerID)
+}
+
+// MockEventHandler is a mock of EventHandler interface.
+type MockEventHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockEventHandlerMockRecorder is the mock recorder for MockEventHandler.
+type MockEventHandlerMockRecorder struct {
+	mock *MockEventHandler
+}
+
+// NewMockEventHandler creates a new mock instance.
+func NewMockEventHandler(ctrl *gomock.Controller) *MockEventHandler {
+	mock := &MockEventHandler{ctrl: ctrl}
+	mock.recorder = &MockEventHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventHandler) EXPECT() *MockEventHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleEvent mocks base method.
+func (m *MockEventHandler) HandleEvent(ctx context.Context, event *outbox.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleEvent indicates an expected call of HandleEvent.
+func (mr *MockEventHandlerMockRecorder) HandleEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvent", reflect.TypeOf((*MockEventHandler)(nil).HandleEvent), ctx, event)
+}
+
+// MockReminderService is a mock of ReminderService interface.
+type MockReminderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReminderServiceMockRecorder is the mock recorder for MockReminderService.
+type MockReminderServiceMockRecorder struct {
+	mock *MockReminderService
+}
+
+// NewMockReminderService creates a new mock instance.
+func NewMockReminderService(ctrl *gomock.Controller) *MockReminderService {
+	mock := &MockReminderService{ctrl: ctrl}
+	mock.recorder = &MockReminderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderService) EXPECT() *MockReminderServiceMockRecorder {
+	return m.recorder
+}
+
+// SendDueReminders mocks base method.
+func (m *MockReminderService) SendDueReminders(ctx context.Context, daysAhead int) (*dto.ReminderResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDueReminders", ctx, daysAhead)
+	ret0, _ := ret[0].(*dto.ReminderResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendDueReminders indicates an expected call of SendDueReminders.
+func (mr *MockReminderServiceMockRecorder) SendDueReminders(ctx, daysAhead any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDueReminders", reflect.TypeOf((*MockReminderService)(nil).SendDueReminders), ctx, daysAhead)
+}
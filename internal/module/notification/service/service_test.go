@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/notification/entity"
+	outboxDto "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/notification"
+	"github.com/hilmiikhsan/multifinance-service/pkg/outbox"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testRecipient() *entity.Recipient {
+	return &entity.Recipient{
+		CustomerID:   1,
+		FullName:     "Budi Santoso",
+		Email:        "budi@example.com",
+		PhoneNumber:  sql.NullString{String: "081234567890", Valid: true},
+		Locale:       "id",
+		EmailEnabled: true,
+		SMSEnabled:   true,
+		InAppEnabled: true,
+	}
+}
+
+func bookedEvent(t *testing.T) *outbox.Event {
+	payload, err := json.Marshal(&outboxDto.TransactionBookedV1{
+		CustomerID:        1,
+		ContractNumber:    "KTR-1",
+		AssetName:         "Honda Beat",
+		OnTheRoadPrice:    12000000,
+		InstallmentAmount: 1100000,
+		InterestAmount:    1200000,
+		TenorMonth:        12,
+	})
+	assert.NoError(t, err)
+
+	return &outbox.Event{
+		ID:         "e1",
+		Type:       constants.EventTransactionBooked,
+		OccurredAt: time.Date(2024, 12, 31, 2, 0, 0, 0, time.UTC),
+		Payload:    payload,
+	}
+}
+
+func Test_notificationService_HandleEvent(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockNotificationRepository(ctrlMock)
+
+	tests := []struct {
+		name      string
+		event     func() *outbox.Event
+		sendErr   error
+		wantErr   bool
+		wantEmail int
+		wantSMS   int
+		mockFn    func()
+	}{
+		{
+			name:      "Booking Confirmed On Every Channel",
+			event:     func() *outbox.Event { return bookedEvent(t) },
+			wantEmail: 1,
+			wantSMS:   1,
+			mockFn: func() {
+				mockRepo.EXPECT().FindRecipientByCustomerID(gomock.Any(), int64(1)).Return(testRecipient(), nil)
+				mockRepo.EXPECT().InsertNewLog(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, data *entity.Log) (int64, bool, error) {
+						assert.Equal(t, notification.TemplateBookingConfirmed, data.Template)
+						assert.Equal(t, "KTR-1", data.Reference)
+						assert.Equal(t, constants.NotificationStatusPending, data.Status)
+						return 1, true, nil
+					}).Times(3)
+				mockRepo.EXPECT().InsertNewInboxMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, data *entity.InboxMessage) error {
+						assert.Equal(t, "Pembiayaan telah aktif", data.Subject)
+						assert.Contains(t, data.Body, "31 Januari 2025")
+						return nil
+					})
+				mockRepo.EXPECT().UpdateLogResult(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, data *entity.Log) error {
+						assert.Equal(t, constants.NotificationStatusSent, data.Status)
+						assert.True(t, data.SentAt.Valid)
+						return nil
+					}).Times(3)
+			},
+		},
+		{
+			name:      "Opted Out Of SMS And Email Already Sent",
+			event:     func() *outbox.Event { return bookedEvent(t) },
+			wantEmail: 0,
+			wantSMS:   0,
+			mockFn: func() {
+				recipient := testRecipient()
+				recipient.SMSEnabled = false
+				mockRepo.EXPECT().FindRecipientByCustomerID(gomock.Any(), int64(1)).Return(recipient, nil)
+				mockRepo.EXPECT().InsertNewLog(gomock.Any(), gomock.Any()).Return(int64(0), false, nil).Times(2)
+			},
+		},
+		{
+			name:    "Failed Send Is Logged",
+			event:   func() *outbox.Event { return bookedEvent(t) },
+			sendErr: errors.New("smtp: 421 service not available"),
+			mockFn: func() {
+				recipient := testRecipient()
+				recipient.InAppEnabled = false
+				mockRepo.EXPECT().FindRecipientByCustomerID(gomock.Any(), int64(1)).Return(recipient, nil)
+				mockRepo.EXPECT().InsertNewLog(gomock.Any(), gomock.Any()).Return(int64(1), true, nil).Times(2)
+				mockRepo.EXPECT().UpdateLogResult(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, data *entity.Log) error {
+						assert.Equal(t, constants.NotificationStatusFailed, data.Status)
+						assert.Equal(t, "smtp: 421 service not available", data.ErrorMessage.String)
+						assert.False(t, data.SentAt.Valid)
+						return nil
+					}).Times(2)
+			},
+		},
+		{
+			name:  "Customer No Longer Exists",
+			event: func() *outbox.Event { return bookedEvent(t) },
+			mockFn: func() {
+				mockRepo.EXPECT().FindRecipientByCustomerID(gomock.Any(), int64(1)).
+					Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound)))
+			},
+		},
+		{
+			name:    "Log Error Is Retried",
+			event:   func() *outbox.Event { return bookedEvent(t) },
+			wantErr: true,
+			mockFn: func() {
+				mockRepo.EXPECT().FindRecipientByCustomerID(gomock.Any(), int64(1)).Return(testRecipient(), nil)
+				mockRepo.EXPECT().InsertNewLog(gomock.Any(), gomock.Any()).
+					Return(int64(0), false, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError)))
+			},
+		},
+		{
+			name: "Undecodable Payload Is Dropped",
+			event: func() *outbox.Event {
+				event := bookedEvent(t)
+				event.Payload = json.RawMessage(`"not an object"`)
+				return event
+			},
+			mockFn: func() {},
+		},
+		{
+			name: "Other Event Is Ignored",
+			event: func() *outbox.Event {
+				return &outbox.Event{ID: "e2", Type: constants.EventCustomerRegistered, Payload: json.RawMessage(`{}`)}
+			},
+			mockFn: func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			email, sms := notification.NewMemorySender(), notification.NewMemorySender()
+			email.FailWith(tt.sendErr)
+			sms.FailWith(tt.sendErr)
+
+			s := NewNotificationService(mockRepo, email, sms)
+
+			err := s.HandleEvent(context.Background(), tt.event())
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			// only an error that retrying cannot fix gives the event up
+			var permanent *outbox.PermanentError
+			assert.False(t, errors.As(err, &permanent))
+			assert.Len(t, email.Messages(), tt.wantEmail)
+			assert.Len(t, sms.Messages(), tt.wantSMS)
+			if tt.wantEmail > 0 {
+				assert.Equal(t, "budi@example.com", email.Messages()[0].To)
+				assert.Equal(t, "Pembiayaan KTR-1 telah aktif", email.Messages()[0].Subject)
+			}
+			if tt.wantSMS > 0 {
+				assert.Equal(t, "081234567890", sms.Messages()[0].To)
+			}
+		})
+	}
+}
+
+func Test_notificationService_HandleEvent_WithoutSenders(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockNotificationRepository(ctrlMock)
+	mockRepo.EXPECT().FindRecipientByCustomerID(gomock.Any(), int64(1)).Return(testRecipient(), nil)
+	mockRepo.EXPECT().InsertNewLog(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, data *entity.Log) (int64, bool, error) {
+			assert.Equal(t, notification.ChannelInApp, data.Channel)
+			return 1, true, nil
+		})
+	mockRepo.EXPECT().InsertNewInboxMessage(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().UpdateLogResult(gomock.Any(), gomock.Any()).Return(nil)
+
+	s := NewNotificationService(mockRepo, nil, nil)
+	assert.NoError(t, s.HandleEvent(context.Background(), bookedEvent(t)))
+}
+
+func Test_notificationService_UpdatePreferences(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockNotificationRepository(ctrlMock)
+	off := false
+
+	tests := []struct {
+		name    string
+		req     *dto.UpdatePreferencesRequest
+		want    *dto.PreferencesResponse
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "Only Sent Fields Change",
+			req:  &dto.UpdatePreferencesRequest{Locale: "en", SMS: &off},
+			want: &dto.PreferencesResponse{Locale: "en", Email: true, SMS: false, InApp: true},
+			mockFn: func() {
+				mockRepo.EXPECT().FindRecipientByCustomerID(gomock.Any(), int64(1)).Return(testRecipient(), nil)
+				mockRepo.EXPECT().UpsertPreference(gomock.Any(), &entity.Preference{
+					CustomerID:   1,
+					Locale:       "en",
+					EmailEnabled: true,
+					SMSEnabled:   false,
+					InAppEnabled: true,
+				}).Return(nil)
+			},
+		},
+		{
+			name:    "Customer Not Found",
+			req:     &dto.UpdatePreferencesRequest{Email: &off},
+			wantErr: true,
+			mockFn: func() {
+				mockRepo.EXPECT().FindRecipientByCustomerID(gomock.Any(), int64(1)).
+					Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound)))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			s := NewNotificationService(mockRepo, nil, nil)
+
+			got, err := s.UpdatePreferences(context.Background(), tt.req, 1)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_notificationService_MarkInboxRead(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockNotificationRepository(ctrlMock)
+	now := time.Date(2024, 12, 31, 9, 0, 0, 0, time.UTC)
+	readAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		wantReadAt string
+		wantErr    bool
+		mockFn     func()
+	}{
+		{
+			name:       "Mark Unread Message",
+			wantReadAt: now.Format(constants.DateTimeFormat),
+			mockFn: func() {
+				mockRepo.EXPECT().FindInboxMessageByIDAndCustomerID(gomock.Any(), int64(5), int64(1)).
+					Return(&entity.InboxMessage{ID: 5, CustomerID: 1, Subject: "s", Body: "b", CreatedAt: now}, nil)
+				mockRepo.EXPECT().MarkInboxMessageRead(gomock.Any(), int64(5), now).Return(nil)
+			},
+		},
+		{
+			name:       "Already Read Keeps First Read Time",
+			wantReadAt: readAt.Format(constants.DateTimeFormat),
+			mockFn: func() {
+				mockRepo.EXPECT().FindInboxMessageByIDAndCustomerID(gomock.Any(), int64(5), int64(1)).
+					Return(&entity.InboxMessage{ID: 5, CustomerID: 1, ReadAt: sql.NullTime{Time: readAt, Valid: true}, CreatedAt: now}, nil)
+			},
+		},
+		{
+			name:    "Message Of Another Customer",
+			wantErr: true,
+			mockFn: func() {
+				mockRepo.EXPECT().FindInboxMessageByIDAndCustomerID(gomock.Any(), int64(5), int64(1)).
+					Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrNotificationNotFound)))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			s := NewNotificationService(mockRepo, nil, nil)
+			s.now = func() time.Time { return now }
+
+			got, err := s.MarkInboxRead(context.Background(), 5, 1)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.True(t, got.Read)
+				assert.Equal(t, tt.wantReadAt, got.ReadAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package dto
+
+// Event payloads, one type per event and schema version. A version only ever
+// gains optional fields; renaming or removing a field needs a new version.
+
+type CustomerRegisteredV1 struct {
+	CustomerID   int64  `json:"customer_id"`
+	ReviewStatus string `json:"review_status"`
+}
+
+type CreditLimitV1 struct {
+	TenorMonth  int     `json:"tenor_month"`
+	LimitAmount float64 `json:"limit_amount"`
+}
+
+// CreditLimitsAssignedV1 carries every limit of the customer after the change,
+// not only the limits that moved.
+type CreditLimitsAssignedV1 struct {
+	CustomerID int64           `json:"customer_id"`
+	Trigger    string          `json:"trigger"`
+	Limits     []CreditLimitV1 `json:"limits"`
+}
+
+type TransactionBookedV1 struct {
+	CustomerID        int     `json:"customer_id"`
+	ContractNumber    string  `json:"contract_number"`
+	Channel           string  `json:"channel"`
+	PartnerCode       string  `json:"partner_code,omitempty"`
+	AssetName         string  `json:"asset_name"`
+	OnTheRoadPrice    float64 `json:"on_the_road_price"`
+	AdminFee          float64 `json:"admin_fee"`
+	InstallmentAmount float64 `json:"installment_amount"`
+	InterestAmount    float64 `json:"interest_amount"`
+	TenorMonth        int     `json:"tenor_month"`
+}
+
+// RelayResult counts what one relay batch did with the events it picked up.
+// Deferred events sit behind a failed event of the same aggregate and are
+// retried with it on the next batch.
+type RelayResult struct {
+	Published int `json:"published"`
+	Failed    int `json:"failed"`
+	Deferred  int `json:"deferred"`
+}
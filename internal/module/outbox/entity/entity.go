@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hilmiikhsan/multifinance-service/pkg/outbox"
+)
+
+// OutboxEvent is a domain event waiting to be published. It is written in the
+// same SQL transaction as the change it describes and relayed in id order.
+type OutboxEvent struct {
+	ID            int64          `db:"id"`
+	EventID       string         `db:"event_id"`
+	AggregateType string         `db:"aggregate_type"`
+	AggregateID   string         `db:"aggregate_id"`
+	EventType     string         `db:"event_type"`
+	EventVersion  int            `db:"event_version"`
+	Payload       string         `db:"payload"`
+	OccurredAt    time.Time      `db:"occurred_at"`
+	PublishedAt   sql.NullTime   `db:"published_at"`
+	Attempts      int            `db:"attempts"`
+	LastError     sql.NullString `db:"last_error"`
+}
+
+func NewOutboxEvent(aggregateType, aggregateID, eventType string, eventVersion int, payload any) (*OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxEvent{
+		EventID:       uuid.NewString(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		EventVersion:  eventVersion,
+		Payload:       string(data),
+		OccurredAt:    time.Now().UTC(),
+	}, nil
+}
+
+func (e *OutboxEvent) Event() *outbox.Event {
+	return &outbox.Event{
+		ID:            e.EventID,
+		Type:          e.EventType,
+		Version:       e.EventVersion,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		OccurredAt:    e.OccurredAt,
+		Payload:       json.RawMessage(e.Payload),
+	}
+}
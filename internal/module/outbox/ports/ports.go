@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/module/outbox/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/outbox/entity"
+)
+
+//go:generate mockgen -source=ports.go -destination=../service/service_mock_test.go -package=service
+//go:generate mockgen -source=ports.go -destination=../../auth/service/service_outbox_mock_test.go -package=service
+//go:generate mockgen -source=ports.go -destination=../../transaction/service/service_outbox_mock_test.go -package=service
+type OutboxRepository interface {
+	InsertNewOutboxEvent(ctx context.Context, tx *sql.Tx, data *entity.OutboxEvent) error
+	LockPendingOutboxEvents(ctx context.Context, tx *sql.Tx, limit int) ([]entity.OutboxEvent, error)
+	MarkOutboxEventsPublished(ctx context.Context, tx *sql.Tx, ids []int64) error
+	RecordOutboxEventFailure(ctx context.Context, tx *sql.Tx, id int64, message string) error
+}
+
+type OutboxRelay interface {
+	RelayPendingEvents(ctx context.Context) (*dto.RelayResult, error)
+}
@@ -0,0 +1,50 @@
+package repository
+
+const (
+	queryInsertNewOutboxEvent = `
+		INSERT INTO outbox
+		(
+			event_id,
+			aggregate_type,
+			aggregate_id,
+			event_type,
+			event_version,
+			payload,
+			occurred_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	// FOR UPDATE without SKIP LOCKED on purpose: a second relay waits for the
+	// first one instead of publishing later events of the same aggregate.
+	queryLockPendingOutboxEvents = `
+		SELECT
+			id,
+			event_id,
+			aggregate_type,
+			aggregate_id,
+			event_type,
+			event_version,
+			payload,
+			occurred_at,
+			published_at,
+			attempts,
+			last_error
+		FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY id ASC
+		LIMIT ?
+		FOR UPDATE
+	`
+
+	queryMarkOutboxEventsPublished = `
+		UPDATE outbox
+		SET published_at = CURRENT_TIMESTAMP
+		WHERE id IN (?)
+	`
+
+	queryRecordOutboxEventFailure = `
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = ?
+		WHERE id = ?
+	`
+)
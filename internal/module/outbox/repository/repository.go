@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/module/outbox/entity"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/outbox/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.OutboxRepository = &outboxRepository{}
+
+// last_error keeps the start of the publisher error only
+const maxLastErrorLength = 255
+
+type outboxRepository struct {
+	db *sqlx.DB
+}
+
+func NewOutboxRepository(db *sqlx.DB) *outboxRepository {
+	return &outboxRepository{
+		db: db,
+	}
+}
+
+func (r *outboxRepository) InsertNewOutboxEvent(ctx context.Context, tx *sql.Tx, data *entity.OutboxEvent) error {
+	ctx, span := tracing.Start(ctx, "outboxRepository.InsertNewOutboxEvent")
+	defer span.End()
+
+	_, err := tx.ExecContext(ctx, r.db.Rebind(queryInsertNewOutboxEvent),
+		data.EventID,
+		data.AggregateType,
+		data.AggregateID,
+		data.EventType,
+		data.EventVersion,
+		data.Payload,
+		data.OccurredAt,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", data).Msg("repository::InsertNewOutboxEvent - Failed to insert new outbox event")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *outboxRepository) LockPendingOutboxEvents(ctx context.Context, tx *sql.Tx, limit int) ([]entity.OutboxEvent, error) {
+	ctx, span := tracing.Start(ctx, "outboxRepository.LockPendingOutboxEvents")
+	defer span.End()
+
+	rows, err := tx.QueryContext(ctx, r.db.Rebind(queryLockPendingOutboxEvents), limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("limit", limit).Msg("repository::LockPendingOutboxEvents - Failed to lock pending outbox events")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+	defer rows.Close()
+
+	res := make([]entity.OutboxEvent, 0, limit)
+	if err := sqlx.StructScan(rows, &res); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::LockPendingOutboxEvents - Failed to scan outbox events")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *outboxRepository) MarkOutboxEventsPublished(ctx context.Context, tx *sql.Tx, ids []int64) error {
+	ctx, span := tracing.Start(ctx, "outboxRepository.MarkOutboxEventsPublished")
+	defer span.End()
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query, args, err := sqlx.In(queryMarkOutboxEventsPublished, ids)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::MarkOutboxEventsPublished - Failed to bind ids")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, r.db.Rebind(query), args...); err != nil {
+		log.Ctx(ctx).Error().Err(err).Ints64("ids", ids).Msg("repository::MarkOutboxEventsPublished - Failed to mark outbox events as published")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *outboxRepository) RecordOutboxEventFailure(ctx context.Context, tx *sql.Tx, id int64, message string) error {
+	ctx, span := tracing.Start(ctx, "outboxRepository.RecordOutboxEventFailure")
+	defer span.End()
+
+	if len(message) > maxLastErrorLength {
+		message = message[:maxLastErrorLength]
+	}
+
+	if _, err := tx.ExecContext(ctx, r.db.Rebind(queryRecordOutboxEventFailure), message, id); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("repository::RecordOutboxEventFailure - Failed to record outbox event failure")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/outbox/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect/dialecttest"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_outboxRepository_InsertNewOutboxEvent(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		event, err := entity.NewOutboxEvent("customer", "1", "customer.registered", 1, map[string]any{"customer_id": 1})
+		assert.NoError(t, err)
+
+		tests := []struct {
+			name    string
+			wantErr bool
+			mockFn  func()
+		}{
+			{
+				name: "Insert New Outbox Event Successfully",
+				mockFn: func() {
+					mock.ExpectBegin()
+					mock.ExpectExec("INSERT INTO outbox").WithArgs(
+						event.EventID,
+						"customer",
+						"1",
+						"customer.registered",
+						1,
+						`{"customer_id":1}`,
+						event.OccurredAt,
+					).WillReturnResult(sqlmock.NewResult(1, 1))
+				},
+			},
+			{
+				name:    "Insert New Outbox Event Failed",
+				wantErr: true,
+				mockFn: func() {
+					mock.ExpectBegin()
+					mock.ExpectExec("INSERT INTO outbox").WillReturnError(fmt.Errorf("database error"))
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn()
+
+				r := NewOutboxRepository(db)
+
+				tx, err := db.Begin()
+				assert.NoError(t, err)
+
+				err = r.InsertNewOutboxEvent(context.Background(), tx, event)
+				assert.Equal(t, tt.wantErr, err != nil)
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_outboxRepository_LockPendingOutboxEvents(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		occurredAt := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM outbox WHERE published_at IS NULL ORDER BY id ASC LIMIT (.+) FOR UPDATE").
+			WithArgs(100).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "aggregate_type", "aggregate_id", "event_type", "event_version", "payload", "occurred_at", "published_at", "attempts", "last_error"}).
+				AddRow(1, "e1", "customer", "1", "customer.registered", 1, `{}`, occurredAt, nil, 0, nil).
+				AddRow(2, "e2", "customer", "1", "transaction.booked", 1, `{}`, occurredAt, nil, 2, "broker is down"))
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		got, err := NewOutboxRepository(db).LockPendingOutboxEvents(context.Background(), tx, 100)
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, "e2", got[1].EventID)
+		assert.Equal(t, 2, got[1].Attempts)
+		assert.Equal(t, "broker is down", got[1].LastError.String)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_outboxRepository_MarkOutboxEventsPublished(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE outbox SET published_at = CURRENT_TIMESTAMP WHERE id IN \(\?, \?\)`).
+			WithArgs(1, 3).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		r := NewOutboxRepository(db)
+		assert.NoError(t, r.MarkOutboxEventsPublished(context.Background(), tx, []int64{1, 3}))
+		assert.NoError(t, r.MarkOutboxEventsPublished(context.Background(), tx, nil))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
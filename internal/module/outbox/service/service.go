@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/outbox/dto"
+	outboxPorts "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/outbox"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ outboxPorts.OutboxRelay = &relayService{}
+
+type relayService struct {
+	db               *sqlx.DB
+	outboxRepository outboxPorts.OutboxRepository
+	publisher        outbox.Publisher
+	batchSize        int
+}
+
+func NewRelayService(db *sqlx.DB, outboxRepository outboxPorts.OutboxRepository, publisher outbox.Publisher, batchSize int) *relayService {
+	return &relayService{
+		db:               db,
+		outboxRepository: outboxRepository,
+		publisher:        publisher,
+		batchSize:        batchSize,
+	}
+}
+
+// RelayPendingEvents publishes the oldest pending events while holding their
+// rows locked. An event is only marked published after the publisher accepted
+// it, so a crash in between publishes it again. Once an event of an aggregate
+// fails, the later events of that aggregate wait for the next batch so
+// consumers never see them out of order.
+func (s *relayService) RelayPendingEvents(ctx context.Context) (*dto.RelayResult, error) {
+	ctx, span := tracing.Start(ctx, "relayService.RelayPendingEvents")
+	defer span.End()
+
+	// read committed takes no gap locks, so bookings keep inserting new events
+	// while the relay holds the pending rows
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::RelayPendingEvents - Failed to begin transaction")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Ctx(ctx).Error().Err(rollbackErr).Msg("service::RelayPendingEvents - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	events, err := s.outboxRepository.LockPendingOutboxEvents(ctx, tx, s.batchSize)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::RelayPendingEvents - Failed to lock pending events")
+		return nil, err
+	}
+
+	var (
+		res       = new(dto.RelayResult)
+		published = make([]int64, 0, len(events))
+		blocked   = make(map[string]bool)
+	)
+
+	for i := range events {
+		event := &events[i]
+		aggregate := event.AggregateType + ":" + event.AggregateID
+
+		if blocked[aggregate] {
+			res.Deferred++
+			continue
+		}
+
+		if publishErr := s.publisher.Publish(ctx, event.Event()); publishErr != nil {
+			log.Ctx(ctx).Error().
+				Err(publishErr).
+				Str("event_id", event.EventID).
+				Str("aggregate", aggregate).
+				Int("attempts", event.Attempts+1).
+				Msg("service::RelayPendingEvents - Failed to publish event")
+
+			blocked[aggregate] = true
+			res.Failed++
+
+			err = s.outboxRepository.RecordOutboxEventFailure(ctx, tx, event.ID, publishErr.Error())
+			if err != nil {
+				log.Ctx(ctx).Error().Err(err).Int64("id", event.ID).Msg("service::RelayPendingEvents - Failed to record event failure")
+				return nil, err
+			}
+			continue
+		}
+
+		published = append(published, event.ID)
+	}
+
+	err = s.outboxRepository.MarkOutboxEventsPublished(ctx, tx, published)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::RelayPendingEvents - Failed to mark events as published")
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::RelayPendingEvents - Failed to commit transaction")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	res.Published = len(published)
+	return res, nil
+}
+
+// Run relays batches until ctx is done. A full batch that went through is
+// followed by the next one straight away, otherwise the relay waits interval.
+// Each batch is its own trace, Run starts no span that would outlive them.
+func (s *relayService) Run(ctx context.Context, interval time.Duration) {
+	for {
+		res, err := s.RelayPendingEvents(ctx)
+
+		wait := interval
+		if err == nil && res.Published == s.batchSize {
+			wait = 0
+		}
+
+		if err == nil && res.Published+res.Failed > 0 {
+			log.Ctx(ctx).Info().Any("result", res).Msg("service::Run - Relayed outbox events")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/outbox/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/outbox/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/outbox"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_relayService_RelayPendingEvents(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockOutboxRepo := NewMockOutboxRepository(ctrlMock)
+
+	pending := []entity.OutboxEvent{
+		{ID: 1, EventID: "e1", AggregateType: "customer", AggregateID: "1", EventType: "customer.registered", EventVersion: 1, Payload: `{}`},
+		{ID: 2, EventID: "e2", AggregateType: "customer", AggregateID: "2", EventType: "customer.registered", EventVersion: 1, Payload: `{}`},
+		{ID: 3, EventID: "e3", AggregateType: "customer", AggregateID: "1", EventType: "transaction.booked", EventVersion: 1, Payload: `{}`},
+		{ID: 4, EventID: "e4", AggregateType: "customer", AggregateID: "2", EventType: "transaction.booked", EventVersion: 1, Payload: `{}`},
+	}
+	brokerDown := errors.New("broker is down")
+
+	tests := []struct {
+		name          string
+		fail          func(event *outbox.Event) error
+		mockFn        func()
+		want          *dto.RelayResult
+		wantPublished []string
+		wantErr       bool
+	}{
+		{
+			name: "Every Event Is Published In Order",
+			mockFn: func() {
+				mock.ExpectBegin()
+				mockOutboxRepo.EXPECT().LockPendingOutboxEvents(gomock.Any(), gomock.Any(), 10).Return(pending, nil)
+				mockOutboxRepo.EXPECT().MarkOutboxEventsPublished(gomock.Any(), gomock.Any(), []int64{1, 2, 3, 4}).Return(nil)
+				mock.ExpectCommit()
+			},
+			want:          &dto.RelayResult{Published: 4},
+			wantPublished: []string{"e1", "e2", "e3", "e4"},
+		},
+		{
+			name: "Failed Event Holds Back Its Aggregate Only",
+			fail: func(event *outbox.Event) error {
+				if event.ID == "e2" {
+					return brokerDown
+				}
+				return nil
+			},
+			mockFn: func() {
+				mock.ExpectBegin()
+				mockOutboxRepo.EXPECT().LockPendingOutboxEvents(gomock.Any(), gomock.Any(), 10).Return(pending, nil)
+				mockOutboxRepo.EXPECT().RecordOutboxEventFailure(gomock.Any(), gomock.Any(), int64(2), brokerDown.Error()).Return(nil)
+				mockOutboxRepo.EXPECT().MarkOutboxEventsPublished(gomock.Any(), gomock.Any(), []int64{1, 3}).Return(nil)
+				mock.ExpectCommit()
+			},
+			want:          &dto.RelayResult{Published: 2, Failed: 1, Deferred: 1},
+			wantPublished: []string{"e1", "e3"},
+		},
+		{
+			name: "Nothing Pending",
+			mockFn: func() {
+				mock.ExpectBegin()
+				mockOutboxRepo.EXPECT().LockPendingOutboxEvents(gomock.Any(), gomock.Any(), 10).Return([]entity.OutboxEvent{}, nil)
+				mockOutboxRepo.EXPECT().MarkOutboxEventsPublished(gomock.Any(), gomock.Any(), []int64{}).Return(nil)
+				mock.ExpectCommit()
+			},
+			want: &dto.RelayResult{},
+		},
+		{
+			name: "Failed Mark Rolls Back So The Events Are Published Again",
+			mockFn: func() {
+				mock.ExpectBegin()
+				mockOutboxRepo.EXPECT().LockPendingOutboxEvents(gomock.Any(), gomock.Any(), 10).Return(pending[:1], nil)
+				mockOutboxRepo.EXPECT().MarkOutboxEventsPublished(gomock.Any(), gomock.Any(), []int64{1}).Return(errors.New("connection lost"))
+				mock.ExpectRollback()
+			},
+			wantPublished: []string{"e1"},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			publisher := outbox.NewMemoryPublisher()
+			publisher.FailWith(tt.fail)
+
+			s := NewRelayService(sqlx.NewDb(db, "mysql"), mockOutboxRepo, publisher, 10)
+
+			got, err := s.RelayPendingEvents(context.Background())
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+
+			var ids []string
+			for _, event := range publisher.Events() {
+				ids = append(ids, event.ID)
+			}
+			assert.Equal(t, tt.wantPublished, ids)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
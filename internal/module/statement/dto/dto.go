@@ -0,0 +1,74 @@
+package dto
+
+import "github.com/hilmiikhsan/multifinance-service/pkg/types"
+
+type GetStatementsRequest struct {
+	Page     int `query:"page" validate:"required,min=1"`
+	Paginate int `query:"paginate" validate:"required,min=1,max=100"`
+}
+
+func (r *GetStatementsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 12
+	}
+}
+
+type GetStatementRequest struct {
+	Format string `query:"format" validate:"omitempty,oneof=json pdf"`
+}
+
+func (r *GetStatementRequest) SetDefault() {
+	if r.Format == "" {
+		r.Format = "json"
+	}
+}
+
+type StatementLineResponse struct {
+	LineType       string  `json:"line_type"`
+	TransactionID  int     `json:"transaction_id"`
+	ContractNumber string  `json:"contract_number"`
+	Amount         float64 `json:"amount"`
+	OccurredAt     string  `json:"occurred_at"`
+}
+
+type StatementResponse struct {
+	Period         string                  `json:"period"`
+	OpeningBalance float64                 `json:"opening_balance"`
+	NewBookings    float64                 `json:"new_bookings"`
+	Payments       float64                 `json:"payments"`
+	Fees           float64                 `json:"fees"`
+	ClosingBalance float64                 `json:"closing_balance"`
+	IssuedAt       string                  `json:"issued_at"`
+	Lines          []StatementLineResponse `json:"lines,omitempty"`
+}
+
+type GetStatementsResponse struct {
+	Items []StatementResponse `json:"items"`
+	Meta  types.Meta          `json:"meta"`
+}
+
+type StatementFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// GenerateStatementsRequest issues the statements of one closed month, for a
+// single customer or, when CustomerID is zero, for every customer with a
+// transaction booked before the end of the month.
+type GenerateStatementsRequest struct {
+	Period     string
+	CustomerID int
+}
+
+type GenerateStatementsResponse struct {
+	Period  string `json:"period"`
+	Created int    `json:"created"`
+	Existed int    `json:"existed"`
+	Skipped int    `json:"skipped"`
+	Failed  int    `json:"failed"`
+}
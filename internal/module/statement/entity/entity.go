@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// Statement is an issued monthly statement. Rows are only ever inserted, so
+// the figures a customer once received never change. Lines holds the JSON
+// encoded []StatementLine.
+type Statement struct {
+	ID             int64     `db:"id"`
+	CustomerID     int       `db:"customer_id"`
+	Period         string    `db:"period"`
+	OpeningBalance float64   `db:"opening_balance"`
+	NewBookings    float64   `db:"new_bookings"`
+	Payments       float64   `db:"payments"`
+	Fees           float64   `db:"fees"`
+	ClosingBalance float64   `db:"closing_balance"`
+	Lines          string    `db:"statement_lines"`
+	IssuedAt       time.Time `db:"issued_at"`
+}
+
+type StatementLine struct {
+	LineType       string    `json:"line_type"`
+	TransactionID  int       `json:"transaction_id"`
+	ContractNumber string    `json:"contract_number"`
+	Amount         float64   `json:"amount"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+// StatementActivity is a booking or a payment inside a statement period. Fee
+// is the admin fee of a booking and zero for a payment.
+type StatementActivity struct {
+	ActivityType   string    `db:"activity_type"`
+	TransactionID  int       `db:"transaction_id"`
+	ContractNumber string    `db:"contract_number"`
+	Amount         float64   `db:"amount"`
+	Fee            float64   `db:"fee"`
+	OccurredAt     time.Time `db:"occurred_at"`
+}
@@ -0,0 +1,134 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	redisRepository "github.com/hilmiikhsan/multifinance-service/internal/infrastructure/redis"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	customerRepository "github.com/hilmiikhsan/multifinance-service/internal/module/customer/repository"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/ports"
+	statementRepository "github.com/hilmiikhsan/multifinance-service/internal/module/statement/repository"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/service"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	jwtHandler "github.com/hilmiikhsan/multifinance-service/pkg/jwt_handler"
+	"github.com/hilmiikhsan/multifinance-service/pkg/response"
+	"github.com/rs/zerolog/log"
+)
+
+type statementHandler struct {
+	service    ports.StatementService
+	middleware middleware.AuthMiddleware
+	validator  adapter.Validator
+}
+
+func NewStatementHandler() *statementHandler {
+	var handler = new(statementHandler)
+
+	// validator
+	validator := adapter.Adapters.Validator
+
+	// redis
+	redisRepository := redisRepository.NewRedisRepository(adapter.Adapters.MultifinanceRedis)
+
+	// jwt
+	jwt := jwtHandler.NewJWT(redisRepository)
+
+	// middleware
+	middlewareHandler := middleware.NewAuthMiddleware(jwt)
+
+	// repository
+	statementRepository := statementRepository.NewStatementRepository(adapter.Adapters.MultifinanceDB)
+	customerRepository := customerRepository.NewCustomerRepository(adapter.Adapters.MultifinanceDB)
+
+	// service
+	statementService := service.NewStatementService(statementRepository, customerRepository)
+
+	// handler
+	handler.service = statementService
+	handler.middleware = *middlewareHandler
+	handler.validator = validator
+
+	return handler
+}
+
+func (h *statementHandler) StatementRoute(router fiber.Router) {
+	router.Get("/", h.middleware.AuthBearer, h.getStatements)
+	router.Get("/:period", h.middleware.AuthBearer, h.getStatement)
+}
+
+func (h *statementHandler) getStatements(c *fiber.Ctx) error {
+	var (
+		ctx    = c.UserContext()
+		req    = new(dto.GetStatementsRequest)
+		locals = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getStatements - Failed to parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := h.validator.Validate(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getStatements - Invalid request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	res, err := h.service.GetStatements(ctx, req, locals.GetCustomerID())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", locals.GetCustomerID()).Msg("handler::getStatements - Failed to get statements")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
+
+// getStatement answers with JSON by default and with the PDF when asked for
+// format=pdf.
+func (h *statementHandler) getStatement(c *fiber.Ctx) error {
+	var (
+		ctx    = c.UserContext()
+		req    = new(dto.GetStatementRequest)
+		locals = middleware.GetLocals(c)
+		period = c.Params("period")
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getStatement - Failed to parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := h.validator.Validate(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getStatement - Invalid request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if req.Format == "pdf" {
+		file, err := h.service.GetStatementPDF(ctx, period, locals.GetCustomerID())
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Int("customer_id", locals.GetCustomerID()).Str("period", period).Msg("handler::getStatement - Failed to get statement pdf")
+			code, errs := err_msg.Errors[error](err)
+			return c.Status(code).JSON(response.Error(errs))
+		}
+
+		c.Set(fiber.HeaderContentType, file.ContentType)
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, file.FileName))
+		return c.Status(fiber.StatusOK).Send(file.Content)
+	}
+
+	res, err := h.service.GetStatement(ctx, period, locals.GetCustomerID())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", locals.GetCustomerID()).Str("period", period).Msg("handler::getStatement - Failed to get statement")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
@@ -0,0 +1,102 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_statementHandler_getStatement(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockStatementService(ctrlMock)
+	mockValidator := NewMockValidator(ctrlMock)
+
+	tests := []struct {
+		name                string
+		path                string
+		mockFn              func()
+		expectedStatus      int
+		expectedContentType string
+	}{
+		{
+			name: "JSON By Default",
+			path: "/2024-12",
+			mockFn: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockSvc.EXPECT().GetStatement(gomock.Any(), "2024-12", 1).Return(&dto.StatementResponse{Period: "2024-12"}, nil)
+			},
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: fiber.MIMEApplicationJSON,
+		},
+		{
+			name: "PDF",
+			path: "/2024-12?format=pdf",
+			mockFn: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockSvc.EXPECT().GetStatementPDF(gomock.Any(), "2024-12", 1).Return(&dto.StatementFile{
+					FileName:    "statement-2024-12.pdf",
+					ContentType: "application/pdf",
+					Content:     []byte("%PDF-1.3"),
+				}, nil)
+			},
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "application/pdf",
+		},
+		{
+			name: "Month Has Not Ended",
+			path: "/2099-01",
+			mockFn: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockSvc.EXPECT().GetStatement(gomock.Any(), "2099-01", 1).
+					Return(nil, err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrStatementPeriodNotClosed)))
+			},
+			expectedStatus:      fiber.StatusBadRequest,
+			expectedContentType: fiber.MIMEApplicationJSON,
+		},
+		{
+			name: "Statement Not Found",
+			path: "/2020-01?format=pdf",
+			mockFn: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockSvc.EXPECT().GetStatementPDF(gomock.Any(), "2020-01", 1).
+					Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrStatementNotFound)))
+			},
+			expectedStatus:      fiber.StatusNotFound,
+			expectedContentType: fiber.MIMEApplicationJSON,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			handler := &statementHandler{
+				service:   mockSvc,
+				validator: mockValidator,
+			}
+
+			app.Get("/:period", func(c *fiber.Ctx) error {
+				c.Locals("customer_id", 1)
+				return handler.getStatement(c)
+			})
+
+			tt.mockFn()
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode, "Unexpected status code")
+			assert.Equal(t, tt.expectedContentType, resp.Header.Get(fiber.HeaderContentType))
+
+			if tt.expectedContentType == "application/pdf" {
+				assert.Contains(t, resp.Header.Get(fiber.HeaderContentDisposition), "statement-2024-12.pdf")
+			}
+		})
+	}
+}
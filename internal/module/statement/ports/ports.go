@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/entity"
+)
+
+//go:generate mockgen -source=ports.go -destination=../service/service_mock_test.go -package=service
+type StatementRepository interface {
+	FindStatementByCustomerIDAndPeriod(ctx context.Context, customerID int, period string) (*entity.Statement, error)
+	FindStatementsByCustomerID(ctx context.Context, req *dto.GetStatementsRequest, customerID int) ([]entity.Statement, int, error)
+	FindOutstandingBalance(ctx context.Context, customerID int, before time.Time) (float64, error)
+	FindStatementActivities(ctx context.Context, customerID int, start, end time.Time) ([]entity.StatementActivity, error)
+	FindCustomerIDsWithTransactionsBefore(ctx context.Context, before time.Time) ([]int, error)
+	InsertNewStatement(ctx context.Context, data *entity.Statement) (bool, error)
+}
+
+//go:generate mockgen -source=ports.go -destination=../handler/rest/handler_mock_test.go -package=rest
+type StatementService interface {
+	GetStatements(ctx context.Context, req *dto.GetStatementsRequest, customerID int) (*dto.GetStatementsResponse, error)
+	GetStatement(ctx context.Context, period string, customerID int) (*dto.StatementResponse, error)
+	GetStatementPDF(ctx context.Context, period string, customerID int) (*dto.StatementFile, error)
+}
+
+// StatementGenerator issues statements outside of a customer request, such as
+// a backfill from the command line.
+type StatementGenerator interface {
+	GenerateStatements(ctx context.Context, req *dto.GenerateStatementsRequest) (*dto.GenerateStatementsResponse, error)
+}
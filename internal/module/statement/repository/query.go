@@ -0,0 +1,133 @@
+package repository
+
+import "github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+
+const (
+	queryFindStatementByCustomerIDAndPeriod = `
+		SELECT
+			id,
+			customer_id,
+			period,
+			opening_balance,
+			new_bookings,
+			payments,
+			fees,
+			closing_balance,
+			statement_lines,
+			issued_at
+		FROM customer_statements
+		WHERE customer_id = ? AND period = ?
+	`
+
+	queryFindStatementsByCustomerID = `
+		SELECT
+			id,
+			customer_id,
+			period,
+			opening_balance,
+			new_bookings,
+			payments,
+			fees,
+			closing_balance,
+			issued_at
+		FROM customer_statements
+		WHERE customer_id = :customer_id
+		ORDER BY period DESC
+		LIMIT :limit OFFSET :offset
+	`
+
+	queryCountStatementsByCustomerID = `
+		SELECT COUNT(*) AS total_data
+		FROM customer_statements
+		WHERE customer_id = :customer_id
+	`
+
+	// everything charged on transactions that were not cancelled, less what
+	// has been paid towards them
+	queryFindOutstandingBalance = `
+		SELECT
+			(
+				SELECT COALESCE(SUM(on_the_road_price + interest_amount + admin_fee), 0)
+				FROM transactions
+				WHERE customer_id = :customer_id AND status <> :cancelled AND created_at < :before
+			) - (
+				SELECT COALESCE(SUM(p.amount), 0)
+				FROM transaction_payments p
+				JOIN transactions t ON t.id = p.transaction_id
+				WHERE t.customer_id = :customer_id AND t.status <> :cancelled AND p.paid_at < :before
+			) AS outstanding_balance
+	`
+
+	queryFindCustomerIDsWithTransactionsBefore = `
+		SELECT DISTINCT customer_id
+		FROM transactions
+		WHERE created_at < ?
+		ORDER BY customer_id ASC
+	`
+
+	queryInsertNewStatement = `
+		INSERT INTO customer_statements
+		(
+			customer_id,
+			period,
+			opening_balance,
+			new_bookings,
+			payments,
+			fees,
+			closing_balance,
+			statement_lines,
+			issued_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+)
+
+var (
+	queryFindStatementActivities = dialect.Query{
+		MySQL: `
+			SELECT
+				:booking AS activity_type,
+				id AS transaction_id,
+				contract_number,
+				on_the_road_price + interest_amount AS amount,
+				admin_fee AS fee,
+				created_at AS occurred_at
+			FROM transactions
+			WHERE customer_id = :customer_id AND status <> :cancelled AND created_at >= :start AND created_at < :end
+			UNION ALL
+			SELECT
+				:payment AS activity_type,
+				p.transaction_id,
+				t.contract_number,
+				p.amount,
+				0 AS fee,
+				p.paid_at AS occurred_at
+			FROM transaction_payments p
+			JOIN transactions t ON t.id = p.transaction_id
+			WHERE t.customer_id = :customer_id AND t.status <> :cancelled AND p.paid_at >= :start AND p.paid_at < :end
+			ORDER BY occurred_at ASC, transaction_id ASC
+		`,
+		Postgres: `
+			SELECT
+				CAST(:booking AS VARCHAR(20)) AS activity_type,
+				id AS transaction_id,
+				contract_number,
+				on_the_road_price + interest_amount AS amount,
+				admin_fee AS fee,
+				created_at AS occurred_at
+			FROM transactions
+			WHERE customer_id = :customer_id AND status <> :cancelled AND created_at >= :start AND created_at < :end
+			UNION ALL
+			SELECT
+				CAST(:payment AS VARCHAR(20)) AS activity_type,
+				p.transaction_id,
+				t.contract_number,
+				p.amount,
+				0 AS fee,
+				p.paid_at AS occurred_at
+			FROM transaction_payments p
+			JOIN transactions t ON t.id = p.transaction_id
+			WHERE t.customer_id = :customer_id AND t.status <> :cancelled AND p.paid_at >= :start AND p.paid_at < :end
+			ORDER BY occurred_at ASC, transaction_id ASC
+		`,
+	}
+)
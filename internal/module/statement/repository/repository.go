@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/entity"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.StatementRepository = &statementRepository{}
+
+type statementRepository struct {
+	db *sqlx.DB
+}
+
+func NewStatementRepository(db *sqlx.DB) *statementRepository {
+	return &statementRepository{
+		db: db,
+	}
+}
+
+func (r *statementRepository) FindStatementByCustomerIDAndPeriod(ctx context.Context, customerID int, period string) (*entity.Statement, error) {
+	ctx, span := tracing.Start(ctx, "statementRepository.FindStatementByCustomerIDAndPeriod")
+	defer span.End()
+
+	var res = new(entity.Statement)
+
+	err := r.db.GetContext(ctx, res, r.db.Rebind(queryFindStatementByCustomerIDAndPeriod), customerID, period)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrStatementNotFound))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Str("period", period).Msg("repository::FindStatementByCustomerIDAndPeriod - Failed to find statement")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *statementRepository) FindStatementsByCustomerID(ctx context.Context, req *dto.GetStatementsRequest, customerID int) ([]entity.Statement, int, error) {
+	ctx, span := tracing.Start(ctx, "statementRepository.FindStatementsByCustomerID")
+	defer span.End()
+
+	var (
+		data      = make([]entity.Statement, 0, req.Paginate)
+		totalData int
+	)
+
+	countQuery, countArgs, err := sqlx.Named(queryCountStatementsByCustomerID, map[string]interface{}{
+		"customer_id": customerID,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindStatementsByCustomerID - Failed to bind named query for count")
+		return nil, 0, err
+	}
+
+	err = r.db.GetContext(ctx, &totalData, r.db.Rebind(countQuery), countArgs...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindStatementsByCustomerID - Failed to count statements")
+		return nil, 0, err
+	}
+
+	query, args, err := sqlx.Named(queryFindStatementsByCustomerID, map[string]interface{}{
+		"customer_id": customerID,
+		"limit":       req.Paginate,
+		"offset":      req.Paginate * (req.Page - 1),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindStatementsByCustomerID - Failed to bind named query")
+		return nil, 0, err
+	}
+
+	err = r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("repository::FindStatementsByCustomerID - Failed to find statements")
+		return nil, 0, err
+	}
+
+	return data, totalData, nil
+}
+
+func (r *statementRepository) FindOutstandingBalance(ctx context.Context, customerID int, before time.Time) (float64, error) {
+	ctx, span := tracing.Start(ctx, "statementRepository.FindOutstandingBalance")
+	defer span.End()
+
+	var res float64
+
+	query, args, err := sqlx.Named(queryFindOutstandingBalance, map[string]interface{}{
+		"customer_id": customerID,
+		"cancelled":   constants.TransactionStatusCancelled,
+		"before":      before,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindOutstandingBalance - Failed to bind named query")
+		return 0, err
+	}
+
+	err = r.db.GetContext(ctx, &res, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Time("before", before).Msg("repository::FindOutstandingBalance - Failed to find outstanding balance")
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (r *statementRepository) FindStatementActivities(ctx context.Context, customerID int, start, end time.Time) ([]entity.StatementActivity, error) {
+	ctx, span := tracing.Start(ctx, "statementRepository.FindStatementActivities")
+	defer span.End()
+
+	var res = make([]entity.StatementActivity, 0)
+
+	query, args, err := sqlx.Named(queryFindStatementActivities.For(r.db.DriverName()), map[string]interface{}{
+		"customer_id": customerID,
+		"cancelled":   constants.TransactionStatusCancelled,
+		"booking":     constants.StatementLineBooking,
+		"payment":     constants.StatementLinePayment,
+		"start":       start,
+		"end":         end,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindStatementActivities - Failed to bind named query")
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &res, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Time("start", start).Time("end", end).Msg("repository::FindStatementActivities - Failed to find statement activities")
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *statementRepository) FindCustomerIDsWithTransactionsBefore(ctx context.Context, before time.Time) ([]int, error) {
+	ctx, span := tracing.Start(ctx, "statementRepository.FindCustomerIDsWithTransactionsBefore")
+	defer span.End()
+
+	var res = make([]int, 0)
+
+	err := r.db.SelectContext(ctx, &res, r.db.Rebind(queryFindCustomerIDsWithTransactionsBefore), before)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Time("before", before).Msg("repository::FindCustomerIDsWithTransactionsBefore - Failed to find customer ids")
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// InsertNewStatement reports false when the statement of that customer and
+// period had already been issued; the existing row is left untouched.
+func (r *statementRepository) InsertNewStatement(ctx context.Context, data *entity.Statement) (bool, error) {
+	ctx, span := tracing.Start(ctx, "statementRepository.InsertNewStatement")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(queryInsertNewStatement),
+		data.CustomerID,
+		data.Period,
+		data.OpeningBalance,
+		data.NewBookings,
+		data.Payments,
+		data.Fees,
+		data.ClosingBalance,
+		data.Lines,
+		data.IssuedAt,
+	)
+	if err != nil {
+		if err_msg.IsDuplicateEntry(err) {
+			log.Ctx(ctx).Warn().Int("customer_id", data.CustomerID).Str("period", data.Period).Msg("repository::InsertNewStatement - Statement already issued")
+			return false, nil
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", data.CustomerID).Str("period", data.Period).Msg("repository::InsertNewStatement - Failed to insert statement")
+		return false, err_msg.NewDatabaseErrors(err)
+	}
+
+	return true, nil
+}
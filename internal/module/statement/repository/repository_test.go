@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect/dialecttest"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_statementRepository_FindStatementByCustomerIDAndPeriod(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		repo := NewStatementRepository(db)
+		issuedAt := time.Date(2024, 12, 1, 0, 0, 5, 0, time.UTC)
+
+		tests := []struct {
+			name     string
+			mockFn   func()
+			wantCode int
+		}{
+			{
+				name: "Statement Found",
+				mockFn: func() {
+					mock.ExpectQuery("SELECT (.+) FROM customer_statements WHERE customer_id = \\? AND period = \\?").
+						WithArgs(1, "2024-11").
+						WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "period", "opening_balance", "new_bookings", "payments", "fees", "closing_balance", "statement_lines", "issued_at"}).
+							AddRow(3, 1, "2024-11", 0, 33600000, 0, 600000, 34200000, "[]", issuedAt))
+				},
+			},
+			{
+				name: "Statement Not Found",
+				mockFn: func() {
+					mock.ExpectQuery("SELECT (.+) FROM customer_statements").
+						WithArgs(1, "2024-11").
+						WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				},
+				wantCode: fiber.StatusNotFound,
+			},
+			{
+				name: "Database Error",
+				mockFn: func() {
+					mock.ExpectQuery("SELECT (.+) FROM customer_statements").
+						WithArgs(1, "2024-11").
+						WillReturnError(errors.New("connection refused"))
+				},
+				wantCode: fiber.StatusInternalServerError,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn()
+
+				got, err := repo.FindStatementByCustomerIDAndPeriod(context.Background(), 1, "2024-11")
+				if tt.wantCode != 0 {
+					assert.Equal(t, tt.wantCode, err.(*err_msg.CustomError).Code)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, float64(34200000), got.ClosingBalance)
+					assert.Equal(t, issuedAt, got.IssuedAt)
+				}
+
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_statementRepository_FindStatementActivities(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		repo := NewStatementRepository(db)
+
+		var (
+			start  = time.Date(2024, 11, 30, 17, 0, 0, 0, time.UTC)
+			end    = time.Date(2024, 12, 31, 17, 0, 0, 0, time.UTC)
+			booked = time.Date(2024, 12, 2, 3, 0, 0, 0, time.UTC)
+			paid   = time.Date(2024, 12, 20, 3, 0, 0, 0, time.UTC)
+		)
+
+		// the named parameters are expanded in the order they appear in the query
+		mock.ExpectQuery("SELECT (.+) FROM transactions (.+) UNION ALL (.+) FROM transaction_payments").
+			WithArgs(
+				constants.StatementLineBooking, 1, constants.TransactionStatusCancelled, start, end,
+				constants.StatementLinePayment, 1, constants.TransactionStatusCancelled, start, end,
+			).
+			WillReturnRows(sqlmock.NewRows([]string{"activity_type", "transaction_id", "contract_number", "amount", "fee", "occurred_at"}).
+				AddRow(constants.StatementLineBooking, 5, "TRX202412020001", 33600000, 600000, booked).
+				AddRow(constants.StatementLinePayment, 5, "TRX202412020001", 2800000, 0, paid))
+
+		got, err := repo.FindStatementActivities(context.Background(), 1, start, end)
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.StatementActivity{
+			{ActivityType: constants.StatementLineBooking, TransactionID: 5, ContractNumber: "TRX202412020001", Amount: 33600000, Fee: 600000, OccurredAt: booked},
+			{ActivityType: constants.StatementLinePayment, TransactionID: 5, ContractNumber: "TRX202412020001", Amount: 2800000, OccurredAt: paid},
+		}, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_statementRepository_InsertNewStatement(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		repo := NewStatementRepository(db)
+
+		data := &entity.Statement{
+			CustomerID:     1,
+			Period:         "2024-12",
+			NewBookings:    33600000,
+			Fees:           600000,
+			Payments:       2800000,
+			ClosingBalance: 31400000,
+			Lines:          "[]",
+			IssuedAt:       time.Date(2025, 1, 1, 0, 0, 5, 0, time.UTC),
+		}
+
+		tests := []struct {
+			name     string
+			mockFn   func()
+			want     bool
+			wantCode int
+		}{
+			{
+				name: "Insert Statement Successfully",
+				mockFn: func() {
+					mock.ExpectExec("INSERT INTO customer_statements").
+						WithArgs(data.CustomerID, data.Period, data.OpeningBalance, data.NewBookings, data.Payments, data.Fees, data.ClosingBalance, data.Lines, data.IssuedAt).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+				},
+				want: true,
+			},
+			{
+				name: "Statement Already Issued",
+				mockFn: func() {
+					mock.ExpectExec("INSERT INTO customer_statements").
+						WillReturnError(dialecttest.DuplicateKey(db, "customer_statements", "uq_customer_statements_customer_id_period", "1-2024-11"))
+				},
+				want: false,
+			},
+			{
+				name: "Database Error",
+				mockFn: func() {
+					mock.ExpectExec("INSERT INTO customer_statements").
+						WillReturnError(errors.New("connection refused"))
+				},
+				wantCode: fiber.StatusInternalServerError,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn()
+
+				got, err := repo.InsertNewStatement(context.Background(), data)
+				if tt.wantCode != 0 {
+					assert.Equal(t, tt.wantCode, err.(*err_msg.CustomError).Code)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, tt.want, got)
+				}
+
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
@@ -0,0 +1,342 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	customerPorts "github.com/hilmiikhsan/multifinance-service/internal/module/customer/ports"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/entity"
+	statementPorts "github.com/hilmiikhsan/multifinance-service/internal/module/statement/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/document"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/export"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	_ statementPorts.StatementService   = &statementService{}
+	_ statementPorts.StatementGenerator = &statementService{}
+)
+
+// statement months follow the customer's calendar, which is Jakarta time
+var statementLocation = export.LookupLocale(export.LocaleID).Location
+
+type statementService struct {
+	statementRepository statementPorts.StatementRepository
+	customerRepository  customerPorts.CustomerRepository
+	now                 func() time.Time
+}
+
+func NewStatementService(statementRepository statementPorts.StatementRepository, customerRepository customerPorts.CustomerRepository) *statementService {
+	return &statementService{
+		statementRepository: statementRepository,
+		customerRepository:  customerRepository,
+		now:                 time.Now,
+	}
+}
+
+func (s *statementService) GetStatements(ctx context.Context, req *dto.GetStatementsRequest, customerID int) (*dto.GetStatementsResponse, error) {
+	ctx, span := tracing.Start(ctx, "statementService.GetStatements")
+	defer span.End()
+
+	statements, totalData, err := s.statementRepository.FindStatementsByCustomerID(ctx, req, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Any("payload", req).Msg("service::GetStatements - Failed to find statements")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	res := &dto.GetStatementsResponse{
+		Items: make([]dto.StatementResponse, 0, len(statements)),
+	}
+
+	for i := range statements {
+		res.Items = append(res.Items, *statementResponse(&statements[i]))
+	}
+
+	res.Meta.CountTotalPage(req.Page, req.Paginate, totalData)
+
+	return res, nil
+}
+
+// GetStatement issues the statement of a closed month the first time it is
+// asked for, so customers do not have to wait for a backfill.
+func (s *statementService) GetStatement(ctx context.Context, period string, customerID int) (*dto.StatementResponse, error) {
+	ctx, span := tracing.Start(ctx, "statementService.GetStatement")
+	defer span.End()
+
+	statement, lines, err := s.getStatement(ctx, period, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Str("period", period).Msg("service::GetStatement - Failed to get statement")
+		return nil, err
+	}
+
+	res := statementResponse(statement)
+	res.Lines = make([]dto.StatementLineResponse, 0, len(lines))
+	for _, line := range lines {
+		res.Lines = append(res.Lines, dto.StatementLineResponse{
+			LineType:       line.LineType,
+			TransactionID:  line.TransactionID,
+			ContractNumber: line.ContractNumber,
+			Amount:         line.Amount,
+			OccurredAt:     line.OccurredAt.Format(constants.DateTimeFormat),
+		})
+	}
+
+	return res, nil
+}
+
+// GetStatementPDF renders the stored statement; nothing is recomputed, so the
+// PDF always shows the figures that were issued.
+func (s *statementService) GetStatementPDF(ctx context.Context, period string, customerID int) (*dto.StatementFile, error) {
+	ctx, span := tracing.Start(ctx, "statementService.GetStatementPDF")
+	defer span.End()
+
+	statement, lines, err := s.getStatement(ctx, period, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Str("period", period).Msg("service::GetStatementPDF - Failed to get statement")
+		return nil, err
+	}
+
+	customer, err := s.customerRepository.FindCustomerByID(ctx, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("service::GetStatementPDF - Failed to find customer")
+		return nil, err
+	}
+
+	start, _ := time.ParseInLocation(constants.PeriodFormat, statement.Period, statementLocation)
+
+	data := &document.StatementData{
+		Period:   start,
+		IssuedAt: statement.IssuedAt.UTC(),
+		Customer: document.Customer{
+			FullName:  customer.FullName,
+			LegalName: customer.LegalName,
+			Nik:       customer.Nik,
+			Email:     customer.Email,
+		},
+		OpeningBalance: statement.OpeningBalance,
+		NewBookings:    statement.NewBookings,
+		Payments:       statement.Payments,
+		Fees:           statement.Fees,
+		ClosingBalance: statement.ClosingBalance,
+		Lines:          make([]document.StatementLine, 0, len(lines)),
+	}
+
+	for _, line := range lines {
+		data.Lines = append(data.Lines, document.StatementLine{
+			LineType:       line.LineType,
+			ContractNumber: line.ContractNumber,
+			Amount:         line.Amount,
+			OccurredAt:     line.OccurredAt,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := document.RenderStatement(&buf, data); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Str("period", period).Msg("service::GetStatementPDF - Failed to render statement")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	return &dto.StatementFile{
+		FileName:    fmt.Sprintf("%s-%s.pdf", document.TypeStatement, statement.Period),
+		ContentType: document.ContentTypePDF,
+		Content:     buf.Bytes(),
+	}, nil
+}
+
+// GenerateStatements keeps going when a single customer fails so one bad
+// record does not hold up a backfill; failures are counted and logged.
+func (s *statementService) GenerateStatements(ctx context.Context, req *dto.GenerateStatementsRequest) (*dto.GenerateStatementsResponse, error) {
+	ctx, span := tracing.Start(ctx, "statementService.GenerateStatements")
+	defer span.End()
+
+	start, end, err := s.parsePeriod(req.Period)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("period", req.Period).Msg("service::GenerateStatements - Invalid period")
+		return nil, err
+	}
+
+	customerIDs := []int{req.CustomerID}
+	if req.CustomerID == 0 {
+		customerIDs, err = s.statementRepository.FindCustomerIDsWithTransactionsBefore(ctx, end)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("period", req.Period).Msg("service::GenerateStatements - Failed to find customers")
+			return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		}
+	}
+
+	res := &dto.GenerateStatementsResponse{Period: req.Period}
+	for _, customerID := range customerIDs {
+		statement, created, err := s.issueStatement(ctx, customerID, req.Period, start, end)
+		switch {
+		case err != nil:
+			log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Str("period", req.Period).Msg("service::GenerateStatements - Failed to issue statement")
+			res.Failed++
+		case statement == nil:
+			res.Skipped++
+		case created:
+			res.Created++
+		default:
+			res.Existed++
+		}
+	}
+
+	return res, nil
+}
+
+func (s *statementService) getStatement(ctx context.Context, period string, customerID int) (*entity.Statement, []entity.StatementLine, error) {
+	start, end, err := s.parsePeriod(period)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	statement, _, err := s.issueStatement(ctx, customerID, period, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if statement == nil {
+		return nil, nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrStatementNotFound))
+	}
+
+	var lines []entity.StatementLine
+	if err := json.Unmarshal([]byte(statement.Lines), &lines); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", statement.ID).Msg("service::getStatement - Failed to decode statement lines")
+		return nil, nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	return statement, lines, nil
+}
+
+// parsePeriod returns the bounds of a month that has already ended.
+func (s *statementService) parsePeriod(period string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation(constants.PeriodFormat, period, statementLocation)
+	if err != nil {
+		return time.Time{}, time.Time{}, err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrInvalidStatementPeriod))
+	}
+
+	end := start.AddDate(0, 1, 0)
+	if end.After(s.now()) {
+		return time.Time{}, time.Time{}, err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrStatementPeriodNotClosed))
+	}
+
+	return start, end, nil
+}
+
+// issueStatement returns the stored statement of the period, or computes and
+// stores it when there is none yet. The opening balance carries over the
+// previous closing balance when that month was issued, and is worked out from
+// the full history otherwise. A customer with nothing outstanding and no
+// activity in the month gets no statement, reported as a nil statement.
+func (s *statementService) issueStatement(ctx context.Context, customerID int, period string, start, end time.Time) (*entity.Statement, bool, error) {
+	existing, err := s.statementRepository.FindStatementByCustomerIDAndPeriod(ctx, customerID, period)
+	if err == nil {
+		return existing, false, nil
+	}
+	if !err_msg.HasCode(err, fiber.StatusNotFound) {
+		return nil, false, err
+	}
+
+	statement := &entity.Statement{
+		CustomerID: customerID,
+		Period:     period,
+		IssuedAt:   s.now().UTC().Truncate(time.Second),
+	}
+
+	previous, err := s.statementRepository.FindStatementByCustomerIDAndPeriod(ctx, customerID, start.AddDate(0, -1, 0).Format(constants.PeriodFormat))
+	switch {
+	case err == nil:
+		statement.OpeningBalance = previous.ClosingBalance
+	case err_msg.HasCode(err, fiber.StatusNotFound):
+		statement.OpeningBalance, err = s.statementRepository.FindOutstandingBalance(ctx, customerID, start)
+		if err != nil {
+			return nil, false, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		}
+	default:
+		return nil, false, err
+	}
+
+	activities, err := s.statementRepository.FindStatementActivities(ctx, customerID, start, end)
+	if err != nil {
+		return nil, false, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	lines := make([]entity.StatementLine, 0, len(activities))
+	for _, activity := range activities {
+		line := entity.StatementLine{
+			LineType:       activity.ActivityType,
+			TransactionID:  activity.TransactionID,
+			ContractNumber: activity.ContractNumber,
+			Amount:         activity.Amount,
+			OccurredAt:     activity.OccurredAt,
+		}
+
+		switch activity.ActivityType {
+		case constants.StatementLineBooking:
+			statement.NewBookings += activity.Amount
+			lines = append(lines, line)
+
+			if activity.Fee > 0 {
+				statement.Fees += activity.Fee
+				line.LineType, line.Amount = constants.StatementLineFee, activity.Fee
+				lines = append(lines, line)
+			}
+		case constants.StatementLinePayment:
+			statement.Payments += activity.Amount
+			lines = append(lines, line)
+		}
+	}
+
+	if statement.OpeningBalance == 0 && len(lines) == 0 {
+		return nil, false, nil
+	}
+
+	statement.OpeningBalance = roundAmount(statement.OpeningBalance)
+	statement.NewBookings = roundAmount(statement.NewBookings)
+	statement.Fees = roundAmount(statement.Fees)
+	statement.Payments = roundAmount(statement.Payments)
+	statement.ClosingBalance = roundAmount(statement.OpeningBalance + statement.NewBookings + statement.Fees - statement.Payments)
+
+	encoded, err := json.Marshal(lines)
+	if err != nil {
+		return nil, false, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+	statement.Lines = string(encoded)
+
+	created, err := s.statementRepository.InsertNewStatement(ctx, statement)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// another run issued it first, theirs is the one the customer keeps
+	if !created {
+		existing, err := s.statementRepository.FindStatementByCustomerIDAndPeriod(ctx, customerID, period)
+		return existing, false, err
+	}
+
+	return statement, true, nil
+}
+
+func statementResponse(statement *entity.Statement) *dto.StatementResponse {
+	return &dto.StatementResponse{
+		Period:         statement.Period,
+		OpeningBalance: statement.OpeningBalance,
+		NewBookings:    statement.NewBookings,
+		Payments:       statement.Payments,
+		Fees:           statement.Fees,
+		ClosingBalance: statement.ClosingBalance,
+		IssuedAt:       statement.IssuedAt.Format(constants.DateTimeFormat),
+	}
+}
+
+func roundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,243 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ports.go -destination=../service/service_mock_test.go -package=service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	dto "github.com/hilmiikhsan/multifinance-service/internal/module/statement/dto"
+	entity "github.com/hilmiikhsan/multifinance-service/internal/module/statement/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatementRepository is a mock of StatementRepository interface.
+type MockStatementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementRepositoryMockRecorder is the mock recorder for MockStatementRepository.
+type MockStatementRepositoryMockRecorder struct {
+	mock *MockStatementRepository
+}
+
+// NewMockStatementRepository creates a new mock instance.
+func NewMockStatementRepository(ctrl *gomock.Controller) *MockStatementRepository {
+	mock := &MockStatementRepository{ctrl: ctrl}
+	mock.recorder = &MockStatementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementRepository) EXPECT() *MockStatementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindCustomerIDsWithTransactionsBefore mocks base method.
+func (m *MockStatementRepository) FindCustomerIDsWithTransactionsBefore(ctx context.Context, before time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCustomerIDsWithTransactionsBefore", ctx, before)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCustomerIDsWithTransactionsBefore indicates an expected call of FindCustomerIDsWithTransactionsBefore.
+func (mr *MockStatementRepositoryMockRecorder) FindCustomerIDsWithTransactionsBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCustomerIDsWithTransactionsBefore", reflect.TypeOf((*MockStatementRepository)(nil).FindCustomerIDsWithTransactionsBefore), ctx, before)
+}
+
+// FindOutstandingBalance mocks base method.
+func (m *MockStatementRepository) FindOutstandingBalance(ctx context.Context, customerID int, before time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOutstandingBalance", ctx, customerID, before)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOutstandingBalance indicates an expected call of FindOutstandingBalance.
+func (mr *MockStatementRepositoryMockRecorder) FindOutstandingBalance(ctx, customerID, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOutstandingBalance", reflect.TypeOf((*MockStatementRepository)(nil).FindOutstandingBalance), ctx, customerID, before)
+}
+
+// FindStatementActivities mocks base method.
+func (m *MockStatementRepository) FindStatementActivities(ctx context.Context, customerID int, start, end time.Time) ([]entity.StatementActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStatementActivities", ctx, customerID, start, end)
+	ret0, _ := ret[0].([]entity.StatementActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStatementActivities indicates an expected call of FindStatementActivities.
+func (mr *MockStatementRepositoryMockRecorder) FindStatementActivities(ctx, customerID, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStatementActivities", reflect.TypeOf((*MockStatementRepository)(nil).FindStatementActivities), ctx, customerID, start, end)
+}
+
+// FindStatementByCustomerIDAndPeriod mocks base method.
+func (m *MockStatementRepository) FindStatementByCustomerIDAndPeriod(ctx context.Context, customerID int, period string) (*entity.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStatementByCustomerIDAndPeriod", ctx, customerID, period)
+	ret0, _ := ret[0].(*entity.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStatementByCustomerIDAndPeriod indicates an expected call of FindStatementByCustomerIDAndPeriod.
+func (mr *MockStatementRepositoryMockRecorder) FindStatementByCustomerIDAndPeriod(ctx, customerID, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStatementByCustomerIDAndPeriod", reflect.TypeOf((*MockStatementRepository)(nil).FindStatementByCustomerIDAndPeriod), ctx, customerID, period)
+}
+
+// FindStatementsByCustomerID mocks base method.
+func (m *MockStatementRepository) FindStatementsByCustomerID(ctx context.Context, req *dto.GetStatementsRequest, customerID int) ([]entity.Statement, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStatementsByCustomerID", ctx, req, customerID)
+	ret0, _ := ret[0].([]entity.Statement)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindStatementsByCustomerID indicates an expected call of FindStatementsByCustomerID.
+func (mr *MockStatementRepositoryMockRecorder) FindStatementsByCustomerID(ctx, req, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStatementsByCustomerID", reflect.TypeOf((*MockStatementRepository)(nil).FindStatementsByCustomerID), ctx, req, customerID)
+}
+
+// InsertNewStatement mocks base method.
+func (m *MockStatementRepository) InsertNewStatement(ctx context.Context, data *entity.Statement) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewStatement", ctx, data)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertNewStatement indicates an expected call of InsertNewStatement.
+func (mr *MockStatementRepositoryMockRecorder) InsertNewStatement(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewStatement", reflect.TypeOf((*MockStatementRepository)(nil).InsertNewStatement), ctx, data)
+}
+
+// MockStatementService is a mock of StatementService interface.
+type MockStatementService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementServiceMockRecorder is the mock recorder for MockStatementService.
+type MockStatementServiceMockRecorder struct {
+	mock *MockStatementService
+}
+
+// NewMockStatementService creates a new mock instance.
+func NewMockStatementService(ctrl *gomock.Controller) *MockStatementService {
+	mock := &MockStatementService{ctrl: ctrl}
+	mock.recorder = &MockStatementServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementService) EXPECT() *MockStatementServiceMockRecorder {
+	return m.recorder
+}
+
+// GetStatement mocks base method.
+func (m *MockStatementService) GetStatement(ctx context.Context, period string, customerID int) (*dto.StatementResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", ctx, period, customerID)
+	ret0, _ := ret[0].(*dto.StatementResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockStatementServiceMockRecorder) GetStatement(ctx, period, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockStatementService)(nil).GetStatement), ctx, period, customerID)
+}
+
+// GetStatementPDF mocks base method.
+func (m *MockStatementService) GetStatementPDF(ctx context.Context, period string, customerID int) (*dto.StatementFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatementPDF", ctx, period, customerID)
+	ret0, _ := ret[0].(*dto.StatementFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatementPDF indicates an expected call of GetStatementPDF.
+func (mr *MockStatementServiceMockRecorder) GetStatementPDF(ctx, period, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatementPDF", reflect.TypeOf((*MockStatementService)(nil).GetStatementPDF), ctx, period, customerID)
+}
+
+// GetStatements mocks base method.
+func (m *MockStatementService) GetStatements(ctx context.Context, req *dto.GetStatementsRequest, customerID int) (*dto.GetStatementsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatements", ctx, req, customerID)
+	ret0, _ := ret[0].(*dto.GetStatementsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatements indicates an expected call of GetStatements.
+func (mr *MockStatementServiceMockRecorder) GetStatements(ctx, req, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatements", reflect.TypeOf((*MockStatementService)(nil).GetStatements), ctx, req, customerID)
+}
+
+// MockStatementGenerator is a mock of StatementGenerator interface.
+type MockStatementGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementGeneratorMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementGeneratorMockRecorder is the mock recorder for MockStatementGenerator.
+type MockStatementGeneratorMockRecorder struct {
+	mock *MockStatementGenerator
+}
+
+// NewMockStatementGenerator creates a new mock instance.
+func NewMockStatementGenerator(ctrl *gomock.Controller) *MockStatementGenerator {
+	mock := &MockStatementGenerator{ctrl: ctrl}
+	mock.recorder = &MockStatementGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementGenerator) EXPECT() *MockStatementGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GenerateStatements mocks base method.
+func (m *MockStatementGenerator) GenerateStatements(ctx context.Context, req *dto.GenerateStatementsRequest) (*dto.GenerateStatementsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateStatements", ctx, req)
+	ret0, _ := ret[0].(*dto.GenerateStatementsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateStatements indicates an expected call of GenerateStatements.
+func (mr *MockStatementGeneratorMockRecorder) GenerateStatements(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateStatements", reflect.TypeOf((*MockStatementGenerator)(nil).GenerateStatements), ctx, req)
+}
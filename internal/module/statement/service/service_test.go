@@ -0,0 +1,315 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	customerEntity "github.com/hilmiikhsan/multifinance-service/internal/module/customer/entity"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/statement/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+var (
+	// December 2024 in Jakarta time
+	decemberStart = time.Date(2024, 12, 1, 0, 0, 0, 0, statementLocation)
+	decemberEnd   = time.Date(2025, 1, 1, 0, 0, 0, 0, statementLocation)
+
+	statementNow = func() time.Time { return time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC) }
+	notFound     = err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrStatementNotFound))
+)
+
+func decemberActivities() []entity.StatementActivity {
+	return []entity.StatementActivity{
+		{
+			ActivityType:   constants.StatementLineBooking,
+			TransactionID:  5,
+			ContractNumber: "TRX202412020001",
+			Amount:         33600000,
+			Fee:            600000,
+			OccurredAt:     time.Date(2024, 12, 2, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			ActivityType:   constants.StatementLinePayment,
+			TransactionID:  2,
+			ContractNumber: "TRX202410010001",
+			Amount:         2800000,
+			OccurredAt:     time.Date(2024, 12, 20, 3, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func Test_statementService_GetStatement(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockStatementRepository(ctrlMock)
+	mockCustomerRepo := NewMockCustomerRepository(ctrlMock)
+
+	stored := &entity.Statement{
+		ID:             9,
+		CustomerID:     1,
+		Period:         "2024-12",
+		OpeningBalance: 10000000,
+		ClosingBalance: 10000000,
+		Lines:          "[]",
+		IssuedAt:       time.Date(2025, 1, 1, 0, 0, 5, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name     string
+		period   string
+		mockFn   func()
+		want     *dto.StatementResponse
+		wantCode int
+	}{
+		{
+			name:   "Opening Balance Carries Over The Previous Statement",
+			period: "2024-12",
+			mockFn: func() {
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 1, "2024-12").Return(nil, notFound)
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 1, "2024-11").Return(&entity.Statement{ClosingBalance: 28000000}, nil)
+				mockRepo.EXPECT().FindStatementActivities(gomock.Any(), 1, decemberStart, decemberEnd).Return(decemberActivities(), nil)
+				mockRepo.EXPECT().InsertNewStatement(gomock.Any(), gomock.Cond(func(x any) bool {
+					s := x.(*entity.Statement)
+					return s.IssuedAt.Equal(statementNow()) && s.ClosingBalance == 59400000
+				})).Return(true, nil)
+			},
+			want: &dto.StatementResponse{
+				Period:         "2024-12",
+				OpeningBalance: 28000000,
+				NewBookings:    33600000,
+				Payments:       2800000,
+				Fees:           600000,
+				ClosingBalance: 59400000,
+				IssuedAt:       "2025-01-02 08:00:00",
+				Lines: []dto.StatementLineResponse{
+					{LineType: constants.StatementLineBooking, TransactionID: 5, ContractNumber: "TRX202412020001", Amount: 33600000, OccurredAt: "2024-12-02 03:00:00"},
+					{LineType: constants.StatementLineFee, TransactionID: 5, ContractNumber: "TRX202412020001", Amount: 600000, OccurredAt: "2024-12-02 03:00:00"},
+					{LineType: constants.StatementLinePayment, TransactionID: 2, ContractNumber: "TRX202410010001", Amount: 2800000, OccurredAt: "2024-12-20 03:00:00"},
+				},
+			},
+		},
+		{
+			name:   "Opening Balance Is Worked Out From History Without A Previous Statement",
+			period: "2024-12",
+			mockFn: func() {
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 1, "2024-12").Return(nil, notFound)
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 1, "2024-11").Return(nil, notFound)
+				mockRepo.EXPECT().FindOutstandingBalance(gomock.Any(), 1, decemberStart).Return(float64(10000000), nil)
+				mockRepo.EXPECT().FindStatementActivities(gomock.Any(), 1, decemberStart, decemberEnd).Return(nil, nil)
+				mockRepo.EXPECT().InsertNewStatement(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			want: &dto.StatementResponse{
+				Period:         "2024-12",
+				OpeningBalance: 10000000,
+				ClosingBalance: 10000000,
+				IssuedAt:       "2025-01-02 08:00:00",
+				Lines:          []dto.StatementLineResponse{},
+			},
+		},
+		{
+			name:   "Issued Statement Is Served As Is",
+			period: "2024-12",
+			mockFn: func() {
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 1, "2024-12").Return(stored, nil)
+			},
+			want: &dto.StatementResponse{
+				Period:         "2024-12",
+				OpeningBalance: 10000000,
+				ClosingBalance: 10000000,
+				IssuedAt:       "2025-01-01 00:00:05",
+				Lines:          []dto.StatementLineResponse{},
+			},
+		},
+		{
+			name:   "Statement Issued Concurrently Is The One Kept",
+			period: "2024-12",
+			mockFn: func() {
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 1, "2024-12").Return(nil, notFound)
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 1, "2024-11").Return(nil, notFound)
+				mockRepo.EXPECT().FindOutstandingBalance(gomock.Any(), 1, decemberStart).Return(float64(10000000), nil)
+				mockRepo.EXPECT().FindStatementActivities(gomock.Any(), 1, decemberStart, decemberEnd).Return(nil, nil)
+				mockRepo.EXPECT().InsertNewStatement(gomock.Any(), gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 1, "2024-12").Return(stored, nil)
+			},
+			want: &dto.StatementResponse{
+				Period:         "2024-12",
+				OpeningBalance: 10000000,
+				ClosingBalance: 10000000,
+				IssuedAt:       "2025-01-01 00:00:05",
+				Lines:          []dto.StatementLineResponse{},
+			},
+		},
+		{
+			name:   "Nothing To Report",
+			period: "2024-12",
+			mockFn: func() {
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 1, "2024-12").Return(nil, notFound)
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 1, "2024-11").Return(nil, notFound)
+				mockRepo.EXPECT().FindOutstandingBalance(gomock.Any(), 1, decemberStart).Return(float64(0), nil)
+				mockRepo.EXPECT().FindStatementActivities(gomock.Any(), 1, decemberStart, decemberEnd).Return(nil, nil)
+			},
+			wantCode: fiber.StatusNotFound,
+		},
+		{
+			name:     "Month Has Not Ended",
+			period:   "2025-01",
+			mockFn:   func() {},
+			wantCode: fiber.StatusBadRequest,
+		},
+		{
+			name:     "Invalid Period",
+			period:   "2024-13",
+			mockFn:   func() {},
+			wantCode: fiber.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			s := NewStatementService(mockRepo, mockCustomerRepo)
+			s.now = statementNow
+
+			got, err := s.GetStatement(context.Background(), tt.period, 1)
+			if tt.wantCode != 0 {
+				assert.Equal(t, tt.wantCode, err.(*err_msg.CustomError).Code)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_statementService_GetStatementPDF(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockStatementRepository(ctrlMock)
+	mockCustomerRepo := NewMockCustomerRepository(ctrlMock)
+
+	stored := &entity.Statement{
+		ID:             9,
+		CustomerID:     1,
+		Period:         "2024-12",
+		OpeningBalance: 28000000,
+		NewBookings:    33600000,
+		Payments:       2800000,
+		Fees:           600000,
+		ClosingBalance: 59400000,
+		Lines:          `[{"line_type":"booking","transaction_id":5,"contract_number":"TRX202412020001","amount":33600000,"occurred_at":"2024-12-02T03:00:00Z"}]`,
+		IssuedAt:       time.Date(2025, 1, 1, 0, 0, 5, 0, time.UTC),
+	}
+
+	mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 1, "2024-12").Return(stored, nil).Times(2)
+	mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), 1).Return(&customerEntity.Customer{
+		ID:       1,
+		Nik:      "3174010101900001",
+		Email:    "budi@example.com",
+		FullName: "Budi Santoso",
+	}, nil).Times(2)
+
+	s := NewStatementService(mockRepo, mockCustomerRepo)
+	s.now = statementNow
+
+	first, err := s.GetStatementPDF(context.Background(), "2024-12", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "statement-2024-12.pdf", first.FileName)
+	assert.True(t, bytes.HasPrefix(first.Content, []byte("%PDF-")))
+
+	second, err := s.GetStatementPDF(context.Background(), "2024-12", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, first.Content, second.Content, "an issued statement must always render the same file")
+}
+
+func Test_statementService_GenerateStatements(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockStatementRepository(ctrlMock)
+	mockCustomerRepo := NewMockCustomerRepository(ctrlMock)
+
+	tests := []struct {
+		name     string
+		req      *dto.GenerateStatementsRequest
+		mockFn   func()
+		want     *dto.GenerateStatementsResponse
+		wantCode int
+	}{
+		{
+			name: "Every Customer Of The Month",
+			req:  &dto.GenerateStatementsRequest{Period: "2024-12"},
+			mockFn: func() {
+				mockRepo.EXPECT().FindCustomerIDsWithTransactionsBefore(gomock.Any(), decemberEnd).Return([]int{1, 2, 3, 4}, nil)
+
+				// customer 1 gets a new statement
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 1, "2024-12").Return(nil, notFound)
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 1, "2024-11").Return(&entity.Statement{ClosingBalance: 28000000}, nil)
+				mockRepo.EXPECT().FindStatementActivities(gomock.Any(), 1, decemberStart, decemberEnd).Return(decemberActivities(), nil)
+				mockRepo.EXPECT().InsertNewStatement(gomock.Any(), gomock.Any()).Return(true, nil)
+
+				// customer 2 already has one
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 2, "2024-12").Return(&entity.Statement{ID: 3}, nil)
+
+				// customer 3 paid everything off before December
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 3, "2024-12").Return(nil, notFound)
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 3, "2024-11").Return(&entity.Statement{}, nil)
+				mockRepo.EXPECT().FindStatementActivities(gomock.Any(), 3, decemberStart, decemberEnd).Return(nil, nil)
+
+				// customer 4 fails without stopping the run
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 4, "2024-12").
+					Return(nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError)))
+			},
+			want: &dto.GenerateStatementsResponse{Period: "2024-12", Created: 1, Existed: 1, Skipped: 1, Failed: 1},
+		},
+		{
+			name: "Single Customer",
+			req:  &dto.GenerateStatementsRequest{Period: "2024-12", CustomerID: 2},
+			mockFn: func() {
+				mockRepo.EXPECT().FindStatementByCustomerIDAndPeriod(gomock.Any(), 2, "2024-12").Return(&entity.Statement{ID: 3}, nil)
+			},
+			want: &dto.GenerateStatementsResponse{Period: "2024-12", Existed: 1},
+		},
+		{
+			name: "Failed To Find Customers",
+			req:  &dto.GenerateStatementsRequest{Period: "2024-12"},
+			mockFn: func() {
+				mockRepo.EXPECT().FindCustomerIDsWithTransactionsBefore(gomock.Any(), decemberEnd).Return(nil, errors.New("connection refused"))
+			},
+			wantCode: fiber.StatusInternalServerError,
+		},
+		{
+			name:     "Month Has Not Ended",
+			req:      &dto.GenerateStatementsRequest{Period: "2025-01"},
+			mockFn:   func() {},
+			wantCode: fiber.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			s := NewStatementService(mockRepo, mockCustomerRepo)
+			s.now = statementNow
+
+			got, err := s.GenerateStatements(context.Background(), tt.req)
+			if tt.wantCode != 0 {
+				assert.Equal(t, tt.wantCode, err.(*err_msg.CustomError).Code)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
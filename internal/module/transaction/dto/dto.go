@@ -1,6 +1,9 @@
 package dto
 
-import "github.com/hilmiikhsan/multifinance-service/pkg/types"
+import (
+	"github.com/hilmiikhsan/multifinance-service/pkg/cursor"
+	"github.com/hilmiikhsan/multifinance-service/pkg/types"
+)
 
 type CreateTransactionRequest struct {
 	CustomerID        int    `json:"customer_id"`
@@ -9,6 +12,9 @@ type CreateTransactionRequest struct {
 	InterestAmount    int    `json:"interest_amount" validate:"required,numeric,amount_number"`
 	AssetName         string `json:"asset_name" validate:"required,valid_text,max=100"`
 	TenorMonth        int    `json:"tenor_month" validate:"required,numeric,amount_number"`
+	DeviceID          string `json:"-"`
+	Channel           string `json:"-"`
+	PartnerCode       string `json:"-"`
 }
 
 type GetDetailTransactionResponse struct {
@@ -31,18 +37,57 @@ type HistoryListTransactionItem struct {
 	AdminFee          float64 `json:"admin_fee" db:"admin_fee"`
 	InstallmentAmount float64 `json:"installment_amount" db:"installment_amount"`
 	InterestAmount    float64 `json:"interest_amount" db:"interest_amount"`
+	TenorMonth        int     `json:"tenor_month" db:"tenor_month"`
 	AssetName         string  `json:"asset_name" db:"asset_name"`
+	Status            string  `json:"status" db:"status"`
 	CreatedAt         string  `json:"created_at" db:"created_at"`
 }
 
+// HistoryFilter narrows the transactions of one customer. Dates are
+// inclusive calendar days and the amount range applies to the on the road price.
+type HistoryFilter struct {
+	StartDate  string  `query:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate    string  `query:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	MinAmount  float64 `query:"min_amount" validate:"omitempty,min=0"`
+	MaxAmount  float64 `query:"max_amount" validate:"omitempty,min=0"`
+	TenorMonth int     `query:"tenor_month" validate:"omitempty,min=1"`
+	Status     string  `query:"status" validate:"omitempty,oneof=active paid_off cancelled"`
+	AssetName  string  `query:"asset_name" validate:"omitempty,max=100"`
+	SortBy     string  `query:"sort_by" validate:"omitempty,oneof=created_at on_the_road_price installment_amount tenor_month asset_name"`
+	SortDir    string  `query:"sort_dir" validate:"omitempty,oneof=asc desc"`
+}
+
+func (r *HistoryFilter) SetDefault() {
+	if r.SortBy == "" {
+		r.SortBy = "created_at"
+	}
+
+	if r.SortDir == "" {
+		r.SortDir = "desc"
+	}
+}
+
+// GetHistoryListTransactionRequest pages through the filtered history.
+// Pagination is page/offset by default; pagination=cursor (or any cursor)
+// switches to keyset pages on (created_at, id) instead.
 type GetHistoryListTransactionRequest struct {
-	Page     int `query:"page" validate:"required,min=1"`
-	Paginate int `query:"paginate" validate:"required,min=1,max=100"`
+	Page       int    `query:"page" validate:"required,min=1"`
+	Paginate   int    `query:"paginate" validate:"required,min=1,max=100"`
+	Pagination string `query:"pagination" validate:"omitempty,oneof=offset cursor"`
+	Cursor     string `query:"cursor" validate:"omitempty,max=512"`
+	WithTotal  bool   `query:"with_total"`
+	HistoryFilter
+
+	// Key is the decoded Cursor, set by the service
+	Key *cursor.Cursor `query:"-"`
 }
 
+// GetHistoryListTransactionResponse carries Meta for page/offset requests
+// and Cursor for keyset requests.
 type GetHistoryListTransactionResponse struct {
-	Items []HistoryListTransactionItem `json:"items"`
-	Meta  types.Meta                   `json:"meta"`
+	Items  []HistoryListTransactionItem `json:"items"`
+	Meta   *types.Meta                  `json:"meta,omitempty"`
+	Cursor *types.CursorMeta            `json:"cursor,omitempty"`
 }
 
 func (r *GetHistoryListTransactionRequest) SetDefault() {
@@ -53,4 +98,57 @@ func (r *GetHistoryListTransactionRequest) SetDefault() {
 	if r.Paginate < 1 {
 		r.Paginate = 10
 	}
+
+	r.HistoryFilter.SetDefault()
+
+	if r.Pagination == "" {
+		r.Pagination = "offset"
+		if r.Cursor != "" {
+			r.Pagination = "cursor"
+		}
+	}
+}
+
+func (r *GetHistoryListTransactionRequest) IsCursor() bool {
+	return r.Pagination == "cursor"
+}
+
+// ExportTransactionRequest exports the filtered history. Locale falls back to
+// the Accept-Language header.
+type ExportTransactionRequest struct {
+	Format string `query:"format" validate:"required,oneof=csv xlsx"`
+	Locale string `query:"locale" validate:"omitempty,oneof=id en"`
+	HistoryFilter
+}
+
+func (r *ExportTransactionRequest) SetDefault() {
+	if r.Format == "" {
+		r.Format = "csv"
+	}
+
+	r.HistoryFilter.SetDefault()
+}
+
+type TransactionExportResponse struct {
+	ID           int64  `json:"id"`
+	Format       string `json:"format"`
+	Locale       string `json:"locale"`
+	Status       string `json:"status"`
+	RowCount     int    `json:"row_count"`
+	ErrorMessage string `json:"error_message,omitempty"`
+	CreatedAt    string `json:"created_at"`
+	FinishedAt   string `json:"finished_at,omitempty"`
+}
+
+// TransactionFile points at a stored file and the name it is downloaded as.
+type TransactionFile struct {
+	Path        string
+	FileName    string
+	ContentType string
+}
+
+// ContractBackfillResponse counts the contracts issued by a backfill run.
+type ContractBackfillResponse struct {
+	Issued int `json:"issued"`
+	Failed int `json:"failed"`
 }
@@ -1,18 +1,32 @@
 package entity
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 type Transaction struct {
-	ID                int       `db:"id"`
-	CustomerID        int       `db:"customer_id"`
-	ContractNumber    string    `db:"contract_number"`
-	OnTheRoadPrice    float64   `db:"on_the_road_price"`
-	AdminFee          float64   `db:"admin_fee"`
-	InstallmentAmount float64   `db:"installment_amount"`
-	InterestAmount    float64   `db:"interest_amount"`
-	AssetName         string    `db:"asset_name"`
-	CreatedAt         time.Time `db:"created_at"`
-	UpdatedAt         time.Time `db:"updated_at"`
+	ID                int            `db:"id"`
+	CustomerID        int            `db:"customer_id"`
+	PartnerID         sql.NullInt64  `db:"partner_id"`
+	PartnerCode       sql.NullString `db:"partner_code"`
+	ContractNumber    string         `db:"contract_number"`
+	OnTheRoadPrice    float64        `db:"on_the_road_price"`
+	AdminFee          float64        `db:"admin_fee"`
+	InstallmentAmount float64        `db:"installment_amount"`
+	InterestAmount    float64        `db:"interest_amount"`
+	TenorMonth        int            `db:"tenor_month"`
+	AssetName         string         `db:"asset_name"`
+	Status            string         `db:"status"`
+	CreatedAt         time.Time      `db:"created_at"`
+	UpdatedAt         time.Time      `db:"updated_at"`
+}
+
+// TransactionPayment is an amount paid towards a transaction.
+type TransactionPayment struct {
+	TransactionID int       `db:"transaction_id"`
+	Amount        float64   `db:"amount"`
+	PaidAt        time.Time `db:"paid_at"`
 }
 
 type TransactionWithCustomer struct {
@@ -26,3 +40,35 @@ type TransactionWithCustomer struct {
 	AssetName         time.Time `db:"asset_name"`
 	CreatedAt         time.Time `db:"created_at"`
 }
+
+// TransactionExport is an export too large to stream in the request. Filter
+// holds the JSON encoded dto.HistoryFilter it was requested with and FileName
+// is relative to the export storage directory. Attempts counts the times a
+// worker claimed the job.
+type TransactionExport struct {
+	ID           int64          `db:"id"`
+	CustomerID   int            `db:"customer_id"`
+	Format       string         `db:"format"`
+	Locale       string         `db:"locale"`
+	Filter       string         `db:"filter"`
+	Status       string         `db:"status"`
+	FileName     sql.NullString `db:"file_name"`
+	RowCount     int            `db:"row_count"`
+	ErrorMessage sql.NullString `db:"error_message"`
+	Attempts     int            `db:"attempts"`
+	CreatedAt    time.Time      `db:"created_at"`
+	FinishedAt   sql.NullTime   `db:"finished_at"`
+}
+
+// TransactionDocument is a generated document such as the contract. A
+// transaction keeps the template version of its first document, and FileName
+// is relative to the document storage directory.
+type TransactionDocument struct {
+	ID              int64     `db:"id"`
+	TransactionID   int       `db:"transaction_id"`
+	DocumentType    string    `db:"document_type"`
+	TemplateVersion string    `db:"template_version"`
+	FileName        string    `db:"file_name"`
+	Checksum        string    `db:"checksum"`
+	CreatedAt       time.Time `db:"created_at"`
+}
@@ -0,0 +1,146 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
+	transactionWiring "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/handler"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	pb "github.com/hilmiikhsan/multifinance-service/pkg/pb/multifinance/v1"
+	"github.com/rs/zerolog/log"
+	googleGrpc "google.golang.org/grpc"
+)
+
+type transactionHandler struct {
+	pb.UnimplementedTransactionServiceServer
+	service   ports.TransactionService
+	validator adapter.Validator
+}
+
+func NewTransactionHandler() *transactionHandler {
+	var handler = new(transactionHandler)
+
+	// handler
+	handler.service = transactionWiring.NewTransactionService()
+	handler.validator = adapter.Adapters.Validator
+
+	return handler
+}
+
+func (h *transactionHandler) TransactionService(server googleGrpc.ServiceRegistrar) {
+	pb.RegisterTransactionServiceServer(server, h)
+}
+
+func (h *transactionHandler) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.GetTransactionResponse, error) {
+	locals := middleware.GetContextLocals(ctx)
+
+	if req.GetId() < 1 {
+		log.Ctx(ctx).Warn().Msg("handler::GetTransaction - ID is required")
+		return nil, err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrParamIdIsRequired))
+	}
+
+	res, err := h.service.GetDetailTransaction(ctx, int(req.GetId()), locals.GetCustomerID())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", locals.GetCustomerID()).Msg("handler::GetTransaction - Failed to get detail transaction")
+		return nil, err
+	}
+
+	return &pb.GetTransactionResponse{
+		Transaction: &pb.Transaction{
+			Id:                int64(res.ID),
+			CustomerId:        int64(res.CustomerID),
+			ContractNumber:    res.ContractNumber,
+			OnTheRoadPrice:    res.OnTheRoadPrice,
+			AdminFee:          res.AdminFee,
+			InstallmentAmount: res.InstallmentAmount,
+			InterestAmount:    res.InterestAmount,
+			AssetName:         res.AssetName,
+			CreatedAt:         res.CreatedAt,
+		},
+	}, nil
+}
+
+func (h *transactionHandler) ListTransactions(ctx context.Context, req *pb.ListTransactionsRequest) (*pb.ListTransactionsResponse, error) {
+	var (
+		locals = middleware.GetContextLocals(ctx)
+		query  = &dto.GetHistoryListTransactionRequest{
+			Page:       int(req.GetPage()),
+			Paginate:   int(req.GetPaginate()),
+			Pagination: req.GetPagination(),
+			Cursor:     req.GetCursor(),
+			WithTotal:  req.GetWithTotal(),
+			HistoryFilter: dto.HistoryFilter{
+				StartDate:  req.GetStartDate(),
+				EndDate:    req.GetEndDate(),
+				MinAmount:  req.GetMinAmount(),
+				MaxAmount:  req.GetMaxAmount(),
+				TenorMonth: int(req.GetTenorMonth()),
+				Status:     req.GetStatus(),
+				AssetName:  req.GetAssetName(),
+				SortBy:     req.GetSortBy(),
+				SortDir:    req.GetSortDir(),
+			},
+		}
+	)
+
+	query.SetDefault()
+
+	if err := h.validator.Validate(query); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::ListTransactions - Invalid request")
+		return nil, err
+	}
+
+	res, err := h.service.GetHistoryListTransction(ctx, query, locals.GetCustomerID())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", locals.GetCustomerID()).Msg("handler::ListTransactions - Failed to get history list transaction")
+		return nil, err
+	}
+
+	items := make([]*pb.Transaction, 0, len(res.Items))
+	for _, item := range res.Items {
+		items = append(items, &pb.Transaction{
+			Id:                int64(item.ID),
+			CustomerId:        int64(item.CustomerID),
+			ContractNumber:    item.ContractNumber,
+			OnTheRoadPrice:    item.OnTheRoadPrice,
+			AdminFee:          item.AdminFee,
+			InstallmentAmount: item.InstallmentAmount,
+			InterestAmount:    item.InterestAmount,
+			TenorMonth:        int32(item.TenorMonth),
+			AssetName:         item.AssetName,
+			Status:            item.Status,
+			CreatedAt:         item.CreatedAt,
+		})
+	}
+
+	list := &pb.ListTransactionsResponse{Items: items}
+
+	if res.Meta != nil {
+		list.Meta = &pb.PageMeta{
+			Page:      int32(res.Meta.Page),
+			Paginate:  int32(res.Meta.Paginate),
+			TotalData: int32(res.Meta.TotalData),
+			TotalPage: int32(res.Meta.TotalPage),
+		}
+	}
+
+	if res.Cursor != nil {
+		list.Cursor = &pb.CursorMeta{
+			Paginate: int32(res.Cursor.Paginate),
+			Next:     res.Cursor.Next,
+			Prev:     res.Cursor.Prev,
+		}
+
+		if res.Cursor.TotalData != nil {
+			total := int32(*res.Cursor.TotalData)
+			list.Cursor.TotalData = &total
+		}
+	}
+
+	return list, nil
+}
@@ -0,0 +1,627 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ports.go -destination=../handler/grpc/handler_mock_test.go -package=grpc
+//
+
+// Package grpc is a generated GoMock package.
+package grpc
+
+import (
+	context "context"
+	sql "database/sql"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	dto "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
+	entity "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/entity"
+	outbox "github.com/hilmiikhsan/multifinance-service/pkg/outbox"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactionRepository is a mock of TransactionRepository interface.
+type MockTransactionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionRepositoryMockRecorder is the mock recorder for MockTransactionRepository.
+type MockTransactionRepositoryMockRecorder struct {
+	mock *MockTransactionRepository
+}
+
+// NewMockTransactionRepository creates a new mock instance.
+func NewMockTransactionRepository(ctrl *gomock.Controller) *MockTransactionRepository {
+	mock := &MockTransactionRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimTransactionExports mocks base method.
+func (m *MockTransactionRepository) ClaimTransactionExports(ctx context.Context, tx *sql.Tx, now time.Time, limit int) ([]entity.TransactionExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimTransactionExports", ctx, tx, now, limit)
+	ret0, _ := ret[0].([]entity.TransactionExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimTransactionExports indicates an expected call of ClaimTransactionExports.
+func (mr *MockTransactionRepositoryMockRecorder) ClaimTransactionExports(ctx, tx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTransactionExports", reflect.TypeOf((*MockTransactionRepository)(nil).ClaimTransactionExports), ctx, tx, now, limit)
+}
+
+// CountTransactionByCustomerID mocks base method.
+func (m *MockTransactionRepository) CountTransactionByCustomerID(ctx context.Context, filter *dto.HistoryFilter, customerID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTransactionByCustomerID", ctx, filter, customerID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTransactionByCustomerID indicates an expected call of CountTransactionByCustomerID.
+func (mr *MockTransactionRepositoryMockRecorder) CountTransactionByCustomerID(ctx, filter, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransactionByCustomerID", reflect.TypeOf((*MockTransactionRepository)(nil).CountTransactionByCustomerID), ctx, filter, customerID)
+}
+
+// FindContractsByCustomerID mocks base method.
+func (m *MockTransactionRepository) FindContractsByCustomerID(ctx context.Context, tx *sql.Tx, customerID int) ([]entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindContractsByCustomerID", ctx, tx, customerID)
+	ret0, _ := ret[0].([]entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindContractsByCustomerID indicates an expected call of FindContractsByCustomerID.
+func (mr *MockTransactionRepositoryMockRecorder) FindContractsByCustomerID(ctx, tx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindContractsByCustomerID", reflect.TypeOf((*MockTransactionRepository)(nil).FindContractsByCustomerID), ctx, tx, customerID)
+}
+
+// FindPaymentsByCustomerID mocks base method.
+func (m *MockTransactionRepository) FindPaymentsByCustomerID(ctx context.Context, tx *sql.Tx, customerID int) ([]entity.TransactionPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaymentsByCustomerID", ctx, tx, customerID)
+	ret0, _ := ret[0].([]entity.TransactionPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPaymentsByCustomerID indicates an expected call of FindPaymentsByCustomerID.
+func (mr *MockTransactionRepositoryMockRecorder) FindPaymentsByCustomerID(ctx, tx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaymentsByCustomerID", reflect.TypeOf((*MockTransactionRepository)(nil).FindPaymentsByCustomerID), ctx, tx, customerID)
+}
+
+// FindTransactionByContractNumber mocks base method.
+func (m *MockTransactionRepository) FindTransactionByContractNumber(ctx context.Context, contractNumber string) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionByContractNumber", ctx, contractNumber)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransactionByContractNumber indicates an expected call of FindTransactionByContractNumber.
+func (mr *MockTransactionRepositoryMockRecorder) FindTransactionByContractNumber(ctx, contractNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionByContractNumber", reflect.TypeOf((*MockTransactionRepository)(nil).FindTransactionByContractNumber), ctx, contractNumber)
+}
+
+// FindTransactionByCustomerID mocks base method.
+func (m *MockTransactionRepository) FindTransactionByCustomerID(ctx context.Context, req *dto.GetHistoryListTransactionRequest, customerID int) (*dto.GetHistoryListTransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionByCustomerID", ctx, req, customerID)
+	ret0, _ := ret[0].(*dto.GetHistoryListTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransactionByCustomerID indicates an expected call of FindTransactionByCustomerID.
+func (mr *MockTransactionRepositoryMockRecorder) FindTransactionByCustomerID(ctx, req, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionByCustomerID", reflect.TypeOf((*MockTransactionRepository)(nil).FindTransactionByCustomerID), ctx, req, customerID)
+}
+
+// FindTransactionByCustomerIDCursor mocks base method.
+func (m *MockTransactionRepository) FindTransactionByCustomerIDCursor(ctx context.Context, req *dto.GetHistoryListTransactionRequest, customerID int) (*dto.GetHistoryListTransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionByCustomerIDCursor", ctx, req, customerID)
+	ret0, _ := ret[0].(*dto.GetHistoryListTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransactionByCustomerIDCursor indicates an expected call of FindTransactionByCustomerIDCursor.
+func (mr *MockTransactionRepositoryMockRecorder) FindTransactionByCustomerIDCursor(ctx, req, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionByCustomerIDCursor", reflect.TypeOf((*MockTransactionRepository)(nil).FindTransactionByCustomerIDCursor), ctx, req, customerID)
+}
+
+// FindTransactionByIdAndCustomerID mocks base method.
+func (m *MockTransactionRepository) FindTransactionByIdAndCustomerID(ctx context.Context, id, customerID int) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionByIdAndCustomerID", ctx, id, customerID)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransactionByIdAndCustomerID indicates an expected call of FindTransactionByIdAndCustomerID.
+func (mr *MockTransactionRepositoryMockRecorder) FindTransactionByIdAndCustomerID(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionByIdAndCustomerID", reflect.TypeOf((*MockTransactionRepository)(nil).FindTransactionByIdAndCustomerID), ctx, id, customerID)
+}
+
+// FindTransactionDocument mocks base method.
+func (m *MockTransactionRepository) FindTransactionDocument(ctx context.Context, transactionID int, documentType string) (*entity.TransactionDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionDocument", ctx, transactionID, documentType)
+	ret0, _ := ret[0].(*entity.TransactionDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransactionDocument indicates an expected call of FindTransactionDocument.
+func (mr *MockTransactionRepositoryMockRecorder) FindTransactionDocument(ctx, transactionID, documentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionDocument", reflect.TypeOf((*MockTransactionRepository)(nil).FindTransactionDocument), ctx, transactionID, documentType)
+}
+
+// FindTransactionExportByIDAndCustomerID mocks base method.
+func (m *MockTransactionRepository) FindTransactionExportByIDAndCustomerID(ctx context.Context, id int64, customerID int) (*entity.TransactionExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionExportByIDAndCustomerID", ctx, id, customerID)
+	ret0, _ := ret[0].(*entity.TransactionExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransactionExportByIDAndCustomerID indicates an expected call of FindTransactionExportByIDAndCustomerID.
+func (mr *MockTransactionRepositoryMockRecorder) FindTransactionExportByIDAndCustomerID(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionExportByIDAndCustomerID", reflect.TypeOf((*MockTransactionRepository)(nil).FindTransactionExportByIDAndCustomerID), ctx, id, customerID)
+}
+
+// FindTransactionsWithoutDocument mocks base method.
+func (m *MockTransactionRepository) FindTransactionsWithoutDocument(ctx context.Context, documentType string, afterID, limit int) ([]entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionsWithoutDocument", ctx, documentType, afterID, limit)
+	ret0, _ := ret[0].([]entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransactionsWithoutDocument indicates an expected call of FindTransactionsWithoutDocument.
+func (mr *MockTransactionRepositoryMockRecorder) FindTransactionsWithoutDocument(ctx, documentType, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionsWithoutDocument", reflect.TypeOf((*MockTransactionRepository)(nil).FindTransactionsWithoutDocument), ctx, documentType, afterID, limit)
+}
+
+// InsertNewTransaction mocks base method.
+func (m *MockTransactionRepository) InsertNewTransaction(ctx context.Context, tx *sql.Tx, data *entity.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewTransaction", ctx, tx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNewTransaction indicates an expected call of InsertNewTransaction.
+func (mr *MockTransactionRepositoryMockRecorder) InsertNewTransaction(ctx, tx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).InsertNewTransaction), ctx, tx, data)
+}
+
+// InsertNewTransactionDocument mocks base method.
+func (m *MockTransactionRepository) InsertNewTransactionDocument(ctx context.Context, data *entity.TransactionDocument) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewTransactionDocument", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNewTransactionDocument indicates an expected call of InsertNewTransactionDocument.
+func (mr *MockTransactionRepositoryMockRecorder) InsertNewTransactionDocument(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewTransactionDocument", reflect.TypeOf((*MockTransactionRepository)(nil).InsertNewTransactionDocument), ctx, data)
+}
+
+// InsertNewTransactionExport mocks base method.
+func (m *MockTransactionRepository) InsertNewTransactionExport(ctx context.Context, data *entity.TransactionExport) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewTransactionExport", ctx, data)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertNewTransactionExport indicates an expected call of InsertNewTransactionExport.
+func (mr *MockTransactionRepositoryMockRecorder) InsertNewTransactionExport(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewTransactionExport", reflect.TypeOf((*MockTransactionRepository)(nil).InsertNewTransactionExport), ctx, data)
+}
+
+// LeaseTransactionExports mocks base method.
+func (m *MockTransactionRepository) LeaseTransactionExports(ctx context.Context, tx *sql.Tx, ids []int64, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaseTransactionExports", ctx, tx, ids, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LeaseTransactionExports indicates an expected call of LeaseTransactionExports.
+func (mr *MockTransactionRepositoryMockRecorder) LeaseTransactionExports(ctx, tx, ids, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaseTransactionExports", reflect.TypeOf((*MockTransactionRepository)(nil).LeaseTransactionExports), ctx, tx, ids, until)
+}
+
+// LockTransactionByID mocks base method.
+func (m *MockTransactionRepository) LockTransactionByID(ctx context.Context, tx *sql.Tx, id int) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockTransactionByID", ctx, tx, id)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockTransactionByID indicates an expected call of LockTransactionByID.
+func (mr *MockTransactionRepositoryMockRecorder) LockTransactionByID(ctx, tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockTransactionByID", reflect.TypeOf((*MockTransactionRepository)(nil).LockTransactionByID), ctx, tx, id)
+}
+
+// StreamTransactionByCustomerID mocks base method.
+func (m *MockTransactionRepository) StreamTransactionByCustomerID(ctx context.Context, filter *dto.HistoryFilter, customerID, limit int, fn func(*entity.Transaction) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamTransactionByCustomerID", ctx, filter, customerID, limit, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamTransactionByCustomerID indicates an expected call of StreamTransactionByCustomerID.
+func (mr *MockTransactionRepositoryMockRecorder) StreamTransactionByCustomerID(ctx, filter, customerID, limit, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamTransactionByCustomerID", reflect.TypeOf((*MockTransactionRepository)(nil).StreamTransactionByCustomerID), ctx, filter, customerID, limit, fn)
+}
+
+// SumActiveInstallmentByCustomerID mocks base method.
+func (m *MockTransactionRepository) SumActiveInstallmentByCustomerID(ctx context.Context, tx *sql.Tx, customerID int, now time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumActiveInstallmentByCustomerID", ctx, tx, customerID, now)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumActiveInstallmentByCustomerID indicates an expected call of SumActiveInstallmentByCustomerID.
+func (mr *MockTransactionRepositoryMockRecorder) SumActiveInstallmentByCustomerID(ctx, tx, customerID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumActiveInstallmentByCustomerID", reflect.TypeOf((*MockTransactionRepository)(nil).SumActiveInstallmentByCustomerID), ctx, tx, customerID, now)
+}
+
+// UpdateTransactionExport mocks base method.
+func (m *MockTransactionRepository) UpdateTransactionExport(ctx context.Context, data *entity.TransactionExport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionExport", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTransactionExport indicates an expected call of UpdateTransactionExport.
+func (mr *MockTransactionRepositoryMockRecorder) UpdateTransactionExport(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionExport", reflect.TypeOf((*MockTransactionRepository)(nil).UpdateTransactionExport), ctx, data)
+}
+
+// UpdateTransactionStatus mocks base method.
+func (m *MockTransactionRepository) UpdateTransactionStatus(ctx context.Context, tx *sql.Tx, id int, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionStatus", ctx, tx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTransactionStatus indicates an expected call of UpdateTransactionStatus.
+func (mr *MockTransactionRepositoryMockRecorder) UpdateTransactionStatus(ctx, tx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionStatus", reflect.TypeOf((*MockTransactionRepository)(nil).UpdateTransactionStatus), ctx, tx, id, status)
+}
+
+// MockTransactionService is a mock of TransactionService interface.
+type MockTransactionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionServiceMockRecorder is the mock recorder for MockTransactionService.
+type MockTransactionServiceMockRecorder struct {
+	mock *MockTransactionService
+}
+
+// NewMockTransactionService creates a new mock instance.
+func NewMockTransactionService(ctrl *gomock.Controller) *MockTransactionService {
+	mock := &MockTransactionService{ctrl: ctrl}
+	mock.recorder = &MockTransactionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionService) EXPECT() *MockTransactionServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelTransaction mocks base method.
+func (m *MockTransactionService) CancelTransaction(ctx context.Context, id int, staffID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelTransaction", ctx, id, staffID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelTransaction indicates an expected call of CancelTransaction.
+func (mr *MockTransactionServiceMockRecorder) CancelTransaction(ctx, id, staffID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelTransaction", reflect.TypeOf((*MockTransactionService)(nil).CancelTransaction), ctx, id, staffID)
+}
+
+// CreateTransaction mocks base method.
+func (m *MockTransactionService) CreateTransaction(ctx context.Context, req *dto.CreateTransactionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransaction", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTransaction indicates an expected call of CreateTransaction.
+func (mr *MockTransactionServiceMockRecorder) CreateTransaction(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockTransactionService)(nil).CreateTransaction), ctx, req)
+}
+
+// GetDetailTransaction mocks base method.
+func (m *MockTransactionService) GetDetailTransaction(ctx context.Context, id, customerID int) (*dto.GetDetailTransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetailTransaction", ctx, id, customerID)
+	ret0, _ := ret[0].(*dto.GetDetailTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetailTransaction indicates an expected call of GetDetailTransaction.
+func (mr *MockTransactionServiceMockRecorder) GetDetailTransaction(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetailTransaction", reflect.TypeOf((*MockTransactionService)(nil).GetDetailTransaction), ctx, id, customerID)
+}
+
+// GetHistoryListTransction mocks base method.
+func (m *MockTransactionService) GetHistoryListTransction(ctx context.Context, req *dto.GetHistoryListTransactionRequest, customerID int) (*dto.GetHistoryListTransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoryListTransction", ctx, req, customerID)
+	ret0, _ := ret[0].(*dto.GetHistoryListTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoryListTransction indicates an expected call of GetHistoryListTransction.
+func (mr *MockTransactionServiceMockRecorder) GetHistoryListTransction(ctx, req, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoryListTransction", reflect.TypeOf((*MockTransactionService)(nil).GetHistoryListTransction), ctx, req, customerID)
+}
+
+// PayOffTransaction mocks base method.
+func (m *MockTransactionService) PayOffTransaction(ctx context.Context, id int, staffID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayOffTransaction", ctx, id, staffID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PayOffTransaction indicates an expected call of PayOffTransaction.
+func (mr *MockTransactionServiceMockRecorder) PayOffTransaction(ctx, id, staffID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOffTransaction", reflect.TypeOf((*MockTransactionService)(nil).PayOffTransaction), ctx, id, staffID)
+}
+
+// MockTransactionExportService is a mock of TransactionExportService interface.
+type MockTransactionExportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionExportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionExportServiceMockRecorder is the mock recorder for MockTransactionExportService.
+type MockTransactionExportServiceMockRecorder struct {
+	mock *MockTransactionExportService
+}
+
+// NewMockTransactionExportService creates a new mock instance.
+func NewMockTransactionExportService(ctrl *gomock.Controller) *MockTransactionExportService {
+	mock := &MockTransactionExportService{ctrl: ctrl}
+	mock.recorder = &MockTransactionExportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionExportService) EXPECT() *MockTransactionExportServiceMockRecorder {
+	return m.recorder
+}
+
+// ExportTransaction mocks base method.
+func (m *MockTransactionExportService) ExportTransaction(ctx context.Context, req *dto.ExportTransactionRequest, customerID int) (*dto.TransactionExportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTransaction", ctx, req, customerID)
+	ret0, _ := ret[0].(*dto.TransactionExportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportTransaction indicates an expected call of ExportTransaction.
+func (mr *MockTransactionExportServiceMockRecorder) ExportTransaction(ctx, req, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTransaction", reflect.TypeOf((*MockTransactionExportService)(nil).ExportTransaction), ctx, req, customerID)
+}
+
+// GetTransactionExport mocks base method.
+func (m *MockTransactionExportService) GetTransactionExport(ctx context.Context, id int64, customerID int) (*dto.TransactionExportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionExport", ctx, id, customerID)
+	ret0, _ := ret[0].(*dto.TransactionExportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionExport indicates an expected call of GetTransactionExport.
+func (mr *MockTransactionExportServiceMockRecorder) GetTransactionExport(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionExport", reflect.TypeOf((*MockTransactionExportService)(nil).GetTransactionExport), ctx, id, customerID)
+}
+
+// GetTransactionExportFile mocks base method.
+func (m *MockTransactionExportService) GetTransactionExportFile(ctx context.Context, id int64, customerID int) (*dto.TransactionFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionExportFile", ctx, id, customerID)
+	ret0, _ := ret[0].(*dto.TransactionFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionExportFile indicates an expected call of GetTransactionExportFile.
+func (mr *MockTransactionExportServiceMockRecorder) GetTransactionExportFile(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionExportFile", reflect.TypeOf((*MockTransactionExportService)(nil).GetTransactionExportFile), ctx, id, customerID)
+}
+
+// WriteTransactionExport mocks base method.
+func (m *MockTransactionExportService) WriteTransactionExport(ctx context.Context, req *dto.ExportTransactionRequest, customerID int, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteTransactionExport", ctx, req, customerID, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteTransactionExport indicates an expected call of WriteTransactionExport.
+func (mr *MockTransactionExportServiceMockRecorder) WriteTransactionExport(ctx, req, customerID, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteTransactionExport", reflect.TypeOf((*MockTransactionExportService)(nil).WriteTransactionExport), ctx, req, customerID, w)
+}
+
+// MockTransactionExportRunner is a mock of TransactionExportRunner interface.
+type MockTransactionExportRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionExportRunnerMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionExportRunnerMockRecorder is the mock recorder for MockTransactionExportRunner.
+type MockTransactionExportRunnerMockRecorder struct {
+	mock *MockTransactionExportRunner
+}
+
+// NewMockTransactionExportRunner creates a new mock instance.
+func NewMockTransactionExportRunner(ctrl *gomock.Controller) *MockTransactionExportRunner {
+	mock := &MockTransactionExportRunner{ctrl: ctrl}
+	mock.recorder = &MockTransactionExportRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionExportRunner) EXPECT() *MockTransactionExportRunnerMockRecorder {
+	return m.recorder
+}
+
+// RunPendingExports mocks base method.
+func (m *MockTransactionExportRunner) RunPendingExports(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunPendingExports", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunPendingExports indicates an expected call of RunPendingExports.
+func (mr *MockTransactionExportRunnerMockRecorder) RunPendingExports(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunPendingExports", reflect.TypeOf((*MockTransactionExportRunner)(nil).RunPendingExports), ctx)
+}
+
+// MockTransactionContractService is a mock of TransactionContractService interface.
+type MockTransactionContractService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionContractServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionContractServiceMockRecorder is the mock recorder for MockTransactionContractService.
+type MockTransactionContractServiceMockRecorder struct {
+	mock *MockTransactionContractService
+}
+
+// NewMockTransactionContractService creates a new mock instance.
+func NewMockTransactionContractService(ctrl *gomock.Controller) *MockTransactionContractService {
+	mock := &MockTransactionContractService{ctrl: ctrl}
+	mock.recorder = &MockTransactionContractServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionContractService) EXPECT() *MockTransactionContractServiceMockRecorder {
+	return m.recorder
+}
+
+// BackfillContracts mocks base method.
+func (m *MockTransactionContractService) BackfillContracts(ctx context.Context, batchSize int) (*dto.ContractBackfillResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillContracts", ctx, batchSize)
+	ret0, _ := ret[0].(*dto.ContractBackfillResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillContracts indicates an expected call of BackfillContracts.
+func (mr *MockTransactionContractServiceMockRecorder) BackfillContracts(ctx, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillContracts", reflect.TypeOf((*MockTransactionContractService)(nil).BackfillContracts), ctx, batchSize)
+}
+
+// GetTransactionContract mocks base method.
+func (m *MockTransactionContractService) GetTransactionContract(ctx context.Context, id, customerID int) (*dto.TransactionFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionContract", ctx, id, customerID)
+	ret0, _ := ret[0].(*dto.TransactionFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionContract indicates an expected call of GetTransactionContract.
+func (mr *MockTransactionContractServiceMockRecorder) GetTransactionContract(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionContract", reflect.TypeOf((*MockTransactionContractService)(nil).GetTransactionContract), ctx, id, customerID)
+}
+
+// HandleEvent mocks base method.
+func (m *MockTransactionContractService) HandleEvent(ctx context.Context, event *outbox.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleEvent indicates an expected call of HandleEvent.
+func (mr *MockTransactionContractServiceMockRecorder) HandleEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvent", reflect.TypeOf((*MockTransactionContractService)(nil).HandleEvent), ctx, event)
+}
@@ -0,0 +1,246 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/jwt_handler"
+	pb "github.com/hilmiikhsan/multifinance-service/pkg/pb/multifinance/v1"
+	"github.com/hilmiikhsan/multifinance-service/pkg/types"
+	"github.com/hilmiikhsan/multifinance-service/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	googleGrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeJWT struct{}
+
+func (fakeJWT) GenerateTokenString(ctx context.Context, payload jwt_handler.CostumClaimsPayload) (string, error) {
+	return "", nil
+}
+
+func (fakeJWT) ParseTokenString(ctx context.Context, tokenString string) (*jwt_handler.CustomClaims, error) {
+	if tokenString != "valid-token" {
+		return nil, errors.New("token is malformed")
+	}
+
+	return &jwt_handler.CustomClaims{CustomerID: 1}, nil
+}
+
+func newTestClient(t *testing.T, handler *transactionHandler) pb.TransactionServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := googleGrpc.NewServer(googleGrpc.ChainUnaryInterceptor(
+		middleware.UnaryError,
+		middleware.NewAuthMiddleware(fakeJWT{}).UnaryAuth,
+	))
+	handler.TransactionService(server)
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := googleGrpc.NewClient("passthrough:///bufnet",
+		googleGrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		googleGrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewTransactionServiceClient(conn)
+}
+
+func Test_transactionHandler_GetTransaction(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockTransactionService(ctrlMock)
+	client := newTestClient(t, &transactionHandler{service: mockSvc, validator: validator.NewValidator()})
+
+	type args struct {
+		id     int64
+		mockFn func()
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		wantCode codes.Code
+	}{
+		{
+			name: "Success",
+			args: args{
+				id: 7,
+				mockFn: func() {
+					mockSvc.EXPECT().GetDetailTransaction(gomock.Any(), 7, 1).Return(&dto.GetDetailTransactionResponse{
+						ID:             7,
+						CustomerID:     1,
+						ContractNumber: "KTR-0007",
+						OnTheRoadPrice: 25000000,
+					}, nil)
+				},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "ID Required",
+			args: args{
+				mockFn: func() {},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Transaction Not Found",
+			args: args{
+				id: 8,
+				mockFn: func() {
+					mockSvc.EXPECT().GetDetailTransaction(gomock.Any(), 8, 1).Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrTransactionNotFound)))
+				},
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.mockFn()
+
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer valid-token")
+
+			res, err := client.GetTransaction(ctx, &pb.GetTransactionRequest{Id: tt.args.id})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, "KTR-0007", res.GetTransaction().GetContractNumber())
+				assert.Equal(t, 25000000.0, res.GetTransaction().GetOnTheRoadPrice())
+			}
+		})
+	}
+}
+
+func Test_transactionHandler_ListTransactions(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockTransactionService(ctrlMock)
+	client := newTestClient(t, &transactionHandler{service: mockSvc, validator: validator.NewValidator()})
+
+	total := 21
+
+	type args struct {
+		req    *pb.ListTransactionsRequest
+		mockFn func()
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		wantCode  codes.Code
+		wantField string
+	}{
+		{
+			name: "Offset Page With Defaults",
+			args: args{
+				req: &pb.ListTransactionsRequest{Status: "active"},
+				mockFn: func() {
+					mockSvc.EXPECT().GetHistoryListTransction(gomock.Any(), gomock.Any(), 1).DoAndReturn(func(ctx context.Context, req *dto.GetHistoryListTransactionRequest, customerID int) (*dto.GetHistoryListTransactionResponse, error) {
+						assert.Equal(t, 1, req.Page)
+						assert.Equal(t, 10, req.Paginate)
+						assert.Equal(t, "offset", req.Pagination)
+						assert.Equal(t, "created_at", req.SortBy)
+						assert.Equal(t, "active", req.Status)
+
+						return &dto.GetHistoryListTransactionResponse{
+							Items: []dto.HistoryListTransactionItem{{ID: 3, CustomerID: 1, TenorMonth: 12, Status: "active"}},
+							Meta:  &types.Meta{Page: 1, Paginate: 10, TotalData: 1, TotalPage: 1},
+						}, nil
+					})
+				},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Cursor Page",
+			args: args{
+				req: &pb.ListTransactionsRequest{Cursor: "next-page", WithTotal: true},
+				mockFn: func() {
+					mockSvc.EXPECT().GetHistoryListTransction(gomock.Any(), gomock.Any(), 1).Return(&dto.GetHistoryListTransactionResponse{
+						Items:  []dto.HistoryListTransactionItem{{ID: 2}},
+						Cursor: &types.CursorMeta{Paginate: 10, Next: "after-2", TotalData: &total},
+					}, nil)
+				},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Invalid Filter",
+			args: args{
+				req:    &pb.ListTransactionsRequest{SortDir: "sideways"},
+				mockFn: func() {},
+			},
+			wantCode:  codes.InvalidArgument,
+			wantField: "HistoryFilter.sort_dir",
+		},
+		{
+			name: "Internal Server Error",
+			args: args{
+				req: &pb.ListTransactionsRequest{},
+				mockFn: func() {
+					mockSvc.EXPECT().GetHistoryListTransction(gomock.Any(), gomock.Any(), 1).Return(nil, errors.New(constants.ErrInternalServerError))
+				},
+			},
+			wantCode: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.mockFn()
+
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer valid-token")
+
+			res, err := client.ListTransactions(ctx, tt.args.req)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantField != "" {
+				var fields []string
+				for _, detail := range status.Convert(err).Details() {
+					if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+						for _, violation := range badRequest.GetFieldViolations() {
+							fields = append(fields, violation.GetField())
+						}
+					}
+				}
+				assert.Contains(t, fields, tt.wantField)
+			}
+
+			if tt.wantCode != codes.OK {
+				return
+			}
+
+			assert.Len(t, res.GetItems(), 1)
+			if res.GetCursor() != nil {
+				assert.Nil(t, res.GetMeta())
+				assert.Equal(t, "after-2", res.GetCursor().GetNext())
+				assert.Equal(t, int32(21), res.GetCursor().GetTotalData())
+			} else {
+				assert.Equal(t, int32(1), res.GetMeta().GetTotalPage())
+				assert.Equal(t, "active", res.GetItems()[0].GetStatus())
+			}
+		})
+	}
+}
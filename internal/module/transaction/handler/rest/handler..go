@@ -1,28 +1,39 @@
 package rest
 
 import (
+	"bufio"
+	"context"
+	"fmt"
+	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hilmiikhsan/multifinance-service/constants"
 	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
 	redisRepository "github.com/hilmiikhsan/multifinance-service/internal/infrastructure/redis"
 	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
-	creditLimitRepository "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/repository"
+	customerRepository "github.com/hilmiikhsan/multifinance-service/internal/module/customer/repository"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
+	transactionWiring "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/handler"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/ports"
 	transactionRepository "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/repository"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/service"
 	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/export"
 	jwtHandler "github.com/hilmiikhsan/multifinance-service/pkg/jwt_handler"
 	"github.com/hilmiikhsan/multifinance-service/pkg/response"
 	"github.com/rs/zerolog/log"
 )
 
 type transactionHandler struct {
-	service    ports.TransactionService
-	middleware middleware.AuthMiddleware
-	validator  adapter.Validator
+	service         ports.TransactionService
+	exportService   ports.TransactionExportService
+	contractService ports.TransactionContractService
+	middleware      middleware.AuthMiddleware
+	staffMiddleware middleware.StaffMiddleware
+	validator       adapter.Validator
 }
 
 func NewTransactionHandler() *transactionHandler {
@@ -39,21 +50,35 @@ func NewTransactionHandler() *transactionHandler {
 
 	// middleware
 	middlewareHandler := middleware.NewAuthMiddleware(jwt)
+	staffMiddlewareHandler := middleware.NewStaffMiddleware(config.Envs.Fraud.StaffApiKey)
 
 	// repository
-	transactionRepository := transactionRepository.NewTransactionRepository(adapter.Adapters.MultifinanceMysql)
-	creditLimitRepository := creditLimitRepository.NewCreditLimitRepository(adapter.Adapters.MultifinanceMysql)
+	transactionRepository := transactionRepository.NewTransactionRepository(adapter.Adapters.MultifinanceDB)
+	customerRepository := customerRepository.NewCustomerRepository(adapter.Adapters.MultifinanceDB)
 
 	// service
-	transactionService := service.NewTransactionService(
-		adapter.Adapters.MultifinanceMysql,
+	transactionService := transactionWiring.NewTransactionService()
+
+	exportService := service.NewExportService(
+		transactionRepository,
+		filepath.Join(config.Envs.App.LocalStoragePrivatePath, "exports"),
+		config.Envs.Export.MaxInlineRows,
+		config.Envs.Export.MaxRows,
+	)
+
+	contractService := service.NewContractService(
 		transactionRepository,
-		creditLimitRepository,
+		customerRepository,
+		filepath.Join(config.Envs.App.LocalStoragePrivatePath, "contracts"),
+		config.Envs.Document.ContractTemplateVersion,
 	)
 
 	// handler
 	handler.service = transactionService
+	handler.exportService = exportService
+	handler.contractService = contractService
 	handler.middleware = *middlewareHandler
+	handler.staffMiddleware = *staffMiddlewareHandler
 	handler.validator = validator
 
 	return handler
@@ -61,31 +86,40 @@ func NewTransactionHandler() *transactionHandler {
 
 func (h *transactionHandler) TransactionRoute(router fiber.Router) {
 	router.Post("/create", h.middleware.AuthBearer, h.createTranscation)
+	router.Get("/export", h.middleware.AuthBearer, h.exportTransaction)
+	router.Get("/export/:id", h.middleware.AuthBearer, h.getTransactionExport)
+	router.Get("/export/:id/download", h.middleware.AuthBearer, h.downloadTransactionExport)
+	router.Get("/:id/contract", h.middleware.AuthBearer, h.downloadTransactionContract)
+	router.Post("/:id/cancel", h.staffMiddleware.StaffKey, h.cancelTransaction)
+	router.Post("/:id/pay-off", h.staffMiddleware.StaffKey, h.payOffTransaction)
 	router.Get("/:id", h.middleware.AuthBearer, h.getDetailTransaction)
 	router.Get("/", h.middleware.AuthBearer, h.getHistoryListTransaction)
 }
 
 func (h *transactionHandler) createTranscation(c *fiber.Ctx) error {
 	var (
-		ctx    = c.Context()
+		ctx    = c.UserContext()
 		req    = new(dto.CreateTransactionRequest)
 		locals = middleware.GetLocals(c)
 	)
 
 	if err := c.BodyParser(req); err != nil {
-		log.Warn().Err(err).Msg("handler::createTranscation - Failed to parse request body")
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::createTranscation - Failed to parse request body")
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
 	if err := h.validator.Validate(req); err != nil {
-		log.Warn().Err(err).Msg("handler::createTranscation - Invalid request body")
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::createTranscation - Invalid request body")
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
 	req.CustomerID = locals.GetCustomerID()
+	req.DeviceID = c.Get(constants.HeaderDeviceID)
+	req.Channel = c.Get(constants.HeaderChannel)
+	req.PartnerCode = c.Get(constants.HeaderPartnerCode)
 
 	if err := h.service.CreateTransaction(ctx, req); err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("handler::createTranscation - Failed to create transaction")
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("handler::createTranscation - Failed to create transaction")
 		code, errs := err_msg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
@@ -93,22 +127,64 @@ func (h *transactionHandler) createTranscation(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(response.Success(nil, ""))
 }
 
+func (h *transactionHandler) cancelTransaction(c *fiber.Ctx) error {
+	var (
+		ctx     = c.UserContext()
+		staffID = middleware.GetStaffID(c)
+	)
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil || id < 1 {
+		log.Ctx(ctx).Warn().Str("id", c.Params("id")).Msg("handler::cancelTransaction - Invalid id")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(constants.ErrParamIdIsRequired))
+	}
+
+	if err := h.service.CancelTransaction(ctx, id, staffID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Msg("handler::cancelTransaction - Failed to cancel transaction")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+func (h *transactionHandler) payOffTransaction(c *fiber.Ctx) error {
+	var (
+		ctx     = c.UserContext()
+		staffID = middleware.GetStaffID(c)
+	)
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil || id < 1 {
+		log.Ctx(ctx).Warn().Str("id", c.Params("id")).Msg("handler::payOffTransaction - Invalid id")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(constants.ErrParamIdIsRequired))
+	}
+
+	if err := h.service.PayOffTransaction(ctx, id, staffID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Msg("handler::payOffTransaction - Failed to pay off transaction")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
 func (h *transactionHandler) getDetailTransaction(c *fiber.Ctx) error {
 	var (
-		ctx    = c.Context()
+		ctx    = c.UserContext()
 		locals = middleware.GetLocals(c)
 	)
 
 	idStr := c.Params("id")
 
 	if idStr == "0" {
-		log.Warn().Msg("handler::getDetailTransaction - ID is required")
+		log.Ctx(ctx).Warn().Msg("handler::getDetailTransaction - ID is required")
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(constants.ErrParamIdIsRequired))
 	}
 
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		log.Error().Err(err).Msg("handler::getDetailTransaction - Failed to parse id")
+		log.Ctx(ctx).Error().Err(err).Msg("handler::getDetailTransaction - Failed to parse id")
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(constants.ErrParamIdIsRequired))
 	}
 
@@ -116,7 +192,7 @@ func (h *transactionHandler) getDetailTransaction(c *fiber.Ctx) error {
 
 	res, err := h.service.GetDetailTransaction(ctx, id, customerID)
 	if err != nil {
-		log.Error().Err(err).Int("customer_id", customerID).Msg("handler::getDetailTransaction - Failed to get detail transaction")
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("handler::getDetailTransaction - Failed to get detail transaction")
 		code, errs := err_msg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
@@ -127,28 +203,146 @@ func (h *transactionHandler) getDetailTransaction(c *fiber.Ctx) error {
 func (h *transactionHandler) getHistoryListTransaction(c *fiber.Ctx) error {
 	var (
 		req    = new(dto.GetHistoryListTransactionRequest)
-		ctx    = c.Context()
+		ctx    = c.UserContext()
 		locals = middleware.GetLocals(c)
 	)
 
 	if err := c.QueryParser(req); err != nil {
-		log.Warn().Err(err).Msg("handler::getHistoryListTransaction - Failed to parse request query")
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getHistoryListTransaction - Failed to parse request query")
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
 	req.SetDefault()
 
 	if err := h.validator.Validate(req); err != nil {
-		log.Warn().Err(err).Msg("handler::getHistoryListTransaction - Invalid request query")
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getHistoryListTransaction - Invalid request query")
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
 	res, err := h.service.GetHistoryListTransction(ctx, req, locals.GetCustomerID())
 	if err != nil {
-		log.Error().Err(err).Int("customer_id", locals.GetCustomerID()).Msg("handler::getHistoryListTransaction - Failed to get history list transaction")
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", locals.GetCustomerID()).Msg("handler::getHistoryListTransaction - Failed to get history list transaction")
 		code, errs := err_msg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
 }
+
+// exportTransaction streams small exports straight into the response and
+// answers 202 with the queued job for larger ones.
+func (h *transactionHandler) exportTransaction(c *fiber.Ctx) error {
+	var (
+		req    = new(dto.ExportTransactionRequest)
+		ctx    = c.UserContext()
+		locals = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::exportTransaction - Failed to parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+	if req.Locale == "" {
+		req.Locale = export.LookupLocale(c.Get(fiber.HeaderAcceptLanguage)).Code
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::exportTransaction - Invalid request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	customerID := locals.GetCustomerID()
+
+	job, err := h.exportService.ExportTransaction(ctx, req, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("handler::exportTransaction - Failed to export transaction")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if job != nil {
+		return c.Status(fiber.StatusAccepted).JSON(response.Success(job, ""))
+	}
+
+	c.Attachment(fmt.Sprintf("transactions-%s.%s", time.Now().Format("20060102"), req.Format))
+	c.Set(fiber.HeaderContentType, export.ContentType(req.Format))
+
+	// the body is written after the handler returns, so it keeps the trace of the request but not its cancellation
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.exportService.WriteTransactionExport(context.WithoutCancel(ctx), req, customerID, w); err != nil {
+			log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("handler::exportTransaction - Failed to stream export")
+		}
+	})
+
+	return nil
+}
+
+func (h *transactionHandler) getTransactionExport(c *fiber.Ctx) error {
+	var (
+		ctx    = c.UserContext()
+		locals = middleware.GetLocals(c)
+	)
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id < 1 {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getTransactionExport - Invalid id")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(constants.ErrParamIdIsRequired))
+	}
+
+	res, err := h.exportService.GetTransactionExport(ctx, id, locals.GetCustomerID())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("handler::getTransactionExport - Failed to get transaction export")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
+
+func (h *transactionHandler) downloadTransactionExport(c *fiber.Ctx) error {
+	var (
+		ctx    = c.UserContext()
+		locals = middleware.GetLocals(c)
+	)
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id < 1 {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::downloadTransactionExport - Invalid id")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(constants.ErrParamIdIsRequired))
+	}
+
+	file, err := h.exportService.GetTransactionExportFile(ctx, id, locals.GetCustomerID())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("handler::downloadTransactionExport - Failed to get transaction export file")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	c.Set(fiber.HeaderContentType, file.ContentType)
+	return c.Download(file.Path, file.FileName)
+}
+
+func (h *transactionHandler) downloadTransactionContract(c *fiber.Ctx) error {
+	var (
+		ctx    = c.UserContext()
+		locals = middleware.GetLocals(c)
+	)
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil || id < 1 {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::downloadTransactionContract - Invalid id")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(constants.ErrParamIdIsRequired))
+	}
+
+	file, err := h.contractService.GetTransactionContract(ctx, id, locals.GetCustomerID())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Msg("handler::downloadTransactionContract - Failed to get transaction contract")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	c.Set(fiber.HeaderContentType, file.ContentType)
+	return c.Download(file.Path, file.FileName)
+}
@@ -3,13 +3,18 @@ package rest
 import (
 	"bytes"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
 	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
 	"github.com/stretchr/testify/assert"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -348,3 +353,242 @@ func Test_transactionHandler_getHistoryListTransaction(t *testing.T) {
 		})
 	}
 }
+
+func Test_transactionHandler_exportTransaction(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockExportSvc := NewMockTransactionExportService(ctrlMock)
+	mockValidator := NewMockValidator(ctrlMock)
+
+	tests := []struct {
+		name           string
+		query          string
+		acceptLanguage string
+		mockFn         func()
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Streams Small Export",
+			query:          "?format=csv",
+			acceptLanguage: "en-US,en;q=0.9",
+			mockFn: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockExportSvc.EXPECT().ExportTransaction(gomock.Any(), gomock.Cond(func(x any) bool {
+					return x.(*dto.ExportTransactionRequest).Locale == "en"
+				}), 1).Return(nil, nil)
+				mockExportSvc.EXPECT().WriteTransactionExport(gomock.Any(), gomock.Any(), 1, gomock.Any()).
+					DoAndReturn(func(_ any, _ any, _ int, w io.Writer) error {
+						_, err := io.WriteString(w, "Contract Number\nKTR-1\n")
+						return err
+					})
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   "Contract Number\nKTR-1\n",
+		},
+		{
+			name:  "Queues Large Export",
+			query: "?format=xlsx&locale=id",
+			mockFn: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockExportSvc.EXPECT().ExportTransaction(gomock.Any(), gomock.Any(), 1).
+					Return(&dto.TransactionExportResponse{ID: 1, Format: "xlsx", Status: "pending"}, nil)
+			},
+			expectedStatus: fiber.StatusAccepted,
+		},
+		{
+			name:  "Invalid Query",
+			query: "?format=pdf",
+			mockFn: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(errors.New("format must be one of csv xlsx"))
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:  "Internal Server Error",
+			query: "?format=csv",
+			mockFn: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockExportSvc.EXPECT().ExportTransaction(gomock.Any(), gomock.Any(), 1).Return(nil, errors.New("internal server error"))
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			handler := &transactionHandler{
+				exportService: mockExportSvc,
+				validator:     mockValidator,
+			}
+
+			app.Get("/export", func(c *fiber.Ctx) error {
+				c.Locals("customer_id", 1)
+				return handler.exportTransaction(c)
+			})
+
+			tt.mockFn()
+
+			req := httptest.NewRequest(http.MethodGet, "/export"+tt.query, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set(fiber.HeaderAcceptLanguage, tt.acceptLanguage)
+			}
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode, "Unexpected status code")
+
+			if tt.expectedBody != "" {
+				body, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBody, string(body))
+				assert.Contains(t, resp.Header.Get(fiber.HeaderContentDisposition), "attachment")
+			}
+		})
+	}
+}
+
+func Test_transactionHandler_downloadTransactionContract(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockContractSvc := NewMockTransactionContractService(ctrlMock)
+
+	path := filepath.Join(t.TempDir(), "contract-1-v1.pdf")
+	assert.NoError(t, os.WriteFile(path, []byte("%PDF-1.3"), 0o600))
+
+	tests := []struct {
+		name           string
+		id             string
+		mockFn         func()
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			id:   "1",
+			mockFn: func() {
+				mockContractSvc.EXPECT().GetTransactionContract(gomock.Any(), 1, 1).Return(&dto.TransactionFile{
+					Path:        path,
+					FileName:    "TRX202412010001.pdf",
+					ContentType: "application/pdf",
+				}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Invalid ID",
+			id:             "abc",
+			mockFn:         func() {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "Transaction Not Found",
+			id:   "2",
+			mockFn: func() {
+				mockContractSvc.EXPECT().GetTransactionContract(gomock.Any(), 2, 1).
+					Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrTransactionNotFound)))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			handler := &transactionHandler{
+				contractService: mockContractSvc,
+			}
+
+			app.Get("/:id/contract", func(c *fiber.Ctx) error {
+				c.Locals("customer_id", 1)
+				return handler.downloadTransactionContract(c)
+			})
+
+			tt.mockFn()
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/"+tt.id+"/contract", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode, "Unexpected status code")
+
+			if tt.expectedStatus == fiber.StatusOK {
+				assert.Equal(t, "application/pdf", resp.Header.Get(fiber.HeaderContentType))
+				assert.Contains(t, resp.Header.Get(fiber.HeaderContentDisposition), "TRX202412010001.pdf")
+			}
+		})
+	}
+}
+
+func Test_transactionHandler_cancelTransaction(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockTransactionService(ctrlMock)
+
+	tests := []struct {
+		name           string
+		id             string
+		mockFn         func()
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			id:   "5",
+			mockFn: func() {
+				mockSvc.EXPECT().CancelTransaction(gomock.Any(), 5, "staff-1").Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Invalid ID",
+			id:             "0",
+			mockFn:         func() {},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "Transaction Not Active",
+			id:   "5",
+			mockFn: func() {
+				mockSvc.EXPECT().CancelTransaction(gomock.Any(), 5, "staff-1").
+					Return(err_msg.NewCustomErrors(fiber.StatusConflict, err_msg.WithMessage(constants.ErrTransactionNotActive)))
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			handler := &transactionHandler{service: mockSvc}
+
+			app.Post("/:id/cancel", func(c *fiber.Ctx) error {
+				c.Locals("staff_id", "staff-1")
+				return handler.cancelTransaction(c)
+			})
+
+			tt.mockFn()
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/"+tt.id+"/cancel", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode, "Unexpected status code")
+		})
+	}
+}
+
+func Test_transactionHandler_payOffTransaction(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockTransactionService(ctrlMock)
+	mockSvc.EXPECT().PayOffTransaction(gomock.Any(), 5, "staff-1").Return(nil)
+
+	app := fiber.New()
+	handler := &transactionHandler{service: mockSvc}
+
+	app.Post("/:id/pay-off", func(c *fiber.Ctx) error {
+		c.Locals("staff_id", "staff-1")
+		return handler.payOffTransaction(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/5/pay-off", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	redisRepository "github.com/hilmiikhsan/multifinance-service/internal/infrastructure/redis"
+	creditLimitRepository "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/repository"
+	creditScoreRepository "github.com/hilmiikhsan/multifinance-service/internal/module/credit_score/repository"
+	customerRepository "github.com/hilmiikhsan/multifinance-service/internal/module/customer/repository"
+	customerService "github.com/hilmiikhsan/multifinance-service/internal/module/customer/service"
+	fraudRepository "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/repository"
+	fraudService "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/service"
+	outboxRepository "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/repository"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/ports"
+	transactionRepository "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/repository"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/service"
+	webhookRepository "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/repository"
+	webhookService "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/service"
+	"github.com/hilmiikhsan/multifinance-service/pkg/eligibility"
+	"github.com/hilmiikhsan/multifinance-service/pkg/scoring"
+	"github.com/hilmiikhsan/multifinance-service/pkg/velocity"
+	"github.com/rs/zerolog/log"
+)
+
+// NewTransactionService wires the transaction service for the REST and gRPC
+// handlers.
+func NewTransactionService() ports.TransactionService {
+	// redis
+	redisRepository := redisRepository.NewRedisRepository(adapter.Adapters.MultifinanceRedis)
+
+	// repository
+	transactionRepository := transactionRepository.NewTransactionRepository(adapter.Adapters.MultifinanceDB)
+	creditLimitRepository := creditLimitRepository.NewCreditLimitRepository(adapter.Adapters.MultifinanceDB)
+	customerRepository := customerRepository.NewCustomerRepository(adapter.Adapters.MultifinanceDB)
+	creditScoreRepository := creditScoreRepository.NewCreditScoreRepository(adapter.Adapters.MultifinanceDB)
+	fraudRepository := fraudRepository.NewFraudRepository(adapter.Adapters.MultifinanceDB)
+	outboxRepository := outboxRepository.NewOutboxRepository(adapter.Adapters.MultifinanceDB)
+	webhookRepository := webhookRepository.NewWebhookRepository(adapter.Adapters.MultifinanceDB)
+
+	// scoring
+	scorecard, err := scoring.LoadScorecard(config.Envs.Scoring.ScorecardPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("handler::NewTransactionService - Failed to load scorecard")
+	}
+
+	// velocity
+	velocityRules, err := velocity.LoadRules(config.Envs.Velocity.RulesPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("handler::NewTransactionService - Failed to load velocity rules")
+	}
+
+	// eligibility
+	eligibilityEngine := eligibility.NewEngine(
+		eligibility.MinAgeAtApplication(config.Envs.Eligibility.MinAge),
+		eligibility.MaxAgeAtTenorEnd(config.Envs.Eligibility.MaxAgeAtTenorEnd),
+	)
+
+	// fraud screening
+	fraudScreener := fraudService.NewFraudService(
+		adapter.Adapters.MultifinanceDB,
+		fraudRepository,
+		customerRepository,
+		config.Envs.App.LocalStoragePrivatePath,
+		config.Envs.Fraud.PhotoHashMaxDistance,
+	)
+
+	return service.NewTransactionService(
+		adapter.Adapters.MultifinanceDB,
+		transactionRepository,
+		creditLimitRepository,
+		customerRepository,
+		eligibilityEngine,
+		config.Envs.Affordability.MaxDebtToIncomeRatio,
+		creditScoreRepository,
+		scorecard,
+		fraudScreener,
+		velocity.NewRedisLimiter(adapter.Adapters.MultifinanceRedis, velocityRules),
+		customerService.NewCustomerService(
+			adapter.Adapters.MultifinanceDB,
+			customerRepository,
+			creditLimitRepository,
+			redisRepository,
+			time.Duration(config.Envs.Summary.CacheTTLSeconds)*time.Second,
+		),
+		outboxRepository,
+		webhookService.NewWebhookService(webhookRepository),
+	)
+}
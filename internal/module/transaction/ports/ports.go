@@ -3,16 +3,36 @@ package ports
 import (
 	"context"
 	"database/sql"
+	"io"
+	"time"
 
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/outbox"
 )
 
 //go:generate mockgen -source=ports.go -destination=../service/service_mock_test.go -package=service
 type TransactionRepository interface {
 	InsertNewTransaction(ctx context.Context, tx *sql.Tx, data *entity.Transaction) error
+	SumActiveInstallmentByCustomerID(ctx context.Context, tx *sql.Tx, customerID int, now time.Time) (float64, error)
+	FindContractsByCustomerID(ctx context.Context, tx *sql.Tx, customerID int) ([]entity.Transaction, error)
+	LockTransactionByID(ctx context.Context, tx *sql.Tx, id int) (*entity.Transaction, error)
+	UpdateTransactionStatus(ctx context.Context, tx *sql.Tx, id int, status string) error
+	FindPaymentsByCustomerID(ctx context.Context, tx *sql.Tx, customerID int) ([]entity.TransactionPayment, error)
 	FindTransactionByIdAndCustomerID(ctx context.Context, id, customerID int) (*entity.Transaction, error)
+	FindTransactionByContractNumber(ctx context.Context, contractNumber string) (*entity.Transaction, error)
 	FindTransactionByCustomerID(ctx context.Context, req *dto.GetHistoryListTransactionRequest, customerID int) (*dto.GetHistoryListTransactionResponse, error)
+	FindTransactionByCustomerIDCursor(ctx context.Context, req *dto.GetHistoryListTransactionRequest, customerID int) (*dto.GetHistoryListTransactionResponse, error)
+	CountTransactionByCustomerID(ctx context.Context, filter *dto.HistoryFilter, customerID int) (int, error)
+	StreamTransactionByCustomerID(ctx context.Context, filter *dto.HistoryFilter, customerID, limit int, fn func(*entity.Transaction) error) error
+	InsertNewTransactionExport(ctx context.Context, data *entity.TransactionExport) (int64, error)
+	UpdateTransactionExport(ctx context.Context, data *entity.TransactionExport) error
+	ClaimTransactionExports(ctx context.Context, tx *sql.Tx, now time.Time, limit int) ([]entity.TransactionExport, error)
+	LeaseTransactionExports(ctx context.Context, tx *sql.Tx, ids []int64, until time.Time) error
+	FindTransactionExportByIDAndCustomerID(ctx context.Context, id int64, customerID int) (*entity.TransactionExport, error)
+	FindTransactionsWithoutDocument(ctx context.Context, documentType string, afterID, limit int) ([]entity.Transaction, error)
+	FindTransactionDocument(ctx context.Context, transactionID int, documentType string) (*entity.TransactionDocument, error)
+	InsertNewTransactionDocument(ctx context.Context, data *entity.TransactionDocument) error
 }
 
 //go:generate mockgen -source=ports.go -destination=../handler/rest/handler_mock_test.go -package=rest
@@ -20,4 +40,31 @@ type TransactionService interface {
 	CreateTransaction(ctx context.Context, req *dto.CreateTransactionRequest) error
 	GetDetailTransaction(ctx context.Context, id, customerID int) (*dto.GetDetailTransactionResponse, error)
 	GetHistoryListTransction(ctx context.Context, req *dto.GetHistoryListTransactionRequest, customerID int) (*dto.GetHistoryListTransactionResponse, error)
+	CancelTransaction(ctx context.Context, id int, staffID string) error
+	PayOffTransaction(ctx context.Context, id int, staffID string) error
+}
+
+// TransactionExportService exports a customer's history. ExportTransaction
+// returns nil when the export is small enough for WriteTransactionExport to
+// stream in the request, and the queued job otherwise.
+type TransactionExportService interface {
+	ExportTransaction(ctx context.Context, req *dto.ExportTransactionRequest, customerID int) (*dto.TransactionExportResponse, error)
+	WriteTransactionExport(ctx context.Context, req *dto.ExportTransactionRequest, customerID int, w io.Writer) error
+	GetTransactionExport(ctx context.Context, id int64, customerID int) (*dto.TransactionExportResponse, error)
+	GetTransactionExportFile(ctx context.Context, id int64, customerID int) (*dto.TransactionFile, error)
+}
+
+// TransactionExportRunner writes the queued exports in the export worker.
+// RunPendingExports returns the number of jobs it claimed.
+type TransactionExportRunner interface {
+	RunPendingExports(ctx context.Context) (int, error)
+}
+
+// TransactionContractService issues the contract of a booked transaction from
+// the booking event and serves the stored file afterwards. BackfillContracts
+// issues the contracts whose booking event was lost.
+type TransactionContractService interface {
+	HandleEvent(ctx context.Context, event *outbox.Event) error
+	GetTransactionContract(ctx context.Context, id, customerID int) (*dto.TransactionFile, error)
+	BackfillContracts(ctx context.Context, batchSize int) (*dto.ContractBackfillResponse, error)
 }
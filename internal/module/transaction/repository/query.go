@@ -1,17 +1,22 @@
 package repository
 
+import "github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+
 const (
 	queryInsertNewTransaction = `
 		INSERT INTO transactions
 		(
 			customer_id,
+			partner_id,
 			contract_number,
 			on_the_road_price,
 			admin_fee,
 			installment_amount,
 			interest_amount,
-			asset_name
-		) VALUES (?, ?, ?, ?, ?, ?, ?)
+			tenor_month,
+			asset_name,
+			status
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	queryFindTransactionByIdAndCustomerID = `
@@ -23,12 +28,33 @@ const (
 			admin_fee,
 			installment_amount,
 			interest_amount,
+			tenor_month,
 			asset_name,
+			status,
 			created_at
 		FROM transactions
 		WHERE id = ? AND customer_id = ?
 	`
 
+	queryFindTransactionByContractNumber = `
+		SELECT
+			id,
+			customer_id,
+			contract_number,
+			on_the_road_price,
+			admin_fee,
+			installment_amount,
+			interest_amount,
+			tenor_month,
+			asset_name,
+			status,
+			created_at
+		FROM transactions
+		WHERE contract_number = ?
+	`
+
+	// %[1]s holds the filters and %[2]s the sort column and direction, both
+	// built from whitelisted fragments only
 	queryFindTransactionByCustomerID = `
 		SELECT
 			id,
@@ -38,17 +64,265 @@ const (
 			admin_fee,
 			installment_amount,
 			interest_amount,
+			tenor_month,
 			asset_name,
+			status,
 			created_at
 		FROM transactions
-		WHERE customer_id = :customer_id
-		ORDER BY created_at DESC
+		WHERE customer_id = :customer_id%[1]s
+		ORDER BY %[2]s
 		LIMIT :limit OFFSET :offset
 	`
 
+	// %[1]s holds the filters, %[2]s the keyset condition and %[3]s the scan
+	// direction; one extra row is fetched to tell whether another page exists
+	queryFindTransactionByCustomerIDCursor = `
+		SELECT
+			id,
+			customer_id,
+			contract_number,
+			on_the_road_price,
+			admin_fee,
+			installment_amount,
+			interest_amount,
+			tenor_month,
+			asset_name,
+			status,
+			created_at
+		FROM transactions
+		WHERE customer_id = :customer_id%[1]s%[2]s
+		ORDER BY created_at %[3]s, id %[3]s
+		LIMIT :limit
+	`
+
 	queryCountTransactionByCustomerID = `
 		SELECT COUNT(*) AS total_data
 		FROM transactions
-		WHERE customer_id = :customer_id	
+		WHERE customer_id = :customer_id%[1]s
+	`
+
+	// same fragments as queryFindTransactionByCustomerID, read row by row for exports
+	queryStreamTransactionByCustomerID = `
+		SELECT
+			id,
+			customer_id,
+			contract_number,
+			on_the_road_price,
+			admin_fee,
+			installment_amount,
+			interest_amount,
+			tenor_month,
+			asset_name,
+			status,
+			created_at
+		FROM transactions
+		WHERE customer_id = :customer_id%[1]s
+		ORDER BY %[2]s
+		LIMIT :limit
+	`
+
+	queryInsertNewTransactionExport = `
+		INSERT INTO transaction_exports
+		(
+			customer_id,
+			format,
+			locale,
+			filter,
+			status
+		) VALUES (?, ?, ?, ?, ?)
 	`
+
+	queryUpdateTransactionExport = `
+		UPDATE transaction_exports
+		SET
+			status = ?,
+			file_name = ?,
+			row_count = ?,
+			error_message = ?,
+			finished_at = ?
+		WHERE id = ?
+	`
+
+	queryClaimTransactionExports = `
+		SELECT
+			id,
+			customer_id,
+			format,
+			locale,
+			filter,
+			status,
+			file_name,
+			row_count,
+			error_message,
+			attempts,
+			created_at,
+			finished_at
+		FROM transaction_exports
+		WHERE status = ? OR (status = ? AND lease_until <= ?)
+		ORDER BY id ASC
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	`
+
+	queryLeaseTransactionExports = `
+		UPDATE transaction_exports
+		SET
+			status = ?,
+			attempts = attempts + 1,
+			lease_until = ?
+		WHERE id IN (?)
+	`
+
+	queryFindTransactionExportByIDAndCustomerID = `
+		SELECT
+			id,
+			customer_id,
+			format,
+			locale,
+			filter,
+			status,
+			file_name,
+			row_count,
+			error_message,
+			created_at,
+			finished_at
+		FROM transaction_exports
+		WHERE id = ? AND customer_id = ?
+	`
+
+	// the partner code is read with a subquery, a lock cannot reach the
+	// nullable side of an outer join on Postgres
+	queryLockTransactionByID = `
+		SELECT
+			t.id,
+			t.customer_id,
+			t.partner_id,
+			(SELECT p.code FROM partners p WHERE p.id = t.partner_id) AS partner_code,
+			t.contract_number,
+			t.on_the_road_price,
+			t.admin_fee,
+			t.installment_amount,
+			t.interest_amount,
+			t.tenor_month,
+			t.asset_name,
+			t.status,
+			t.created_at
+		FROM transactions t
+		WHERE t.id = ?
+		FOR UPDATE
+	`
+
+	queryUpdateTransactionStatus = `
+		UPDATE transactions SET status = ? WHERE id = ?
+	`
+
+	// keyset paged so documents issued along the way do not shift the pages
+	queryFindTransactionsWithoutDocument = `
+		SELECT
+			t.id,
+			t.customer_id,
+			t.contract_number,
+			t.on_the_road_price,
+			t.admin_fee,
+			t.installment_amount,
+			t.interest_amount,
+			t.tenor_month,
+			t.asset_name,
+			t.status,
+			t.created_at
+		FROM transactions t
+		WHERE t.id > ?
+			AND NOT EXISTS (
+				SELECT 1
+				FROM transaction_documents d
+				WHERE d.transaction_id = t.id AND d.document_type = ?
+			)
+		ORDER BY t.id ASC
+		LIMIT ?
+	`
+
+	queryFindTransactionDocument = `
+		SELECT
+			id,
+			transaction_id,
+			document_type,
+			template_version,
+			file_name,
+			checksum,
+			created_at
+		FROM transaction_documents
+		WHERE transaction_id = ? AND document_type = ?
+	`
+)
+
+var (
+	// a contract whose tenor could not be backfilled is counted until it is paid off
+	querySumActiveInstallmentByCustomerID = dialect.Query{
+		MySQL: `
+			SELECT COALESCE(SUM(installment_amount), 0)
+			FROM transactions
+			WHERE customer_id = ?
+				AND status = ?
+				AND (tenor_month = 0 OR DATE_ADD(created_at, INTERVAL tenor_month MONTH) > ?)
+		`,
+		Postgres: `
+			SELECT COALESCE(SUM(installment_amount), 0)
+			FROM transactions
+			WHERE customer_id = ?
+				AND status = ?
+				AND (tenor_month = 0 OR created_at + MAKE_INTERVAL(months => tenor_month) > ?)
+		`,
+	}
+
+	queryFindContractsByCustomerID = `
+		SELECT
+			id,
+			on_the_road_price,
+			admin_fee,
+			installment_amount,
+			interest_amount,
+			tenor_month,
+			status,
+			created_at
+		FROM transactions
+		WHERE customer_id = ? AND status IN (?, ?)
+		ORDER BY created_at ASC, id ASC
+	`
+
+	queryFindPaymentsByCustomerID = `
+		SELECT
+			p.transaction_id,
+			p.amount,
+			p.paid_at
+		FROM transaction_payments p
+		JOIN transactions t ON t.id = p.transaction_id
+		WHERE t.customer_id = ?
+		ORDER BY p.paid_at ASC, p.id ASC
+	`
+
+	// a concurrent request may have stored the same document first, which is fine
+	queryInsertNewTransactionDocument = dialect.Query{
+		MySQL: `
+			INSERT INTO transaction_documents
+			(
+				transaction_id,
+				document_type,
+				template_version,
+				file_name,
+				checksum
+			) VALUES (?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE id = id
+		`,
+		Postgres: `
+			INSERT INTO transaction_documents
+			(
+				transaction_id,
+				document_type,
+				template_version,
+				file_name,
+				checksum
+			) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (transaction_id, document_type) DO NOTHING
+		`,
+	}
 )
@@ -3,13 +3,20 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hilmiikhsan/multifinance-service/constants"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/entity"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/cursor"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect"
 	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/hilmiikhsan/multifinance-service/pkg/types"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
 )
@@ -27,24 +34,135 @@ func NewTransactionRepository(db *sqlx.DB) *transactionRepository {
 }
 
 func (r *transactionRepository) InsertNewTransaction(ctx context.Context, tx *sql.Tx, data *entity.Transaction) error {
+	ctx, span := tracing.Start(ctx, "transactionRepository.InsertNewTransaction")
+	defer span.End()
+
 	_, err := tx.ExecContext(ctx, r.db.Rebind(queryInsertNewTransaction),
 		data.CustomerID,
+		data.PartnerID,
 		data.ContractNumber,
 		data.OnTheRoadPrice,
 		data.AdminFee,
 		data.InstallmentAmount,
 		data.InterestAmount,
+		data.TenorMonth,
 		data.AssetName,
+		data.Status,
 	)
 	if err != nil {
-		log.Error().Err(err).Msg("repository::CreateTransaction - Failed to insert new transaction")
-		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		log.Ctx(ctx).Error().Err(err).Msg("repository::CreateTransaction - Failed to insert new transaction")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *transactionRepository) SumActiveInstallmentByCustomerID(ctx context.Context, tx *sql.Tx, customerID int, now time.Time) (float64, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.SumActiveInstallmentByCustomerID")
+	defer span.End()
+
+	var total float64
+
+	err := tx.QueryRowContext(ctx, r.db.Rebind(querySumActiveInstallmentByCustomerID.For(r.db.DriverName())), customerID, constants.TransactionStatusActive, now).Scan(&total)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("repository::SumActiveInstallmentByCustomerID - Failed to sum active installments")
+		return 0, err_msg.NewDatabaseErrors(err)
+	}
+
+	return total, nil
+}
+
+func (r *transactionRepository) FindContractsByCustomerID(ctx context.Context, tx *sql.Tx, customerID int) ([]entity.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.FindContractsByCustomerID")
+	defer span.End()
+
+	rows, err := tx.QueryContext(ctx, r.db.Rebind(queryFindContractsByCustomerID), customerID, constants.TransactionStatusActive, constants.TransactionStatusPaidOff)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("repository::FindContractsByCustomerID - Failed to find contracts")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+	defer rows.Close()
+
+	res := make([]entity.Transaction, 0)
+	if err := sqlx.StructScan(rows, &res); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("repository::FindContractsByCustomerID - Failed to scan contracts")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *transactionRepository) LockTransactionByID(ctx context.Context, tx *sql.Tx, id int) (*entity.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.LockTransactionByID")
+	defer span.End()
+
+	res := new(entity.Transaction)
+
+	err := tx.QueryRowContext(ctx, r.db.Rebind(queryLockTransactionByID), id).Scan(
+		&res.ID,
+		&res.CustomerID,
+		&res.PartnerID,
+		&res.PartnerCode,
+		&res.ContractNumber,
+		&res.OnTheRoadPrice,
+		&res.AdminFee,
+		&res.InstallmentAmount,
+		&res.InterestAmount,
+		&res.TenorMonth,
+		&res.AssetName,
+		&res.Status,
+		&res.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Ctx(ctx).Error().Err(err).Int("id", id).Msg("repository::LockTransactionByID - Transaction not found")
+			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrTransactionNotFound))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Msg("repository::LockTransactionByID - Failed to lock transaction")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *transactionRepository) UpdateTransactionStatus(ctx context.Context, tx *sql.Tx, id int, status string) error {
+	ctx, span := tracing.Start(ctx, "transactionRepository.UpdateTransactionStatus")
+	defer span.End()
+
+	_, err := tx.ExecContext(ctx, r.db.Rebind(queryUpdateTransactionStatus), status, id)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Str("status", status).Msg("repository::UpdateTransactionStatus - Failed to update transaction status")
+		return err_msg.NewDatabaseErrors(err)
 	}
 
 	return nil
 }
 
+func (r *transactionRepository) FindPaymentsByCustomerID(ctx context.Context, tx *sql.Tx, customerID int) ([]entity.TransactionPayment, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.FindPaymentsByCustomerID")
+	defer span.End()
+
+	rows, err := tx.QueryContext(ctx, r.db.Rebind(queryFindPaymentsByCustomerID), customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("repository::FindPaymentsByCustomerID - Failed to find payments")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+	defer rows.Close()
+
+	res := make([]entity.TransactionPayment, 0)
+	if err := sqlx.StructScan(rows, &res); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("repository::FindPaymentsByCustomerID - Failed to scan payments")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
 func (r *transactionRepository) FindTransactionByIdAndCustomerID(ctx context.Context, id, customerID int) (*entity.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.FindTransactionByIdAndCustomerID")
+	defer span.End()
+
 	var (
 		res = new(entity.Transaction)
 	)
@@ -52,61 +170,495 @@ func (r *transactionRepository) FindTransactionByIdAndCustomerID(ctx context.Con
 	err := r.db.GetContext(ctx, res, r.db.Rebind(queryFindTransactionByIdAndCustomerID), id, customerID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			log.Error().Err(err).Msg("repository::FindTransactionByIdAndCustomerID - Transaction not found")
+			log.Ctx(ctx).Error().Err(err).Msg("repository::FindTransactionByIdAndCustomerID - Transaction not found")
 			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrTransactionNotFound))
 		}
 
-		log.Error().Err(err).Msg("repository::FindTransactionByIdAndCustomerID - Failed to find transaction by ID and customer ID")
-		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindTransactionByIdAndCustomerID - Failed to find transaction by ID and customer ID")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *transactionRepository) FindTransactionByContractNumber(ctx context.Context, contractNumber string) (*entity.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.FindTransactionByContractNumber")
+	defer span.End()
+
+	res := new(entity.Transaction)
+
+	err := r.db.GetContext(ctx, res, r.db.Rebind(queryFindTransactionByContractNumber), contractNumber)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Ctx(ctx).Error().Err(err).Str("contract_number", contractNumber).Msg("repository::FindTransactionByContractNumber - Transaction not found")
+			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrTransactionNotFound))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Str("contract_number", contractNumber).Msg("repository::FindTransactionByContractNumber - Failed to find transaction by contract number")
+		return nil, err_msg.NewDatabaseErrors(err)
 	}
 
 	return res, nil
 }
 
 func (r *transactionRepository) FindTransactionByCustomerID(ctx context.Context, req *dto.GetHistoryListTransactionRequest, customerID int) (*dto.GetHistoryListTransactionResponse, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.FindTransactionByCustomerID")
+	defer span.End()
+
 	var (
-		resp       = new(dto.GetHistoryListTransactionResponse)
-		data       = make([]dto.HistoryListTransactionItem, 0, req.Paginate)
-		query      = queryFindTransactionByCustomerID
-		countQuery = queryCountTransactionByCustomerID
+		resp = new(dto.GetHistoryListTransactionResponse)
+		data = make([]dto.HistoryListTransactionItem, 0, req.Paginate)
 	)
 
+	filters, params := historyFilters(&req.HistoryFilter, customerID)
+
 	var totalData int
-	countQuery, countArgs, err := sqlx.Named(countQuery, map[string]interface{}{
-		"customer_id": customerID,
-	})
+	countQuery, countArgs, err := sqlx.Named(fmt.Sprintf(queryCountTransactionByCustomerID, filters), params)
 	if err != nil {
-		log.Error().Err(err).Msg("repository::FindTransactionByCustomerID - Failed to bind named query for count")
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindTransactionByCustomerID - Failed to bind named query for count")
 		return nil, err
 	}
 
 	countQuery = r.db.Rebind(countQuery)
 	err = r.db.GetContext(ctx, &totalData, countQuery, countArgs...)
 	if err != nil {
-		log.Error().Err(err).Msg("repository::FindTransactionByCustomerID - Failed to count transactions")
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindTransactionByCustomerID - Failed to count transactions")
 		return nil, err
 	}
 
-	query, args, err := sqlx.Named(query, map[string]interface{}{
-		"customer_id": customerID,
-		"limit":       req.Paginate,
-		"offset":      req.Paginate * (req.Page - 1),
-	})
+	params["limit"] = req.Paginate
+	params["offset"] = req.Paginate * (req.Page - 1)
+
+	query, args, err := sqlx.Named(fmt.Sprintf(queryFindTransactionByCustomerID, filters, historyOrder(&req.HistoryFilter)), params)
 	if err != nil {
-		log.Error().Err(err).Msg("repository::FindTransactionByCustomerID - Failed to bind named query")
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindTransactionByCustomerID - Failed to bind named query")
 		return nil, err
 	}
 
 	query = r.db.Rebind(query)
 	err = r.db.SelectContext(ctx, &data, query, args...)
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("repository::FindTransactionByCustomerID - Failed to find transactions")
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("repository::FindTransactionByCustomerID - Failed to find transactions")
 		return nil, err
 	}
 
 	resp.Items = data
-	resp.Meta.TotalData = totalData
+	resp.Meta = new(types.Meta)
 	resp.Meta.CountTotalPage(req.Page, req.Paginate, totalData)
 
 	return resp, nil
 }
+
+// FindTransactionByCustomerIDCursor reads one keyset page after (or, for a
+// backward cursor, before) req.Key. The total is only counted on request.
+func (r *transactionRepository) FindTransactionByCustomerIDCursor(ctx context.Context, req *dto.GetHistoryListTransactionRequest, customerID int) (*dto.GetHistoryListTransactionResponse, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.FindTransactionByCustomerIDCursor")
+	defer span.End()
+
+	var (
+		resp     = &dto.GetHistoryListTransactionResponse{Cursor: &types.CursorMeta{Paginate: req.Paginate}}
+		data     = make([]dto.HistoryListTransactionItem, 0, req.Paginate+1)
+		desc     = req.SortDir != "asc"
+		backward = req.Key != nil && req.Key.Backward
+	)
+
+	filters, params := historyFilters(&req.HistoryFilter, customerID)
+
+	if req.WithTotal {
+		var totalData int
+		countQuery, countArgs, err := sqlx.Named(fmt.Sprintf(queryCountTransactionByCustomerID, filters), params)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("repository::FindTransactionByCustomerIDCursor - Failed to bind named query for count")
+			return nil, err
+		}
+
+		err = r.db.GetContext(ctx, &totalData, r.db.Rebind(countQuery), countArgs...)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("repository::FindTransactionByCustomerIDCursor - Failed to count transactions")
+			return nil, err
+		}
+
+		resp.Cursor.TotalData = &totalData
+	}
+
+	// reading backwards scans against the list order and flips the page afterwards
+	scanDesc := desc != backward
+	direction, operator := "ASC", ">"
+	if scanDesc {
+		direction, operator = "DESC", "<"
+	}
+
+	var keyset string
+	if req.Key != nil {
+		keyset = fmt.Sprintf(" AND (created_at %[1]s :cursor_created_at OR (created_at = :cursor_created_at AND id %[1]s :cursor_id))", operator)
+		params["cursor_created_at"] = req.Key.CreatedAt
+		params["cursor_id"] = req.Key.ID
+	}
+	params["limit"] = req.Paginate + 1
+
+	query, args, err := sqlx.Named(fmt.Sprintf(queryFindTransactionByCustomerIDCursor, filters, keyset, direction), params)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindTransactionByCustomerIDCursor - Failed to bind named query")
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("repository::FindTransactionByCustomerIDCursor - Failed to find transactions")
+		return nil, err
+	}
+
+	hasMore := len(data) > req.Paginate
+	if hasMore {
+		data = data[:req.Paginate]
+	}
+
+	if backward {
+		for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+			data[i], data[j] = data[j], data[i]
+		}
+	}
+
+	resp.Items = data
+	if len(data) == 0 {
+		return resp, nil
+	}
+
+	first, err := historyCursor(data[0], desc, true)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("id", data[0].ID).Msg("repository::FindTransactionByCustomerIDCursor - Failed to build cursor")
+		return nil, err
+	}
+
+	last, err := historyCursor(data[len(data)-1], desc, false)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("id", data[len(data)-1].ID).Msg("repository::FindTransactionByCustomerIDCursor - Failed to build cursor")
+		return nil, err
+	}
+
+	// a page reached through a cursor always has a neighbour on the side it came from
+	if backward {
+		resp.Cursor.Next = last
+		if hasMore {
+			resp.Cursor.Prev = first
+		}
+	} else {
+		if hasMore {
+			resp.Cursor.Next = last
+		}
+		if req.Key != nil {
+			resp.Cursor.Prev = first
+		}
+	}
+
+	return resp, nil
+}
+
+func historyCursor(item dto.HistoryListTransactionItem, desc, backward bool) (string, error) {
+	createdAt, err := time.Parse(time.RFC3339Nano, item.CreatedAt)
+	if err != nil {
+		return "", err
+	}
+
+	return cursor.Encode(cursor.Cursor{
+		CreatedAt: createdAt,
+		ID:        item.ID,
+		Desc:      desc,
+		Backward:  backward,
+	}), nil
+}
+
+func (r *transactionRepository) CountTransactionByCustomerID(ctx context.Context, filter *dto.HistoryFilter, customerID int) (int, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.CountTransactionByCustomerID")
+	defer span.End()
+
+	var totalData int
+
+	filters, params := historyFilters(filter, customerID)
+
+	query, args, err := sqlx.Named(fmt.Sprintf(queryCountTransactionByCustomerID, filters), params)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::CountTransactionByCustomerID - Failed to bind named query")
+		return 0, err_msg.NewDatabaseErrors(err)
+	}
+
+	err = r.db.GetContext(ctx, &totalData, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("repository::CountTransactionByCustomerID - Failed to count transactions")
+		return 0, err_msg.NewDatabaseErrors(err)
+	}
+
+	return totalData, nil
+}
+
+// StreamTransactionByCustomerID hands the filtered transactions to fn one row
+// at a time, so an export never holds more than one row in memory. An error
+// from fn stops the scan and is returned as is.
+func (r *transactionRepository) StreamTransactionByCustomerID(ctx context.Context, filter *dto.HistoryFilter, customerID, limit int, fn func(*entity.Transaction) error) error {
+	ctx, span := tracing.Start(ctx, "transactionRepository.StreamTransactionByCustomerID")
+	defer span.End()
+
+	filters, params := historyFilters(filter, customerID)
+	params["limit"] = limit
+
+	query, args, err := sqlx.Named(fmt.Sprintf(queryStreamTransactionByCustomerID, filters, historyOrder(filter)), params)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::StreamTransactionByCustomerID - Failed to bind named query")
+		return err
+	}
+
+	rows, err := r.db.QueryxContext(ctx, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("repository::StreamTransactionByCustomerID - Failed to query transactions")
+		return err
+	}
+	defer rows.Close()
+
+	row := new(entity.Transaction)
+	for rows.Next() {
+		if err := rows.StructScan(row); err != nil {
+			log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("repository::StreamTransactionByCustomerID - Failed to scan transaction")
+			return err
+		}
+
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *transactionRepository) InsertNewTransactionExport(ctx context.Context, data *entity.TransactionExport) (int64, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.InsertNewTransactionExport")
+	defer span.End()
+
+	id, err := dialect.InsertID(ctx, r.db, r.db.DriverName(), r.db.Rebind(queryInsertNewTransactionExport),
+		data.CustomerID,
+		data.Format,
+		data.Locale,
+		data.Filter,
+		data.Status,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", data).Msg("repository::InsertNewTransactionExport - Failed to insert transaction export")
+		return 0, err_msg.NewDatabaseErrors(err)
+	}
+
+	return id, nil
+}
+
+func (r *transactionRepository) UpdateTransactionExport(ctx context.Context, data *entity.TransactionExport) error {
+	ctx, span := tracing.Start(ctx, "transactionRepository.UpdateTransactionExport")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(queryUpdateTransactionExport),
+		data.Status,
+		data.FileName,
+		data.RowCount,
+		data.ErrorMessage,
+		data.FinishedAt,
+		data.ID,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", data.ID).Msg("repository::UpdateTransactionExport - Failed to update transaction export")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *transactionRepository) ClaimTransactionExports(ctx context.Context, tx *sql.Tx, now time.Time, limit int) ([]entity.TransactionExport, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.ClaimTransactionExports")
+	defer span.End()
+
+	rows, err := tx.QueryContext(ctx, r.db.Rebind(queryClaimTransactionExports), constants.ExportStatusPending, constants.ExportStatusRunning, now, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("limit", limit).Msg("repository::ClaimTransactionExports - Failed to claim transaction exports")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+	defer rows.Close()
+
+	res := make([]entity.TransactionExport, 0, limit)
+	if err := sqlx.StructScan(rows, &res); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::ClaimTransactionExports - Failed to scan transaction exports")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *transactionRepository) LeaseTransactionExports(ctx context.Context, tx *sql.Tx, ids []int64, until time.Time) error {
+	ctx, span := tracing.Start(ctx, "transactionRepository.LeaseTransactionExports")
+	defer span.End()
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query, args, err := sqlx.In(queryLeaseTransactionExports, constants.ExportStatusRunning, until, ids)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::LeaseTransactionExports - Failed to bind ids")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, r.db.Rebind(query), args...); err != nil {
+		log.Ctx(ctx).Error().Err(err).Ints64("ids", ids).Msg("repository::LeaseTransactionExports - Failed to lease transaction exports")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *transactionRepository) FindTransactionExportByIDAndCustomerID(ctx context.Context, id int64, customerID int) (*entity.TransactionExport, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.FindTransactionExportByIDAndCustomerID")
+	defer span.End()
+
+	var res = new(entity.TransactionExport)
+
+	err := r.db.GetContext(ctx, res, r.db.Rebind(queryFindTransactionExportByIDAndCustomerID), id, customerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Ctx(ctx).Warn().Int64("id", id).Int("customer_id", customerID).Msg("repository::FindTransactionExportByIDAndCustomerID - Transaction export not found")
+			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrExportNotFound))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("repository::FindTransactionExportByIDAndCustomerID - Failed to find transaction export")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *transactionRepository) FindTransactionsWithoutDocument(ctx context.Context, documentType string, afterID, limit int) ([]entity.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.FindTransactionsWithoutDocument")
+	defer span.End()
+
+	res := make([]entity.Transaction, 0, limit)
+
+	err := r.db.SelectContext(ctx, &res, r.db.Rebind(queryFindTransactionsWithoutDocument), afterID, documentType, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("document_type", documentType).Int("after_id", afterID).Msg("repository::FindTransactionsWithoutDocument - Failed to find transactions without document")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *transactionRepository) FindTransactionDocument(ctx context.Context, transactionID int, documentType string) (*entity.TransactionDocument, error) {
+	ctx, span := tracing.Start(ctx, "transactionRepository.FindTransactionDocument")
+	defer span.End()
+
+	var res = new(entity.TransactionDocument)
+
+	err := r.db.GetContext(ctx, res, r.db.Rebind(queryFindTransactionDocument), transactionID, documentType)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrDocumentNotFound))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int("transaction_id", transactionID).Str("document_type", documentType).Msg("repository::FindTransactionDocument - Failed to find transaction document")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *transactionRepository) InsertNewTransactionDocument(ctx context.Context, data *entity.TransactionDocument) error {
+	ctx, span := tracing.Start(ctx, "transactionRepository.InsertNewTransactionDocument")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(queryInsertNewTransactionDocument.For(r.db.DriverName())),
+		data.TransactionID,
+		data.DocumentType,
+		data.TemplateVersion,
+		data.FileName,
+		data.Checksum,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", data).Msg("repository::InsertNewTransactionDocument - Failed to insert transaction document")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+var (
+	historySortColumns = map[string]string{
+		"created_at":         "created_at",
+		"on_the_road_price":  "on_the_road_price",
+		"installment_amount": "installment_amount",
+		"tenor_month":        "tenor_month",
+		"asset_name":         "asset_name",
+	}
+
+	historySortDirections = map[string]string{
+		"asc":  "ASC",
+		"desc": "DESC",
+	}
+
+	likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+)
+
+// historyFilters only adds conditions for the filters that are set; every
+// value is passed as a named parameter.
+func historyFilters(req *dto.HistoryFilter, customerID int) (string, map[string]interface{}) {
+	var (
+		conditions strings.Builder
+		params     = map[string]interface{}{"customer_id": customerID}
+	)
+
+	if startDate, err := time.Parse(constants.DateFormat, req.StartDate); err == nil {
+		conditions.WriteString(" AND created_at >= :start_date")
+		params["start_date"] = startDate
+	}
+
+	// the end date is inclusive, so everything before the next day matches
+	if endDate, err := time.Parse(constants.DateFormat, req.EndDate); err == nil {
+		conditions.WriteString(" AND created_at < :end_date")
+		params["end_date"] = endDate.AddDate(0, 0, 1)
+	}
+
+	if req.MinAmount > 0 {
+		conditions.WriteString(" AND on_the_road_price >= :min_amount")
+		params["min_amount"] = req.MinAmount
+	}
+
+	if req.MaxAmount > 0 {
+		conditions.WriteString(" AND on_the_road_price <= :max_amount")
+		params["max_amount"] = req.MaxAmount
+	}
+
+	if req.TenorMonth > 0 {
+		conditions.WriteString(" AND tenor_month = :tenor_month")
+		params["tenor_month"] = req.TenorMonth
+	}
+
+	if req.Status != "" {
+		conditions.WriteString(" AND status = :status")
+		params["status"] = req.Status
+	}
+
+	if req.AssetName != "" {
+		conditions.WriteString(" AND asset_name LIKE :asset_name")
+		params["asset_name"] = "%" + likeEscaper.Replace(req.AssetName) + "%"
+	}
+
+	return conditions.String(), params
+}
+
+// historyOrder falls back to the newest first for unknown fields, and breaks
+// ties on id so pages do not overlap.
+func historyOrder(req *dto.HistoryFilter) string {
+	column, ok := historySortColumns[req.SortBy]
+	if !ok {
+		column = "created_at"
+	}
+
+	direction, ok := historySortDirections[req.SortDir]
+	if !ok {
+		direction = "DESC"
+	}
+
+	return fmt.Sprintf("%s %s, id %s", column, direction, direction)
+}
@@ -2,37 +2,149 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/cursor"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect/dialecttest"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 )
 
 func Test_transactionRepository_InsertNewTransaction(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	assert.NoError(t, err)
-	defer db.Close()
-
-	mysqlDB := sqlx.NewDb(db, "mysql")
-
-	type args struct {
-		ctx   context.Context
-		model *entity.Transaction
-	}
-	tests := []struct {
-		name    string
-		args    args
-		wantErr bool
-		mockFn  func(args args, mock sqlmock.Sqlmock)
-	}{
-		{
-			name: "Insert New Transaction Successfully",
-			args: args{
-				ctx: context.Background(),
-				model: &entity.Transaction{
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		type args struct {
+			ctx   context.Context
+			model *entity.Transaction
+		}
+		tests := []struct {
+			name    string
+			args    args
+			wantErr bool
+			mockFn  func(args args, mock sqlmock.Sqlmock)
+		}{
+			{
+				name: "Insert New Transaction Successfully",
+				args: args{
+					ctx: context.Background(),
+					model: &entity.Transaction{
+						CustomerID:        1,
+						ContractNumber:    "123456",
+						OnTheRoadPrice:    500000,
+						AdminFee:          5000,
+						InstallmentAmount: 50000,
+						InterestAmount:    5000,
+						TenorMonth:        12,
+						AssetName:         "Yamaha NMAX",
+						Status:            "active",
+					},
+				},
+				wantErr: false,
+				mockFn: func(args args, mock sqlmock.Sqlmock) {
+					mock.ExpectBegin()
+					mock.ExpectExec("INSERT INTO transactions").WithArgs(
+						args.model.CustomerID,
+						args.model.PartnerID,
+						args.model.ContractNumber,
+						args.model.OnTheRoadPrice,
+						args.model.AdminFee,
+						args.model.InstallmentAmount,
+						args.model.InterestAmount,
+						args.model.TenorMonth,
+						args.model.AssetName,
+						args.model.Status,
+					).WillReturnResult(sqlmock.NewResult(1, 1))
+				},
+			},
+			{
+				name: "Insert New Transaction With Query Error",
+				args: args{
+					ctx: context.Background(),
+					model: &entity.Transaction{
+						CustomerID:        1,
+						ContractNumber:    "123456",
+						OnTheRoadPrice:    500000,
+						AdminFee:          5000,
+						InstallmentAmount: 50000,
+						InterestAmount:    5000,
+						AssetName:         "Yamaha NMAX",
+					},
+				},
+				wantErr: true,
+				mockFn: func(args args, mock sqlmock.Sqlmock) {
+					mock.ExpectBegin()
+					mock.ExpectExec("INSERT INTO transactions").WithArgs(
+						args.model.CustomerID,
+						args.model.PartnerID,
+						args.model.ContractNumber,
+						args.model.OnTheRoadPrice,
+						args.model.AdminFee,
+						args.model.InstallmentAmount,
+						args.model.InterestAmount,
+						args.model.TenorMonth,
+						args.model.AssetName,
+						args.model.Status,
+					).WillReturnError(fmt.Errorf("insert failed"))
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn(tt.args, mock)
+				r := &transactionRepository{
+					db: db,
+				}
+
+				tx, err := db.BeginTx(tt.args.ctx, nil)
+				assert.NoError(t, err)
+
+				err = r.InsertNewTransaction(tt.args.ctx, tx, tt.args.model)
+
+				if tt.wantErr {
+					assert.Error(t, err, "Expected error, got nil")
+				} else {
+					assert.NoError(t, err, "Expected no error, got: %v", err)
+				}
+
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_transactionRepository_FindTransactionByIdAndCustomerID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		type args struct {
+			ctx        context.Context
+			id         int
+			customerID int
+		}
+		tests := []struct {
+			name    string
+			args    args
+			want    *entity.Transaction
+			wantErr bool
+			mockFn  func(args args, mock sqlmock.Sqlmock)
+		}{
+			{
+				name: "Find Transaction By ID and Customer ID Successfully",
+				args: args{
+					ctx:        context.Background(),
+					id:         1,
+					customerID: 1,
+				},
+				want: &entity.Transaction{
+					ID:                1,
 					CustomerID:        1,
 					ContractNumber:    "123456",
 					OnTheRoadPrice:    500000,
@@ -41,158 +153,779 @@ func Test_transactionRepository_InsertNewTransaction(t *testing.T) {
 					InterestAmount:    5000,
 					AssetName:         "Yamaha NMAX",
 				},
+				wantErr: false,
+				mockFn: func(args args, mock sqlmock.Sqlmock) {
+					rows := sqlmock.NewRows([]string{"id", "customer_id", "contract_number", "on_the_road_price", "admin_fee", "installment_amount", "interest_amount", "asset_name"}).
+						AddRow(1, 1, "123456", 500000, 5000, 50000, 5000, "Yamaha NMAX")
+
+					mock.ExpectQuery("SELECT (.+) FROM transactions").WithArgs(args.id, args.customerID).WillReturnRows(rows)
+				},
 			},
-			wantErr: false,
-			mockFn: func(args args, mock sqlmock.Sqlmock) {
-				mock.ExpectBegin()
-				mock.ExpectExec("INSERT INTO transactions").WithArgs(
-					args.model.CustomerID,
-					args.model.ContractNumber,
-					args.model.OnTheRoadPrice,
-					args.model.AdminFee,
-					args.model.InstallmentAmount,
-					args.model.InterestAmount,
-					args.model.AssetName,
-				).WillReturnResult(sqlmock.NewResult(1, 1))
-			},
-		},
-		{
-			name: "Insert New Transaction With Query Error",
-			args: args{
-				ctx: context.Background(),
-				model: &entity.Transaction{
+			{
+				name: "Find Transaction By ID and Customer ID With Query Error",
+				args: args{
+					ctx:        context.Background(),
+					id:         1,
+					customerID: 1,
+				},
+				want:    nil,
+				wantErr: true,
+				mockFn: func(args args, mock sqlmock.Sqlmock) {
+					mock.ExpectQuery("SELECT (.+) FROM transactions").WithArgs(args.id, args.customerID).WillReturnError(fmt.Errorf("query failed"))
+				},
+			},
+			{
+				name: "Find Transaction By ID and Customer ID With No Rows",
+				args: args{
+					ctx:        context.Background(),
+					id:         1,
+					customerID: 1,
+				},
+				want:    nil,
+				wantErr: true,
+				mockFn: func(args args, mock sqlmock.Sqlmock) {
+					rows := sqlmock.NewRows([]string{"id", "customer_id", "contract_number", "on_the_road_price", "admin_fee", "installment_amount", "interest_amount", "asset_name"})
+
+					mock.ExpectQuery("SELECT (.+) FROM transactions").WithArgs(args.id, args.customerID).WillReturnRows(rows)
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn(tt.args, mock)
+				r := &transactionRepository{
+					db: db,
+				}
+				got, err := r.FindTransactionByIdAndCustomerID(tt.args.ctx, tt.args.id, tt.args.customerID)
+
+				assert.Equal(t, tt.wantErr, err != nil, "error state mismatch")
+				assert.Equal(t, tt.want, got, "result mismatch")
+			})
+		}
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_transactionRepository_FindTransactionByContractNumber(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		columns := []string{"id", "customer_id", "contract_number", "on_the_road_price", "admin_fee", "installment_amount", "interest_amount", "asset_name"}
+
+		tests := []struct {
+			name     string
+			want     *entity.Transaction
+			wantCode int
+			mockFn   func(mock sqlmock.Sqlmock)
+		}{
+			{
+				name: "Find Transaction By Contract Number Successfully",
+				want: &entity.Transaction{
+					ID:                1,
 					CustomerID:        1,
-					ContractNumber:    "123456",
+					ContractNumber:    "TRX202412010001",
+					OnTheRoadPrice:    500000,
+					AdminFee:          5000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+				},
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery("SELECT (.+) FROM transactions WHERE contract_number = \\?").WithArgs("TRX202412010001").
+						WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 1, "TRX202412010001", 500000, 5000, 50000, 5000, "Yamaha NMAX"))
+				},
+			},
+			{
+				name:     "Find Transaction By Contract Number With No Rows",
+				wantCode: fiber.StatusNotFound,
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery("SELECT (.+) FROM transactions").WithArgs("TRX202412010001").WillReturnRows(sqlmock.NewRows(columns))
+				},
+			},
+			{
+				name:     "Find Transaction By Contract Number With Query Error",
+				wantCode: fiber.StatusInternalServerError,
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery("SELECT (.+) FROM transactions").WithArgs("TRX202412010001").WillReturnError(fmt.Errorf("query failed"))
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn(mock)
+				r := &transactionRepository{
+					db: db,
+				}
+
+				got, err := r.FindTransactionByContractNumber(context.Background(), "TRX202412010001")
+
+				assert.Equal(t, tt.want, got, "result mismatch")
+				if tt.wantCode == 0 {
+					assert.NoError(t, err)
+				} else {
+					var customErr *err_msg.CustomError
+					assert.True(t, errors.As(err, &customErr), "expected a custom error")
+					assert.Equal(t, tt.wantCode, customErr.Code)
+				}
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_transactionRepository_SumActiveInstallmentByCustomerID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		now := time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC)
+
+		type args struct {
+			ctx        context.Context
+			customerID int
+		}
+
+		tests := []struct {
+			name    string
+			args    args
+			want    float64
+			wantErr bool
+			mockFn  func(args args, mock sqlmock.Sqlmock)
+		}{
+			{
+				name: "Sum Active Installment Successfully",
+				args: args{
+					ctx:        context.Background(),
+					customerID: 1,
+				},
+				want:    150000,
+				wantErr: false,
+				mockFn: func(args args, mock sqlmock.Sqlmock) {
+					mock.ExpectBegin()
+					// contracts without a tenor are still counted
+					mock.ExpectQuery("SELECT COALESCE\\(SUM\\(installment_amount\\), 0\\) FROM transactions (.+) AND \\(tenor_month = 0 OR ").
+						WithArgs(args.customerID, "active", now).
+						WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(150000))
+				},
+			},
+			{
+				name: "Sum Active Installment With Query Error",
+				args: args{
+					ctx:        context.Background(),
+					customerID: 1,
+				},
+				want:    0,
+				wantErr: true,
+				mockFn: func(args args, mock sqlmock.Sqlmock) {
+					mock.ExpectBegin()
+					mock.ExpectQuery("SELECT COALESCE\\(SUM\\(installment_amount\\), 0\\) FROM transactions").
+						WithArgs(args.customerID, "active", now).
+						WillReturnError(fmt.Errorf("query failed"))
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn(tt.args, mock)
+				r := &transactionRepository{
+					db: db,
+				}
+
+				tx, err := db.BeginTx(tt.args.ctx, nil)
+				assert.NoError(t, err)
+
+				got, err := r.SumActiveInstallmentByCustomerID(tt.args.ctx, tx, tt.args.customerID, now)
+
+				assert.Equal(t, tt.wantErr, err != nil, "error state mismatch")
+				assert.Equal(t, tt.want, got, "result mismatch")
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_transactionRepository_FindContractsByCustomerID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		createdAt := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+		columns := []string{"id", "on_the_road_price", "admin_fee", "installment_amount", "interest_amount", "tenor_month", "status", "created_at"}
+
+		tests := []struct {
+			name    string
+			want    []entity.Transaction
+			wantErr bool
+			mockFn  func(mock sqlmock.Sqlmock)
+		}{
+			{
+				name: "Find Active And Paid Off Contracts",
+				want: []entity.Transaction{
+					{ID: 7, OnTheRoadPrice: 300000, AdminFee: 5000, InstallmentAmount: 100000, TenorMonth: 3, Status: "active", CreatedAt: createdAt},
+				},
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectBegin()
+					mock.ExpectQuery("FROM transactions WHERE customer_id = \\? AND status IN \\(\\?, \\?\\)").
+						WithArgs(1, "active", "paid_off").
+						WillReturnRows(sqlmock.NewRows(columns).AddRow(7, 300000, 5000, 100000, 0, 3, "active", createdAt))
+				},
+			},
+			{
+				name:    "Find Contracts With Query Error",
+				wantErr: true,
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectBegin()
+					mock.ExpectQuery("FROM transactions").
+						WithArgs(1, "active", "paid_off").
+						WillReturnError(fmt.Errorf("query failed"))
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn(mock)
+				r := &transactionRepository{
+					db: db,
+				}
+
+				tx, err := db.BeginTx(context.Background(), nil)
+				assert.NoError(t, err)
+
+				got, err := r.FindContractsByCustomerID(context.Background(), tx, 1)
+
+				assert.Equal(t, tt.wantErr, err != nil, "error state mismatch")
+				assert.Equal(t, tt.want, got, "result mismatch")
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_transactionRepository_LockTransactionByID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		createdAt := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+		columns := []string{"id", "customer_id", "partner_id", "partner_code", "contract_number", "on_the_road_price", "admin_fee", "installment_amount", "interest_amount", "tenor_month", "asset_name", "status", "created_at"}
+
+		tests := []struct {
+			name     string
+			want     *entity.Transaction
+			wantCode int
+			mockFn   func(mock sqlmock.Sqlmock)
+		}{
+			{
+				name: "Lock Transaction With Its Partner",
+				want: &entity.Transaction{
+					ID:                5,
+					CustomerID:        1,
+					PartnerID:         sql.NullInt64{Int64: 7, Valid: true},
+					PartnerCode:       sql.NullString{String: "DEALER-1", Valid: true},
+					ContractNumber:    "TRX202412010001",
 					OnTheRoadPrice:    500000,
 					AdminFee:          5000,
 					InstallmentAmount: 50000,
 					InterestAmount:    5000,
+					TenorMonth:        12,
 					AssetName:         "Yamaha NMAX",
+					Status:            "active",
+					CreatedAt:         createdAt,
+				},
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery("FROM transactions t WHERE t.id = \\? FOR UPDATE").WithArgs(5).
+						WillReturnRows(sqlmock.NewRows(columns).AddRow(5, 1, 7, "DEALER-1", "TRX202412010001", 500000, 5000, 50000, 5000, 12, "Yamaha NMAX", "active", createdAt))
+				},
+			},
+			{
+				name:     "Lock Transaction With No Rows",
+				wantCode: fiber.StatusNotFound,
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery("FROM transactions t").WithArgs(5).WillReturnRows(sqlmock.NewRows(columns))
+				},
+			},
+			{
+				name:     "Lock Transaction With Query Error",
+				wantCode: fiber.StatusInternalServerError,
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery("FROM transactions t").WithArgs(5).WillReturnError(fmt.Errorf("query failed"))
 				},
 			},
-			wantErr: true,
-			mockFn: func(args args, mock sqlmock.Sqlmock) {
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
 				mock.ExpectBegin()
-				mock.ExpectExec("INSERT INTO transactions").WithArgs(
-					args.model.CustomerID,
-					args.model.ContractNumber,
-					args.model.OnTheRoadPrice,
-					args.model.AdminFee,
-					args.model.InstallmentAmount,
-					args.model.InterestAmount,
-					args.model.AssetName,
-				).WillReturnError(fmt.Errorf("insert failed"))
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tt.mockFn(tt.args, mock)
-			r := &transactionRepository{
-				db: mysqlDB,
-			}
+				tt.mockFn(mock)
 
-			tx, err := mysqlDB.BeginTx(tt.args.ctx, nil)
-			assert.NoError(t, err)
+				tx, err := db.Begin()
+				assert.NoError(t, err)
+
+				r := NewTransactionRepository(db)
+				got, err := r.LockTransactionByID(context.Background(), tx, 5)
+
+				assert.Equal(t, tt.want, got, "result mismatch")
+				if tt.wantCode == 0 {
+					assert.NoError(t, err)
+				} else {
+					assert.True(t, err_msg.HasCode(err, tt.wantCode), "expected status %d, got %v", tt.wantCode, err)
+				}
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
 
-			err = r.InsertNewTransaction(tt.args.ctx, tx, tt.args.model)
+func Test_transactionRepository_UpdateTransactionStatus(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE transactions SET status = ? WHERE id = ?")).
+			WithArgs("cancelled", 5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
 
-			if tt.wantErr {
-				assert.Error(t, err, "Expected error, got nil")
-			} else {
-				assert.NoError(t, err, "Expected no error, got: %v", err)
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		r := NewTransactionRepository(db)
+		assert.NoError(t, r.UpdateTransactionStatus(context.Background(), tx, 5, "cancelled"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_transactionRepository_FindPaymentsByCustomerID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		paidAt := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+
+		tests := []struct {
+			name    string
+			want    []entity.TransactionPayment
+			wantErr bool
+			mockFn  func(mock sqlmock.Sqlmock)
+		}{
+			{
+				name: "Find Payments In Order",
+				want: []entity.TransactionPayment{
+					{TransactionID: 7, Amount: 105000, PaidAt: paidAt},
+				},
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectBegin()
+					mock.ExpectQuery("FROM transaction_payments p JOIN transactions t ON t.id = p.transaction_id WHERE t.customer_id = \\? ORDER BY p.paid_at ASC, p.id ASC").
+						WithArgs(1).
+						WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "amount", "paid_at"}).AddRow(7, 105000, paidAt))
+				},
+			},
+			{
+				name:    "Find Payments With Query Error",
+				wantErr: true,
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectBegin()
+					mock.ExpectQuery("FROM transaction_payments").
+						WithArgs(1).
+						WillReturnError(fmt.Errorf("query failed"))
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn(mock)
+				r := &transactionRepository{
+					db: db,
+				}
+
+				tx, err := db.BeginTx(context.Background(), nil)
+				assert.NoError(t, err)
+
+				got, err := r.FindPaymentsByCustomerID(context.Background(), tx, 1)
+
+				assert.Equal(t, tt.wantErr, err != nil, "error state mismatch")
+				assert.Equal(t, tt.want, got, "result mismatch")
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_transactionRepository_FindTransactionByCustomerID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		r := &transactionRepository{
+			db: db,
+		}
+
+		columns := []string{"id", "customer_id", "contract_number", "on_the_road_price", "admin_fee", "installment_amount", "interest_amount", "tenor_month", "asset_name", "status", "created_at"}
+
+		tests := []struct {
+			name      string
+			req       *dto.GetHistoryListTransactionRequest
+			wantWhere string
+			wantOrder string
+			wantArgs  []driver.Value
+		}{
+			{
+				name:      "Without Filters",
+				req:       &dto.GetHistoryListTransactionRequest{Page: 1, Paginate: 10, HistoryFilter: dto.HistoryFilter{SortBy: "created_at", SortDir: "desc"}},
+				wantWhere: "WHERE customer_id = ?",
+				wantOrder: "ORDER BY created_at DESC, id DESC",
+				wantArgs:  []driver.Value{1},
+			},
+			{
+				name: "All Filters",
+				req: &dto.GetHistoryListTransactionRequest{
+					Page:     2,
+					Paginate: 5,
+					HistoryFilter: dto.HistoryFilter{
+						StartDate:  "2024-12-01",
+						EndDate:    "2024-12-31",
+						MinAmount:  100000,
+						MaxAmount:  900000,
+						TenorMonth: 6,
+						Status:     "active",
+						AssetName:  "50%_off",
+						SortBy:     "on_the_road_price",
+						SortDir:    "asc",
+					},
+				},
+				wantWhere: "WHERE customer_id = ? AND created_at >= ? AND created_at < ? AND on_the_road_price >= ? AND on_the_road_price <= ? AND tenor_month = ? AND status = ? AND asset_name LIKE ?",
+				wantOrder: "ORDER BY on_the_road_price ASC, id ASC",
+				wantArgs: []driver.Value{
+					1,
+					time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+					time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					float64(100000),
+					float64(900000),
+					6,
+					"active",
+					`%50\%\_off%`,
+				},
+			},
+			{
+				name:      "Unknown Sort Falls Back To Newest First",
+				req:       &dto.GetHistoryListTransactionRequest{Page: 1, Paginate: 10, HistoryFilter: dto.HistoryFilter{SortBy: "id; DROP TABLE transactions", SortDir: "sideways"}},
+				wantWhere: "WHERE customer_id = ?",
+				wantOrder: "ORDER BY created_at DESC, id DESC",
+				wantArgs:  []driver.Value{1},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) AS total_data FROM transactions " + tt.wantWhere)).
+					WithArgs(tt.wantArgs...).
+					WillReturnRows(sqlmock.NewRows([]string{"total_data"}).AddRow(1))
+
+				pageArgs := append(append([]driver.Value{}, tt.wantArgs...), tt.req.Paginate, tt.req.Paginate*(tt.req.Page-1))
+				mock.ExpectQuery(regexp.QuoteMeta(tt.wantWhere + " " + tt.wantOrder + " LIMIT ? OFFSET ?")).
+					WithArgs(pageArgs...).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, 1, "KTR-1", 500000, 5000, 50000, 5000, 6, "Yamaha NMAX", "active", "2024-12-10 10:00:00"))
+
+				got, err := r.FindTransactionByCustomerID(context.Background(), tt.req, 1)
+				assert.NoError(t, err)
+				assert.Len(t, got.Items, 1)
+				assert.Equal(t, 1, got.Meta.TotalData)
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_transactionRepository_FindTransactionByCustomerIDCursor(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		r := NewTransactionRepository(db)
+
+		columns := []string{"id", "customer_id", "contract_number", "on_the_road_price", "admin_fee", "installment_amount", "interest_amount", "tenor_month", "asset_name", "status", "created_at"}
+		rows := func(ids ...int) *sqlmock.Rows {
+			res := sqlmock.NewRows(columns)
+			for _, id := range ids {
+				res.AddRow(id, 1, fmt.Sprintf("KTR-%d", id), 500000, 5000, 50000, 5000, 6, "Yamaha NMAX", "active", fmt.Sprintf("2024-12-%02dT10:00:00Z", id))
 			}
+			return res
+		}
+		key := &cursor.Cursor{CreatedAt: time.Date(2024, 12, 5, 10, 0, 0, 0, time.UTC), ID: 5, Desc: true}
+		backKey := &cursor.Cursor{CreatedAt: key.CreatedAt, ID: key.ID, Desc: true, Backward: true}
+
+		tests := []struct {
+			name      string
+			req       *dto.GetHistoryListTransactionRequest
+			wantQuery string
+			wantArgs  []driver.Value
+			rows      *sqlmock.Rows
+			wantIDs   []int
+			wantNext  bool
+			wantPrev  bool
+		}{
+			{
+				name:      "First Page With Total",
+				req:       &dto.GetHistoryListTransactionRequest{Paginate: 2, WithTotal: true, HistoryFilter: dto.HistoryFilter{SortDir: "desc"}},
+				wantQuery: "WHERE customer_id = ? ORDER BY created_at DESC, id DESC LIMIT ?",
+				wantArgs:  []driver.Value{1, 3},
+				rows:      rows(9, 8, 7),
+				wantIDs:   []int{9, 8},
+				wantNext:  true,
+			},
+			{
+				name:      "Last Page After Cursor",
+				req:       &dto.GetHistoryListTransactionRequest{Paginate: 2, Key: key, HistoryFilter: dto.HistoryFilter{SortDir: "desc"}},
+				wantQuery: "WHERE customer_id = ? AND (created_at < ? OR (created_at = ? AND id < ?)) ORDER BY created_at DESC, id DESC LIMIT ?",
+				wantArgs:  []driver.Value{1, key.CreatedAt, key.CreatedAt, 5, 3},
+				rows:      rows(4, 3),
+				wantIDs:   []int{4, 3},
+				wantPrev:  true,
+			},
+			{
+				name:      "Previous Page Before Cursor",
+				req:       &dto.GetHistoryListTransactionRequest{Paginate: 2, Key: backKey, HistoryFilter: dto.HistoryFilter{SortDir: "desc"}},
+				wantQuery: "WHERE customer_id = ? AND (created_at > ? OR (created_at = ? AND id > ?)) ORDER BY created_at ASC, id ASC LIMIT ?",
+				wantArgs:  []driver.Value{1, key.CreatedAt, key.CreatedAt, 5, 3},
+				rows:      rows(6, 7, 8),
+				wantIDs:   []int{7, 6},
+				wantNext:  true,
+				wantPrev:  true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if tt.req.WithTotal {
+					mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) AS total_data FROM transactions WHERE customer_id = ?")).
+						WithArgs(1).
+						WillReturnRows(sqlmock.NewRows([]string{"total_data"}).AddRow(12))
+				}
+
+				mock.ExpectQuery(regexp.QuoteMeta(tt.wantQuery)).
+					WithArgs(tt.wantArgs...).
+					WillReturnRows(tt.rows)
+
+				got, err := r.FindTransactionByCustomerIDCursor(context.Background(), tt.req, 1)
+				assert.NoError(t, err)
+				assert.NoError(t, mock.ExpectationsWereMet())
+
+				ids := make([]int, 0, len(got.Items))
+				for _, item := range got.Items {
+					ids = append(ids, item.ID)
+				}
+				assert.Equal(t, tt.wantIDs, ids)
+				assert.Nil(t, got.Meta)
+				assert.Equal(t, tt.wantNext, got.Cursor.Next != "")
+				assert.Equal(t, tt.wantPrev, got.Cursor.Prev != "")
+				assert.Equal(t, tt.req.WithTotal, got.Cursor.TotalData != nil)
+
+				if tt.wantNext {
+					next, err := cursor.Decode(got.Cursor.Next)
+					assert.NoError(t, err)
+					assert.Equal(t, tt.wantIDs[len(tt.wantIDs)-1], next.ID)
+					assert.False(t, next.Backward)
+				}
+				if tt.wantPrev {
+					prev, err := cursor.Decode(got.Cursor.Prev)
+					assert.NoError(t, err)
+					assert.Equal(t, tt.wantIDs[0], prev.ID)
+					assert.True(t, prev.Backward)
+				}
+			})
+		}
+	})
+}
+
+func Test_transactionRepository_StreamTransactionByCustomerID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		r := NewTransactionRepository(db)
+
+		columns := []string{"id", "customer_id", "contract_number", "on_the_road_price", "admin_fee", "installment_amount", "interest_amount", "tenor_month", "asset_name", "status", "created_at"}
+		createdAt := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+		filter := &dto.HistoryFilter{Status: "active", SortBy: "created_at", SortDir: "asc"}
+
+		t.Run("Stream All Rows", func(t *testing.T) {
+			mock.ExpectQuery(regexp.QuoteMeta("WHERE customer_id = ? AND status = ? ORDER BY created_at ASC, id ASC LIMIT ?")).
+				WithArgs(1, "active", 100).
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(1, 1, "KTR-1", 500000, 5000, 50000, 5000, 6, "Yamaha NMAX", "active", createdAt).
+					AddRow(2, 1, "KTR-2", 700000, 5000, 70000, 7000, 12, "Honda Vario", "active", createdAt))
+
+			var contracts []string
+			err := r.StreamTransactionByCustomerID(context.Background(), filter, 1, 100, func(t *entity.Transaction) error {
+				contracts = append(contracts, t.ContractNumber)
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"KTR-1", "KTR-2"}, contracts)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
 
+		t.Run("Stop On Callback Error", func(t *testing.T) {
+			mock.ExpectQuery(regexp.QuoteMeta("WHERE customer_id = ? AND status = ? ORDER BY created_at ASC, id ASC LIMIT ?")).
+				WithArgs(1, "active", 100).
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(1, 1, "KTR-1", 500000, 5000, 50000, 5000, 6, "Yamaha NMAX", "active", createdAt).
+					AddRow(2, 1, "KTR-2", 700000, 5000, 70000, 7000, 12, "Honda Vario", "active", createdAt))
+
+			calls := 0
+			wantErr := errors.New("client went away")
+			err := r.StreamTransactionByCustomerID(context.Background(), filter, 1, 100, func(t *entity.Transaction) error {
+				calls++
+				return wantErr
+			})
+			assert.ErrorIs(t, err, wantErr)
+			assert.Equal(t, 1, calls)
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
-	}
+	})
 }
 
-func Test_transactionRepository_FindTransactionByIdAndCustomerID(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	assert.NoError(t, err)
-	defer db.Close()
-
-	mysqlDB := sqlx.NewDb(db, "mysql")
-
-	type args struct {
-		ctx        context.Context
-		id         int
-		customerID int
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    *entity.Transaction
-		wantErr bool
-		mockFn  func(args args, mock sqlmock.Sqlmock)
-	}{
-		{
-			name: "Find Transaction By ID and Customer ID Successfully",
-			args: args{
-				ctx:        context.Background(),
-				id:         1,
-				customerID: 1,
-			},
-			want: &entity.Transaction{
-				ID:                1,
-				CustomerID:        1,
-				ContractNumber:    "123456",
-				OnTheRoadPrice:    500000,
-				AdminFee:          5000,
-				InstallmentAmount: 50000,
-				InterestAmount:    5000,
-				AssetName:         "Yamaha NMAX",
-			},
-			wantErr: false,
-			mockFn: func(args args, mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "customer_id", "contract_number", "on_the_road_price", "admin_fee", "installment_amount", "interest_amount", "asset_name"}).
-					AddRow(1, 1, "123456", 500000, 5000, 50000, 5000, "Yamaha NMAX")
-
-				mock.ExpectQuery("SELECT (.+) FROM transactions").WithArgs(args.id, args.customerID).WillReturnRows(rows)
-			},
-		},
-		{
-			name: "Find Transaction By ID and Customer ID With Query Error",
-			args: args{
-				ctx:        context.Background(),
-				id:         1,
-				customerID: 1,
-			},
-			want:    nil,
-			wantErr: true,
-			mockFn: func(args args, mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("SELECT (.+) FROM transactions").WithArgs(args.id, args.customerID).WillReturnError(fmt.Errorf("query failed"))
-			},
-		},
-		{
-			name: "Find Transaction By ID and Customer ID With No Rows",
-			args: args{
-				ctx:        context.Background(),
-				id:         1,
-				customerID: 1,
-			},
-			want:    nil,
-			wantErr: true,
-			mockFn: func(args args, mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "customer_id", "contract_number", "on_the_road_price", "admin_fee", "installment_amount", "interest_amount", "asset_name"})
-
-				mock.ExpectQuery("SELECT (.+) FROM transactions").WithArgs(args.id, args.customerID).WillReturnRows(rows)
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tt.mockFn(tt.args, mock)
-			r := &transactionRepository{
-				db: mysqlDB,
-			}
-			got, err := r.FindTransactionByIdAndCustomerID(tt.args.ctx, tt.args.id, tt.args.customerID)
+func Test_transactionRepository_ClaimTransactionExports(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		now := time.Date(2024, 12, 26, 9, 0, 0, 0, time.UTC)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("FROM transaction_exports WHERE status = ? OR (status = ? AND lease_until <= ?) ORDER BY id ASC LIMIT ? FOR UPDATE SKIP LOCKED")).
+			WithArgs("pending", "running", now, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "format", "locale", "filter", "status", "file_name", "row_count", "error_message", "attempts", "created_at", "finished_at"}).
+				AddRow(9, 1, "csv", "id", "{}", "running", nil, 0, nil, 1, now, nil))
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		got, err := NewTransactionRepository(db).ClaimTransactionExports(context.Background(), tx, now, 2)
+		assert.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, int64(9), got[0].ID)
+			assert.Equal(t, 1, got[0].Attempts)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_transactionRepository_LeaseTransactionExports(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		until := time.Date(2024, 12, 26, 9, 31, 0, 0, time.UTC)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE transaction_exports SET status = ?, attempts = attempts + 1, lease_until = ? WHERE id IN (?, ?)")).
+			WithArgs("running", until, 9, 10).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		r := NewTransactionRepository(db)
+		assert.NoError(t, r.LeaseTransactionExports(context.Background(), tx, []int64{9, 10}, until))
+		assert.NoError(t, r.LeaseTransactionExports(context.Background(), tx, nil, until))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_transactionRepository_FindTransactionExportByIDAndCustomerID(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		r := NewTransactionRepository(db)
+
+		columns := []string{"id", "customer_id", "format", "locale", "filter", "status", "file_name", "row_count", "error_message", "created_at", "finished_at"}
+
+		tests := []struct {
+			name    string
+			mockFn  func()
+			wantErr bool
+		}{
+			{
+				name: "Found",
+				mockFn: func() {
+					mock.ExpectQuery(regexp.QuoteMeta("FROM transaction_exports WHERE id = ? AND customer_id = ?")).
+						WithArgs(9, 1).
+						WillReturnRows(sqlmock.NewRows(columns).
+							AddRow(9, 1, "csv", "id", "{}", "done", "1/transactions-9.csv", 12000, nil, time.Now(), time.Now()))
+				},
+			},
+			{
+				name: "Not Found",
+				mockFn: func() {
+					mock.ExpectQuery(regexp.QuoteMeta("FROM transaction_exports WHERE id = ? AND customer_id = ?")).
+						WithArgs(9, 1).
+						WillReturnError(sql.ErrNoRows)
+				},
+				wantErr: true,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn()
+
+				got, err := r.FindTransactionExportByIDAndCustomerID(context.Background(), 9, 1)
+				assert.Equal(t, tt.wantErr, err != nil)
+				if !tt.wantErr {
+					assert.Equal(t, "1/transactions-9.csv", got.FileName.String)
+					assert.False(t, got.ErrorMessage.Valid)
+				}
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
 
-			assert.Equal(t, tt.wantErr, err != nil, "error state mismatch")
-			assert.Equal(t, tt.want, got, "result mismatch")
+func Test_transactionRepository_FindTransactionDocument(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		r := NewTransactionRepository(db)
+
+		t.Run("Found", func(t *testing.T) {
+			mock.ExpectQuery(regexp.QuoteMeta("FROM transaction_documents WHERE transaction_id = ? AND document_type = ?")).
+				WithArgs(5, "contract").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "transaction_id", "document_type", "template_version", "file_name", "checksum", "created_at"}).
+					AddRow(1, 5, "contract", "v1", "1/contract-5-v1.pdf", "abc", time.Now()))
+
+			got, err := r.FindTransactionDocument(context.Background(), 5, "contract")
+			assert.NoError(t, err)
+			assert.Equal(t, "v1", got.TemplateVersion)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+
+		t.Run("Not Found", func(t *testing.T) {
+			mock.ExpectQuery(regexp.QuoteMeta("FROM transaction_documents WHERE transaction_id = ? AND document_type = ?")).
+				WithArgs(5, "contract").
+				WillReturnError(sql.ErrNoRows)
+
+			_, err := r.FindTransactionDocument(context.Background(), 5, "contract")
+			code, _ := err_msg.Errors[error](err)
+			assert.Equal(t, 404, code)
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
-	}
+	})
+}
+
+func Test_transactionRepository_FindTransactionsWithoutDocument(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		createdAt := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+		columns := []string{"id", "customer_id", "contract_number", "on_the_road_price", "admin_fee", "installment_amount", "interest_amount", "tenor_month", "asset_name", "status", "created_at"}
+
+		tests := []struct {
+			name    string
+			want    []entity.Transaction
+			wantErr bool
+			mockFn  func(mock sqlmock.Sqlmock)
+		}{
+			{
+				name: "Find Transactions Without Contract",
+				want: []entity.Transaction{
+					{ID: 6, CustomerID: 1, ContractNumber: "TRX202412010002", OnTheRoadPrice: 300000, AdminFee: 5000, InstallmentAmount: 100000, TenorMonth: 3, AssetName: "Honda Beat", Status: "active", CreatedAt: createdAt},
+				},
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery("FROM transactions t WHERE t.id > \\? AND NOT EXISTS").
+						WithArgs(5, "contract", 2).
+						WillReturnRows(sqlmock.NewRows(columns).AddRow(6, 1, "TRX202412010002", 300000, 5000, 100000, 0, 3, "Honda Beat", "active", createdAt))
+				},
+			},
+			{
+				name:    "Find Transactions Without Contract With Query Error",
+				wantErr: true,
+				mockFn: func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery("FROM transactions t").
+						WithArgs(5, "contract", 2).
+						WillReturnError(fmt.Errorf("query failed"))
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn(mock)
+				r := &transactionRepository{
+					db: db,
+				}
+
+				got, err := r.FindTransactionsWithoutDocument(context.Background(), "contract", 5, 2)
 
-	assert.NoError(t, mock.ExpectationsWereMet())
+				assert.Equal(t, tt.wantErr, err != nil, "error state mismatch")
+				assert.Equal(t, tt.want, got, "result mismatch")
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
 }
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	customerPorts "github.com/hilmiikhsan/multifinance-service/internal/module/customer/ports"
+	outboxDto "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/entity"
+	transactionPorts "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/document"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/nik"
+	"github.com/hilmiikhsan/multifinance-service/pkg/outbox"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+var _ transactionPorts.TransactionContractService = &contractService{}
+
+type contractService struct {
+	transactionRepository transactionPorts.TransactionRepository
+	customerRepository    customerPorts.CustomerRepository
+	storagePath           string
+	templateVersion       string
+}
+
+func NewContractService(transactionRepository transactionPorts.TransactionRepository, customerRepository customerPorts.CustomerRepository, storagePath, templateVersion string) *contractService {
+	return &contractService{
+		transactionRepository: transactionRepository,
+		customerRepository:    customerRepository,
+		storagePath:           storagePath,
+		templateVersion:       templateVersion,
+	}
+}
+
+// HandleEvent issues the contract of a booked transaction, so the document
+// keeps the template version and customer details of the day it was booked.
+func (s *contractService) HandleEvent(ctx context.Context, event *outbox.Event) error {
+	ctx, span := tracing.Start(ctx, "contractService.HandleEvent")
+	defer span.End()
+
+	switch event.Type {
+	case constants.EventTransactionBooked:
+		return s.handleTransactionBooked(ctx, event)
+	default:
+		return nil
+	}
+}
+
+func (s *contractService) handleTransactionBooked(ctx context.Context, event *outbox.Event) error {
+	var payload outboxDto.TransactionBookedV1
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		// retrying cannot fix the payload, so the event is let go
+		log.Ctx(ctx).Error().Err(err).Str("event_id", event.ID).Msg("service::HandleEvent - Failed to decode transaction booked payload")
+		return nil
+	}
+
+	transaction, err := s.transactionRepository.FindTransactionByContractNumber(ctx, payload.ContractNumber)
+	if err != nil {
+		if err_msg.HasCode(err, fiber.StatusNotFound) {
+			log.Ctx(ctx).Error().Str("event_id", event.ID).Str("contract_number", payload.ContractNumber).Msg("service::HandleEvent - Booked transaction not found")
+			return nil
+		}
+
+		log.Ctx(ctx).Error().Err(err).Str("contract_number", payload.ContractNumber).Msg("service::HandleEvent - Failed to find booked transaction")
+		return err
+	}
+
+	return s.issueContract(ctx, transaction)
+}
+
+// issueContract renders the contract with the configured template version and
+// records it. A contract already issued is left as it is, so an event handed
+// over again does not replace it.
+func (s *contractService) issueContract(ctx context.Context, transaction *entity.Transaction) error {
+	stored, err := s.findContract(ctx, transaction.ID)
+	if err != nil {
+		return err
+	}
+
+	if stored != nil {
+		return nil
+	}
+
+	version := s.templateVersion
+	fileName := filepath.Join(fmt.Sprint(transaction.CustomerID), fmt.Sprintf("contract-%d-%s.pdf", transaction.ID, version))
+
+	checksum, err := s.renderContract(ctx, transaction, version, filepath.Join(s.storagePath, fileName))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("id", transaction.ID).Str("version", version).Msg("service::HandleEvent - Failed to render contract")
+		return err
+	}
+
+	err = s.transactionRepository.InsertNewTransactionDocument(ctx, &entity.TransactionDocument{
+		TransactionID:   transaction.ID,
+		DocumentType:    document.TypeContract,
+		TemplateVersion: version,
+		FileName:        fileName,
+		Checksum:        checksum,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("id", transaction.ID).Msg("service::HandleEvent - Failed to insert contract document")
+		return err
+	}
+
+	log.Ctx(ctx).Info().Int("id", transaction.ID).Str("version", version).Msg("service::HandleEvent - Contract issued")
+	return nil
+}
+
+// BackfillContracts issues the contract of every booked transaction that has
+// none, for bookings whose event was dead-lettered or never relayed. A
+// contract that fails is counted and left for the next run.
+func (s *contractService) BackfillContracts(ctx context.Context, batchSize int) (*dto.ContractBackfillResponse, error) {
+	ctx, span := tracing.Start(ctx, "contractService.BackfillContracts")
+	defer span.End()
+
+	res := new(dto.ContractBackfillResponse)
+
+	for afterID := 0; ctx.Err() == nil; {
+		transactions, err := s.transactionRepository.FindTransactionsWithoutDocument(ctx, document.TypeContract, afterID, batchSize)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Int("after_id", afterID).Msg("service::BackfillContracts - Failed to find transactions without contract")
+			return res, err
+		}
+
+		for i := range transactions {
+			if err := s.issueContract(ctx, &transactions[i]); err != nil {
+				log.Ctx(ctx).Error().Err(err).Int("id", transactions[i].ID).Msg("service::BackfillContracts - Failed to issue contract")
+				res.Failed++
+				continue
+			}
+
+			res.Issued++
+		}
+
+		if len(transactions) < batchSize {
+			return res, nil
+		}
+
+		afterID = transactions[len(transactions)-1].ID
+	}
+
+	return res, ctx.Err()
+}
+
+// GetTransactionContract serves the contract issued when the transaction was
+// booked. It is never rendered here, a contract that is not issued yet is
+// reported as not found.
+func (s *contractService) GetTransactionContract(ctx context.Context, id, customerID int) (*dto.TransactionFile, error) {
+	ctx, span := tracing.Start(ctx, "contractService.GetTransactionContract")
+	defer span.End()
+
+	transaction, err := s.transactionRepository.FindTransactionByIdAndCustomerID(ctx, id, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Int("customer_id", customerID).Msg("service::GetTransactionContract - Failed to find transaction")
+		return nil, err
+	}
+
+	stored, err := s.findContract(ctx, transaction.ID)
+	if err != nil {
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	if stored == nil {
+		log.Ctx(ctx).Warn().Int("id", id).Msg("service::GetTransactionContract - Contract is not issued yet")
+		return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrContractNotIssued))
+	}
+
+	file := &dto.TransactionFile{
+		Path:        filepath.Join(s.storagePath, stored.FileName),
+		FileName:    transaction.ContractNumber + ".pdf",
+		ContentType: document.ContentTypePDF,
+	}
+
+	if _, err := os.Stat(file.Path); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Str("file_name", stored.FileName).Msg("service::GetTransactionContract - Contract file is missing")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	return file, nil
+}
+
+// findContract returns the issued contract of the transaction, or nil when
+// there is none yet.
+func (s *contractService) findContract(ctx context.Context, transactionID int) (*entity.TransactionDocument, error) {
+	stored, err := s.transactionRepository.FindTransactionDocument(ctx, transactionID, document.TypeContract)
+	if err != nil {
+		if err_msg.HasCode(err, fiber.StatusNotFound) {
+			return nil, nil
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int("id", transactionID).Msg("service::findContract - Failed to find contract document")
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// renderContract writes through a temporary file so a concurrent download
+// never sees half a PDF, and returns the SHA-256 of the document.
+func (s *contractService) renderContract(ctx context.Context, transaction *entity.Transaction, version, path string) (string, error) {
+	tmpl, err := document.LoadContractTemplate(version)
+	if err != nil {
+		return "", err
+	}
+
+	customer, err := s.customerRepository.FindCustomerByID(ctx, transaction.CustomerID)
+	if err != nil {
+		return "", err
+	}
+
+	data := &document.ContractData{
+		ContractNumber: transaction.ContractNumber,
+		IssuedAt:       transaction.CreatedAt,
+		Customer: document.Customer{
+			FullName:    customer.FullName,
+			LegalName:   customer.LegalName,
+			Nik:         customer.Nik,
+			BirthPlace:  customer.BirthPlace,
+			BirthDate:   customer.BirthDate,
+			Email:       customer.Email,
+			PhoneNumber: customer.PhoneNumber.String,
+		},
+		AssetName:         transaction.AssetName,
+		OnTheRoadPrice:    transaction.OnTheRoadPrice,
+		AdminFee:          transaction.AdminFee,
+		InterestAmount:    transaction.InterestAmount,
+		InstallmentAmount: transaction.InstallmentAmount,
+		TenorMonth:        transaction.TenorMonth,
+		Schedule:          document.BuildSchedule(transaction.CreatedAt, transaction.OnTheRoadPrice, transaction.InterestAmount, transaction.InstallmentAmount, transaction.TenorMonth),
+	}
+
+	if parsed, err := nik.Parse(customer.Nik); err == nil {
+		data.Customer.Province = parsed.ProvinceName()
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Render(&buf, data); err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".contract-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return "", err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	customerEntity "github.com/hilmiikhsan/multifinance-service/internal/module/customer/entity"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/document"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/outbox"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_contractService_GetTransactionContract(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockTransactionRepository(ctrlMock)
+	mockCustomerRepo := NewMockCustomerRepository(ctrlMock)
+
+	transaction := &entity.Transaction{
+		ID:                5,
+		CustomerID:        1,
+		ContractNumber:    "TRX202412010001",
+		OnTheRoadPrice:    30000000,
+		AdminFee:          600000,
+		InstallmentAmount: 2800000,
+		InterestAmount:    3600000,
+		TenorMonth:        12,
+		AssetName:         "Yamaha NMAX",
+		Status:            constants.TransactionStatusActive,
+		CreatedAt:         time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC),
+	}
+	notFound := err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrDocumentNotFound))
+
+	stored := &entity.TransactionDocument{
+		TransactionID:   5,
+		DocumentType:    document.TypeContract,
+		TemplateVersion: "v1",
+		FileName:        filepath.Join("1", "contract-5-v1.pdf"),
+	}
+
+	tests := []struct {
+		name     string
+		existing bool
+		mockFn   func()
+		wantCode int
+	}{
+		{
+			name:     "Stored Contract Is Served As Is",
+			existing: true,
+			mockFn: func() {
+				mockRepo.EXPECT().FindTransactionByIdAndCustomerID(gomock.Any(), 5, 1).Return(transaction, nil)
+				mockRepo.EXPECT().FindTransactionDocument(gomock.Any(), 5, document.TypeContract).Return(stored, nil)
+			},
+		},
+		{
+			name: "Contract Not Issued Yet",
+			mockFn: func() {
+				mockRepo.EXPECT().FindTransactionByIdAndCustomerID(gomock.Any(), 5, 1).Return(transaction, nil)
+				mockRepo.EXPECT().FindTransactionDocument(gomock.Any(), 5, document.TypeContract).Return(nil, notFound)
+			},
+			wantCode: fiber.StatusNotFound,
+		},
+		{
+			name: "Stored File Is Missing",
+			mockFn: func() {
+				mockRepo.EXPECT().FindTransactionByIdAndCustomerID(gomock.Any(), 5, 1).Return(transaction, nil)
+				mockRepo.EXPECT().FindTransactionDocument(gomock.Any(), 5, document.TypeContract).Return(stored, nil)
+			},
+			wantCode: fiber.StatusInternalServerError,
+		},
+		{
+			name: "Transaction Not Found",
+			mockFn: func() {
+				mockRepo.EXPECT().FindTransactionByIdAndCustomerID(gomock.Any(), 5, 1).
+					Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrTransactionNotFound)))
+			},
+			wantCode: fiber.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storagePath := t.TempDir()
+			path := filepath.Join(storagePath, "1", "contract-5-v1.pdf")
+			if tt.existing {
+				assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+				assert.NoError(t, os.WriteFile(path, []byte("stored"), 0o600))
+			}
+
+			tt.mockFn()
+
+			// the download never renders, so the customer is never read
+			s := NewContractService(mockRepo, mockCustomerRepo, storagePath, "v2")
+
+			got, err := s.GetTransactionContract(context.Background(), 5, 1)
+			if tt.wantCode != 0 {
+				customErr, ok := err.(*err_msg.CustomError)
+				assert.True(t, ok, "expected a custom error")
+				if ok {
+					assert.Equal(t, tt.wantCode, customErr.Code)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, path, got.Path)
+			assert.Equal(t, "TRX202412010001.pdf", got.FileName)
+		})
+	}
+}
+
+func Test_contractService_HandleEvent(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockTransactionRepository(ctrlMock)
+	mockCustomerRepo := NewMockCustomerRepository(ctrlMock)
+
+	transaction := &entity.Transaction{
+		ID:                5,
+		CustomerID:        1,
+		ContractNumber:    "TRX202412010001",
+		OnTheRoadPrice:    30000000,
+		AdminFee:          600000,
+		InstallmentAmount: 2800000,
+		InterestAmount:    3600000,
+		TenorMonth:        12,
+		AssetName:         "Yamaha NMAX",
+		Status:            constants.TransactionStatusActive,
+		CreatedAt:         time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC),
+	}
+	customer := &customerEntity.Customer{
+		ID:         1,
+		Nik:        "3174010101900001",
+		Email:      "budi@example.com",
+		FullName:   "Budi Santoso",
+		LegalName:  "BUDI SANTOSO",
+		BirthPlace: "Jakarta",
+		BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	notFound := err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrDocumentNotFound))
+	booked := &outbox.Event{
+		ID:      "e1",
+		Type:    constants.EventTransactionBooked,
+		Payload: json.RawMessage(`{"customer_id":1,"contract_number":"TRX202412010001"}`),
+	}
+
+	tests := []struct {
+		name       string
+		event      *outbox.Event
+		mockFn     func()
+		wantRender bool
+		wantErr    bool
+	}{
+		{
+			name:  "Booking Renders And Stores The Contract",
+			event: booked,
+			mockFn: func() {
+				mockRepo.EXPECT().FindTransactionByContractNumber(gomock.Any(), "TRX202412010001").Return(transaction, nil)
+				mockRepo.EXPECT().FindTransactionDocument(gomock.Any(), 5, document.TypeContract).Return(nil, notFound)
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), 1).Return(customer, nil)
+				mockRepo.EXPECT().InsertNewTransactionDocument(gomock.Any(), gomock.Cond(func(x any) bool {
+					doc := x.(*entity.TransactionDocument)
+					return doc.TemplateVersion == "v1" && doc.FileName == filepath.Join("1", "contract-5-v1.pdf") && len(doc.Checksum) == 64
+				})).Return(nil)
+			},
+			wantRender: true,
+		},
+		{
+			name:  "Issued Contract Is Left As It Is",
+			event: booked,
+			mockFn: func() {
+				mockRepo.EXPECT().FindTransactionByContractNumber(gomock.Any(), "TRX202412010001").Return(transaction, nil)
+				mockRepo.EXPECT().FindTransactionDocument(gomock.Any(), 5, document.TypeContract).Return(&entity.TransactionDocument{
+					TransactionID:   5,
+					DocumentType:    document.TypeContract,
+					TemplateVersion: "v1",
+					FileName:        filepath.Join("1", "contract-5-v1.pdf"),
+				}, nil)
+			},
+		},
+		{
+			name:  "Insert Document Error Is Retried",
+			event: booked,
+			mockFn: func() {
+				mockRepo.EXPECT().FindTransactionByContractNumber(gomock.Any(), "TRX202412010001").Return(transaction, nil)
+				mockRepo.EXPECT().FindTransactionDocument(gomock.Any(), 5, document.TypeContract).Return(nil, notFound)
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), 1).Return(customer, nil)
+				mockRepo.EXPECT().InsertNewTransactionDocument(gomock.Any(), gomock.Any()).Return(err_msg.NewDatabaseErrors(errors.New("database error")))
+			},
+			wantRender: true,
+			wantErr:    true,
+		},
+		{
+			name:  "Unknown Contract Is Let Go",
+			event: booked,
+			mockFn: func() {
+				mockRepo.EXPECT().FindTransactionByContractNumber(gomock.Any(), "TRX202412010001").
+					Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrTransactionNotFound)))
+			},
+		},
+		{
+			name:   "Other Events Are Skipped",
+			event:  &outbox.Event{ID: "e2", Type: constants.EventCustomerRegistered, Payload: json.RawMessage(`{}`)},
+			mockFn: func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storagePath := t.TempDir()
+
+			tt.mockFn()
+
+			s := NewContractService(mockRepo, mockCustomerRepo, storagePath, "v1")
+
+			err := s.HandleEvent(context.Background(), tt.event)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			content, err := os.ReadFile(filepath.Join(storagePath, "1", "contract-5-v1.pdf"))
+			assert.Equal(t, tt.wantRender, err == nil && bytes.HasPrefix(content, []byte("%PDF-")))
+		})
+	}
+}
+
+func Test_contractService_BackfillContracts(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockTransactionRepository(ctrlMock)
+	mockCustomerRepo := NewMockCustomerRepository(ctrlMock)
+
+	booked := func(id int) entity.Transaction {
+		return entity.Transaction{
+			ID:                id,
+			CustomerID:        1,
+			ContractNumber:    fmt.Sprintf("TRX20241201000%d", id),
+			OnTheRoadPrice:    30000000,
+			AdminFee:          600000,
+			InstallmentAmount: 2800000,
+			InterestAmount:    3600000,
+			TenorMonth:        12,
+			AssetName:         "Yamaha NMAX",
+			Status:            constants.TransactionStatusActive,
+			CreatedAt:         time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC),
+		}
+	}
+	customer := &customerEntity.Customer{
+		ID:         1,
+		Nik:        "3174010101900001",
+		Email:      "budi@example.com",
+		FullName:   "Budi Santoso",
+		LegalName:  "BUDI SANTOSO",
+		BirthPlace: "Jakarta",
+		BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	notFound := err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrDocumentNotFound))
+
+	tests := []struct {
+		name    string
+		mockFn  func()
+		want    *dto.ContractBackfillResponse
+		wantErr bool
+	}{
+		{
+			name: "Missing Contracts Are Issued Page By Page",
+			mockFn: func() {
+				mockRepo.EXPECT().FindTransactionsWithoutDocument(gomock.Any(), document.TypeContract, 0, 2).Return([]entity.Transaction{booked(5), booked(6)}, nil)
+				mockRepo.EXPECT().FindTransactionsWithoutDocument(gomock.Any(), document.TypeContract, 6, 2).Return([]entity.Transaction{booked(7)}, nil)
+
+				for _, id := range []int{5, 6, 7} {
+					mockRepo.EXPECT().FindTransactionDocument(gomock.Any(), id, document.TypeContract).Return(nil, notFound)
+				}
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), 1).Return(customer, nil).Times(3)
+				mockRepo.EXPECT().InsertNewTransactionDocument(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				mockRepo.EXPECT().InsertNewTransactionDocument(gomock.Any(), gomock.Any()).Return(err_msg.NewDatabaseErrors(errors.New("database error")))
+			},
+			want: &dto.ContractBackfillResponse{Issued: 2, Failed: 1},
+		},
+		{
+			name: "Nothing To Backfill",
+			mockFn: func() {
+				mockRepo.EXPECT().FindTransactionsWithoutDocument(gomock.Any(), document.TypeContract, 0, 2).Return([]entity.Transaction{}, nil)
+			},
+			want: &dto.ContractBackfillResponse{},
+		},
+		{
+			name: "Find Error Stops The Run",
+			mockFn: func() {
+				mockRepo.EXPECT().FindTransactionsWithoutDocument(gomock.Any(), document.TypeContract, 0, 2).Return(nil, err_msg.NewDatabaseErrors(errors.New("database error")))
+			},
+			want:    &dto.ContractBackfillResponse{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			s := NewContractService(mockRepo, mockCustomerRepo, t.TempDir(), "v1")
+
+			got, err := s.BackfillContracts(context.Background(), 2)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
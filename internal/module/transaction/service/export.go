@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/entity"
+	transactionPorts "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/export"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+var _ transactionPorts.TransactionExportService = &exportService{}
+
+var exportColumns = map[string][]export.Column{
+	export.LocaleID: {
+		{Header: "No. Kontrak", Kind: export.KindText},
+		{Header: "Tanggal", Kind: export.KindDate},
+		{Header: "Aset", Kind: export.KindText},
+		{Header: "Harga OTR", Kind: export.KindAmount},
+		{Header: "Biaya Admin", Kind: export.KindAmount},
+		{Header: "Cicilan", Kind: export.KindAmount},
+		{Header: "Bunga", Kind: export.KindAmount},
+		{Header: "Tenor (Bulan)", Kind: export.KindInteger},
+		{Header: "Status", Kind: export.KindText},
+	},
+	export.LocaleEN: {
+		{Header: "Contract Number", Kind: export.KindText},
+		{Header: "Date", Kind: export.KindDate},
+		{Header: "Asset", Kind: export.KindText},
+		{Header: "On The Road Price", Kind: export.KindAmount},
+		{Header: "Admin Fee", Kind: export.KindAmount},
+		{Header: "Installment", Kind: export.KindAmount},
+		{Header: "Interest", Kind: export.KindAmount},
+		{Header: "Tenor (Months)", Kind: export.KindInteger},
+		{Header: "Status", Kind: export.KindText},
+	},
+}
+
+type exportService struct {
+	transactionRepository transactionPorts.TransactionRepository
+	storagePath           string
+	maxInlineRows         int
+	maxRows               int
+}
+
+func NewExportService(transactionRepository transactionPorts.TransactionRepository, storagePath string, maxInlineRows, maxRows int) *exportService {
+	return &exportService{
+		transactionRepository: transactionRepository,
+		storagePath:           storagePath,
+		maxInlineRows:         maxInlineRows,
+		maxRows:               maxRows,
+	}
+}
+
+func (s *exportService) ExportTransaction(ctx context.Context, req *dto.ExportTransactionRequest, customerID int) (*dto.TransactionExportResponse, error) {
+	ctx, span := tracing.Start(ctx, "exportService.ExportTransaction")
+	defer span.End()
+
+	if err := validateHistoryFilter(&req.HistoryFilter); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Any("payload", req).Msg("service::ExportTransaction - Invalid history filter")
+		return nil, err
+	}
+
+	total, err := s.transactionRepository.CountTransactionByCustomerID(ctx, &req.HistoryFilter, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("service::ExportTransaction - Failed to count transactions")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	if total > s.maxRows {
+		log.Ctx(ctx).Warn().Int("customer_id", customerID).Int("total", total).Msg("service::ExportTransaction - Export is too large")
+		return nil, err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrExportTooLarge))
+	}
+
+	if total <= s.maxInlineRows {
+		return nil, nil
+	}
+
+	filter, err := json.Marshal(req.HistoryFilter)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::ExportTransaction - Failed to encode filter")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	job := &entity.TransactionExport{
+		CustomerID: customerID,
+		Format:     req.Format,
+		Locale:     req.Locale,
+		Filter:     string(filter),
+		Status:     constants.ExportStatusPending,
+		CreatedAt:  time.Now(),
+	}
+
+	job.ID, err = s.transactionRepository.InsertNewTransactionExport(ctx, job)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("service::ExportTransaction - Failed to insert transaction export")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	return exportResponse(job), nil
+}
+
+func (s *exportService) WriteTransactionExport(ctx context.Context, req *dto.ExportTransactionRequest, customerID int, w io.Writer) error {
+	ctx, span := tracing.Start(ctx, "exportService.WriteTransactionExport")
+	defer span.End()
+
+	_, err := s.writeExport(ctx, req, customerID, s.maxInlineRows, w)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("service::WriteTransactionExport - Failed to write export")
+		return err
+	}
+
+	return nil
+}
+
+func (s *exportService) GetTransactionExport(ctx context.Context, id int64, customerID int) (*dto.TransactionExportResponse, error) {
+	ctx, span := tracing.Start(ctx, "exportService.GetTransactionExport")
+	defer span.End()
+
+	job, err := s.transactionRepository.FindTransactionExportByIDAndCustomerID(ctx, id, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("service::GetTransactionExport - Failed to find transaction export")
+		return nil, err
+	}
+
+	return exportResponse(job), nil
+}
+
+func (s *exportService) GetTransactionExportFile(ctx context.Context, id int64, customerID int) (*dto.TransactionFile, error) {
+	ctx, span := tracing.Start(ctx, "exportService.GetTransactionExportFile")
+	defer span.End()
+
+	job, err := s.transactionRepository.FindTransactionExportByIDAndCustomerID(ctx, id, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("service::GetTransactionExportFile - Failed to find transaction export")
+		return nil, err
+	}
+
+	if job.Status != constants.ExportStatusDone || !job.FileName.Valid {
+		log.Ctx(ctx).Warn().Int64("id", id).Str("status", job.Status).Msg("service::GetTransactionExportFile - Export is not ready")
+		return nil, err_msg.NewCustomErrors(fiber.StatusConflict, err_msg.WithMessage(constants.ErrExportNotReady))
+	}
+
+	return &dto.TransactionFile{
+		Path:        filepath.Join(s.storagePath, job.FileName.String),
+		FileName:    exportFileName(job.ID, job.Format),
+		ContentType: export.ContentType(job.Format),
+	}, nil
+}
+
+func (s *exportService) writeExportFile(ctx context.Context, req *dto.ExportTransactionRequest, customerID int, path string) (int, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return 0, err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := s.writeExport(ctx, req, customerID, s.maxRows, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(path)
+		return rows, err
+	}
+
+	return rows, nil
+}
+
+func (s *exportService) writeExport(ctx context.Context, req *dto.ExportTransactionRequest, customerID, limit int, w io.Writer) (int, error) {
+	locale := export.LookupLocale(req.Locale)
+
+	writer, err := export.NewWriter(req.Format, w, locale, exportColumns[locale.Code])
+	if err != nil {
+		return 0, err
+	}
+
+	var rows int
+	err = s.transactionRepository.StreamTransactionByCustomerID(ctx, &req.HistoryFilter, customerID, limit, func(t *entity.Transaction) error {
+		rows++
+		return writer.WriteRow(
+			t.ContractNumber,
+			t.CreatedAt,
+			t.AssetName,
+			t.OnTheRoadPrice,
+			t.AdminFee,
+			t.InstallmentAmount,
+			t.InterestAmount,
+			t.TenorMonth,
+			t.Status,
+		)
+	})
+	if err != nil {
+		writer.Close()
+		return rows, err
+	}
+
+	return rows, writer.Close()
+}
+
+func exportFileName(id int64, format string) string {
+	return fmt.Sprintf("transactions-%d.%s", id, format)
+}
+
+func exportResponse(job *entity.TransactionExport) *dto.TransactionExportResponse {
+	res := &dto.TransactionExportResponse{
+		ID:           job.ID,
+		Format:       job.Format,
+		Locale:       job.Locale,
+		Status:       job.Status,
+		RowCount:     job.RowCount,
+		ErrorMessage: job.ErrorMessage.String,
+		CreatedAt:    job.CreatedAt.Format(constants.DateTimeFormat),
+	}
+
+	if job.FinishedAt.Valid {
+		res.FinishedAt = job.FinishedAt.Time.Format(constants.DateTimeFormat)
+	}
+
+	return res
+}
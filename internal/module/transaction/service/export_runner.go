@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/entity"
+	transactionPorts "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ transactionPorts.TransactionExportRunner = &exportRunnerService{}
+
+// the lease outlasts the job timeout, so a job is only claimed again once its
+// worker is gone and not while it is still recording the result
+const exportLeaseMargin = time.Minute
+
+var errExportAttemptsExceeded = errors.New("export was claimed too many times")
+
+type exportRunnerService struct {
+	db          *sqlx.DB
+	exporter    *exportService
+	timeout     time.Duration
+	maxAttempts int
+	slots       chan struct{}
+	now         func() time.Time
+}
+
+func NewExportRunnerService(db *sqlx.DB, transactionRepository transactionPorts.TransactionRepository, storagePath string, maxRows, concurrency int, timeout time.Duration, maxAttempts int) *exportRunnerService {
+	return &exportRunnerService{
+		db: db,
+		exporter: &exportService{
+			transactionRepository: transactionRepository,
+			storagePath:           storagePath,
+			maxRows:               maxRows,
+		},
+		timeout:     timeout,
+		maxAttempts: maxAttempts,
+		slots:       make(chan struct{}, concurrency),
+		now:         time.Now,
+	}
+}
+
+// RunPendingExports claims as many queued exports as there are free slots and
+// writes them, it returns once they are all recorded.
+func (s *exportRunnerService) RunPendingExports(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "exportRunnerService.RunPendingExports")
+	defer span.End()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	return s.startPendingExports(ctx, &wg)
+}
+
+// Run fills the free slots every interval until ctx is done. Exports that are
+// still running then go back to the queue for the next worker. Run starts no
+// span of its own, each claim and each export is traced on its own.
+func (s *exportRunnerService) Run(ctx context.Context, interval time.Duration) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		started, err := s.startPendingExports(ctx, &wg)
+		if err == nil && started > 0 {
+			log.Ctx(ctx).Info().Int("started", started).Int("running", len(s.slots)).Msg("service::Run - Started transaction exports")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// startPendingExports claims up to the free slots, a job holds its slot until
+// its result is recorded.
+func (s *exportRunnerService) startPendingExports(ctx context.Context, wg *sync.WaitGroup) (int, error) {
+	free := cap(s.slots) - len(s.slots)
+	if free == 0 {
+		return 0, nil
+	}
+
+	jobs, err := s.claimExports(ctx, free)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range jobs {
+		s.slots <- struct{}{}
+		wg.Add(1)
+
+		go func(job *entity.TransactionExport) {
+			defer func() {
+				<-s.slots
+				wg.Done()
+			}()
+
+			s.runExportJob(ctx, job)
+		}(&jobs[i])
+	}
+
+	return len(jobs), nil
+}
+
+func (s *exportRunnerService) claimExports(ctx context.Context, limit int) ([]entity.TransactionExport, error) {
+	ctx, span := tracing.Start(ctx, "exportRunnerService.claimExports")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::RunPendingExports - Failed to begin transaction")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Ctx(ctx).Error().Err(rollbackErr).Msg("service::RunPendingExports - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	now := s.now()
+
+	jobs, err := s.exporter.transactionRepository.ClaimTransactionExports(ctx, tx, now, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::RunPendingExports - Failed to claim transaction exports")
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(jobs))
+	for i := range jobs {
+		ids = append(ids, jobs[i].ID)
+	}
+
+	err = s.exporter.transactionRepository.LeaseTransactionExports(ctx, tx, ids, now.Add(s.timeout+exportLeaseMargin))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::RunPendingExports - Failed to lease transaction exports")
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::RunPendingExports - Failed to commit transaction")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	return jobs, nil
+}
+
+// runExportJob writes the file next to the other exports of the customer and
+// records the outcome; a failed job leaves no file behind. A job cut off by
+// the worker stopping goes back to the queue, one whose worker kept dying is
+// failed once it has used up its attempts.
+func (s *exportRunnerService) runExportJob(ctx context.Context, job *entity.TransactionExport) {
+	ctx, span := tracing.Start(ctx, "exportRunnerService.runExportJob")
+	defer span.End()
+
+	job.Attempts++
+
+	fileName := filepath.Join(fmt.Sprint(job.CustomerID), exportFileName(job.ID, job.Format))
+
+	rows, err := 0, errExportAttemptsExceeded
+	if job.Attempts <= s.maxAttempts {
+		rows, err = s.writeExportJob(ctx, job, fileName)
+	}
+
+	// the result is recorded even when the job ran out of time or was stopped
+	recordCtx := context.WithoutCancel(ctx)
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		log.Ctx(ctx).Warn().Err(err).Int64("id", job.ID).Msg("service::runExportJob - Export was stopped, returning it to the queue")
+		job.Status = constants.ExportStatusPending
+	case err != nil:
+		log.Ctx(ctx).Error().Err(err).Int64("id", job.ID).Int("attempts", job.Attempts).Msg("service::runExportJob - Failed to write export")
+		job.Status = constants.ExportStatusFailed
+		job.RowCount = rows
+		job.ErrorMessage = sql.NullString{String: constants.ErrInternalServerError, Valid: true}
+		job.FinishedAt = sql.NullTime{Time: s.now(), Valid: true}
+	default:
+		job.Status = constants.ExportStatusDone
+		job.RowCount = rows
+		job.FileName = sql.NullString{String: fileName, Valid: true}
+		job.FinishedAt = sql.NullTime{Time: s.now(), Valid: true}
+	}
+
+	if err := s.exporter.transactionRepository.UpdateTransactionExport(recordCtx, job); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", job.ID).Msg("service::runExportJob - Failed to record export result")
+	}
+}
+
+func (s *exportRunnerService) writeExportJob(ctx context.Context, job *entity.TransactionExport, fileName string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	req := &dto.ExportTransactionRequest{
+		Format: job.Format,
+		Locale: job.Locale,
+	}
+
+	if err := json.Unmarshal([]byte(job.Filter), &req.HistoryFilter); err != nil {
+		return 0, err
+	}
+
+	return s.exporter.writeExportFile(ctx, req, job.CustomerID, filepath.Join(s.exporter.storagePath, fileName))
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_exportRunnerService_RunPendingExports(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockTransactionRepository(ctrlMock)
+
+	now := time.Date(2024, 12, 26, 9, 0, 0, 0, time.UTC)
+
+	queued := func(attempts int) entity.TransactionExport {
+		return entity.TransactionExport{
+			ID:         9,
+			CustomerID: 1,
+			Format:     "csv",
+			Locale:     "id",
+			Filter:     `{"Status":"active"}`,
+			Status:     constants.ExportStatusRunning,
+			Attempts:   attempts,
+		}
+	}
+
+	activeOnly := gomock.Cond(func(x any) bool {
+		return x.(*dto.HistoryFilter).Status == constants.TransactionStatusActive
+	})
+
+	tests := []struct {
+		name         string
+		job          entity.TransactionExport
+		mockFn       func(stop context.CancelFunc)
+		wantStatus   string
+		wantAttempts int
+		wantFile     bool
+	}{
+		{
+			name: "Written",
+			job:  queued(0),
+			mockFn: func(stop context.CancelFunc) {
+				mockRepo.EXPECT().StreamTransactionByCustomerID(gomock.Any(), activeOnly, 1, 5, gomock.Any()).DoAndReturn(streamTransactions(3))
+			},
+			wantStatus:   constants.ExportStatusDone,
+			wantAttempts: 1,
+			wantFile:     true,
+		},
+		{
+			name: "Failed Job Is Recorded",
+			job:  queued(1),
+			mockFn: func(stop context.CancelFunc) {
+				mockRepo.EXPECT().StreamTransactionByCustomerID(gomock.Any(), activeOnly, 1, 5, gomock.Any()).Return(errors.New("connection reset"))
+			},
+			wantStatus:   constants.ExportStatusFailed,
+			wantAttempts: 2,
+		},
+		{
+			name:         "Failed Once Attempts Are Used Up",
+			job:          queued(3),
+			mockFn:       func(stop context.CancelFunc) {},
+			wantStatus:   constants.ExportStatusFailed,
+			wantAttempts: 4,
+		},
+		{
+			name: "Returned To The Queue When The Worker Stops",
+			job:  queued(0),
+			mockFn: func(stop context.CancelFunc) {
+				mockRepo.EXPECT().StreamTransactionByCustomerID(gomock.Any(), activeOnly, 1, 5, gomock.Any()).DoAndReturn(func(ctx context.Context, _ *dto.HistoryFilter, _, _ int, _ func(*entity.Transaction) error) error {
+					stop()
+					return ctx.Err()
+				})
+			},
+			wantStatus:   constants.ExportStatusPending,
+			wantAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, dbMock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			ctx, stop := context.WithCancel(context.Background())
+			defer stop()
+
+			dbMock.ExpectBegin()
+			mockRepo.EXPECT().ClaimTransactionExports(gomock.Any(), gomock.Any(), now, 2).Return([]entity.TransactionExport{tt.job}, nil)
+			mockRepo.EXPECT().LeaseTransactionExports(gomock.Any(), gomock.Any(), []int64{9}, now.Add(10*time.Minute+exportLeaseMargin)).Return(nil)
+			dbMock.ExpectCommit()
+
+			tt.mockFn(stop)
+
+			mockRepo.EXPECT().UpdateTransactionExport(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, job *entity.TransactionExport) error {
+				assert.NoError(t, ctx.Err())
+				assert.Equal(t, tt.wantStatus, job.Status)
+				assert.Equal(t, tt.wantAttempts, job.Attempts)
+				assert.Equal(t, tt.wantStatus != constants.ExportStatusPending, job.FinishedAt.Valid)
+				assert.Equal(t, tt.wantFile, job.FileName.Valid)
+				return nil
+			})
+
+			storagePath := t.TempDir()
+			s := NewExportRunnerService(sqlx.NewDb(db, "mysql"), mockRepo, storagePath, 5, 2, 10*time.Minute, 3)
+			s.now = func() time.Time { return now }
+
+			started, err := s.RunPendingExports(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, started)
+			assert.Empty(t, s.slots)
+			assert.NoError(t, dbMock.ExpectationsWereMet())
+
+			_, statErr := os.Stat(filepath.Join(storagePath, "1", "transactions-9.csv"))
+			assert.Equal(t, tt.wantFile, statErr == nil)
+		})
+	}
+}
+
+func Test_exportRunnerService_RunPendingExports_NoFreeSlot(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	// every slot is taken, so nothing is claimed
+	s := NewExportRunnerService(nil, NewMockTransactionRepository(ctrlMock), t.TempDir(), 5, 1, time.Minute, 3)
+	s.slots <- struct{}{}
+
+	started, err := s.RunPendingExports(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, started)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/entity"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func streamTransactions(n int) func(context.Context, *dto.HistoryFilter, int, int, func(*entity.Transaction) error) error {
+	return func(_ context.Context, _ *dto.HistoryFilter, _, _ int, fn func(*entity.Transaction) error) error {
+		for i := 1; i <= n; i++ {
+			err := fn(&entity.Transaction{
+				ID:                i,
+				ContractNumber:    "KTR-1",
+				OnTheRoadPrice:    12500000,
+				AdminFee:          50000,
+				InstallmentAmount: 1100000,
+				InterestAmount:    100000,
+				TenorMonth:        12,
+				AssetName:         "Yamaha NMAX",
+				Status:            constants.TransactionStatusActive,
+				CreatedAt:         time.Date(2024, 12, 1, 3, 0, 0, 0, time.UTC),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func Test_exportService_ExportTransaction(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockTransactionRepository(ctrlMock)
+
+	tests := []struct {
+		name    string
+		req     *dto.ExportTransactionRequest
+		mockFn  func()
+		wantJob bool
+		wantErr bool
+	}{
+		{
+			name: "Small Export Is Streamed",
+			req:  &dto.ExportTransactionRequest{Format: "csv", Locale: "id"},
+			mockFn: func() {
+				mockRepo.EXPECT().CountTransactionByCustomerID(gomock.Any(), gomock.Any(), 1).Return(2, nil)
+			},
+		},
+		{
+			name: "Large Export Is Queued",
+			req:  &dto.ExportTransactionRequest{Format: "xlsx", Locale: "en", HistoryFilter: dto.HistoryFilter{Status: constants.TransactionStatusActive}},
+			mockFn: func() {
+				mockRepo.EXPECT().CountTransactionByCustomerID(gomock.Any(), gomock.Any(), 1).Return(3, nil)
+				mockRepo.EXPECT().InsertNewTransactionExport(gomock.Any(), gomock.Cond(func(x any) bool {
+					job := x.(*entity.TransactionExport)
+					return job.Status == constants.ExportStatusPending && job.Format == "xlsx" && strings.Contains(job.Filter, `"Status":"active"`)
+				})).Return(int64(9), nil)
+			},
+			wantJob: true,
+		},
+		{
+			name: "Too Many Rows",
+			req:  &dto.ExportTransactionRequest{Format: "csv", Locale: "id"},
+			mockFn: func() {
+				mockRepo.EXPECT().CountTransactionByCustomerID(gomock.Any(), gomock.Any(), 1).Return(6, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Date Range",
+			req:     &dto.ExportTransactionRequest{Format: "csv", HistoryFilter: dto.HistoryFilter{StartDate: "2024-12-02", EndDate: "2024-12-01"}},
+			mockFn:  func() {},
+			wantErr: true,
+		},
+		{
+			name: "Count Failed",
+			req:  &dto.ExportTransactionRequest{Format: "csv", Locale: "id"},
+			mockFn: func() {
+				mockRepo.EXPECT().CountTransactionByCustomerID(gomock.Any(), gomock.Any(), 1).Return(0, errors.New(constants.ErrInternalServerError))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			s := &exportService{
+				transactionRepository: mockRepo,
+				maxInlineRows:         2,
+				maxRows:               5,
+			}
+
+			got, err := s.ExportTransaction(context.Background(), tt.req, 1)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantJob, got != nil)
+
+			if tt.wantJob {
+				assert.Equal(t, int64(9), got.ID)
+				assert.Equal(t, constants.ExportStatusPending, got.Status)
+			}
+		})
+	}
+}
+
+func Test_exportService_WriteTransactionExport(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockTransactionRepository(ctrlMock)
+	mockRepo.EXPECT().StreamTransactionByCustomerID(gomock.Any(), gomock.Any(), 1, 2, gomock.Any()).DoAndReturn(streamTransactions(1))
+
+	s := &exportService{transactionRepository: mockRepo, maxInlineRows: 2}
+
+	var buf bytes.Buffer
+	err := s.WriteTransactionExport(context.Background(), &dto.ExportTransactionRequest{Format: "csv", Locale: "id"}, 1, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"No. Kontrak;Tanggal;Aset;Harga OTR;Biaya Admin;Cicilan;Bunga;Tenor (Bulan);Status\n"+
+			"KTR-1;01/12/2024 10:00;Yamaha NMAX;12.500.000,00;50.000,00;1.100.000,00;100.000,00;12;active\n",
+		buf.String())
+}
+
+func Test_exportService_GetTransactionExportFile(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockTransactionRepository(ctrlMock)
+
+	tests := []struct {
+		name    string
+		job     *entity.TransactionExport
+		wantErr bool
+	}{
+		{
+			name: "Done",
+			job:  &entity.TransactionExport{ID: 9, Format: "xlsx", Status: constants.ExportStatusDone, FileName: sql.NullString{String: "1/transactions-9.xlsx", Valid: true}},
+		},
+		{
+			name:    "Still Running",
+			job:     &entity.TransactionExport{ID: 9, Format: "xlsx", Status: constants.ExportStatusRunning},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo.EXPECT().FindTransactionExportByIDAndCustomerID(gomock.Any(), int64(9), 1).Return(tt.job, nil)
+
+			s := &exportService{transactionRepository: mockRepo, storagePath: "/exports"}
+
+			got, err := s.GetTransactionExportFile(context.Background(), 9, 1)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, filepath.Join("/exports", "1", "transactions-9.xlsx"), got.Path)
+				assert.Equal(t, "transactions-9.xlsx", got.FileName)
+			}
+		})
+	}
+}
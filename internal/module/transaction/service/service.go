@@ -2,114 +2,578 @@ package service
 
 import (
 	"context"
-	"strings"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hilmiikhsan/multifinance-service/constants"
 	creditLimitPorts "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/ports"
+	creditScoreEntity "github.com/hilmiikhsan/multifinance-service/internal/module/credit_score/entity"
+	creditScorePorts "github.com/hilmiikhsan/multifinance-service/internal/module/credit_score/ports"
+	customerPorts "github.com/hilmiikhsan/multifinance-service/internal/module/customer/ports"
+	fraudDto "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/dto"
+	fraudPorts "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/ports"
+	outboxDto "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/dto"
+	outboxEntity "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/entity"
+	outboxPorts "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/ports"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/entity"
 	transactionPorts "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/ports"
+	webhookDto "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/dto"
+	webhookPorts "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/cursor"
+	"github.com/hilmiikhsan/multifinance-service/pkg/document"
+	"github.com/hilmiikhsan/multifinance-service/pkg/eligibility"
 	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/metrics"
+	"github.com/hilmiikhsan/multifinance-service/pkg/scoring"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
 	"github.com/hilmiikhsan/multifinance-service/pkg/utils"
+	"github.com/hilmiikhsan/multifinance-service/pkg/velocity"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
 )
 
 var _ transactionPorts.TransactionService = &transactionService{}
 
+// velocityReleaseTimeout bounds the release of a velocity reservation after a
+// failed booking, which runs even when the request is cancelled.
+const velocityReleaseTimeout = 2 * time.Second
+
 type transactionService struct {
 	db                    *sqlx.DB
 	transactionRepository transactionPorts.TransactionRepository
 	creditLimitRepository creditLimitPorts.CreditLimitRepository
+	customerRepository    customerPorts.CustomerRepository
+	eligibility           *eligibility.Engine
+	maxDebtToIncomeRatio  float64
+	creditScoreRepository creditScorePorts.CreditScoreRepository
+	scorer                scoring.Scorer
+	fraud                 fraudPorts.FraudScreener
+	velocity              velocity.Limiter
+	summaryCache          customerPorts.CustomerSummaryCache
+	outboxRepository      outboxPorts.OutboxRepository
+	webhooks              webhookPorts.WebhookEnqueuer
 }
 
-func NewTransactionService(db *sqlx.DB, transactionRepository transactionPorts.TransactionRepository, creditLimitRepository creditLimitPorts.CreditLimitRepository) *transactionService {
+func NewTransactionService(db *sqlx.DB, transactionRepository transactionPorts.TransactionRepository, creditLimitRepository creditLimitPorts.CreditLimitRepository, customerRepository customerPorts.CustomerRepository, eligibility *eligibility.Engine, maxDebtToIncomeRatio float64, creditScoreRepository creditScorePorts.CreditScoreRepository, scorer scoring.Scorer, fraud fraudPorts.FraudScreener, velocity velocity.Limiter, summaryCache customerPorts.CustomerSummaryCache, outboxRepository outboxPorts.OutboxRepository, webhooks webhookPorts.WebhookEnqueuer) *transactionService {
 	return &transactionService{
 		db:                    db,
 		transactionRepository: transactionRepository,
 		creditLimitRepository: creditLimitRepository,
+		customerRepository:    customerRepository,
+		eligibility:           eligibility,
+		maxDebtToIncomeRatio:  maxDebtToIncomeRatio,
+		creditScoreRepository: creditScoreRepository,
+		scorer:                scorer,
+		fraud:                 fraud,
+		velocity:              velocity,
+		summaryCache:          summaryCache,
+		outboxRepository:      outboxRepository,
+		webhooks:              webhooks,
 	}
 }
 
 func (s *transactionService) CreateTransaction(ctx context.Context, req *dto.CreateTransactionRequest) error {
-	// Step 0: Begin transaction
-	tx, err := s.db.Begin()
+	ctx, span := tracing.Start(ctx, "transactionService.CreateTransaction")
+	defer span.End()
+
+	// Step 0: Check the customer is eligible for the requested tenor
+	customer, err := s.customerRepository.FindCustomerByID(ctx, req.CustomerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Failed to find customer")
+		if err_msg.HasCode(err, fiber.StatusNotFound) {
+			return err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound))
+		}
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	reasons := s.eligibility.Evaluate(eligibility.Applicant{
+		BirthDate:  customer.BirthDate,
+		TenorMonth: req.TenorMonth,
+		AppliedAt:  time.Now(),
+	})
+	if len(reasons) > 0 {
+		log.Ctx(ctx).Warn().Any("reasons", reasons).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Customer is not eligible")
+		return eligibility.NewRejectionError(reasons)
+	}
+
+	// a booking made through a partner is attributed to it and reported to its webhooks
+	var partnerID sql.NullInt64
+	if req.PartnerCode != "" {
+		id, err := s.webhooks.FindPartnerIDByCode(ctx, req.PartnerCode)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("partner_code", req.PartnerCode).Msg("service::CreateTransaction - Failed to find partner")
+			return err
+		}
+
+		partnerID = sql.NullInt64{Int64: id, Valid: true}
+	}
+
+	// photos were already compared at registration, a booking re-checks the watchlist
+	screening, err := s.fraud.Screen(ctx, &fraudDto.ScreeningSubject{
+		CustomerID:  customer.ID,
+		Nik:         customer.Nik,
+		Email:       customer.Email,
+		PhoneNumber: customer.PhoneNumber.String,
+		DeviceID:    req.DeviceID,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Failed to screen customer")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	// the booking counts against the velocity rules of its channel unless it fails
+	reservation, err := s.velocity.Reserve(ctx, req.CustomerID, req.Channel, float64(req.OnTheRoadPrice))
+	if err != nil {
+		var breach *velocity.Breach
+		if errors.As(err, &breach) {
+			return s.rejectVelocityBreach(ctx, req, breach)
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Failed to check velocity")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	booked := false
+	defer func() {
+		if !booked {
+			// the request deadline may have cancelled ctx, the reservation is released anyway
+			releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), velocityReleaseTimeout)
+			defer cancel()
+
+			if releaseErr := s.velocity.Release(releaseCtx, reservation); releaseErr != nil {
+				log.Ctx(ctx).Error().Err(releaseErr).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Failed to release velocity")
+			}
+		}
+	}()
+
+	// Step 1: Begin transaction
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("service::CreateTransaction - Failed to begin transaction")
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("service::CreateTransaction - Failed to begin transaction")
 		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 	defer func() {
 		if err != nil {
 			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				log.Error().Err(rollbackErr).Any("payload", req).Msg("service::CreateTransaction - Failed to rollback transaction")
+				log.Ctx(ctx).Error().Err(rollbackErr).Any("payload", req).Msg("service::CreateTransaction - Failed to rollback transaction")
 			}
 		}
 	}()
 
-	// Step 1: Validate tenor and credit limit with locking
+	// Step 2: Lock the customer so concurrent bookings on any tenor are affordability-checked one at a time
+	customerLock, err := s.customerRepository.LockCustomerByID(ctx, tx, req.CustomerID)
+	if err != nil {
+		if err_msg.HasCode(err, fiber.StatusNotFound) {
+			log.Ctx(ctx).Warn().Err(err).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Customer not found")
+			return err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Failed to lock customer")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	switch customerLock.ReviewStatus {
+	case constants.CustomerReviewStatusRejected:
+		log.Ctx(ctx).Warn().Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Customer account is rejected")
+		err = err_msg.NewCustomErrors(fiber.StatusForbidden, err_msg.WithMessage(constants.ErrAccountRejected))
+		return err
+	case constants.CustomerReviewStatusPendingReview:
+		log.Ctx(ctx).Warn().Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Customer account is under review")
+		err = err_msg.NewCustomErrors(fiber.StatusForbidden, err_msg.WithMessage(constants.ErrAccountUnderReview))
+		return err
+	}
+
+	// a new fraud signal opens a review case that is kept even though the booking is refused,
+	// a signal staff already decided on does not hold the customer again
+	if screening.Flagged() {
+		var underReview bool
+		underReview, err = s.fraud.OpenReview(ctx, tx, int64(req.CustomerID), constants.FraudTriggerTransaction, screening.Reasons)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Failed to open fraud review")
+			return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		}
+
+		if underReview {
+			log.Ctx(ctx).Warn().Any("reasons", screening.Reasons).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Customer flagged for manual review")
+
+			err = tx.Commit()
+			if err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("service::CreateTransaction - Failed to commit fraud review")
+				return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+			}
+
+			return err_msg.NewCustomErrors(fiber.StatusForbidden, err_msg.WithMessage(constants.ErrAccountUnderReview))
+		}
+	}
+
+	// Step 3: Validate tenor and credit limit with locking
 	creditLimit, err := s.creditLimitRepository.FindLimitByCustomerAndTenor(ctx, tx, req.CustomerID, req.TenorMonth)
 	if err != nil {
-		log.Error().Err(err).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Failed to find credit limit for customer and tenor")
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Failed to find credit limit for customer and tenor")
 		return err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrInvalidOrCreditLimit))
 	}
 
-	// Step 2: Validate OnTheRoadPrice does not exceed limit amount
+	// Step 4: Validate OnTheRoadPrice does not exceed limit amount
 	if req.OnTheRoadPrice > int(creditLimit.LimitAmount) {
-		log.Warn().
+		log.Ctx(ctx).Warn().
 			Int("customer_id", req.CustomerID).
 			Int("on_the_road_price", req.OnTheRoadPrice).
 			Float64("limit_amount", creditLimit.LimitAmount).
 			Msg("service::CreateTransaction - On the road price exceeds credit limit")
-		return err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrOnTheRoadPriceExceedLimit))
+		metrics.LimitRejections.WithLabelValues(strconv.Itoa(req.TenorMonth)).Inc()
+		err = err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrOnTheRoadPriceExceedLimit))
+		return err
 	}
 
-	// Step 3: Generate contract number
+	// Step 5: Generate contract number
 	contractNumber := utils.GenerateContractNumber(req.CustomerID)
 
-	// Step 4: Calculate fees and amounts
+	// Step 6: Calculate fees and amounts
 	adminFee := utils.CalculateAdminFee(req.OnTheRoadPrice)
 	interestAmount := utils.CalculateInterest(req.OnTheRoadPrice, req.TenorMonth)
 	installmentAmount := utils.CalculateInstallment(req.OnTheRoadPrice, interestAmount, req.TenorMonth)
 
-	// Step 5: Create transaction entity
+	// Step 7: Validate installments on active contracts plus the new one stay within the allowed share of income
+	activeInstallment, err := s.transactionRepository.SumActiveInstallmentByCustomerID(ctx, tx, req.CustomerID, time.Now())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Failed to sum active installments")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	debtToIncomeRatio := utils.CalculateDebtToIncomeRatio(activeInstallment+float64(installmentAmount), customerLock.Salary)
+	if customerLock.Salary <= 0 || debtToIncomeRatio > s.maxDebtToIncomeRatio {
+		log.Ctx(ctx).Warn().
+			Int("customer_id", req.CustomerID).
+			Float64("active_installment", activeInstallment).
+			Int("installment_amount", installmentAmount).
+			Float64("salary", customerLock.Salary).
+			Float64("debt_to_income_ratio", debtToIncomeRatio).
+			Msg("service::CreateTransaction - Debt to income ratio exceeds maximum")
+		err = err_msg.NewCustomErrors(fiber.StatusUnprocessableEntity,
+			err_msg.WithMessage(constants.ErrDebtToIncomeRatioExceeded),
+			err_msg.WithErrors("debt_to_income_ratio", strconv.FormatFloat(debtToIncomeRatio, 'f', 4, 64)),
+			err_msg.WithErrors("max_debt_to_income_ratio", strconv.FormatFloat(s.maxDebtToIncomeRatio, 'f', 4, 64)),
+		)
+		return err
+	}
+
+	// Step 8: Score the customer; the score is kept even when the grade rejects the booking
+	now := time.Now()
+	latePayments, usedAmount, err := s.paymentHistory(ctx, tx, req.CustomerID, req.TenorMonth, now)
+	if err != nil {
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	score, err := s.scorer.Score(scoring.Inputs{
+		Age:          eligibility.AgeAt(customer.BirthDate, now),
+		Salary:       customerLock.Salary,
+		TenureMonths: scoring.MonthsBetween(customer.CreatedAt, now),
+		LatePayments: latePayments,
+		Utilisation:  (usedAmount + float64(req.OnTheRoadPrice)) / creditLimit.LimitAmount,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Failed to calculate credit score")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	creditScore, err := creditScoreEntity.NewCreditScore(int64(req.CustomerID), constants.CreditScoreTriggerTransaction, score)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("score", score).Msg("service::CreateTransaction - Failed to build credit score")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	err = s.creditScoreRepository.InsertNewCreditScore(ctx, tx, creditScore)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", creditScore).Msg("service::CreateTransaction - Failed to insert new credit score")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	if !score.Grade.Approve {
+		log.Ctx(ctx).Warn().
+			Int("customer_id", req.CustomerID).
+			Int("score", score.Score).
+			Str("grade", score.Grade.Name).
+			Strs("reason_codes", score.ReasonCodes).
+			Msg("service::CreateTransaction - Credit score is too low")
+
+		err = tx.Commit()
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("service::CreateTransaction - Failed to commit credit score")
+			return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		}
+
+		rejection := err_msg.NewCustomErrors(fiber.StatusUnprocessableEntity,
+			err_msg.WithMessage(constants.ErrCreditScoreTooLow),
+			err_msg.WithErrors("grade", score.Grade.Name),
+		)
+		for _, reasonCode := range score.ReasonCodes {
+			rejection.Add("credit_score", reasonCode)
+		}
+		return rejection
+	}
+
+	// Step 9: Create transaction entity
 	transaction := &entity.Transaction{
 		CustomerID:        req.CustomerID,
+		PartnerID:         partnerID,
 		ContractNumber:    contractNumber,
 		OnTheRoadPrice:    float64(req.OnTheRoadPrice),
 		AdminFee:          float64(adminFee),
 		InstallmentAmount: float64(installmentAmount),
 		InterestAmount:    float64(interestAmount),
+		TenorMonth:        req.TenorMonth,
 		AssetName:         req.AssetName,
+		Status:            constants.TransactionStatusActive,
 	}
 
-	// Step 6: Insert transaction into database
+	// Step 10: Insert transaction into database
 	err = s.transactionRepository.InsertNewTransaction(ctx, tx, transaction)
 	if err != nil {
-		log.Error().Err(err).Msg("service::CreateTransaction - Failed to insert new transaction")
+		log.Ctx(ctx).Error().Err(err).Msg("service::CreateTransaction - Failed to insert new transaction")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	// Step 11: Record the booking event, keyed by customer so it follows the registration events
+	booking, err := outboxEntity.NewOutboxEvent(constants.AggregateTypeCustomer, strconv.Itoa(req.CustomerID), constants.EventTransactionBooked, constants.EventTransactionBookedVersion, &outboxDto.TransactionBookedV1{
+		CustomerID:        req.CustomerID,
+		ContractNumber:    transaction.ContractNumber,
+		Channel:           req.Channel,
+		PartnerCode:       req.PartnerCode,
+		AssetName:         transaction.AssetName,
+		OnTheRoadPrice:    transaction.OnTheRoadPrice,
+		AdminFee:          transaction.AdminFee,
+		InstallmentAmount: transaction.InstallmentAmount,
+		InterestAmount:    transaction.InterestAmount,
+		TenorMonth:        transaction.TenorMonth,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::CreateTransaction - Failed to build booking event")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	err = s.outboxRepository.InsertNewOutboxEvent(ctx, tx, booking)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::CreateTransaction - Failed to record booking event")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	// Step 12: Queue the partner webhooks, the dispatcher sends them once the booking is committed
+	if partnerID.Valid {
+		err = s.webhooks.EnqueueEvent(ctx, tx, partnerID.Int64, constants.WebhookEventContractCreated, &webhookDto.ContractData{
+			ContractNumber:    transaction.ContractNumber,
+			PartnerCode:       req.PartnerCode,
+			Status:            transaction.Status,
+			AssetName:         transaction.AssetName,
+			OnTheRoadPrice:    transaction.OnTheRoadPrice,
+			AdminFee:          transaction.AdminFee,
+			InstallmentAmount: transaction.InstallmentAmount,
+			InterestAmount:    transaction.InterestAmount,
+			TenorMonth:        transaction.TenorMonth,
+		})
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Int64("partner_id", partnerID.Int64).Msg("service::CreateTransaction - Failed to queue partner webhooks")
+			return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		}
+	}
+
+	// Step 13: Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::CreateTransaction - Failed to commit transaction")
 		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
-	// Step 7: Commit transaction
+	booked = true
+
+	// the reservation holds the channel the rules know, so the labels stay bounded
+	channel, tenor := velocity.DefaultChannel, strconv.Itoa(req.TenorMonth)
+	if reservation != nil && reservation.Channel != "" {
+		channel = reservation.Channel
+	}
+	metrics.Bookings.WithLabelValues(channel, tenor).Inc()
+	metrics.BookedAmount.WithLabelValues(channel, tenor).Add(float64(req.OnTheRoadPrice))
+
+	// the booking stands either way, a stale summary expires with its TTL
+	if err := s.summaryCache.InvalidateCustomerSummary(ctx, req.CustomerID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Failed to invalidate customer summary")
+	}
+
+	log.Ctx(ctx).Info().Str("contract_number", contractNumber).Msg("service::CreateTransaction - Transaction created successfully")
+	return nil
+}
+
+// paymentHistory counts the installments the customer paid late or still owes
+// past their due date across active and paid off contracts, and sums the on
+// the road price of the active contracts drawn on the tenor's limit, as the
+// customer summary does.
+func (s *transactionService) paymentHistory(ctx context.Context, tx *sql.Tx, customerID, tenorMonth int, now time.Time) (int, float64, error) {
+	contracts, err := s.transactionRepository.FindContractsByCustomerID(ctx, tx, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("service::CreateTransaction - Failed to find contracts")
+		return 0, 0, err
+	}
+
+	payments, err := s.transactionRepository.FindPaymentsByCustomerID(ctx, tx, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("service::CreateTransaction - Failed to find payments")
+		return 0, 0, err
+	}
+
+	paid := make(map[int][]document.Payment, len(contracts))
+	for _, payment := range payments {
+		paid[payment.TransactionID] = append(paid[payment.TransactionID], document.Payment{Amount: payment.Amount, PaidAt: payment.PaidAt})
+	}
+
+	var (
+		latePayments int
+		usedAmount   float64
+	)
+
+	for _, contract := range contracts {
+		schedule := document.BuildSchedule(contract.CreatedAt, contract.OnTheRoadPrice, contract.InterestAmount, contract.InstallmentAmount, contract.TenorMonth)
+		latePayments += document.LateInstallments(schedule, contract.AdminFee, paid[contract.ID], now)
+
+		if contract.Status == constants.TransactionStatusActive && contract.TenorMonth == tenorMonth {
+			usedAmount += contract.OnTheRoadPrice
+		}
+	}
+
+	return latePayments, usedAmount, nil
+}
+
+// rejectVelocityBreach records the breach as a fraud event and refuses the booking.
+// Failing to record the event does not let the booking through.
+func (s *transactionService) rejectVelocityBreach(ctx context.Context, req *dto.CreateTransactionRequest, breach *velocity.Breach) error {
+	log.Ctx(ctx).Warn().
+		Int("customer_id", req.CustomerID).
+		Str("channel", breach.Channel).
+		Str("window", breach.Rule.Window.String()).
+		Str("limit", breach.Limit).
+		Msg("service::CreateTransaction - Velocity limit exceeded")
+
+	err := s.fraud.RecordEvent(ctx, int64(req.CustomerID), constants.FraudEventVelocityBreach, breach.Channel, map[string]any{
+		"window":            breach.Rule.Window.String(),
+		"limit":             breach.Limit,
+		"max_count":         breach.Rule.MaxCount,
+		"max_amount":        breach.Rule.MaxAmount,
+		"current":           breach.Current,
+		"on_the_road_price": req.OnTheRoadPrice,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", req.CustomerID).Msg("service::CreateTransaction - Failed to record velocity breach")
+	}
+
+	return err_msg.NewCustomErrors(fiber.StatusTooManyRequests,
+		err_msg.WithMessage(constants.ErrVelocityLimitExceeded),
+		err_msg.WithErrors("velocity", breach.Limit+"_"+breach.Rule.Window.String()),
+	)
+}
+
+// CancelTransaction cancels an active contract. It no longer counts against
+// the customer's limits once it is cancelled.
+func (s *transactionService) CancelTransaction(ctx context.Context, id int, staffID string) error {
+	ctx, span := tracing.Start(ctx, "transactionService.CancelTransaction")
+	defer span.End()
+
+	return s.closeTransaction(ctx, id, staffID, constants.TransactionStatusCancelled, constants.WebhookEventContractCancelled)
+}
+
+// PayOffTransaction marks an active contract as paid off, including one
+// settled early.
+func (s *transactionService) PayOffTransaction(ctx context.Context, id int, staffID string) error {
+	ctx, span := tracing.Start(ctx, "transactionService.PayOffTransaction")
+	defer span.End()
+
+	return s.closeTransaction(ctx, id, staffID, constants.TransactionStatusPaidOff, constants.WebhookEventContractPaidOff)
+}
+
+// closeTransaction moves an active contract to its final status and queues the
+// partner webhooks in the same database transaction, as a booking does, so a
+// partner only hears of a change that was committed.
+func (s *transactionService) closeTransaction(ctx context.Context, id int, staffID, status, eventType string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Msg("service::closeTransaction - Failed to begin transaction")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Ctx(ctx).Error().Err(rollbackErr).Int("id", id).Msg("service::closeTransaction - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	transaction, err := s.transactionRepository.LockTransactionByID(ctx, tx, id)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Msg("service::closeTransaction - Failed to lock transaction")
+		if err_msg.HasCode(err, fiber.StatusNotFound) {
+			return err
+		}
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	if transaction.Status != constants.TransactionStatusActive {
+		log.Ctx(ctx).Warn().Int("id", id).Str("status", transaction.Status).Str("to", status).Msg("service::closeTransaction - Transaction is not active")
+		err = err_msg.NewCustomErrors(fiber.StatusConflict, err_msg.WithMessage(constants.ErrTransactionNotActive))
+		return err
+	}
+
+	err = s.transactionRepository.UpdateTransactionStatus(ctx, tx, id, status)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Str("status", status).Msg("service::closeTransaction - Failed to update transaction status")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	if transaction.PartnerID.Valid {
+		err = s.webhooks.EnqueueEvent(ctx, tx, transaction.PartnerID.Int64, eventType, &webhookDto.ContractData{
+			ContractNumber:    transaction.ContractNumber,
+			PartnerCode:       transaction.PartnerCode.String,
+			Status:            status,
+			AssetName:         transaction.AssetName,
+			OnTheRoadPrice:    transaction.OnTheRoadPrice,
+			AdminFee:          transaction.AdminFee,
+			InstallmentAmount: transaction.InstallmentAmount,
+			InterestAmount:    transaction.InterestAmount,
+			TenorMonth:        transaction.TenorMonth,
+		})
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Int64("partner_id", transaction.PartnerID.Int64).Msg("service::closeTransaction - Failed to queue partner webhooks")
+			return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
-		log.Error().Err(err).Msg("service::CreateTransaction - Failed to commit transaction")
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Msg("service::closeTransaction - Failed to commit transaction")
 		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
-	log.Info().Str("contract_number", contractNumber).Msg("service::CreateTransaction - Transaction created successfully")
+	// the change stands either way, a stale summary expires with its TTL
+	if err := s.summaryCache.InvalidateCustomerSummary(ctx, transaction.CustomerID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int("customer_id", transaction.CustomerID).Msg("service::closeTransaction - Failed to invalidate customer summary")
+	}
+
+	log.Ctx(ctx).Info().Str("contract_number", transaction.ContractNumber).Str("status", status).Str("staff_id", staffID).Msg("service::closeTransaction - Transaction closed")
 	return nil
 }
 
 func (s *transactionService) GetDetailTransaction(ctx context.Context, id, customerID int) (*dto.GetDetailTransactionResponse, error) {
+	ctx, span := tracing.Start(ctx, "transactionService.GetDetailTransaction")
+	defer span.End()
+
 	transaction, err := s.transactionRepository.FindTransactionByIdAndCustomerID(ctx, id, customerID)
 	if err != nil {
-		if strings.Contains(err.Error(), constants.ErrTransactionNotFound) {
-			log.Error().Err(err).Int("id", id).Int("customer_id", customerID).Msg("service::GetDetailTransaction - Transaction not found")
+		if err_msg.HasCode(err, fiber.StatusNotFound) {
+			log.Ctx(ctx).Error().Err(err).Int("id", id).Int("customer_id", customerID).Msg("service::GetDetailTransaction - Transaction not found")
 			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrTransactionNotFound))
 		}
 
-		log.Error().Err(err).Int("id", id).Int("customer_id", customerID).Msg("service::GetDetailTransaction - Failed to find transaction by ID and customer ID")
+		log.Ctx(ctx).Error().Err(err).Int("id", id).Int("customer_id", customerID).Msg("service::GetDetailTransaction - Failed to find transaction by ID and customer ID")
 		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
@@ -127,11 +591,63 @@ func (s *transactionService) GetDetailTransaction(ctx context.Context, id, custo
 }
 
 func (s *transactionService) GetHistoryListTransction(ctx context.Context, req *dto.GetHistoryListTransactionRequest, customerID int) (*dto.GetHistoryListTransactionResponse, error) {
+	ctx, span := tracing.Start(ctx, "transactionService.GetHistoryListTransction")
+	defer span.End()
+
+	if err := validateHistoryFilter(&req.HistoryFilter); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Any("payload", req).Msg("service::GetHistoryListTransction - Invalid history filter")
+		return nil, err
+	}
+
+	if req.IsCursor() {
+		return s.getHistoryListTransactionCursor(ctx, req, customerID)
+	}
+
 	res, err := s.transactionRepository.FindTransactionByCustomerID(ctx, req, customerID)
 	if err != nil {
-		log.Error().Err(err).Int("customer_id", customerID).Msg("service::GetHistoryListTransction - Failed to find transaction by customer ID")
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("service::GetHistoryListTransction - Failed to find transaction by customer ID")
 		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
 	}
 
 	return res, nil
 }
+
+// getHistoryListTransactionCursor pages on (created_at, id), so other sort
+// fields are refused and a cursor is only valid for the direction it was issued in.
+func (s *transactionService) getHistoryListTransactionCursor(ctx context.Context, req *dto.GetHistoryListTransactionRequest, customerID int) (*dto.GetHistoryListTransactionResponse, error) {
+	if req.SortBy != "created_at" {
+		log.Ctx(ctx).Warn().Any("payload", req).Msg("service::getHistoryListTransactionCursor - Unsupported sort field for cursor pagination")
+		return nil, err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrCursorSortUnsupported), err_msg.WithErrors("sort_by", constants.ErrCursorSortUnsupported))
+	}
+
+	if req.Cursor != "" {
+		key, err := cursor.Decode(req.Cursor)
+		if err != nil || key.Desc != (req.SortDir == "desc") {
+			log.Ctx(ctx).Warn().Err(err).Any("payload", req).Msg("service::getHistoryListTransactionCursor - Invalid cursor")
+			return nil, err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrInvalidCursor), err_msg.WithErrors("cursor", constants.ErrInvalidCursor))
+		}
+
+		req.Key = key
+	}
+
+	res, err := s.transactionRepository.FindTransactionByCustomerIDCursor(ctx, req, customerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("customer_id", customerID).Msg("service::getHistoryListTransactionCursor - Failed to find transaction by customer ID")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	return res, nil
+}
+
+// validateHistoryFilter checks the ranges the validator cannot compare.
+func validateHistoryFilter(filter *dto.HistoryFilter) error {
+	if filter.StartDate != "" && filter.EndDate != "" && filter.StartDate > filter.EndDate {
+		return err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrInvalidDateRange), err_msg.WithErrors("start_date", constants.ErrInvalidDateRange))
+	}
+
+	if filter.MaxAmount > 0 && filter.MinAmount > filter.MaxAmount {
+		return err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrInvalidAmountRange), err_msg.WithErrors("min_amount", constants.ErrInvalidAmountRange))
+	}
+
+	return nil
+}
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=ports.go -destination=../service/service_mock_test.go -package=service
+//	mockgen -source=ports.go -destination=../../transaction/service/service_credit_limit_mock_test.go -package=service
 //
 
 // Package service is a generated GoMock package.
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ports.go -destination=../../transaction/service/service_outbox_mock_test.go -package=service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	dto "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/dto"
+	entity "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// InsertNewOutboxEvent mocks base method.
+func (m *MockOutboxRepository) InsertNewOutboxEvent(ctx context.Context, tx *sql.Tx, data *entity.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewOutboxEvent", ctx, tx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNewOutboxEvent indicates an expected call of InsertNewOutboxEvent.
+func (mr *MockOutboxRepositoryMockRecorder) InsertNewOutboxEvent(ctx, tx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewOutboxEvent", reflect.TypeOf((*MockOutboxRepository)(nil).InsertNewOutboxEvent), ctx, tx, data)
+}
+
+// LockPendingOutboxEvents mocks base method.
+func (m *MockOutboxRepository) LockPendingOutboxEvents(ctx context.Context, tx *sql.Tx, limit int) ([]entity.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPendingOutboxEvents", ctx, tx, limit)
+	ret0, _ := ret[0].([]entity.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockPendingOutboxEvents indicates an expected call of LockPendingOutboxEvents.
+func (mr *MockOutboxRepositoryMockRecorder) LockPendingOutboxEvents(ctx, tx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPendingOutboxEvents", reflect.TypeOf((*MockOutboxRepository)(nil).LockPendingOutboxEvents), ctx, tx, limit)
+}
+
+// MarkOutboxEventsPublished mocks base method.
+func (m *MockOutboxRepository) MarkOutboxEventsPublished(ctx context.Context, tx *sql.Tx, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxEventsPublished", ctx, tx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOutboxEventsPublished indicates an expected call of MarkOutboxEventsPublished.
+func (mr *MockOutboxRepositoryMockRecorder) MarkOutboxEventsPublished(ctx, tx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxEventsPublished", reflect.TypeOf((*MockOutboxRepository)(nil).MarkOutboxEventsPublished), ctx, tx, ids)
+}
+
+// RecordOutboxEventFailure mocks base method.
+func (m *MockOutboxRepository) RecordOutboxEventFailure(ctx context.Context, tx *sql.Tx, id int64, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordOutboxEventFailure", ctx, tx, id, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordOutboxEventFailure indicates an expected call of RecordOutboxEventFailure.
+func (mr *MockOutboxRepositoryMockRecorder) RecordOutboxEventFailure(ctx, tx, id, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordOutboxEventFailure", reflect.TypeOf((*MockOutboxRepository)(nil).RecordOutboxEventFailure), ctx, tx, id, message)
+}
+
+// MockOutboxRelay is a mock of OutboxRelay interface.
+type MockOutboxRelay struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRelayMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRelayMockRecorder is the mock recorder for MockOutboxRelay.
+type MockOutboxRelayMockRecorder struct {
+	mock *MockOutboxRelay
+}
+
+// NewMockOutboxRelay creates a new mock instance.
+func NewMockOutboxRelay(ctrl *gomock.Controller) *MockOutboxRelay {
+	mock := &MockOutboxRelay{ctrl: ctrl}
+	mock.recorder = &MockOutboxRelayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRelay) EXPECT() *MockOutboxRelayMockRecorder {
+	return m.recorder
+}
+
+// RelayPendingEvents mocks base method.
+func (m *MockOutboxRelay) RelayPendingEvents(ctx context.Context) (*dto.RelayResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayPendingEvents", ctx)
+	ret0, _ := ret[0].(*dto.RelayResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayPendingEvents indicates an expected call of RelayPendingEvents.
+func (mr *MockOutboxRelayMockRecorder) RelayPendingEvents(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayPendingEvents", reflect.TypeOf((*MockOutboxRelay)(nil).RelayPendingEvents), ctx)
+}
@@ -2,66 +2,774 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
 	"github.com/hilmiikhsan/multifinance-service/constants"
 	creditLimitEntity "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/entity"
+	creditScoreEntity "github.com/hilmiikhsan/multifinance-service/internal/module/credit_score/entity"
+	customerEntity "github.com/hilmiikhsan/multifinance-service/internal/module/customer/entity"
+	fraudDto "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/dto"
+	outboxEntity "github.com/hilmiikhsan/multifinance-service/internal/module/outbox/entity"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/dto"
 	"github.com/hilmiikhsan/multifinance-service/internal/module/transaction/entity"
+	webhookDto "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/cursor"
+	"github.com/hilmiikhsan/multifinance-service/pkg/eligibility"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/scoring"
+	"github.com/hilmiikhsan/multifinance-service/pkg/velocity"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	gomock "go.uber.org/mock/gomock"
 )
 
+type stubScorer struct {
+	result *scoring.Result
+}
+
+func (s stubScorer) Score(inputs scoring.Inputs) (*scoring.Result, error) {
+	res := *s.result
+	res.Inputs = inputs
+	return &res, nil
+}
+
+// cancelAwareLimiter fails a release on a cancelled context like a call that
+// has to reach Redis does.
+type cancelAwareLimiter struct {
+	velocity.Limiter
+}
+
+func (l cancelAwareLimiter) Release(ctx context.Context, reservation *velocity.Reservation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return l.Limiter.Release(ctx, reservation)
+}
+
+func transactionScore(customerID int64, grade string) gomock.Matcher {
+	return gomock.Cond(func(data *creditScoreEntity.CreditScore) bool {
+		return data.CustomerID == customerID &&
+			data.TriggerEvent == constants.CreditScoreTriggerTransaction &&
+			data.Grade == grade
+	})
+}
+
+func scoredOn(latePayments int, utilisation float64) gomock.Matcher {
+	return gomock.Cond(func(data *creditScoreEntity.CreditScore) bool {
+		var inputs scoring.Inputs
+		if err := json.Unmarshal([]byte(data.Inputs), &inputs); err != nil {
+			return false
+		}
+
+		return inputs.LatePayments == latePayments && inputs.Utilisation == utilisation
+	})
+}
+
+func bookingEvent(customerID int) gomock.Matcher {
+	return gomock.Cond(func(data *outboxEntity.OutboxEvent) bool {
+		return data.AggregateType == constants.AggregateTypeCustomer &&
+			data.AggregateID == strconv.Itoa(customerID) &&
+			data.EventType == constants.EventTransactionBooked &&
+			strings.Contains(data.Payload, `"contract_number":"TRX`)
+	})
+}
+
 func Test_transactionService_CreateTransaction(t *testing.T) {
 	ctrlMock := gomock.NewController(t)
 	defer ctrlMock.Finish()
 
-	mockTransactionRepo := NewMockTransactionRepository(ctrlMock)
-	mockCreditLimitRepo := NewMockCreditLimitRepository(ctrlMock)
+	mockTransactionRepo := NewMockTransactionRepository(ctrlMock)
+	mockCreditLimitRepo := NewMockCreditLimitRepository(ctrlMock)
+	mockCustomerRepo := NewMockCustomerRepository(ctrlMock)
+	mockCreditScoreRepo := NewMockCreditScoreRepository(ctrlMock)
+	mockFraudScreener := NewMockFraudScreener(ctrlMock)
+	mockSummaryCache := NewMockCustomerSummaryCache(ctrlMock)
+	mockOutboxRepo := NewMockOutboxRepository(ctrlMock)
+	mockWebhooks := NewMockWebhookEnqueuer(ctrlMock)
+
+	mr := miniredis.RunT(t)
+	velocityRules, err := velocity.ParseRules([]byte("default:\n  - { window: 1h, max_count: 1 }\n"))
+	assert.NoError(t, err)
+	limiter := velocity.NewRedisLimiter(redis.NewClient(&redis.Options{Addr: mr.Addr()}), velocityRules)
+
+	gradeB := &scoring.Result{
+		Version:     "v1",
+		Score:       700,
+		Grade:       scoring.Grade{Name: "B", MinScore: 680, LimitMultiplier: 1.0, Approve: true},
+		ReasonCodes: []string{"UTILISATION_MEDIUM"},
+	}
+
+	eligibleCustomer := &customerEntity.Customer{
+		ID:        1,
+		BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// cancelled halfway through a booking, like a request that runs past its deadline
+	deadlineCtx, cancelDeadline := context.WithCancel(context.Background())
+	defer cancelDeadline()
+
+	type args struct {
+		ctx context.Context
+		req *dto.CreateTransactionRequest
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		score    *scoring.Result
+		wantErr  bool
+		wantCode int
+		// bookings still counted against the velocity rules afterwards
+		wantReserved int
+		mockFn       func(args args, dbMock sqlmock.Sqlmock)
+	}{
+		{
+			name: "CreateTransaction Success",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr:      false,
+			wantReserved: 1,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
+					LimitAmount: 1000000,
+				}, nil)
+
+				mockTransactionRepo.EXPECT().SumActiveInstallmentByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID, gomock.Any()).Return(float64(1000000), nil)
+
+				mockTransactionRepo.EXPECT().FindContractsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+				mockTransactionRepo.EXPECT().FindPaymentsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+
+				mockCreditScoreRepo.EXPECT().InsertNewCreditScore(gomock.Any(), gomock.Any(), transactionScore(1, "B")).Return(nil)
+
+				mockTransactionRepo.EXPECT().InsertNewTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+				mockOutboxRepo.EXPECT().InsertNewOutboxEvent(gomock.Any(), gomock.Any(), bookingEvent(1)).Return(nil)
+
+				dbMock.ExpectCommit()
+
+				mockSummaryCache.EXPECT().InvalidateCustomerSummary(gomock.Any(), args.req.CustomerID).Return(nil)
+			},
+		},
+		{
+			name: "CreateTransaction Success - Scored On Payment History",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr:      false,
+			wantReserved: 1,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				issuedAt := time.Now().AddDate(0, -3, -1)
+
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
+					LimitAmount: 1000000,
+				}, nil)
+
+				mockTransactionRepo.EXPECT().SumActiveInstallmentByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID, gomock.Any()).Return(float64(100000), nil)
+
+				// three installments have fallen due on the active contract and only the first was paid, the paid off contract was settled on time
+				mockTransactionRepo.EXPECT().FindContractsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return([]entity.Transaction{
+					{ID: 7, OnTheRoadPrice: 300000, InstallmentAmount: 100000, TenorMonth: 12, Status: constants.TransactionStatusActive, CreatedAt: issuedAt},
+					{ID: 8, OnTheRoadPrice: 100000, InstallmentAmount: 100000, TenorMonth: 1, Status: constants.TransactionStatusPaidOff, CreatedAt: issuedAt},
+				}, nil)
+				mockTransactionRepo.EXPECT().FindPaymentsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return([]entity.TransactionPayment{
+					{TransactionID: 7, Amount: 100000, PaidAt: issuedAt.AddDate(0, 1, 0)},
+					{TransactionID: 8, Amount: 100000, PaidAt: issuedAt.AddDate(0, 1, 0)},
+				}, nil)
+
+				mockCreditScoreRepo.EXPECT().InsertNewCreditScore(gomock.Any(), gomock.Any(), scoredOn(2, 0.8)).Return(nil)
+
+				mockTransactionRepo.EXPECT().InsertNewTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+				mockOutboxRepo.EXPECT().InsertNewOutboxEvent(gomock.Any(), gomock.Any(), bookingEvent(1)).Return(nil)
+
+				dbMock.ExpectCommit()
+
+				mockSummaryCache.EXPECT().InvalidateCustomerSummary(gomock.Any(), args.req.CustomerID).Return(nil)
+			},
+		},
+		{
+			name: "CreateTransaction Failed - Find Payment History Error",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr:      true,
+			wantReserved: 0,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
+					LimitAmount: 1000000,
+				}, nil)
+
+				mockTransactionRepo.EXPECT().SumActiveInstallmentByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID, gomock.Any()).Return(float64(0), nil)
+
+				mockTransactionRepo.EXPECT().FindContractsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, errors.New("database error"))
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name: "CreateTransaction Success - Through Partner",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+					PartnerCode:       "DEALER01",
+				},
+			},
+			wantErr:      false,
+			wantReserved: 1,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockWebhooks.EXPECT().FindPartnerIDByCode(gomock.Any(), "DEALER01").Return(int64(7), nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
+					LimitAmount: 1000000,
+				}, nil)
+
+				mockTransactionRepo.EXPECT().SumActiveInstallmentByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID, gomock.Any()).Return(float64(1000000), nil)
+
+				mockTransactionRepo.EXPECT().FindContractsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+				mockTransactionRepo.EXPECT().FindPaymentsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+
+				mockCreditScoreRepo.EXPECT().InsertNewCreditScore(gomock.Any(), gomock.Any(), transactionScore(1, "B")).Return(nil)
+
+				mockTransactionRepo.EXPECT().InsertNewTransaction(gomock.Any(), gomock.Any(), gomock.Cond(func(data *entity.Transaction) bool {
+					return data.PartnerID.Valid && data.PartnerID.Int64 == 7
+				})).Return(nil)
+
+				mockOutboxRepo.EXPECT().InsertNewOutboxEvent(gomock.Any(), gomock.Any(), bookingEvent(1)).Return(nil)
+
+				mockWebhooks.EXPECT().EnqueueEvent(gomock.Any(), gomock.Any(), int64(7), constants.WebhookEventContractCreated, gomock.Cond(func(data *webhookDto.ContractData) bool {
+					return data.PartnerCode == "DEALER01" && strings.HasPrefix(data.ContractNumber, "TRX")
+				})).Return(nil)
+
+				dbMock.ExpectCommit()
+
+				mockSummaryCache.EXPECT().InvalidateCustomerSummary(gomock.Any(), args.req.CustomerID).Return(nil)
+			},
+		},
+		{
+			name: "CreateTransaction Failed - Unknown Partner",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+					PartnerCode:       "UNKNOWN",
+				},
+			},
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockWebhooks.EXPECT().FindPartnerIDByCode(gomock.Any(), "UNKNOWN").Return(int64(0), err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrPartnerNotFound)))
+			},
+		},
+		{
+			name: "CreateTransaction Failed - Limit Not Found",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockCreditLimitRepo.EXPECT().
+					FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), args.req.CustomerID, args.req.TenorMonth).
+					Return(nil, errors.New(constants.ErrInvalidOrCreditLimit))
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name: "CreateTransaction Failed - Insert Transaction Error",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
+					LimitAmount: 1000000,
+				}, nil)
+
+				mockTransactionRepo.EXPECT().SumActiveInstallmentByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID, gomock.Any()).Return(float64(1000000), nil)
+
+				mockTransactionRepo.EXPECT().FindContractsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+				mockTransactionRepo.EXPECT().FindPaymentsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+
+				mockCreditScoreRepo.EXPECT().InsertNewCreditScore(gomock.Any(), gomock.Any(), transactionScore(1, "B")).Return(nil)
+
+				mockTransactionRepo.EXPECT().InsertNewTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New(constants.ErrInternalServerError))
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name: "CreateTransaction Failed - Begin Transaction Error",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin().WillReturnError(errors.New(constants.ErrInternalServerError))
+			},
+		},
+		{
+			name: "CreateTransaction Failed - Request Deadline Passed",
+			args: args{
+				ctx: deadlineCtx,
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr:      true,
+			wantReserved: 0,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				// the reservation is still released with the request context cancelled
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).DoAndReturn(func(context.Context, any, int) (*customerEntity.Customer, error) {
+					cancelDeadline()
+					return nil, context.Canceled
+				})
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name: "CreateTransaction Failed - Commit Transaction Error",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
+					LimitAmount: 1000000,
+				}, nil)
+
+				mockTransactionRepo.EXPECT().SumActiveInstallmentByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID, gomock.Any()).Return(float64(1000000), nil)
+
+				mockTransactionRepo.EXPECT().FindContractsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+				mockTransactionRepo.EXPECT().FindPaymentsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+
+				mockCreditScoreRepo.EXPECT().InsertNewCreditScore(gomock.Any(), gomock.Any(), transactionScore(1, "B")).Return(nil)
+
+				mockTransactionRepo.EXPECT().InsertNewTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+				mockOutboxRepo.EXPECT().InsertNewOutboxEvent(gomock.Any(), gomock.Any(), bookingEvent(1)).Return(nil)
+
+				dbMock.ExpectCommit().WillReturnError(errors.New(constants.ErrInternalServerError))
+			},
+		},
+		{
+			name: "CreateTransaction Failed - Record Booking Event Error",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
+					LimitAmount: 1000000,
+				}, nil)
+
+				mockTransactionRepo.EXPECT().SumActiveInstallmentByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID, gomock.Any()).Return(float64(1000000), nil)
+
+				mockTransactionRepo.EXPECT().FindContractsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+				mockTransactionRepo.EXPECT().FindPaymentsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+
+				mockCreditScoreRepo.EXPECT().InsertNewCreditScore(gomock.Any(), gomock.Any(), transactionScore(1, "B")).Return(nil)
+
+				mockTransactionRepo.EXPECT().InsertNewTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+				mockOutboxRepo.EXPECT().InsertNewOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New(constants.ErrInternalServerError))
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name: "CreateTransaction Failed - Rollback Transaction Error",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockCreditLimitRepo.EXPECT().
+					FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), args.req.CustomerID, args.req.TenorMonth).
+					Return(nil, errors.New(constants.ErrInvalidOrCreditLimit))
+
+				dbMock.ExpectRollback().WillReturnError(errors.New(constants.ErrInternalServerError))
+			},
+		},
+		{
+			name: "CreateTransaction Failed - Find Limit By Customer And Tenor Error",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    50000000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
+					LimitAmount: 1000000,
+				}, nil)
+
+				if args.req.OnTheRoadPrice > 1000000 {
+					assert.Error(t, errors.New(constants.ErrOnTheRoadPriceExceedLimit), "expected an error but got none")
+				}
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name: "CreateTransaction Failed - On the road price exceeds credit limit",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				dbMock.ExpectBegin()
+
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockCreditLimitRepo.EXPECT().
+					FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), args.req.CustomerID, args.req.TenorMonth).
+					Return(&creditLimitEntity.Limits{
+						LimitAmount: 100000,
+					}, nil)
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name: "CreateTransaction Failed - Debt To Income Ratio Exceeded",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
 
-	type args struct {
-		ctx context.Context
-		req *dto.CreateTransactionRequest
-	}
+				dbMock.ExpectBegin()
 
-	tests := []struct {
-		name    string
-		args    args
-		wantErr bool
-		mockFn  func(args args, dbMock sqlmock.Sqlmock)
-	}{
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
+					LimitAmount: 1000000,
+				}, nil)
+
+				mockTransactionRepo.EXPECT().SumActiveInstallmentByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID, gomock.Any()).Return(float64(2990000), nil)
+
+				dbMock.ExpectRollback()
+			},
+		},
 		{
-			name: "CreateTransaction Success",
+			name: "CreateTransaction Failed - Credit Score Too Low",
 			args: args{
 				ctx: context.Background(),
 				req: &dto.CreateTransactionRequest{
 					CustomerID:        1,
-					OnTheRoadPrice:    500000,
+					OnTheRoadPrice:    950000,
 					InstallmentAmount: 50000,
 					InterestAmount:    5000,
 					AssetName:         "Yamaha NMAX",
 					TenorMonth:        12,
 				},
 			},
-			wantErr: false,
+			score: &scoring.Result{
+				Version:     "v1",
+				Score:       560,
+				Grade:       scoring.Grade{Name: "D", MinScore: 0, LimitMultiplier: 0.5, Approve: false},
+				ReasonCodes: []string{"UTILISATION_VERY_HIGH", "TENURE_SHORT"},
+			},
+			wantErr: true,
 			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
 				dbMock.ExpectBegin()
 
-				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(args.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
 					LimitAmount: 1000000,
 				}, nil)
 
-				mockTransactionRepo.EXPECT().InsertNewTransaction(args.ctx, gomock.Any(), gomock.Any()).Return(nil)
+				mockTransactionRepo.EXPECT().SumActiveInstallmentByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID, gomock.Any()).Return(float64(0), nil)
 
+				mockTransactionRepo.EXPECT().FindContractsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+				mockTransactionRepo.EXPECT().FindPaymentsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+
+				mockCreditScoreRepo.EXPECT().InsertNewCreditScore(gomock.Any(), gomock.Any(), transactionScore(1, "D")).Return(nil)
+
+				// the rejected score is committed so it can be explained later
 				dbMock.ExpectCommit()
 			},
 		},
 		{
-			name: "CreateTransaction Failed - Limit Not Found",
+			name: "CreateTransaction Failed - Flagged By Fraud Screening",
 			args: args{
 				ctx: context.Background(),
 				req: &dto.CreateTransactionRequest{
@@ -71,21 +779,36 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 					InterestAmount:    5000,
 					AssetName:         "Yamaha NMAX",
 					TenorMonth:        12,
+					DeviceID:          "device-1",
 				},
 			},
 			wantErr: true,
 			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				reasons := []fraudDto.Reason{{Code: constants.FraudReasonWatchlistPrefix + constants.WatchlistTypeDeviceID, Detail: "chargeback"}}
+
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), &fraudDto.ScreeningSubject{
+					CustomerID: 1,
+					DeviceID:   "device-1",
+				}).Return(&fraudDto.ScreeningResult{Reasons: reasons}, nil)
+
 				dbMock.ExpectBegin()
 
-				mockCreditLimitRepo.EXPECT().
-					FindLimitByCustomerAndTenor(args.ctx, gomock.Any(), args.req.CustomerID, args.req.TenorMonth).
-					Return(nil, errors.New(constants.ErrInvalidOrCreditLimit))
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
 
-				dbMock.ExpectRollback()
+				mockFraudScreener.EXPECT().OpenReview(gomock.Any(), gomock.Any(), int64(1), constants.FraudTriggerTransaction, reasons).Return(true, nil)
+
+				// the review case is kept
+				dbMock.ExpectCommit()
 			},
 		},
 		{
-			name: "CreateTransaction Failed - Insert Transaction Error",
+			name: "CreateTransaction Success - Fraud Signal Approved By Staff",
 			args: args{
 				ctx: context.Background(),
 				req: &dto.CreateTransactionRequest{
@@ -95,23 +818,51 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 					InterestAmount:    5000,
 					AssetName:         "Yamaha NMAX",
 					TenorMonth:        12,
+					DeviceID:          "device-1",
 				},
 			},
-			wantErr: true,
+			wantErr:      false,
+			wantReserved: 1,
 			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				reasons := []fraudDto.Reason{{Code: constants.FraudReasonWatchlistPrefix + constants.WatchlistTypeDeviceID, Detail: "chargeback"}}
+
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				// the device is still on the watchlist, staff approved the case it opened
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{Reasons: reasons}, nil)
+
 				dbMock.ExpectBegin()
 
-				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(args.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusClear,
+					Salary:       10000000,
+				}, nil)
+
+				mockFraudScreener.EXPECT().OpenReview(gomock.Any(), gomock.Any(), int64(1), constants.FraudTriggerTransaction, reasons).Return(false, nil)
+
+				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
 					LimitAmount: 1000000,
 				}, nil)
 
-				mockTransactionRepo.EXPECT().InsertNewTransaction(args.ctx, gomock.Any(), gomock.Any()).Return(errors.New(constants.ErrInternalServerError))
+				mockTransactionRepo.EXPECT().SumActiveInstallmentByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID, gomock.Any()).Return(float64(0), nil)
+
+				mockTransactionRepo.EXPECT().FindContractsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+				mockTransactionRepo.EXPECT().FindPaymentsByCustomerID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, nil)
+
+				mockCreditScoreRepo.EXPECT().InsertNewCreditScore(gomock.Any(), gomock.Any(), transactionScore(1, "B")).Return(nil)
+
+				mockTransactionRepo.EXPECT().InsertNewTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+				mockOutboxRepo.EXPECT().InsertNewOutboxEvent(gomock.Any(), gomock.Any(), bookingEvent(1)).Return(nil)
 
 				dbMock.ExpectCommit()
+
+				mockSummaryCache.EXPECT().InvalidateCustomerSummary(gomock.Any(), args.req.CustomerID).Return(nil)
 			},
 		},
 		{
-			name: "CreateTransaction Failed - Begin Transaction Error",
+			name: "CreateTransaction Failed - Flagged While Under Review",
 			args: args{
 				ctx: context.Background(),
 				req: &dto.CreateTransactionRequest{
@@ -121,15 +872,31 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 					InterestAmount:    5000,
 					AssetName:         "Yamaha NMAX",
 					TenorMonth:        12,
+					DeviceID:          "device-1",
 				},
 			},
 			wantErr: true,
 			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
-				dbMock.ExpectBegin().WillReturnError(errors.New(constants.ErrInternalServerError))
+				reasons := []fraudDto.Reason{{Code: constants.FraudReasonWatchlistPrefix + constants.WatchlistTypeDeviceID, Detail: "chargeback"}}
+
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{Reasons: reasons}, nil)
+
+				dbMock.ExpectBegin()
+
+				// the pending case already holds the customer, no second case is opened
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusPendingReview,
+					Salary:       10000000,
+				}, nil)
+
+				dbMock.ExpectRollback()
 			},
 		},
 		{
-			name: "CreateTransaction Failed - Commit Transaction Error",
+			name: "CreateTransaction Failed - Account Under Review",
 			args: args{
 				ctx: context.Background(),
 				req: &dto.CreateTransactionRequest{
@@ -143,19 +910,23 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 			},
 			wantErr: true,
 			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
 				dbMock.ExpectBegin()
 
-				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(args.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
-					LimitAmount: 1000000,
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusPendingReview,
+					Salary:       10000000,
 				}, nil)
 
-				mockTransactionRepo.EXPECT().InsertNewTransaction(args.ctx, gomock.Any(), gomock.Any()).Return(nil)
-
-				dbMock.ExpectCommit().WillReturnError(errors.New(constants.ErrInternalServerError))
+				dbMock.ExpectRollback()
 			},
 		},
 		{
-			name: "CreateTransaction Failed - Rollback Transaction Error",
+			name: "CreateTransaction Failed - Customer Not Found When Locking",
 			args: args{
 				ctx: context.Background(),
 				req: &dto.CreateTransactionRequest{
@@ -167,24 +938,27 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 					TenorMonth:        12,
 				},
 			},
-			wantErr: true,
+			wantErr:  true,
+			wantCode: fiber.StatusNotFound,
 			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
 				dbMock.ExpectBegin()
 
-				mockCreditLimitRepo.EXPECT().
-					FindLimitByCustomerAndTenor(args.ctx, gomock.Any(), args.req.CustomerID, args.req.TenorMonth).
-					Return(nil, errors.New(constants.ErrInvalidOrCreditLimit))
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound)))
 
-				dbMock.ExpectRollback().WillReturnError(errors.New(constants.ErrInternalServerError))
+				dbMock.ExpectRollback()
 			},
 		},
 		{
-			name: "CreateTransaction Failed - Find Limit By Customer And Tenor Error",
+			name: "CreateTransaction Failed - Account Rejected",
 			args: args{
 				ctx: context.Background(),
 				req: &dto.CreateTransactionRequest{
 					CustomerID:        1,
-					OnTheRoadPrice:    50000000,
+					OnTheRoadPrice:    500000,
 					InstallmentAmount: 50000,
 					InterestAmount:    5000,
 					AssetName:         "Yamaha NMAX",
@@ -193,21 +967,23 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 			},
 			wantErr: true,
 			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
+
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
 				dbMock.ExpectBegin()
 
-				mockCreditLimitRepo.EXPECT().FindLimitByCustomerAndTenor(args.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&creditLimitEntity.Limits{
-					LimitAmount: 1000000,
+				mockCustomerRepo.EXPECT().LockCustomerByID(gomock.Any(), gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:           1,
+					ReviewStatus: constants.CustomerReviewStatusRejected,
+					Salary:       10000000,
 				}, nil)
 
-				if args.req.OnTheRoadPrice > 1000000 {
-					assert.Error(t, errors.New(constants.ErrOnTheRoadPriceExceedLimit), "expected an error but got none")
-				}
-
 				dbMock.ExpectRollback()
 			},
 		},
 		{
-			name: "CreateTransaction Failed - On the road price exceeds credit limit",
+			name: "CreateTransaction Failed - Velocity Limit Exceeded",
 			args: args{
 				ctx: context.Background(),
 				req: &dto.CreateTransactionRequest{
@@ -217,19 +993,59 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 					InterestAmount:    5000,
 					AssetName:         "Yamaha NMAX",
 					TenorMonth:        12,
+					Channel:           "web",
 				},
 			},
-			wantErr: true,
+			wantErr:      true,
+			wantReserved: 1,
 			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
-				dbMock.ExpectBegin()
+				_, err := limiter.Reserve(args.ctx, args.req.CustomerID, args.req.Channel, 100000)
+				assert.NoError(t, err)
 
-				mockCreditLimitRepo.EXPECT().
-					FindLimitByCustomerAndTenor(args.ctx, gomock.Any(), args.req.CustomerID, args.req.TenorMonth).
-					Return(&creditLimitEntity.Limits{
-						LimitAmount: 100000,
-					}, nil)
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(eligibleCustomer, nil)
 
-				dbMock.ExpectRollback()
+				mockFraudScreener.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&fraudDto.ScreeningResult{}, nil)
+
+				mockFraudScreener.EXPECT().RecordEvent(gomock.Any(), int64(1), constants.FraudEventVelocityBreach, velocity.DefaultChannel, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "CreateTransaction Failed - Customer Not Eligible At Tenor End",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(&customerEntity.Customer{
+					ID:        1,
+					BirthDate: time.Now().AddDate(-60, -6, 0),
+				}, nil)
+			},
+		},
+		{
+			name: "CreateTransaction Failed - Customer Not Found",
+			args: args{
+				ctx: context.Background(),
+				req: &dto.CreateTransactionRequest{
+					CustomerID:        1,
+					OnTheRoadPrice:    500000,
+					InstallmentAmount: 50000,
+					InterestAmount:    5000,
+					AssetName:         "Yamaha NMAX",
+					TenorMonth:        12,
+				},
+			},
+			wantErr: true,
+			mockFn: func(args args, dbMock sqlmock.Sqlmock) {
+				mockCustomerRepo.EXPECT().FindCustomerByID(gomock.Any(), args.req.CustomerID).Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrUserNotFound)))
 			},
 		},
 	}
@@ -243,12 +1059,32 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 
 			mockDB := sqlx.NewDb(db, "mysql")
 
+			mr.FlushAll()
+
 			tt.mockFn(tt.args, dbMock)
 
+			score := tt.score
+			if score == nil {
+				score = gradeB
+			}
+
 			s := &transactionService{
 				db:                    mockDB,
 				transactionRepository: mockTransactionRepo,
 				creditLimitRepository: mockCreditLimitRepo,
+				customerRepository:    mockCustomerRepo,
+				maxDebtToIncomeRatio:  0.3,
+				eligibility: eligibility.NewEngine(
+					eligibility.MinAgeAtApplication(21),
+					eligibility.MaxAgeAtTenorEnd(60),
+				),
+				creditScoreRepository: mockCreditScoreRepo,
+				scorer:                stubScorer{result: score},
+				fraud:                 mockFraudScreener,
+				velocity:              cancelAwareLimiter{limiter},
+				summaryCache:          mockSummaryCache,
+				outboxRepository:      mockOutboxRepo,
+				webhooks:              mockWebhooks,
 			}
 			err = s.CreateTransaction(tt.args.ctx, tt.args.req)
 
@@ -257,10 +1093,169 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 			} else {
 				assert.NoError(t, err, "did not expect an error but got one")
 			}
+
+			if tt.wantCode != 0 {
+				assert.True(t, err_msg.HasCode(err, tt.wantCode), "expected status %d, got %v", tt.wantCode, err)
+			}
+
+			assert.NoError(t, dbMock.ExpectationsWereMet())
+
+			reserved, _ := mr.ZMembers("velocity:default:1")
+			assert.Len(t, reserved, tt.wantReserved)
+		})
+	}
+}
+
+func Test_transactionService_CancelTransaction(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockTransactionRepo := NewMockTransactionRepository(ctrlMock)
+	mockSummaryCache := NewMockCustomerSummaryCache(ctrlMock)
+	mockWebhooks := NewMockWebhookEnqueuer(ctrlMock)
+
+	contract := func(status string, partnerID int64) *entity.Transaction {
+		return &entity.Transaction{
+			ID:                5,
+			CustomerID:        1,
+			PartnerID:         sql.NullInt64{Int64: partnerID, Valid: partnerID != 0},
+			PartnerCode:       sql.NullString{String: "DEALER-1", Valid: partnerID != 0},
+			ContractNumber:    "TRX202412010001",
+			OnTheRoadPrice:    30000000,
+			AdminFee:          600000,
+			InstallmentAmount: 2800000,
+			InterestAmount:    3600000,
+			TenorMonth:        12,
+			AssetName:         "Yamaha NMAX",
+			Status:            status,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		mockFn   func(dbMock sqlmock.Sqlmock)
+		wantCode int
+	}{
+		{
+			name: "Cancelled And Partner Webhook Queued",
+			mockFn: func(dbMock sqlmock.Sqlmock) {
+				dbMock.ExpectBegin()
+				mockTransactionRepo.EXPECT().LockTransactionByID(gomock.Any(), gomock.Any(), 5).Return(contract(constants.TransactionStatusActive, 7), nil)
+				mockTransactionRepo.EXPECT().UpdateTransactionStatus(gomock.Any(), gomock.Any(), 5, constants.TransactionStatusCancelled).Return(nil)
+				mockWebhooks.EXPECT().EnqueueEvent(gomock.Any(), gomock.Any(), int64(7), constants.WebhookEventContractCancelled, gomock.Cond(func(data *webhookDto.ContractData) bool {
+					return data.ContractNumber == "TRX202412010001" && data.PartnerCode == "DEALER-1" && data.Status == constants.TransactionStatusCancelled
+				})).Return(nil)
+				dbMock.ExpectCommit()
+				mockSummaryCache.EXPECT().InvalidateCustomerSummary(gomock.Any(), 1).Return(nil)
+			},
+		},
+		{
+			name: "Cancelled Without Partner",
+			mockFn: func(dbMock sqlmock.Sqlmock) {
+				dbMock.ExpectBegin()
+				mockTransactionRepo.EXPECT().LockTransactionByID(gomock.Any(), gomock.Any(), 5).Return(contract(constants.TransactionStatusActive, 0), nil)
+				mockTransactionRepo.EXPECT().UpdateTransactionStatus(gomock.Any(), gomock.Any(), 5, constants.TransactionStatusCancelled).Return(nil)
+				dbMock.ExpectCommit()
+				mockSummaryCache.EXPECT().InvalidateCustomerSummary(gomock.Any(), 1).Return(nil)
+			},
+		},
+		{
+			name: "Transaction Not Found",
+			mockFn: func(dbMock sqlmock.Sqlmock) {
+				dbMock.ExpectBegin()
+				mockTransactionRepo.EXPECT().LockTransactionByID(gomock.Any(), gomock.Any(), 5).
+					Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrTransactionNotFound)))
+				dbMock.ExpectRollback()
+			},
+			wantCode: fiber.StatusNotFound,
+		},
+		{
+			name: "Paid Off Transaction Is Not Cancelled",
+			mockFn: func(dbMock sqlmock.Sqlmock) {
+				dbMock.ExpectBegin()
+				mockTransactionRepo.EXPECT().LockTransactionByID(gomock.Any(), gomock.Any(), 5).Return(contract(constants.TransactionStatusPaidOff, 7), nil)
+				dbMock.ExpectRollback()
+			},
+			wantCode: fiber.StatusConflict,
+		},
+		{
+			name: "Webhook Queue Error Rolls Back",
+			mockFn: func(dbMock sqlmock.Sqlmock) {
+				dbMock.ExpectBegin()
+				mockTransactionRepo.EXPECT().LockTransactionByID(gomock.Any(), gomock.Any(), 5).Return(contract(constants.TransactionStatusActive, 7), nil)
+				mockTransactionRepo.EXPECT().UpdateTransactionStatus(gomock.Any(), gomock.Any(), 5, constants.TransactionStatusCancelled).Return(nil)
+				mockWebhooks.EXPECT().EnqueueEvent(gomock.Any(), gomock.Any(), int64(7), constants.WebhookEventContractCancelled, gomock.Any()).Return(errors.New("database error"))
+				dbMock.ExpectRollback()
+			},
+			wantCode: fiber.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, dbMock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockFn(dbMock)
+
+			s := &transactionService{
+				db:                    sqlx.NewDb(db, "mysql"),
+				transactionRepository: mockTransactionRepo,
+				summaryCache:          mockSummaryCache,
+				webhooks:              mockWebhooks,
+			}
+
+			err = s.CancelTransaction(context.Background(), 5, "staff-1")
+			if tt.wantCode == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, err_msg.HasCode(err, tt.wantCode), "expected status %d, got %v", tt.wantCode, err)
+			}
+
+			assert.NoError(t, dbMock.ExpectationsWereMet())
 		})
 	}
 }
 
+func Test_transactionService_PayOffTransaction(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockTransactionRepo := NewMockTransactionRepository(ctrlMock)
+	mockSummaryCache := NewMockCustomerSummaryCache(ctrlMock)
+	mockWebhooks := NewMockWebhookEnqueuer(ctrlMock)
+
+	db, dbMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	dbMock.ExpectBegin()
+	mockTransactionRepo.EXPECT().LockTransactionByID(gomock.Any(), gomock.Any(), 5).Return(&entity.Transaction{
+		ID:             5,
+		CustomerID:     1,
+		PartnerID:      sql.NullInt64{Int64: 7, Valid: true},
+		PartnerCode:    sql.NullString{String: "DEALER-1", Valid: true},
+		ContractNumber: "TRX202412010001",
+		Status:         constants.TransactionStatusActive,
+	}, nil)
+	mockTransactionRepo.EXPECT().UpdateTransactionStatus(gomock.Any(), gomock.Any(), 5, constants.TransactionStatusPaidOff).Return(nil)
+	mockWebhooks.EXPECT().EnqueueEvent(gomock.Any(), gomock.Any(), int64(7), constants.WebhookEventContractPaidOff, gomock.Cond(func(data *webhookDto.ContractData) bool {
+		return data.Status == constants.TransactionStatusPaidOff
+	})).Return(nil)
+	dbMock.ExpectCommit()
+	mockSummaryCache.EXPECT().InvalidateCustomerSummary(gomock.Any(), 1).Return(nil)
+
+	s := &transactionService{
+		db:                    sqlx.NewDb(db, "mysql"),
+		transactionRepository: mockTransactionRepo,
+		summaryCache:          mockSummaryCache,
+		webhooks:              mockWebhooks,
+	}
+
+	assert.NoError(t, s.PayOffTransaction(context.Background(), 5, "staff-1"))
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
 func Test_transactionService_GetDetailTransaction(t *testing.T) {
 	ctrlMock := gomock.NewController(t)
 	defer ctrlMock.Finish()
@@ -318,7 +1313,7 @@ func Test_transactionService_GetDetailTransaction(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 			mockFn: func(args args) {
-				mockRepo.EXPECT().FindTransactionByIdAndCustomerID(gomock.Any(), args.id, args.customerID).Return(nil, errors.New(constants.ErrTransactionNotFound))
+				mockRepo.EXPECT().FindTransactionByIdAndCustomerID(gomock.Any(), args.id, args.customerID).Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrTransactionNotFound)))
 			},
 		},
 		{
@@ -355,3 +1350,91 @@ func Test_transactionService_GetDetailTransaction(t *testing.T) {
 		})
 	}
 }
+
+func Test_transactionService_GetHistoryListTransction(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockTransactionRepository(ctrlMock)
+
+	tests := []struct {
+		name    string
+		req     *dto.GetHistoryListTransactionRequest
+		wantErr bool
+		mockFn  func(req *dto.GetHistoryListTransactionRequest)
+	}{
+		{
+			name: "GetHistoryListTransction Success",
+			req:  &dto.GetHistoryListTransactionRequest{Page: 1, Paginate: 10, HistoryFilter: dto.HistoryFilter{StartDate: "2024-12-01", EndDate: "2024-12-01", MinAmount: 100000, MaxAmount: 100000}},
+			mockFn: func(req *dto.GetHistoryListTransactionRequest) {
+				mockRepo.EXPECT().FindTransactionByCustomerID(gomock.Any(), req, 1).Return(&dto.GetHistoryListTransactionResponse{}, nil)
+			},
+		},
+		{
+			name:    "GetHistoryListTransction Failed - Start Date After End Date",
+			req:     &dto.GetHistoryListTransactionRequest{Page: 1, Paginate: 10, HistoryFilter: dto.HistoryFilter{StartDate: "2024-12-02", EndDate: "2024-12-01"}},
+			wantErr: true,
+			mockFn:  func(req *dto.GetHistoryListTransactionRequest) {},
+		},
+		{
+			name:    "GetHistoryListTransction Failed - Minimum Amount Above Maximum",
+			req:     &dto.GetHistoryListTransactionRequest{Page: 1, Paginate: 10, HistoryFilter: dto.HistoryFilter{MinAmount: 200000, MaxAmount: 100000}},
+			wantErr: true,
+			mockFn:  func(req *dto.GetHistoryListTransactionRequest) {},
+		},
+		{
+			name: "GetHistoryListTransction Success - Cursor",
+			req: &dto.GetHistoryListTransactionRequest{
+				Page: 1, Paginate: 10, Pagination: "cursor", HistoryFilter: dto.HistoryFilter{SortBy: "created_at", SortDir: "desc"},
+				Cursor: cursor.Encode(cursor.Cursor{CreatedAt: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), ID: 7, Desc: true}),
+			},
+			mockFn: func(req *dto.GetHistoryListTransactionRequest) {
+				mockRepo.EXPECT().FindTransactionByCustomerIDCursor(gomock.Any(), gomock.Cond(func(x any) bool {
+					key := x.(*dto.GetHistoryListTransactionRequest).Key
+					return key != nil && key.ID == 7
+				}), 1).Return(&dto.GetHistoryListTransactionResponse{}, nil)
+			},
+		},
+		{
+			name:    "GetHistoryListTransction Failed - Invalid Cursor",
+			req:     &dto.GetHistoryListTransactionRequest{Page: 1, Paginate: 10, Pagination: "cursor", Cursor: "garbage", HistoryFilter: dto.HistoryFilter{SortBy: "created_at", SortDir: "desc"}},
+			wantErr: true,
+			mockFn:  func(req *dto.GetHistoryListTransactionRequest) {},
+		},
+		{
+			name: "GetHistoryListTransction Failed - Cursor Issued For Other Direction",
+			req: &dto.GetHistoryListTransactionRequest{
+				Page: 1, Paginate: 10, Pagination: "cursor", HistoryFilter: dto.HistoryFilter{SortBy: "created_at", SortDir: "asc"},
+				Cursor: cursor.Encode(cursor.Cursor{CreatedAt: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), ID: 7, Desc: true}),
+			},
+			wantErr: true,
+			mockFn:  func(req *dto.GetHistoryListTransactionRequest) {},
+		},
+		{
+			name:    "GetHistoryListTransction Failed - Cursor With Unsupported Sort",
+			req:     &dto.GetHistoryListTransactionRequest{Page: 1, Paginate: 10, Pagination: "cursor", HistoryFilter: dto.HistoryFilter{SortBy: "on_the_road_price", SortDir: "desc"}},
+			wantErr: true,
+			mockFn:  func(req *dto.GetHistoryListTransactionRequest) {},
+		},
+		{
+			name:    "GetHistoryListTransction Failed - Internal Server Error",
+			req:     &dto.GetHistoryListTransactionRequest{Page: 1, Paginate: 10},
+			wantErr: true,
+			mockFn: func(req *dto.GetHistoryListTransactionRequest) {
+				mockRepo.EXPECT().FindTransactionByCustomerID(gomock.Any(), req, 1).Return(nil, errors.New(constants.ErrInternalServerError))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn(tt.req)
+
+			s := &transactionService{
+				transactionRepository: mockRepo,
+			}
+
+			_, err := s.GetHistoryListTransction(context.Background(), tt.req, 1)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
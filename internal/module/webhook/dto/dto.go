@@ -0,0 +1,116 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/hilmiikhsan/multifinance-service/pkg/types"
+)
+
+type CreatePartnerRequest struct {
+	Code string `json:"code" validate:"required,max=50,alphanum"`
+	Name string `json:"name" validate:"required,max=100,valid_text"`
+}
+
+// CreatePartnerResponse is the only time the API key is shown.
+type CreatePartnerResponse struct {
+	ID     int64  `json:"id"`
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	APIKey string `json:"api_key" log:"redact"`
+}
+
+type CreateSubscriptionRequest struct {
+	URL        string   `json:"url" validate:"required,url,max=255"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,unique,dive,oneof=contract.created contract.cancelled contract.paid_off"`
+}
+
+// SubscriptionResponse carries the signing secret only when the subscription
+// is created.
+type SubscriptionResponse struct {
+	ID         int64    `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+	Secret     string   `json:"secret,omitempty" log:"redact"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+type GetDeliveriesRequest struct {
+	Page           int    `query:"page" validate:"required,min=1"`
+	Paginate       int    `query:"paginate" validate:"required,min=1,max=100"`
+	Status         string `query:"status" validate:"omitempty,oneof=pending delivered dead"`
+	SubscriptionID int64  `query:"subscription_id" validate:"omitempty,min=1"`
+}
+
+func (r *GetDeliveriesRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type DeliveryResponse struct {
+	ID             int64  `json:"id"`
+	SubscriptionID int64  `json:"subscription_id"`
+	EventID        string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`
+	LastStatusCode int    `json:"last_status_code,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+type DeliveryAttemptResponse struct {
+	Attempt      int    `json:"attempt"`
+	StatusCode   int    `json:"status_code,omitempty"`
+	ResponseBody string `json:"response_body,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+	DurationMs   int64  `json:"duration_ms"`
+	AttemptedAt  string `json:"attempted_at"`
+}
+
+type DeliveryDetailResponse struct {
+	DeliveryResponse
+	Payload     json.RawMessage           `json:"payload"`
+	AttemptsLog []DeliveryAttemptResponse `json:"attempts_log"`
+}
+
+type GetDeliveriesResponse struct {
+	Items []DeliveryResponse `json:"items"`
+	Meta  types.Meta         `json:"meta"`
+}
+
+// Event is the body of every delivery. ID stays the same across retries and
+// redeliveries so receivers can drop duplicates.
+type Event struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	CreatedAt string `json:"created_at"`
+	Data      any    `json:"data"`
+}
+
+type ContractData struct {
+	ContractNumber    string  `json:"contract_number"`
+	PartnerCode       string  `json:"partner_code"`
+	Status            string  `json:"status"`
+	AssetName         string  `json:"asset_name"`
+	OnTheRoadPrice    float64 `json:"on_the_road_price"`
+	AdminFee          float64 `json:"admin_fee"`
+	InstallmentAmount float64 `json:"installment_amount"`
+	InterestAmount    float64 `json:"interest_amount"`
+	TenorMonth        int     `json:"tenor_month"`
+}
+
+// DispatchResult counts what one dispatcher batch did with the deliveries it
+// claimed.
+type DispatchResult struct {
+	Delivered int `json:"delivered"`
+	Retrying  int `json:"retrying"`
+	Dead      int `json:"dead"`
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Partner is a dealer or platform that originates contracts. Only the SHA-256
+// of its API key is stored, the key itself is shown once when it is created.
+type Partner struct {
+	ID         int64     `db:"id"`
+	Code       string    `db:"code"`
+	Name       string    `db:"name"`
+	APIKeyHash string    `db:"api_key_hash" log:"redact"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// Subscription sends the events listed in EventTypes, a JSON array, to URL.
+// A removed subscription is kept inactive so its delivery log stays readable.
+type Subscription struct {
+	ID         int64     `db:"id"`
+	PartnerID  int64     `db:"partner_id"`
+	URL        string    `db:"url"`
+	Secret     string    `db:"secret" log:"redact"`
+	EventTypes string    `db:"event_types"`
+	Active     bool      `db:"active"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// Delivery is one event on its way to one subscription. It stays pending
+// while retries are left and ends delivered or dead.
+type Delivery struct {
+	ID             int64          `db:"id"`
+	SubscriptionID int64          `db:"subscription_id"`
+	EventID        string         `db:"event_id"`
+	EventType      string         `db:"event_type"`
+	Payload        string         `db:"payload"`
+	Status         string         `db:"status"`
+	Attempts       int            `db:"attempts"`
+	NextAttemptAt  time.Time      `db:"next_attempt_at"`
+	LastStatusCode sql.NullInt64  `db:"last_status_code"`
+	LastError      sql.NullString `db:"last_error"`
+	DeliveredAt    sql.NullTime   `db:"delivered_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+// DueDelivery is a delivery claimed by the dispatcher together with where and
+// how it is sent.
+type DueDelivery struct {
+	Delivery
+	URL                string `db:"url"`
+	Secret             string `db:"secret" log:"redact"`
+	SubscriptionActive bool   `db:"subscription_active"`
+}
+
+// DeliveryAttempt is one line of the delivery log.
+type DeliveryAttempt struct {
+	ID           int64          `db:"id"`
+	DeliveryID   int64          `db:"delivery_id"`
+	Attempt      int            `db:"attempt"`
+	StatusCode   sql.NullInt64  `db:"status_code"`
+	ResponseBody sql.NullString `db:"response_body"`
+	ErrorMessage sql.NullString `db:"error_message"`
+	DurationMs   int64          `db:"duration_ms"`
+	AttemptedAt  time.Time      `db:"attempted_at"`
+}
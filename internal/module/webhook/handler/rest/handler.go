@@ -0,0 +1,223 @@
+package rest
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/ports"
+	webhookRepository "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/repository"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/service"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/response"
+	"github.com/rs/zerolog/log"
+)
+
+type webhookHandler struct {
+	service           ports.WebhookService
+	middleware        middleware.StaffMiddleware
+	partnerMiddleware middleware.PartnerMiddleware
+	validator         adapter.Validator
+}
+
+func NewWebhookHandler() *webhookHandler {
+	var handler = new(webhookHandler)
+
+	// validator
+	validator := adapter.Adapters.Validator
+
+	// repository
+	webhookRepository := webhookRepository.NewWebhookRepository(adapter.Adapters.MultifinanceDB)
+
+	// service
+	webhookService := service.NewWebhookService(webhookRepository)
+
+	// middleware
+	middlewareHandler := middleware.NewStaffMiddleware(config.Envs.Fraud.StaffApiKey)
+	partnerMiddleware := middleware.NewPartnerMiddleware(webhookService)
+
+	// handler
+	handler.service = webhookService
+	handler.middleware = *middlewareHandler
+	handler.partnerMiddleware = *partnerMiddleware
+	handler.validator = validator
+
+	return handler
+}
+
+func (h *webhookHandler) WebhookRoute(router fiber.Router) {
+	router.Post("/", h.middleware.StaffKey, h.createPartner)
+	router.Post("/webhooks", h.partnerMiddleware.PartnerKey, h.createSubscription)
+	router.Get("/webhooks", h.partnerMiddleware.PartnerKey, h.getSubscriptions)
+	router.Delete("/webhooks/:id", h.partnerMiddleware.PartnerKey, h.deleteSubscription)
+	router.Get("/webhook-deliveries", h.partnerMiddleware.PartnerKey, h.getDeliveries)
+	router.Get("/webhook-deliveries/:id", h.partnerMiddleware.PartnerKey, h.getDelivery)
+	router.Post("/webhook-deliveries/:id/redeliver", h.partnerMiddleware.PartnerKey, h.redeliverDelivery)
+}
+
+func (h *webhookHandler) createPartner(c *fiber.Ctx) error {
+	var (
+		ctx = c.UserContext()
+		req = new(dto.CreatePartnerRequest)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::createPartner - Failed to parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::createPartner - Invalid request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	res, err := h.service.CreatePartner(ctx, req)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("handler::createPartner - Failed to create partner")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(res, ""))
+}
+
+func (h *webhookHandler) createSubscription(c *fiber.Ctx) error {
+	var (
+		ctx       = c.UserContext()
+		req       = new(dto.CreateSubscriptionRequest)
+		partnerID = middleware.GetPartnerID(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::createSubscription - Failed to parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::createSubscription - Invalid request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	res, err := h.service.CreateSubscription(ctx, req, partnerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("handler::createSubscription - Failed to create subscription")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(res, ""))
+}
+
+func (h *webhookHandler) getSubscriptions(c *fiber.Ctx) error {
+	var (
+		ctx       = c.UserContext()
+		partnerID = middleware.GetPartnerID(c)
+	)
+
+	res, err := h.service.GetSubscriptions(ctx, partnerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("partner_id", partnerID).Msg("handler::getSubscriptions - Failed to get subscriptions")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
+
+func (h *webhookHandler) deleteSubscription(c *fiber.Ctx) error {
+	var (
+		ctx       = c.UserContext()
+		partnerID = middleware.GetPartnerID(c)
+	)
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id < 1 {
+		log.Ctx(ctx).Warn().Str("id", c.Params("id")).Msg("handler::deleteSubscription - Invalid id")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(constants.ErrParamIdIsRequired))
+	}
+
+	if err := h.service.DeleteSubscription(ctx, id, partnerID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("handler::deleteSubscription - Failed to delete subscription")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+func (h *webhookHandler) getDeliveries(c *fiber.Ctx) error {
+	var (
+		ctx       = c.UserContext()
+		req       = new(dto.GetDeliveriesRequest)
+		partnerID = middleware.GetPartnerID(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getDeliveries - Failed to parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := h.validator.Validate(req); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("handler::getDeliveries - Invalid request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	res, err := h.service.GetDeliveries(ctx, req, partnerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("handler::getDeliveries - Failed to get deliveries")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
+
+func (h *webhookHandler) getDelivery(c *fiber.Ctx) error {
+	var (
+		ctx       = c.UserContext()
+		partnerID = middleware.GetPartnerID(c)
+	)
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id < 1 {
+		log.Ctx(ctx).Warn().Str("id", c.Params("id")).Msg("handler::getDelivery - Invalid id")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(constants.ErrParamIdIsRequired))
+	}
+
+	res, err := h.service.GetDelivery(ctx, id, partnerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("handler::getDelivery - Failed to get delivery")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(res, ""))
+}
+
+func (h *webhookHandler) redeliverDelivery(c *fiber.Ctx) error {
+	var (
+		ctx       = c.UserContext()
+		partnerID = middleware.GetPartnerID(c)
+	)
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id < 1 {
+		log.Ctx(ctx).Warn().Str("id", c.Params("id")).Msg("handler::redeliverDelivery - Invalid id")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(constants.ErrParamIdIsRequired))
+	}
+
+	res, err := h.service.RedeliverDelivery(ctx, id, partnerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("handler::redeliverDelivery - Failed to redeliver delivery")
+		code, errs := err_msg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(response.Success(res, ""))
+}
@@ -0,0 +1,501 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ports.go -destination=../handler/rest/handler_mock_test.go -package=rest
+//
+
+// Package rest is a generated GoMock package.
+package rest
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+
+	dto "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/dto"
+	entity "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueDeliveries mocks base method.
+func (m *MockWebhookRepository) ClaimDueDeliveries(ctx context.Context, tx *sql.Tx, now time.Time, limit int) ([]entity.DueDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueDeliveries", ctx, tx, now, limit)
+	ret0, _ := ret[0].([]entity.DueDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueDeliveries indicates an expected call of ClaimDueDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ClaimDueDeliveries(ctx, tx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ClaimDueDeliveries), ctx, tx, now, limit)
+}
+
+// DeactivateSubscription mocks base method.
+func (m *MockWebhookRepository) DeactivateSubscription(ctx context.Context, id, partnerID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateSubscription", ctx, id, partnerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateSubscription indicates an expected call of DeactivateSubscription.
+func (mr *MockWebhookRepositoryMockRecorder) DeactivateSubscription(ctx, id, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateSubscription", reflect.TypeOf((*MockWebhookRepository)(nil).DeactivateSubscription), ctx, id, partnerID)
+}
+
+// FindActiveSubscriptionsByPartnerID mocks base method.
+func (m *MockWebhookRepository) FindActiveSubscriptionsByPartnerID(ctx context.Context, partnerID int64) ([]entity.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveSubscriptionsByPartnerID", ctx, partnerID)
+	ret0, _ := ret[0].([]entity.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveSubscriptionsByPartnerID indicates an expected call of FindActiveSubscriptionsByPartnerID.
+func (mr *MockWebhookRepositoryMockRecorder) FindActiveSubscriptionsByPartnerID(ctx, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveSubscriptionsByPartnerID", reflect.TypeOf((*MockWebhookRepository)(nil).FindActiveSubscriptionsByPartnerID), ctx, partnerID)
+}
+
+// FindDeliveriesByPartnerID mocks base method.
+func (m *MockWebhookRepository) FindDeliveriesByPartnerID(ctx context.Context, req *dto.GetDeliveriesRequest, partnerID int64) ([]entity.Delivery, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeliveriesByPartnerID", ctx, req, partnerID)
+	ret0, _ := ret[0].([]entity.Delivery)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindDeliveriesByPartnerID indicates an expected call of FindDeliveriesByPartnerID.
+func (mr *MockWebhookRepositoryMockRecorder) FindDeliveriesByPartnerID(ctx, req, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveriesByPartnerID", reflect.TypeOf((*MockWebhookRepository)(nil).FindDeliveriesByPartnerID), ctx, req, partnerID)
+}
+
+// FindDeliveryAttemptsByDeliveryID mocks base method.
+func (m *MockWebhookRepository) FindDeliveryAttemptsByDeliveryID(ctx context.Context, deliveryID int64) ([]entity.DeliveryAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeliveryAttemptsByDeliveryID", ctx, deliveryID)
+	ret0, _ := ret[0].([]entity.DeliveryAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeliveryAttemptsByDeliveryID indicates an expected call of FindDeliveryAttemptsByDeliveryID.
+func (mr *MockWebhookRepositoryMockRecorder) FindDeliveryAttemptsByDeliveryID(ctx, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveryAttemptsByDeliveryID", reflect.TypeOf((*MockWebhookRepository)(nil).FindDeliveryAttemptsByDeliveryID), ctx, deliveryID)
+}
+
+// FindDeliveryByIDAndPartnerID mocks base method.
+func (m *MockWebhookRepository) FindDeliveryByIDAndPartnerID(ctx context.Context, id, partnerID int64) (*entity.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeliveryByIDAndPartnerID", ctx, id, partnerID)
+	ret0, _ := ret[0].(*entity.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeliveryByIDAndPartnerID indicates an expected call of FindDeliveryByIDAndPartnerID.
+func (mr *MockWebhookRepositoryMockRecorder) FindDeliveryByIDAndPartnerID(ctx, id, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveryByIDAndPartnerID", reflect.TypeOf((*MockWebhookRepository)(nil).FindDeliveryByIDAndPartnerID), ctx, id, partnerID)
+}
+
+// FindPartnerByAPIKeyHash mocks base method.
+func (m *MockWebhookRepository) FindPartnerByAPIKeyHash(ctx context.Context, apiKeyHash string) (*entity.Partner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPartnerByAPIKeyHash", ctx, apiKeyHash)
+	ret0, _ := ret[0].(*entity.Partner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPartnerByAPIKeyHash indicates an expected call of FindPartnerByAPIKeyHash.
+func (mr *MockWebhookRepositoryMockRecorder) FindPartnerByAPIKeyHash(ctx, apiKeyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPartnerByAPIKeyHash", reflect.TypeOf((*MockWebhookRepository)(nil).FindPartnerByAPIKeyHash), ctx, apiKeyHash)
+}
+
+// FindPartnerByCode mocks base method.
+func (m *MockWebhookRepository) FindPartnerByCode(ctx context.Context, code string) (*entity.Partner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPartnerByCode", ctx, code)
+	ret0, _ := ret[0].(*entity.Partner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPartnerByCode indicates an expected call of FindPartnerByCode.
+func (mr *MockWebhookRepositoryMockRecorder) FindPartnerByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPartnerByCode", reflect.TypeOf((*MockWebhookRepository)(nil).FindPartnerByCode), ctx, code)
+}
+
+// InsertNewDeliveries mocks base method.
+func (m *MockWebhookRepository) InsertNewDeliveries(ctx context.Context, tx *sql.Tx, data []entity.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewDeliveries", ctx, tx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNewDeliveries indicates an expected call of InsertNewDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) InsertNewDeliveries(ctx, tx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).InsertNewDeliveries), ctx, tx, data)
+}
+
+// InsertNewDeliveryAttempt mocks base method.
+func (m *MockWebhookRepository) InsertNewDeliveryAttempt(ctx context.Context, data *entity.DeliveryAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewDeliveryAttempt", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNewDeliveryAttempt indicates an expected call of InsertNewDeliveryAttempt.
+func (mr *MockWebhookRepositoryMockRecorder) InsertNewDeliveryAttempt(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewDeliveryAttempt", reflect.TypeOf((*MockWebhookRepository)(nil).InsertNewDeliveryAttempt), ctx, data)
+}
+
+// InsertNewPartner mocks base method.
+func (m *MockWebhookRepository) InsertNewPartner(ctx context.Context, data *entity.Partner) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewPartner", ctx, data)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertNewPartner indicates an expected call of InsertNewPartner.
+func (mr *MockWebhookRepositoryMockRecorder) InsertNewPartner(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewPartner", reflect.TypeOf((*MockWebhookRepository)(nil).InsertNewPartner), ctx, data)
+}
+
+// InsertNewSubscription mocks base method.
+func (m *MockWebhookRepository) InsertNewSubscription(ctx context.Context, data *entity.Subscription) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewSubscription", ctx, data)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertNewSubscription indicates an expected call of InsertNewSubscription.
+func (mr *MockWebhookRepositoryMockRecorder) InsertNewSubscription(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewSubscription", reflect.TypeOf((*MockWebhookRepository)(nil).InsertNewSubscription), ctx, data)
+}
+
+// LeaseDeliveries mocks base method.
+func (m *MockWebhookRepository) LeaseDeliveries(ctx context.Context, tx *sql.Tx, ids []int64, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaseDeliveries", ctx, tx, ids, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LeaseDeliveries indicates an expected call of LeaseDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) LeaseDeliveries(ctx, tx, ids, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaseDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).LeaseDeliveries), ctx, tx, ids, until)
+}
+
+// ResetDelivery mocks base method.
+func (m *MockWebhookRepository) ResetDelivery(ctx context.Context, id int64, nextAttemptAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetDelivery", ctx, id, nextAttemptAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetDelivery indicates an expected call of ResetDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) ResetDelivery(ctx, id, nextAttemptAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).ResetDelivery), ctx, id, nextAttemptAt)
+}
+
+// UpdateDeliveryResult mocks base method.
+func (m *MockWebhookRepository) UpdateDeliveryResult(ctx context.Context, data *entity.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeliveryResult", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeliveryResult indicates an expected call of UpdateDeliveryResult.
+func (mr *MockWebhookRepositoryMockRecorder) UpdateDeliveryResult(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeliveryResult", reflect.TypeOf((*MockWebhookRepository)(nil).UpdateDeliveryResult), ctx, data)
+}
+
+// MockWebhookService is a mock of WebhookService interface.
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService.
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance.
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// AuthenticatePartner mocks base method.
+func (m *MockWebhookService) AuthenticatePartner(ctx context.Context, apiKey string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticatePartner", ctx, apiKey)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticatePartner indicates an expected call of AuthenticatePartner.
+func (mr *MockWebhookServiceMockRecorder) AuthenticatePartner(ctx, apiKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticatePartner", reflect.TypeOf((*MockWebhookService)(nil).AuthenticatePartner), ctx, apiKey)
+}
+
+// CreatePartner mocks base method.
+func (m *MockWebhookService) CreatePartner(ctx context.Context, req *dto.CreatePartnerRequest) (*dto.CreatePartnerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePartner", ctx, req)
+	ret0, _ := ret[0].(*dto.CreatePartnerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePartner indicates an expected call of CreatePartner.
+func (mr *MockWebhookServiceMockRecorder) CreatePartner(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePartner", reflect.TypeOf((*MockWebhookService)(nil).CreatePartner), ctx, req)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockWebhookService) CreateSubscription(ctx context.Context, req *dto.CreateSubscriptionRequest, partnerID int64) (*dto.SubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, req, partnerID)
+	ret0, _ := ret[0].(*dto.SubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockWebhookServiceMockRecorder) CreateSubscription(ctx, req, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockWebhookService)(nil).CreateSubscription), ctx, req, partnerID)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockWebhookService) DeleteSubscription(ctx context.Context, id, partnerID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, id, partnerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockWebhookServiceMockRecorder) DeleteSubscription(ctx, id, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockWebhookService)(nil).DeleteSubscription), ctx, id, partnerID)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhookService) GetDeliveries(ctx context.Context, req *dto.GetDeliveriesRequest, partnerID int64) (*dto.GetDeliveriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, req, partnerID)
+	ret0, _ := ret[0].(*dto.GetDeliveriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookServiceMockRecorder) GetDeliveries(ctx, req, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookService)(nil).GetDeliveries), ctx, req, partnerID)
+}
+
+// GetDelivery mocks base method.
+func (m *MockWebhookService) GetDelivery(ctx context.Context, id, partnerID int64) (*dto.DeliveryDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", ctx, id, partnerID)
+	ret0, _ := ret[0].(*dto.DeliveryDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockWebhookServiceMockRecorder) GetDelivery(ctx, id, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockWebhookService)(nil).GetDelivery), ctx, id, partnerID)
+}
+
+// GetSubscriptions mocks base method.
+func (m *MockWebhookService) GetSubscriptions(ctx context.Context, partnerID int64) ([]dto.SubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptions", ctx, partnerID)
+	ret0, _ := ret[0].([]dto.SubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptions indicates an expected call of GetSubscriptions.
+func (mr *MockWebhookServiceMockRecorder) GetSubscriptions(ctx, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockWebhookService)(nil).GetSubscriptions), ctx, partnerID)
+}
+
+// RedeliverDelivery mocks base method.
+func (m *MockWebhookService) RedeliverDelivery(ctx context.Context, id, partnerID int64) (*dto.DeliveryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeliverDelivery", ctx, id, partnerID)
+	ret0, _ := ret[0].(*dto.DeliveryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeliverDelivery indicates an expected call of RedeliverDelivery.
+func (mr *MockWebhookServiceMockRecorder) RedeliverDelivery(ctx, id, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeliverDelivery", reflect.TypeOf((*MockWebhookService)(nil).RedeliverDelivery), ctx, id, partnerID)
+}
+
+// MockWebhookEnqueuer is a mock of WebhookEnqueuer interface.
+type MockWebhookEnqueuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookEnqueuerMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookEnqueuerMockRecorder is the mock recorder for MockWebhookEnqueuer.
+type MockWebhookEnqueuerMockRecorder struct {
+	mock *MockWebhookEnqueuer
+}
+
+// NewMockWebhookEnqueuer creates a new mock instance.
+func NewMockWebhookEnqueuer(ctrl *gomock.Controller) *MockWebhookEnqueuer {
+	mock := &MockWebhookEnqueuer{ctrl: ctrl}
+	mock.recorder = &MockWebhookEnqueuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookEnqueuer) EXPECT() *MockWebhookEnqueuerMockRecorder {
+	return m.recorder
+}
+
+// EnqueueEvent mocks base method.
+func (m *MockWebhookEnqueuer) EnqueueEvent(ctx context.Context, tx *sql.Tx, partnerID int64, eventType string, data any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueEvent", ctx, tx, partnerID, eventType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueEvent indicates an expected call of EnqueueEvent.
+func (mr *MockWebhookEnqueuerMockRecorder) EnqueueEvent(ctx, tx, partnerID, eventType, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueEvent", reflect.TypeOf((*MockWebhookEnqueuer)(nil).EnqueueEvent), ctx, tx, partnerID, eventType, data)
+}
+
+// FindPartnerIDByCode mocks base method.
+func (m *MockWebhookEnqueuer) FindPartnerIDByCode(ctx context.Context, code string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPartnerIDByCode", ctx, code)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPartnerIDByCode indicates an expected call of FindPartnerIDByCode.
+func (mr *MockWebhookEnqueuerMockRecorder) FindPartnerIDByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPartnerIDByCode", reflect.TypeOf((*MockWebhookEnqueuer)(nil).FindPartnerIDByCode), ctx, code)
+}
+
+// MockWebhookDispatcher is a mock of WebhookDispatcher interface.
+type MockWebhookDispatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDispatcherMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookDispatcherMockRecorder is the mock recorder for MockWebhookDispatcher.
+type MockWebhookDispatcherMockRecorder struct {
+	mock *MockWebhookDispatcher
+}
+
+// NewMockWebhookDispatcher creates a new mock instance.
+func NewMockWebhookDispatcher(ctrl *gomock.Controller) *MockWebhookDispatcher {
+	mock := &MockWebhookDispatcher{ctrl: ctrl}
+	mock.recorder = &MockWebhookDispatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDispatcher) EXPECT() *MockWebhookDispatcherMockRecorder {
+	return m.recorder
+}
+
+// DispatchDueDeliveries mocks base method.
+func (m *MockWebhookDispatcher) DispatchDueDeliveries(ctx context.Context) (*dto.DispatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchDueDeliveries", ctx)
+	ret0, _ := ret[0].(*dto.DispatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DispatchDueDeliveries indicates an expected call of DispatchDueDeliveries.
+func (mr *MockWebhookDispatcherMockRecorder) DispatchDueDeliveries(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchDueDeliveries", reflect.TypeOf((*MockWebhookDispatcher)(nil).DispatchDueDeliveries), ctx)
+}
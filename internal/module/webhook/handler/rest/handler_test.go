@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/dto"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_webhookHandler_redeliverDelivery(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockWebhookService(ctrlMock)
+	mockValidator := NewMockValidator(ctrlMock)
+
+	invalidKey := err_msg.NewCustomErrors(fiber.StatusUnauthorized, err_msg.WithMessage(constants.ErrInvalidPartnerKey))
+
+	type args struct {
+		path       string
+		partnerKey string
+		statusCode int
+		mockFn     func()
+	}
+
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Success",
+			args: args{
+				path:       "/webhook-deliveries/1/redeliver",
+				partnerKey: "pk_valid",
+				statusCode: http.StatusAccepted,
+				mockFn: func() {
+					mockSvc.EXPECT().AuthenticatePartner(gomock.Any(), "pk_valid").Return(int64(7), nil)
+					mockSvc.EXPECT().RedeliverDelivery(gomock.Any(), int64(1), int64(7)).
+						Return(&dto.DeliveryResponse{ID: 1, Status: constants.WebhookDeliveryStatusPending}, nil)
+				},
+			},
+		},
+		{
+			name: "Failure - Invalid Partner Key",
+			args: args{
+				path:       "/webhook-deliveries/1/redeliver",
+				partnerKey: "pk_wrong",
+				statusCode: http.StatusUnauthorized,
+				mockFn: func() {
+					mockSvc.EXPECT().AuthenticatePartner(gomock.Any(), "pk_wrong").Return(int64(0), invalidKey)
+				},
+			},
+		},
+		{
+			name: "Failure - Invalid ID",
+			args: args{
+				path:       "/webhook-deliveries/abc/redeliver",
+				partnerKey: "pk_valid",
+				statusCode: http.StatusBadRequest,
+				mockFn: func() {
+					mockSvc.EXPECT().AuthenticatePartner(gomock.Any(), "pk_valid").Return(int64(7), nil)
+				},
+			},
+		},
+		{
+			name: "Failure - Still Pending",
+			args: args{
+				path:       "/webhook-deliveries/1/redeliver",
+				partnerKey: "pk_valid",
+				statusCode: http.StatusConflict,
+				mockFn: func() {
+					mockSvc.EXPECT().AuthenticatePartner(gomock.Any(), "pk_valid").Return(int64(7), nil)
+					mockSvc.EXPECT().RedeliverDelivery(gomock.Any(), int64(1), int64(7)).
+						Return(nil, err_msg.NewCustomErrors(fiber.StatusConflict, err_msg.WithMessage(constants.ErrWebhookDeliveryPending)))
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			handler := &webhookHandler{
+				service:           mockSvc,
+				middleware:        *middleware.NewStaffMiddleware("secret"),
+				partnerMiddleware: *middleware.NewPartnerMiddleware(mockSvc),
+				validator:         mockValidator,
+			}
+			handler.WebhookRoute(app)
+
+			tt.args.mockFn()
+
+			req := httptest.NewRequest(http.MethodPost, tt.args.path, nil)
+			req.Header.Set(constants.HeaderPartnerKey, tt.args.partnerKey)
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.args.statusCode, resp.StatusCode)
+		})
+	}
+}
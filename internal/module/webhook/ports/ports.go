@@ -0,0 +1,54 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/entity"
+)
+
+//go:generate mockgen -source=ports.go -destination=../service/service_mock_test.go -package=service
+//go:generate mockgen -source=ports.go -destination=../../transaction/service/service_webhook_mock_test.go -package=service
+type WebhookRepository interface {
+	InsertNewPartner(ctx context.Context, data *entity.Partner) (int64, error)
+	FindPartnerByAPIKeyHash(ctx context.Context, apiKeyHash string) (*entity.Partner, error)
+	FindPartnerByCode(ctx context.Context, code string) (*entity.Partner, error)
+	InsertNewSubscription(ctx context.Context, data *entity.Subscription) (int64, error)
+	FindActiveSubscriptionsByPartnerID(ctx context.Context, partnerID int64) ([]entity.Subscription, error)
+	DeactivateSubscription(ctx context.Context, id, partnerID int64) error
+	InsertNewDeliveries(ctx context.Context, tx *sql.Tx, data []entity.Delivery) error
+	ClaimDueDeliveries(ctx context.Context, tx *sql.Tx, now time.Time, limit int) ([]entity.DueDelivery, error)
+	LeaseDeliveries(ctx context.Context, tx *sql.Tx, ids []int64, until time.Time) error
+	UpdateDeliveryResult(ctx context.Context, data *entity.Delivery) error
+	InsertNewDeliveryAttempt(ctx context.Context, data *entity.DeliveryAttempt) error
+	FindDeliveriesByPartnerID(ctx context.Context, req *dto.GetDeliveriesRequest, partnerID int64) ([]entity.Delivery, int, error)
+	FindDeliveryByIDAndPartnerID(ctx context.Context, id, partnerID int64) (*entity.Delivery, error)
+	FindDeliveryAttemptsByDeliveryID(ctx context.Context, deliveryID int64) ([]entity.DeliveryAttempt, error)
+	ResetDelivery(ctx context.Context, id int64, nextAttemptAt time.Time) (bool, error)
+}
+
+//go:generate mockgen -source=ports.go -destination=../handler/rest/handler_mock_test.go -package=rest
+type WebhookService interface {
+	CreatePartner(ctx context.Context, req *dto.CreatePartnerRequest) (*dto.CreatePartnerResponse, error)
+	AuthenticatePartner(ctx context.Context, apiKey string) (int64, error)
+	CreateSubscription(ctx context.Context, req *dto.CreateSubscriptionRequest, partnerID int64) (*dto.SubscriptionResponse, error)
+	GetSubscriptions(ctx context.Context, partnerID int64) ([]dto.SubscriptionResponse, error)
+	DeleteSubscription(ctx context.Context, id, partnerID int64) error
+	GetDeliveries(ctx context.Context, req *dto.GetDeliveriesRequest, partnerID int64) (*dto.GetDeliveriesResponse, error)
+	GetDelivery(ctx context.Context, id, partnerID int64) (*dto.DeliveryDetailResponse, error)
+	RedeliverDelivery(ctx context.Context, id, partnerID int64) (*dto.DeliveryResponse, error)
+}
+
+// WebhookEnqueuer lets the modules that own a contract queue its events in
+// their own database transaction, so a delivery exists only if the change it
+// reports was committed.
+type WebhookEnqueuer interface {
+	FindPartnerIDByCode(ctx context.Context, code string) (int64, error)
+	EnqueueEvent(ctx context.Context, tx *sql.Tx, partnerID int64, eventType string, data any) error
+}
+
+type WebhookDispatcher interface {
+	DispatchDueDeliveries(ctx context.Context) (*dto.DispatchResult, error)
+}
@@ -0,0 +1,212 @@
+package repository
+
+const (
+	queryInsertNewPartner = `
+		INSERT INTO partners
+		(
+			code,
+			name,
+			api_key_hash
+		) VALUES (?, ?, ?)
+	`
+
+	queryFindPartnerByAPIKeyHash = `
+		SELECT
+			id,
+			code,
+			name,
+			api_key_hash,
+			created_at
+		FROM partners
+		WHERE api_key_hash = ?
+	`
+
+	queryFindPartnerByCode = `
+		SELECT
+			id,
+			code,
+			name,
+			api_key_hash,
+			created_at
+		FROM partners
+		WHERE code = ?
+	`
+
+	queryInsertNewSubscription = `
+		INSERT INTO webhook_subscriptions
+		(
+			partner_id,
+			url,
+			secret,
+			event_types
+		) VALUES (?, ?, ?, ?)
+	`
+
+	queryFindActiveSubscriptionsByPartnerID = `
+		SELECT
+			id,
+			partner_id,
+			url,
+			secret,
+			event_types,
+			active,
+			created_at,
+			updated_at
+		FROM webhook_subscriptions
+		WHERE partner_id = ? AND active = TRUE
+		ORDER BY id ASC
+	`
+
+	queryDeactivateSubscription = `
+		UPDATE webhook_subscriptions
+		SET active = FALSE
+		WHERE id = ? AND partner_id = ? AND active = TRUE
+	`
+
+	queryInsertNewDelivery = `
+		INSERT INTO webhook_deliveries
+		(
+			subscription_id,
+			event_id,
+			event_type,
+			payload,
+			status,
+			next_attempt_at
+		) VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	// SKIP LOCKED lets several dispatchers share the queue, deliveries have
+	// no order to keep between each other.
+	queryClaimDueDeliveries = `
+		SELECT
+			d.id,
+			d.subscription_id,
+			d.event_id,
+			d.event_type,
+			d.payload,
+			d.status,
+			d.attempts,
+			d.next_attempt_at,
+			d.last_status_code,
+			d.last_error,
+			d.delivered_at,
+			d.created_at,
+			d.updated_at,
+			s.url,
+			s.secret,
+			s.active AS subscription_active
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE d.status = ? AND d.next_attempt_at <= ?
+		ORDER BY d.next_attempt_at ASC, d.id ASC
+		LIMIT ?
+		FOR UPDATE OF d SKIP LOCKED
+	`
+
+	queryLeaseDeliveries = `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = ?
+		WHERE id IN (?)
+	`
+
+	queryUpdateDeliveryResult = `
+		UPDATE webhook_deliveries
+		SET
+			status = ?,
+			attempts = ?,
+			next_attempt_at = ?,
+			last_status_code = ?,
+			last_error = ?,
+			delivered_at = ?
+		WHERE id = ?
+	`
+
+	queryInsertNewDeliveryAttempt = `
+		INSERT INTO webhook_delivery_attempts
+		(
+			delivery_id,
+			attempt,
+			status_code,
+			response_body,
+			error_message,
+			duration_ms,
+			attempted_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	queryFindDeliveriesByPartnerID = `
+		SELECT
+			d.id,
+			d.subscription_id,
+			d.event_id,
+			d.event_type,
+			d.payload,
+			d.status,
+			d.attempts,
+			d.next_attempt_at,
+			d.last_status_code,
+			d.last_error,
+			d.delivered_at,
+			d.created_at,
+			d.updated_at
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE s.partner_id = :partner_id
+			AND (:status = '' OR d.status = :status)
+			AND (:subscription_id = 0 OR d.subscription_id = :subscription_id)
+		ORDER BY d.id DESC
+		LIMIT :limit OFFSET :offset
+	`
+
+	queryCountDeliveriesByPartnerID = `
+		SELECT COUNT(*) AS total_data
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE s.partner_id = :partner_id
+			AND (:status = '' OR d.status = :status)
+			AND (:subscription_id = 0 OR d.subscription_id = :subscription_id)
+	`
+
+	queryFindDeliveryByIDAndPartnerID = `
+		SELECT
+			d.id,
+			d.subscription_id,
+			d.event_id,
+			d.event_type,
+			d.payload,
+			d.status,
+			d.attempts,
+			d.next_attempt_at,
+			d.last_status_code,
+			d.last_error,
+			d.delivered_at,
+			d.created_at,
+			d.updated_at
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE d.id = ? AND s.partner_id = ?
+	`
+
+	queryFindDeliveryAttemptsByDeliveryID = `
+		SELECT
+			id,
+			delivery_id,
+			attempt,
+			status_code,
+			response_body,
+			error_message,
+			duration_ms,
+			attempted_at
+		FROM webhook_delivery_attempts
+		WHERE delivery_id = ?
+		ORDER BY id ASC
+	`
+
+	// only a delivery that is done can be sent again, a pending one is
+	// still owned by the dispatcher
+	queryResetDelivery = `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = 0, next_attempt_at = ?, delivered_at = NULL
+		WHERE id = ? AND status <> ?
+	`
+)
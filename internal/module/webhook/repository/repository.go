@@ -0,0 +1,356 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/entity"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.WebhookRepository = &webhookRepository{}
+
+type webhookRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookRepository(db *sqlx.DB) *webhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+func (r *webhookRepository) InsertNewPartner(ctx context.Context, data *entity.Partner) (int64, error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.InsertNewPartner")
+	defer span.End()
+
+	id, err := dialect.InsertID(ctx, r.db, r.db.DriverName(), r.db.Rebind(queryInsertNewPartner),
+		data.Code,
+		data.Name,
+		data.APIKeyHash,
+	)
+	if err != nil {
+		if err_msg.IsDuplicateEntry(err) {
+			log.Ctx(ctx).Warn().Err(err).Str("code", data.Code).Msg("repository::InsertNewPartner - Partner code already exists")
+			return 0, err_msg.NewCustomErrors(fiber.StatusConflict, err_msg.WithMessage(constants.ErrPartnerCodeExists))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Str("code", data.Code).Msg("repository::InsertNewPartner - Failed to insert new partner")
+		return 0, err_msg.NewDatabaseErrors(err)
+	}
+
+	return id, nil
+}
+
+func (r *webhookRepository) FindPartnerByAPIKeyHash(ctx context.Context, apiKeyHash string) (*entity.Partner, error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.FindPartnerByAPIKeyHash")
+	defer span.End()
+
+	var res = new(entity.Partner)
+
+	err := r.db.GetContext(ctx, res, r.db.Rebind(queryFindPartnerByAPIKeyHash), apiKeyHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err_msg.NewCustomErrors(fiber.StatusUnauthorized, err_msg.WithMessage(constants.ErrInvalidPartnerKey))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindPartnerByAPIKeyHash - Failed to find partner")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *webhookRepository) FindPartnerByCode(ctx context.Context, code string) (*entity.Partner, error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.FindPartnerByCode")
+	defer span.End()
+
+	var res = new(entity.Partner)
+
+	err := r.db.GetContext(ctx, res, r.db.Rebind(queryFindPartnerByCode), code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err_msg.NewCustomErrors(fiber.StatusBadRequest, err_msg.WithMessage(constants.ErrPartnerNotFound))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Str("code", code).Msg("repository::FindPartnerByCode - Failed to find partner")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *webhookRepository) InsertNewSubscription(ctx context.Context, data *entity.Subscription) (int64, error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.InsertNewSubscription")
+	defer span.End()
+
+	id, err := dialect.InsertID(ctx, r.db, r.db.DriverName(), r.db.Rebind(queryInsertNewSubscription),
+		data.PartnerID,
+		data.URL,
+		data.Secret,
+		data.EventTypes,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("partner_id", data.PartnerID).Str("url", data.URL).Msg("repository::InsertNewSubscription - Failed to insert new subscription")
+		return 0, err_msg.NewDatabaseErrors(err)
+	}
+
+	return id, nil
+}
+
+func (r *webhookRepository) FindActiveSubscriptionsByPartnerID(ctx context.Context, partnerID int64) ([]entity.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.FindActiveSubscriptionsByPartnerID")
+	defer span.End()
+
+	var res = make([]entity.Subscription, 0)
+
+	err := r.db.SelectContext(ctx, &res, r.db.Rebind(queryFindActiveSubscriptionsByPartnerID), partnerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("partner_id", partnerID).Msg("repository::FindActiveSubscriptionsByPartnerID - Failed to find subscriptions")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *webhookRepository) DeactivateSubscription(ctx context.Context, id, partnerID int64) error {
+	ctx, span := tracing.Start(ctx, "webhookRepository.DeactivateSubscription")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(queryDeactivateSubscription), id, partnerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("repository::DeactivateSubscription - Failed to deactivate subscription")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::DeactivateSubscription - Failed to retrieve affected rows")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	if rows == 0 {
+		return err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrWebhookSubscriptionMissing))
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) InsertNewDeliveries(ctx context.Context, tx *sql.Tx, data []entity.Delivery) error {
+	ctx, span := tracing.Start(ctx, "webhookRepository.InsertNewDeliveries")
+	defer span.End()
+
+	for i := range data {
+		_, err := tx.ExecContext(ctx, r.db.Rebind(queryInsertNewDelivery),
+			data[i].SubscriptionID,
+			data[i].EventID,
+			data[i].EventType,
+			data[i].Payload,
+			data[i].Status,
+			data[i].NextAttemptAt,
+		)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Int64("subscription_id", data[i].SubscriptionID).Str("event_id", data[i].EventID).Msg("repository::InsertNewDeliveries - Failed to insert new delivery")
+			return err_msg.NewDatabaseErrors(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, tx *sql.Tx, now time.Time, limit int) ([]entity.DueDelivery, error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.ClaimDueDeliveries")
+	defer span.End()
+
+	rows, err := tx.QueryContext(ctx, r.db.Rebind(queryClaimDueDeliveries), constants.WebhookDeliveryStatusPending, now, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("limit", limit).Msg("repository::ClaimDueDeliveries - Failed to claim due deliveries")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+	defer rows.Close()
+
+	res := make([]entity.DueDelivery, 0, limit)
+	if err := sqlx.StructScan(rows, &res); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::ClaimDueDeliveries - Failed to scan deliveries")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *webhookRepository) LeaseDeliveries(ctx context.Context, tx *sql.Tx, ids []int64, until time.Time) error {
+	ctx, span := tracing.Start(ctx, "webhookRepository.LeaseDeliveries")
+	defer span.End()
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query, args, err := sqlx.In(queryLeaseDeliveries, until, ids)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::LeaseDeliveries - Failed to bind ids")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, r.db.Rebind(query), args...); err != nil {
+		log.Ctx(ctx).Error().Err(err).Ints64("ids", ids).Msg("repository::LeaseDeliveries - Failed to lease deliveries")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) UpdateDeliveryResult(ctx context.Context, data *entity.Delivery) error {
+	ctx, span := tracing.Start(ctx, "webhookRepository.UpdateDeliveryResult")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(queryUpdateDeliveryResult),
+		data.Status,
+		data.Attempts,
+		data.NextAttemptAt,
+		data.LastStatusCode,
+		data.LastError,
+		data.DeliveredAt,
+		data.ID,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", data.ID).Msg("repository::UpdateDeliveryResult - Failed to update delivery")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) InsertNewDeliveryAttempt(ctx context.Context, data *entity.DeliveryAttempt) error {
+	ctx, span := tracing.Start(ctx, "webhookRepository.InsertNewDeliveryAttempt")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(queryInsertNewDeliveryAttempt),
+		data.DeliveryID,
+		data.Attempt,
+		data.StatusCode,
+		data.ResponseBody,
+		data.ErrorMessage,
+		data.DurationMs,
+		data.AttemptedAt,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("delivery_id", data.DeliveryID).Msg("repository::InsertNewDeliveryAttempt - Failed to insert delivery attempt")
+		return err_msg.NewDatabaseErrors(err)
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) FindDeliveriesByPartnerID(ctx context.Context, req *dto.GetDeliveriesRequest, partnerID int64) ([]entity.Delivery, int, error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.FindDeliveriesByPartnerID")
+	defer span.End()
+
+	var (
+		data      = make([]entity.Delivery, 0, req.Paginate)
+		totalData int
+	)
+
+	countQuery, countArgs, err := sqlx.Named(queryCountDeliveriesByPartnerID, map[string]interface{}{
+		"partner_id":      partnerID,
+		"status":          req.Status,
+		"subscription_id": req.SubscriptionID,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindDeliveriesByPartnerID - Failed to bind named query for count")
+		return nil, 0, err
+	}
+
+	err = r.db.GetContext(ctx, &totalData, r.db.Rebind(countQuery), countArgs...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindDeliveriesByPartnerID - Failed to count deliveries")
+		return nil, 0, err
+	}
+
+	query, args, err := sqlx.Named(queryFindDeliveriesByPartnerID, map[string]interface{}{
+		"partner_id":      partnerID,
+		"status":          req.Status,
+		"subscription_id": req.SubscriptionID,
+		"limit":           req.Paginate,
+		"offset":          req.Paginate * (req.Page - 1),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::FindDeliveriesByPartnerID - Failed to bind named query")
+		return nil, 0, err
+	}
+
+	err = r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Any("payload", req).Msg("repository::FindDeliveriesByPartnerID - Failed to find deliveries")
+		return nil, 0, err
+	}
+
+	return data, totalData, nil
+}
+
+func (r *webhookRepository) FindDeliveryByIDAndPartnerID(ctx context.Context, id, partnerID int64) (*entity.Delivery, error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.FindDeliveryByIDAndPartnerID")
+	defer span.End()
+
+	var res = new(entity.Delivery)
+
+	err := r.db.GetContext(ctx, res, r.db.Rebind(queryFindDeliveryByIDAndPartnerID), id, partnerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrWebhookDeliveryNotFound))
+		}
+
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("repository::FindDeliveryByIDAndPartnerID - Failed to find delivery")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *webhookRepository) FindDeliveryAttemptsByDeliveryID(ctx context.Context, deliveryID int64) ([]entity.DeliveryAttempt, error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.FindDeliveryAttemptsByDeliveryID")
+	defer span.End()
+
+	var res = make([]entity.DeliveryAttempt, 0)
+
+	err := r.db.SelectContext(ctx, &res, r.db.Rebind(queryFindDeliveryAttemptsByDeliveryID), deliveryID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("delivery_id", deliveryID).Msg("repository::FindDeliveryAttemptsByDeliveryID - Failed to find delivery attempts")
+		return nil, err_msg.NewDatabaseErrors(err)
+	}
+
+	return res, nil
+}
+
+func (r *webhookRepository) ResetDelivery(ctx context.Context, id int64, nextAttemptAt time.Time) (bool, error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.ResetDelivery")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(queryResetDelivery),
+		constants.WebhookDeliveryStatusPending,
+		nextAttemptAt,
+		id,
+		constants.WebhookDeliveryStatusPending,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("repository::ResetDelivery - Failed to reset delivery")
+		return false, err_msg.NewDatabaseErrors(err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("repository::ResetDelivery - Failed to retrieve affected rows")
+		return false, err_msg.NewDatabaseErrors(err)
+	}
+
+	return rows > 0, nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect/dialecttest"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_webhookRepository_InsertNewPartner(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		partner := &entity.Partner{Code: "DEALER01", Name: "Dealer", APIKeyHash: "hash"}
+
+		tests := []struct {
+			name    string
+			wantErr bool
+			mockFn  func()
+		}{
+			{
+				name: "Insert New Partner Successfully",
+				mockFn: func() {
+					dialecttest.ExpectInsert(mock, db, "INSERT INTO partners").WithArgs("DEALER01", "Dealer", "hash").
+						WillReturnID(7)
+				},
+			},
+			{
+				name:    "Duplicate Partner Code",
+				wantErr: true,
+				mockFn: func() {
+					dialecttest.ExpectInsert(mock, db, "INSERT INTO partners").WillReturnError(dialecttest.DuplicateKey(db, "partners", "uq_partners_code", "DEALER01"))
+				},
+			},
+			{
+				name:    "Insert New Partner Failed",
+				wantErr: true,
+				mockFn: func() {
+					dialecttest.ExpectInsert(mock, db, "INSERT INTO partners").WillReturnError(fmt.Errorf("database error"))
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.mockFn()
+
+				_, err := NewWebhookRepository(db).InsertNewPartner(context.Background(), partner)
+				assert.Equal(t, tt.wantErr, err != nil)
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+}
+
+func Test_webhookRepository_ClaimDueDeliveries(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		now := time.Date(2024, 12, 30, 9, 0, 0, 0, time.UTC)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM webhook_deliveries d JOIN webhook_subscriptions s (.+) FOR UPDATE OF d SKIP LOCKED").
+			WithArgs("pending", now, 50).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts", "next_attempt_at", "last_status_code", "last_error", "delivered_at", "created_at", "updated_at", "url", "secret", "subscription_active"}).
+				AddRow(1, 3, "e1", "contract.created", `{}`, "pending", 2, now, 503, "try later", nil, now, now, "https://partner.test/hook", "whsec_test", true))
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		got, err := NewWebhookRepository(db).ClaimDueDeliveries(context.Background(), tx, now, 50)
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, "https://partner.test/hook", got[0].URL)
+		assert.Equal(t, 2, got[0].Attempts)
+		assert.Equal(t, int64(503), got[0].LastStatusCode.Int64)
+		assert.True(t, got[0].SubscriptionActive)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_webhookRepository_ResetDelivery(t *testing.T) {
+	dialecttest.Run(t, func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock) {
+		now := time.Date(2024, 12, 30, 9, 0, 0, 0, time.UTC)
+
+		mock.ExpectExec("UPDATE webhook_deliveries SET status = (.+) WHERE id = (.+) AND status <> (.+)").
+			WithArgs("pending", now, 1, "pending").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE webhook_deliveries").
+			WithArgs("pending", now, 2, "pending").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		r := NewWebhookRepository(db)
+
+		reset, err := r.ResetDelivery(context.Background(), 1, now)
+		assert.NoError(t, err)
+		assert.True(t, reset)
+
+		reset, err = r.ResetDelivery(context.Background(), 2, now)
+		assert.NoError(t, err)
+		assert.False(t, reset)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
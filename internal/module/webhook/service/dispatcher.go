@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/entity"
+	webhookPorts "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/hilmiikhsan/multifinance-service/pkg/webhook"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ webhookPorts.WebhookDispatcher = &dispatcherService{}
+
+// error messages are cut to fit last_error and the attempt log
+const maxErrorLength = 255
+
+type dispatcherService struct {
+	db                *sqlx.DB
+	webhookRepository webhookPorts.WebhookRepository
+	sender            *webhook.Sender
+	backoff           webhook.Backoff
+	batchSize         int
+	lease             time.Duration
+	now               func() time.Time
+}
+
+func NewDispatcherService(db *sqlx.DB, webhookRepository webhookPorts.WebhookRepository, sender *webhook.Sender, backoff webhook.Backoff, batchSize int, lease time.Duration) *dispatcherService {
+	return &dispatcherService{
+		db:                db,
+		webhookRepository: webhookRepository,
+		sender:            sender,
+		backoff:           backoff,
+		batchSize:         batchSize,
+		lease:             lease,
+		now:               time.Now,
+	}
+}
+
+// DispatchDueDeliveries claims the pending deliveries that are due and sends
+// them. Claiming moves next_attempt_at past the lease and commits before
+// anything is sent, so other dispatchers skip them while the receivers are
+// slow, and a crashed dispatcher only delays them by the lease.
+func (s *dispatcherService) DispatchDueDeliveries(ctx context.Context) (*dto.DispatchResult, error) {
+	ctx, span := tracing.Start(ctx, "dispatcherService.DispatchDueDeliveries")
+	defer span.End()
+
+	deliveries, err := s.claimDueDeliveries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(dto.DispatchResult)
+
+	for i := range deliveries {
+		status, err := s.dispatch(ctx, &deliveries[i])
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Int64("id", deliveries[i].ID).Msg("service::DispatchDueDeliveries - Failed to record delivery result")
+			continue
+		}
+
+		switch status {
+		case constants.WebhookDeliveryStatusDelivered:
+			res.Delivered++
+		case constants.WebhookDeliveryStatusDead:
+			res.Dead++
+		default:
+			res.Retrying++
+		}
+	}
+
+	return res, nil
+}
+
+func (s *dispatcherService) claimDueDeliveries(ctx context.Context) ([]entity.DueDelivery, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::DispatchDueDeliveries - Failed to begin transaction")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Ctx(ctx).Error().Err(rollbackErr).Msg("service::DispatchDueDeliveries - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	now := s.now()
+
+	deliveries, err := s.webhookRepository.ClaimDueDeliveries(ctx, tx, now, s.batchSize)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::DispatchDueDeliveries - Failed to claim due deliveries")
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(deliveries))
+	for i := range deliveries {
+		ids = append(ids, deliveries[i].ID)
+	}
+
+	err = s.webhookRepository.LeaseDeliveries(ctx, tx, ids, now.Add(s.lease))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::DispatchDueDeliveries - Failed to lease deliveries")
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::DispatchDueDeliveries - Failed to commit transaction")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	return deliveries, nil
+}
+
+// dispatch sends one delivery, logs the attempt and returns the status the
+// delivery ends up in. A delivery of a removed subscription is not sent and
+// goes straight to dead.
+func (s *dispatcherService) dispatch(ctx context.Context, due *entity.DueDelivery) (string, error) {
+	delivery := due.Delivery
+	delivery.Attempts++
+
+	if !due.SubscriptionActive {
+		delivery.Status = constants.WebhookDeliveryStatusDead
+		delivery.LastError = sql.NullString{String: "subscription was removed", Valid: true}
+
+		return delivery.Status, s.webhookRepository.UpdateDeliveryResult(ctx, &delivery)
+	}
+
+	attemptedAt := s.now()
+	result, sendErr := s.sender.Send(ctx, due.URL, due.Secret, &webhook.Message{
+		ID:    delivery.EventID,
+		Event: delivery.EventType,
+		Body:  []byte(delivery.Payload),
+	})
+
+	attempt := &entity.DeliveryAttempt{
+		DeliveryID:  delivery.ID,
+		Attempt:     delivery.Attempts,
+		DurationMs:  result.Duration.Milliseconds(),
+		AttemptedAt: attemptedAt,
+	}
+
+	if result.StatusCode != 0 {
+		attempt.StatusCode = sql.NullInt64{Int64: int64(result.StatusCode), Valid: true}
+		attempt.ResponseBody = sql.NullString{String: result.ResponseBody, Valid: result.ResponseBody != ""}
+	}
+
+	// a bad status is already in status_code, only transport errors are kept
+	var statusErr *webhook.StatusError
+	if sendErr != nil && !errors.As(sendErr, &statusErr) {
+		attempt.ErrorMessage = sql.NullString{String: truncate(sendErr.Error()), Valid: true}
+	}
+
+	if err := s.webhookRepository.InsertNewDeliveryAttempt(ctx, attempt); err != nil {
+		return "", err
+	}
+
+	delivery.LastStatusCode = attempt.StatusCode
+	delivery.LastError = sql.NullString{}
+
+	switch wait, retry := s.backoff.Next(delivery.Attempts); {
+	case sendErr == nil:
+		delivery.Status = constants.WebhookDeliveryStatusDelivered
+		delivery.DeliveredAt = sql.NullTime{Time: s.now(), Valid: true}
+	case retry:
+		delivery.Status = constants.WebhookDeliveryStatusPending
+		delivery.NextAttemptAt = s.now().Add(wait)
+		delivery.LastError = sql.NullString{String: truncate(sendErr.Error()), Valid: true}
+	default:
+		delivery.Status = constants.WebhookDeliveryStatusDead
+		delivery.LastError = sql.NullString{String: truncate(sendErr.Error()), Valid: true}
+
+		log.Ctx(ctx).Warn().
+			Err(sendErr).
+			Int64("id", delivery.ID).
+			Int64("subscription_id", delivery.SubscriptionID).
+			Int("attempts", delivery.Attempts).
+			Msg("service::DispatchDueDeliveries - Giving up on delivery")
+	}
+
+	return delivery.Status, s.webhookRepository.UpdateDeliveryResult(ctx, &delivery)
+}
+
+// Run dispatches batches until ctx is done. A full batch is followed by the
+// next one straight away, otherwise the dispatcher waits interval. Each batch
+// is its own trace, Run starts no span that would outlive them.
+func (s *dispatcherService) Run(ctx context.Context, interval time.Duration) {
+	for {
+		res, err := s.DispatchDueDeliveries(ctx)
+
+		wait := interval
+		total := 0
+		if err == nil {
+			total = res.Delivered + res.Retrying + res.Dead
+		}
+
+		if total == s.batchSize {
+			wait = 0
+		}
+
+		if total > 0 {
+			log.Ctx(ctx).Info().Any("result", res).Msg("service::Run - Dispatched webhook deliveries")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func truncate(message string) string {
+	if len(message) > maxErrorLength {
+		return message[:maxErrorLength]
+	}
+
+	return message
+}
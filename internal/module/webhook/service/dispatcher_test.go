@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/webhook"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_dispatcherService_DispatchDueDeliveries(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockWebhookRepository(ctrlMock)
+
+	var (
+		now      = time.Date(2024, 12, 30, 9, 0, 0, 0, time.UTC)
+		secret   = "whsec_test"
+		payload  = `{"id":"e1","type":"contract.created"}`
+		backoff  = webhook.Backoff{Base: 30 * time.Second, Max: time.Hour, MaxAttempts: 3}
+		received atomic.Int32
+	)
+
+	// the receiver checks the signature like a partner would and answers with
+	// the status named in the path
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := webhook.Verify(secret, r.Header.Get(webhook.HeaderTimestamp), r.Header.Get(webhook.HeaderSignature), body, time.Now(), time.Minute); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		received.Add(1)
+
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("try later"))
+		}
+	}))
+	defer receiver.Close()
+
+	due := func(id int64, path string, attempts int, active bool) entity.DueDelivery {
+		return entity.DueDelivery{
+			Delivery: entity.Delivery{
+				ID:             id,
+				SubscriptionID: 1,
+				EventID:        "e1",
+				EventType:      constants.WebhookEventContractCreated,
+				Payload:        payload,
+				Status:         constants.WebhookDeliveryStatusPending,
+				Attempts:       attempts,
+			},
+			URL:                receiver.URL + path,
+			Secret:             secret,
+			SubscriptionActive: active,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		delivery     entity.DueDelivery
+		wantStatus   string
+		wantReceived int32
+		check        func(t *testing.T, data *entity.Delivery)
+	}{
+		{
+			name:         "Delivered",
+			delivery:     due(1, "/ok", 0, true),
+			wantStatus:   constants.WebhookDeliveryStatusDelivered,
+			wantReceived: 1,
+			check: func(t *testing.T, data *entity.Delivery) {
+				assert.Equal(t, 1, data.Attempts)
+				assert.Equal(t, int64(http.StatusOK), data.LastStatusCode.Int64)
+				assert.True(t, data.DeliveredAt.Valid)
+			},
+		},
+		{
+			name:         "Retried With Backoff",
+			delivery:     due(2, "/down", 1, true),
+			wantStatus:   constants.WebhookDeliveryStatusPending,
+			wantReceived: 1,
+			check: func(t *testing.T, data *entity.Delivery) {
+				assert.Equal(t, 2, data.Attempts)
+				assert.Equal(t, now.Add(time.Minute), data.NextAttemptAt)
+				assert.Equal(t, int64(http.StatusServiceUnavailable), data.LastStatusCode.Int64)
+			},
+		},
+		{
+			name:         "Dead After Last Attempt",
+			delivery:     due(3, "/down", 2, true),
+			wantStatus:   constants.WebhookDeliveryStatusDead,
+			wantReceived: 1,
+			check: func(t *testing.T, data *entity.Delivery) {
+				assert.Equal(t, 3, data.Attempts)
+				assert.True(t, data.LastError.Valid)
+			},
+		},
+		{
+			name:       "Dead When Subscription Was Removed",
+			delivery:   due(4, "/ok", 0, false),
+			wantStatus: constants.WebhookDeliveryStatusDead,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, dbMock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			received.Store(0)
+
+			dbMock.ExpectBegin()
+			mockRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), now, 10).Return([]entity.DueDelivery{tt.delivery}, nil)
+			mockRepo.EXPECT().LeaseDeliveries(gomock.Any(), gomock.Any(), []int64{tt.delivery.ID}, now.Add(time.Minute)).Return(nil)
+			dbMock.ExpectCommit()
+
+			if tt.delivery.SubscriptionActive {
+				mockRepo.EXPECT().InsertNewDeliveryAttempt(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, data *entity.DeliveryAttempt) error {
+					assert.Equal(t, tt.delivery.ID, data.DeliveryID)
+					assert.Equal(t, tt.delivery.Attempts+1, data.Attempt)
+					return nil
+				})
+			}
+
+			mockRepo.EXPECT().UpdateDeliveryResult(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, data *entity.Delivery) error {
+				assert.Equal(t, tt.wantStatus, data.Status)
+				if tt.check != nil {
+					tt.check(t, data)
+				}
+				return nil
+			})
+
+			s := NewDispatcherService(sqlx.NewDb(db, "mysql"), mockRepo, webhook.NewSender(time.Second), backoff, 10, time.Minute)
+			s.now = func() time.Time { return now }
+
+			res, err := s.DispatchDueDeliveries(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 1, res.Delivered+res.Retrying+res.Dead)
+			assert.Equal(t, tt.wantReceived, received.Load())
+			assert.NoError(t, dbMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/entity"
+	webhookPorts "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/ports"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/hilmiikhsan/multifinance-service/pkg/tracing"
+	"github.com/hilmiikhsan/multifinance-service/pkg/webhook"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	_ webhookPorts.WebhookService  = &webhookService{}
+	_ webhookPorts.WebhookEnqueuer = &webhookService{}
+)
+
+const partnerKeyPrefix = "pk_"
+
+type webhookService struct {
+	webhookRepository webhookPorts.WebhookRepository
+	now               func() time.Time
+}
+
+func NewWebhookService(webhookRepository webhookPorts.WebhookRepository) *webhookService {
+	return &webhookService{
+		webhookRepository: webhookRepository,
+		now:               time.Now,
+	}
+}
+
+// CreatePartner registers a partner and returns its API key. Only the hash
+// is stored, so the key cannot be shown again.
+func (s *webhookService) CreatePartner(ctx context.Context, req *dto.CreatePartnerRequest) (*dto.CreatePartnerResponse, error) {
+	ctx, span := tracing.Start(ctx, "webhookService.CreatePartner")
+	defer span.End()
+
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::CreatePartner - Failed to generate partner key")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	apiKey := partnerKeyPrefix + hex.EncodeToString(buf)
+
+	id, err := s.webhookRepository.InsertNewPartner(ctx, &entity.Partner{
+		Code:       req.Code,
+		Name:       req.Name,
+		APIKeyHash: hashPartnerKey(apiKey),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("code", req.Code).Msg("service::CreatePartner - Failed to insert new partner")
+		return nil, err
+	}
+
+	return &dto.CreatePartnerResponse{
+		ID:     id,
+		Code:   req.Code,
+		Name:   req.Name,
+		APIKey: apiKey,
+	}, nil
+}
+
+func (s *webhookService) AuthenticatePartner(ctx context.Context, apiKey string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "webhookService.AuthenticatePartner")
+	defer span.End()
+
+	if apiKey == "" {
+		return 0, err_msg.NewCustomErrors(fiber.StatusUnauthorized, err_msg.WithMessage(constants.ErrInvalidPartnerKey))
+	}
+
+	partner, err := s.webhookRepository.FindPartnerByAPIKeyHash(ctx, hashPartnerKey(apiKey))
+	if err != nil {
+		return 0, err
+	}
+
+	return partner.ID, nil
+}
+
+func (s *webhookService) CreateSubscription(ctx context.Context, req *dto.CreateSubscriptionRequest, partnerID int64) (*dto.SubscriptionResponse, error) {
+	ctx, span := tracing.Start(ctx, "webhookService.CreateSubscription")
+	defer span.End()
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::CreateSubscription - Failed to generate secret")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	eventTypes, err := json.Marshal(req.EventTypes)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("service::CreateSubscription - Failed to marshal event types")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	subscription := &entity.Subscription{
+		PartnerID:  partnerID,
+		URL:        req.URL,
+		Secret:     secret,
+		EventTypes: string(eventTypes),
+		Active:     true,
+		CreatedAt:  s.now(),
+	}
+
+	subscription.ID, err = s.webhookRepository.InsertNewSubscription(ctx, subscription)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("partner_id", partnerID).Msg("service::CreateSubscription - Failed to insert new subscription")
+		return nil, err
+	}
+
+	res := subscriptionResponse(subscription)
+	res.Secret = secret
+
+	return res, nil
+}
+
+func (s *webhookService) GetSubscriptions(ctx context.Context, partnerID int64) ([]dto.SubscriptionResponse, error) {
+	ctx, span := tracing.Start(ctx, "webhookService.GetSubscriptions")
+	defer span.End()
+
+	subscriptions, err := s.webhookRepository.FindActiveSubscriptionsByPartnerID(ctx, partnerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("partner_id", partnerID).Msg("service::GetSubscriptions - Failed to find subscriptions")
+		return nil, err
+	}
+
+	res := make([]dto.SubscriptionResponse, 0, len(subscriptions))
+	for i := range subscriptions {
+		res = append(res, *subscriptionResponse(&subscriptions[i]))
+	}
+
+	return res, nil
+}
+
+// DeleteSubscription stops new deliveries to the subscription. Pending ones
+// are marked dead by the dispatcher when they come up.
+func (s *webhookService) DeleteSubscription(ctx context.Context, id, partnerID int64) error {
+	ctx, span := tracing.Start(ctx, "webhookService.DeleteSubscription")
+	defer span.End()
+
+	return s.webhookRepository.DeactivateSubscription(ctx, id, partnerID)
+}
+
+func (s *webhookService) GetDeliveries(ctx context.Context, req *dto.GetDeliveriesRequest, partnerID int64) (*dto.GetDeliveriesResponse, error) {
+	ctx, span := tracing.Start(ctx, "webhookService.GetDeliveries")
+	defer span.End()
+
+	deliveries, totalData, err := s.webhookRepository.FindDeliveriesByPartnerID(ctx, req, partnerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("partner_id", partnerID).Any("payload", req).Msg("service::GetDeliveries - Failed to find deliveries")
+		return nil, err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	res := &dto.GetDeliveriesResponse{
+		Items: make([]dto.DeliveryResponse, 0, len(deliveries)),
+	}
+
+	for i := range deliveries {
+		res.Items = append(res.Items, *deliveryResponse(&deliveries[i]))
+	}
+
+	res.Meta.CountTotalPage(req.Page, req.Paginate, totalData)
+
+	return res, nil
+}
+
+func (s *webhookService) GetDelivery(ctx context.Context, id, partnerID int64) (*dto.DeliveryDetailResponse, error) {
+	ctx, span := tracing.Start(ctx, "webhookService.GetDelivery")
+	defer span.End()
+
+	delivery, err := s.webhookRepository.FindDeliveryByIDAndPartnerID(ctx, id, partnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.webhookRepository.FindDeliveryAttemptsByDeliveryID(ctx, id)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("service::GetDelivery - Failed to find delivery attempts")
+		return nil, err
+	}
+
+	res := &dto.DeliveryDetailResponse{
+		DeliveryResponse: *deliveryResponse(delivery),
+		Payload:          json.RawMessage(delivery.Payload),
+		AttemptsLog:      make([]dto.DeliveryAttemptResponse, 0, len(attempts)),
+	}
+
+	for _, attempt := range attempts {
+		res.AttemptsLog = append(res.AttemptsLog, dto.DeliveryAttemptResponse{
+			Attempt:      attempt.Attempt,
+			StatusCode:   int(attempt.StatusCode.Int64),
+			ResponseBody: attempt.ResponseBody.String,
+			ErrorMessage: attempt.ErrorMessage.String,
+			DurationMs:   attempt.DurationMs,
+			AttemptedAt:  attempt.AttemptedAt.Format(constants.DateTimeFormat),
+		})
+	}
+
+	return res, nil
+}
+
+// RedeliverDelivery queues a delivered or dead delivery again with a fresh
+// set of attempts. The body and event ID stay the same, so receivers that
+// already processed it can recognise the duplicate.
+func (s *webhookService) RedeliverDelivery(ctx context.Context, id, partnerID int64) (*dto.DeliveryResponse, error) {
+	ctx, span := tracing.Start(ctx, "webhookService.RedeliverDelivery")
+	defer span.End()
+
+	delivery, err := s.webhookRepository.FindDeliveryByIDAndPartnerID(ctx, id, partnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+
+	reset, err := s.webhookRepository.ResetDelivery(ctx, id, now)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("id", id).Msg("service::RedeliverDelivery - Failed to reset delivery")
+		return nil, err
+	}
+
+	if !reset {
+		return nil, err_msg.NewCustomErrors(fiber.StatusConflict, err_msg.WithMessage(constants.ErrWebhookDeliveryPending))
+	}
+
+	delivery.Status = constants.WebhookDeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now
+	delivery.DeliveredAt = sql.NullTime{}
+
+	return deliveryResponse(delivery), nil
+}
+
+func (s *webhookService) FindPartnerIDByCode(ctx context.Context, code string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "webhookService.FindPartnerIDByCode")
+	defer span.End()
+
+	partner, err := s.webhookRepository.FindPartnerByCode(ctx, code)
+	if err != nil {
+		return 0, err
+	}
+
+	return partner.ID, nil
+}
+
+// EnqueueEvent queues one delivery per active subscription of the partner
+// that asked for eventType. Data is wrapped in the event envelope, whose ID
+// is shared by all of them.
+func (s *webhookService) EnqueueEvent(ctx context.Context, tx *sql.Tx, partnerID int64, eventType string, data any) error {
+	ctx, span := tracing.Start(ctx, "webhookService.EnqueueEvent")
+	defer span.End()
+
+	subscriptions, err := s.webhookRepository.FindActiveSubscriptionsByPartnerID(ctx, partnerID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("partner_id", partnerID).Msg("service::EnqueueEvent - Failed to find subscriptions")
+		return err
+	}
+
+	now := s.now().UTC()
+	event := &dto.Event{
+		ID:        uuid.NewString(),
+		Type:      eventType,
+		CreatedAt: now.Format(time.RFC3339),
+		Data:      data,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("event_type", eventType).Msg("service::EnqueueEvent - Failed to marshal event")
+		return err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError))
+	}
+
+	deliveries := make([]entity.Delivery, 0, len(subscriptions))
+	for i := range subscriptions {
+		var eventTypes []string
+		if err := json.Unmarshal([]byte(subscriptions[i].EventTypes), &eventTypes); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("subscription_id", subscriptions[i].ID).Msg("service::EnqueueEvent - Skipping subscription with unreadable event types")
+			continue
+		}
+
+		if !slices.Contains(eventTypes, eventType) {
+			continue
+		}
+
+		deliveries = append(deliveries, entity.Delivery{
+			SubscriptionID: subscriptions[i].ID,
+			EventID:        event.ID,
+			EventType:      eventType,
+			Payload:        string(payload),
+			Status:         constants.WebhookDeliveryStatusPending,
+			NextAttemptAt:  now,
+		})
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := s.webhookRepository.InsertNewDeliveries(ctx, tx, deliveries); err != nil {
+		log.Ctx(ctx).Error().Err(err).Int64("partner_id", partnerID).Str("event_id", event.ID).Msg("service::EnqueueEvent - Failed to insert deliveries")
+		return err
+	}
+
+	return nil
+}
+
+func hashPartnerKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func subscriptionResponse(subscription *entity.Subscription) *dto.SubscriptionResponse {
+	var eventTypes []string
+	_ = json.Unmarshal([]byte(subscription.EventTypes), &eventTypes)
+
+	return &dto.SubscriptionResponse{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: eventTypes,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt.Format(constants.DateTimeFormat),
+	}
+}
+
+func deliveryResponse(delivery *entity.Delivery) *dto.DeliveryResponse {
+	res := &dto.DeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: int(delivery.LastStatusCode.Int64),
+		LastError:      delivery.LastError.String,
+		CreatedAt:      delivery.CreatedAt.Format(constants.DateTimeFormat),
+	}
+
+	if delivery.Status == constants.WebhookDeliveryStatusPending {
+		res.NextAttemptAt = delivery.NextAttemptAt.Format(constants.DateTimeFormat)
+	}
+
+	if delivery.DeliveredAt.Valid {
+		res.DeliveredAt = delivery.DeliveredAt.Time.Format(constants.DateTimeFormat)
+	}
+
+	return res
+}
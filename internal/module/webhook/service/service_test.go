@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/dto"
+	"github.com/hilmiikhsan/multifinance-service/internal/module/webhook/entity"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func Test_webhookService_EnqueueEvent(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockWebhookRepository(ctrlMock)
+
+	subscriptions := []entity.Subscription{
+		{ID: 1, PartnerID: 7, EventTypes: `["contract.created","contract.paid_off"]`, Active: true},
+		{ID: 2, PartnerID: 7, EventTypes: `["contract.cancelled"]`, Active: true},
+		{ID: 3, PartnerID: 7, EventTypes: `["contract.created"]`, Active: true},
+	}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "Enqueue For Matching Subscriptions",
+			mockFn: func() {
+				mockRepo.EXPECT().FindActiveSubscriptionsByPartnerID(gomock.Any(), int64(7)).Return(subscriptions, nil)
+				mockRepo.EXPECT().InsertNewDeliveries(gomock.Any(), gomock.Nil(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ *sql.Tx, data []entity.Delivery) error {
+						assert.Len(t, data, 2)
+						assert.Equal(t, int64(1), data[0].SubscriptionID)
+						assert.Equal(t, int64(3), data[1].SubscriptionID)
+						assert.Equal(t, data[0].EventID, data[1].EventID)
+						assert.Equal(t, constants.WebhookDeliveryStatusPending, data[0].Status)
+
+						var event dto.Event
+						assert.NoError(t, json.Unmarshal([]byte(data[0].Payload), &event))
+						assert.Equal(t, data[0].EventID, event.ID)
+						assert.Equal(t, constants.WebhookEventContractCreated, event.Type)
+						return nil
+					})
+			},
+		},
+		{
+			name: "No Matching Subscription",
+			mockFn: func() {
+				mockRepo.EXPECT().FindActiveSubscriptionsByPartnerID(gomock.Any(), int64(7)).Return(subscriptions[1:2], nil)
+			},
+		},
+		{
+			name:    "Insert Deliveries Error",
+			wantErr: true,
+			mockFn: func() {
+				mockRepo.EXPECT().FindActiveSubscriptionsByPartnerID(gomock.Any(), int64(7)).Return(subscriptions, nil)
+				mockRepo.EXPECT().InsertNewDeliveries(gomock.Any(), gomock.Nil(), gomock.Any()).
+					Return(err_msg.NewCustomErrors(fiber.StatusInternalServerError, err_msg.WithMessage(constants.ErrInternalServerError)))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			s := NewWebhookService(mockRepo)
+
+			err := s.EnqueueEvent(context.Background(), nil, 7, constants.WebhookEventContractCreated, &dto.ContractData{ContractNumber: "TRX-1"})
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_webhookService_RedeliverDelivery(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockWebhookRepository(ctrlMock)
+	now := time.Date(2024, 12, 30, 9, 0, 0, 0, time.UTC)
+
+	dead := func() *entity.Delivery {
+		return &entity.Delivery{
+			ID:             1,
+			SubscriptionID: 3,
+			EventID:        "e1",
+			Status:         constants.WebhookDeliveryStatusDead,
+			Attempts:       8,
+			LastStatusCode: sql.NullInt64{Int64: 500, Valid: true},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		wantCode int
+		mockFn   func()
+	}{
+		{
+			name: "Redeliver Dead Delivery",
+			mockFn: func() {
+				mockRepo.EXPECT().FindDeliveryByIDAndPartnerID(gomock.Any(), int64(1), int64(7)).Return(dead(), nil)
+				mockRepo.EXPECT().ResetDelivery(gomock.Any(), int64(1), now).Return(true, nil)
+			},
+		},
+		{
+			name:     "Delivery Still Pending",
+			wantCode: fiber.StatusConflict,
+			mockFn: func() {
+				mockRepo.EXPECT().FindDeliveryByIDAndPartnerID(gomock.Any(), int64(1), int64(7)).Return(dead(), nil)
+				mockRepo.EXPECT().ResetDelivery(gomock.Any(), int64(1), now).Return(false, nil)
+			},
+		},
+		{
+			name:     "Delivery Of Other Partner",
+			wantCode: fiber.StatusNotFound,
+			mockFn: func() {
+				mockRepo.EXPECT().FindDeliveryByIDAndPartnerID(gomock.Any(), int64(1), int64(7)).
+					Return(nil, err_msg.NewCustomErrors(fiber.StatusNotFound, err_msg.WithMessage(constants.ErrWebhookDeliveryNotFound)))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			s := NewWebhookService(mockRepo)
+			s.now = func() time.Time { return now }
+
+			res, err := s.RedeliverDelivery(context.Background(), 1, 7)
+			if tt.wantCode != 0 {
+				var customErr *err_msg.CustomError
+				assert.True(t, errors.As(err, &customErr))
+				assert.Equal(t, tt.wantCode, customErr.Code)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, constants.WebhookDeliveryStatusPending, res.Status)
+			assert.Zero(t, res.Attempts)
+			assert.Equal(t, now.Format(constants.DateTimeFormat), res.NextAttemptAt)
+		})
+	}
+}
+
+func Test_webhookService_AuthenticatePartner(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockWebhookRepository(ctrlMock)
+	s := NewWebhookService(mockRepo)
+
+	mockRepo.EXPECT().InsertNewPartner(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, data *entity.Partner) (int64, error) {
+		assert.Len(t, data.APIKeyHash, 64)
+		mockRepo.EXPECT().FindPartnerByAPIKeyHash(gomock.Any(), data.APIKeyHash).Return(&entity.Partner{ID: 7}, nil)
+		return 7, nil
+	})
+
+	partner, err := s.CreatePartner(context.Background(), &dto.CreatePartnerRequest{Code: "DEALER01", Name: "Dealer"})
+	assert.NoError(t, err)
+	assert.NotContains(t, partner.APIKey, "DEALER01")
+
+	id, err := s.AuthenticatePartner(context.Background(), partner.APIKey)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+
+	_, err = s.AuthenticatePartner(context.Background(), "")
+	assert.Error(t, err)
+}
@@ -7,22 +7,38 @@ import (
 	authRest "github.com/hilmiikhsan/multifinance-service/internal/module/auth/handler/rest"
 	creditLimitRest "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/handler/rest"
 	customerRest "github.com/hilmiikhsan/multifinance-service/internal/module/customer/handler/rest"
+	fraudRest "github.com/hilmiikhsan/multifinance-service/internal/module/fraud/handler/rest"
+	healthRest "github.com/hilmiikhsan/multifinance-service/internal/module/health/handler/rest"
+	notificationRest "github.com/hilmiikhsan/multifinance-service/internal/module/notification/handler/rest"
+	statementRest "github.com/hilmiikhsan/multifinance-service/internal/module/statement/handler/rest"
 	transactionRest "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/handler/rest"
+	webhookRest "github.com/hilmiikhsan/multifinance-service/internal/module/webhook/handler/rest"
 	"github.com/rs/zerolog/log"
 )
 
 func SetupRoutes(app *fiber.App) {
 	var (
-		authAPIV1        = app.Group("/api/v1/auth")
-		customerAPIV1    = app.Group("/api/v1/customer")
-		creditLimitAPIV1 = app.Group("/api/v1/credit")
-		transactionAPIV1 = app.Group("/api/v1/transaction")
+		authAPIV1         = app.Group("/api/v1/auth")
+		customerAPIV1     = app.Group("/api/v1/customer")
+		creditLimitAPIV1  = app.Group("/api/v1/credit")
+		transactionAPIV1  = app.Group("/api/v1/transaction")
+		fraudAPIV1        = app.Group("/api/v1/fraud")
+		statementAPIV1    = app.Group("/api/v1/statement")
+		partnerAPIV1      = app.Group("/api/v1/partner")
+		notificationAPIV1 = app.Group("/api/v1/notification")
 	)
 
+	healthRest.NewHealthHandler().HealthRoute(app)
 	authRest.NewAuthHandler().AuthRoute(authAPIV1)
 	customerRest.NewCustomerHandler().CustomerRoute(customerAPIV1)
 	creditLimitRest.NewCreditLimitHandler().CreditLimitRoute(creditLimitAPIV1)
 	transactionRest.NewTransactionHandler().TransactionRoute(transactionAPIV1)
+	fraudRest.NewFraudHandler().FraudRoute(fraudAPIV1)
+	statementRest.NewStatementHandler().StatementRoute(statementAPIV1)
+	webhookRest.NewWebhookHandler().WebhookRoute(partnerAPIV1)
+	notificationRest.NewNotificationHandler().NotificationRoute(notificationAPIV1)
+
+	docsRoute(app)
 
 	// fallback route
 	app.Use(func(c *fiber.Ctx) error {
@@ -0,0 +1,116 @@
+package route
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	pkgConfig "github.com/hilmiikhsan/multifinance-service/pkg/config"
+	"github.com/hilmiikhsan/multifinance-service/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+func newTestApp(t *testing.T) *fiber.App {
+	config.Envs = new(config.Config)
+	if err := pkgConfig.Load(pkgConfig.Opts{Config: config.Envs}); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	adapter.Adapters = new(adapter.Adapter)
+
+	app := fiber.New()
+	SetupRoutes(app)
+
+	return app
+}
+
+// operations lists the registered routes as "METHOD /path" in OpenAPI form.
+func operations(app *fiber.App) map[string]bool {
+	ops := make(map[string]bool)
+	for _, r := range app.GetRoutes(true) {
+		// fiber adds a HEAD for every GET
+		if r.Method == fiber.MethodHead {
+			continue
+		}
+
+		path := pathParam.ReplaceAllString(r.Path, "{$1}")
+		if len(path) > 1 {
+			path = strings.TrimSuffix(path, "/")
+		}
+
+		ops[fmt.Sprintf("%s %s", r.Method, path)] = true
+	}
+
+	return ops
+}
+
+func TestSetupRoutes_OpenAPICoverage(t *testing.T) {
+	spec, err := openapi.Parse()
+	if err != nil {
+		t.Fatalf("failed to parse openapi document: %v", err)
+	}
+
+	documented := make(map[string]bool)
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			documented[fmt.Sprintf("%s %s", strings.ToUpper(method), path)] = true
+		}
+	}
+
+	registered := operations(newTestApp(t))
+	assert.NotEmpty(t, registered)
+
+	for op := range registered {
+		assert.True(t, documented[op], "route %s is not in pkg/openapi/openapi.yaml", op)
+	}
+
+	for op := range documented {
+		assert.True(t, registered[op], "pkg/openapi/openapi.yaml documents %s but it is not registered", op)
+	}
+}
+
+func TestSetupRoutes_Docs(t *testing.T) {
+	app := newTestApp(t)
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		contains    string
+	}{
+		{
+			name:        "openapi document",
+			path:        "/openapi.json",
+			contentType: fiber.MIMEApplicationJSON,
+			contains:    `"openapi":"3.0.3"`,
+		},
+		{
+			name:        "documentation UI",
+			path:        "/docs",
+			contentType: fiber.MIMETextHTML,
+			contains:    "/openapi.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.path, nil))
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			assert.Contains(t, resp.Header.Get(fiber.HeaderContentType), tt.contentType)
+			assert.Contains(t, string(body), tt.contains)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package route
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/pkg/openapi"
+	"github.com/hilmiikhsan/multifinance-service/pkg/response"
+	"github.com/rs/zerolog/log"
+)
+
+// swaggerUI renders /openapi.json with a pinned Swagger UI release.
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <meta name="viewport" content="width=device-width, initial-scale=1" />
+  <title>Multifinance Service API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: '/openapi.json', dom_id: '#swagger-ui' });
+    };
+  </script>
+</body>
+</html>`
+
+func docsRoute(app *fiber.App) {
+	app.Get("/openapi.json", func(c *fiber.Ctx) error {
+		doc, err := openapi.JSON()
+		if err != nil {
+			log.Error().Err(err).Msg("route::docsRoute - Failed to load openapi document")
+			return c.Status(fiber.StatusInternalServerError).JSON(response.Error(err.Error()))
+		}
+
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.Send(doc)
+	})
+
+	app.Get("/docs", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(swaggerUI)
+	})
+}
@@ -0,0 +1,38 @@
+package route
+
+import (
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/internal/adapter"
+	"github.com/hilmiikhsan/multifinance-service/internal/infrastructure/config"
+	redisRepository "github.com/hilmiikhsan/multifinance-service/internal/infrastructure/redis"
+	"github.com/hilmiikhsan/multifinance-service/internal/middleware"
+	creditLimitGrpc "github.com/hilmiikhsan/multifinance-service/internal/module/credit_limit/handler/grpc"
+	customerGrpc "github.com/hilmiikhsan/multifinance-service/internal/module/customer/handler/grpc"
+	transactionGrpc "github.com/hilmiikhsan/multifinance-service/internal/module/transaction/handler/grpc"
+	jwtHandler "github.com/hilmiikhsan/multifinance-service/pkg/jwt_handler"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// NewGRPCServer builds the gRPC API. Every call needs the same access token
+// as the REST routes, so it is built once the driven adapters are connected.
+func NewGRPCServer() *grpc.Server {
+	authMiddleware := middleware.NewAuthMiddleware(jwtHandler.NewJWT(redisRepository.NewRedisRepository(adapter.Adapters.MultifinanceRedis)))
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		middleware.UnaryRequestID,
+		middleware.UnaryError,
+		authMiddleware.UnaryAuth,
+	))
+
+	customerGrpc.NewCustomerHandler().CustomerService(server)
+	creditLimitGrpc.NewCreditLimitHandler().CreditLimitService(server)
+	transactionGrpc.NewTransactionHandler().TransactionService(server)
+
+	// lets grpcurl and similar tools list the services outside production
+	if config.Envs.App.Environtment != constants.EnvProduction {
+		reflection.Register(server)
+	}
+
+	return server
+}
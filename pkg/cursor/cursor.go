@@ -0,0 +1,43 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// A cursor points at one row of a list ordered by (created_at, id). Clients
+// only ever see it as an opaque token and hand it back unchanged.
+
+var ErrInvalid = errors.New("cursor is not valid")
+
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int       `json:"i"`
+	Desc      bool      `json:"d,omitempty"` // sort direction the cursor was issued for
+	Backward  bool      `json:"b,omitempty"` // true when it points at the previous page
+}
+
+func Encode(c Cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func Decode(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+
+	c := new(Cursor)
+	if err := json.Unmarshal(raw, c); err != nil {
+		return nil, ErrInvalid
+	}
+
+	if c.ID < 1 || c.CreatedAt.IsZero() {
+		return nil, ErrInvalid
+	}
+
+	return c, nil
+}
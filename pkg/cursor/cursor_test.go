@@ -0,0 +1,39 @@
+package cursor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	want := Cursor{
+		CreatedAt: time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC),
+		ID:        42,
+		Desc:      true,
+		Backward:  true,
+	}
+
+	got, err := Decode(Encode(want))
+	assert.NoError(t, err)
+	assert.Equal(t, want, *got)
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "Not Base64", token: "not a cursor!"},
+		{name: "Not JSON", token: "bm90IGpzb24"},
+		{name: "Missing ID", token: Encode(Cursor{CreatedAt: time.Now()})},
+		{name: "Missing Created At", token: Encode(Cursor{ID: 1})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.token)
+			assert.ErrorIs(t, err, ErrInvalid)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Package dialect keeps the differences between the databases the
+// repositories run on in one place. Queries are written once with ?
+// placeholders and rebound by sqlx; the few statements that cannot be written
+// for both databases are a Query with one text per dialect.
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// sqlx driver names of the supported databases
+const (
+	MySQL    = "mysql"
+	Postgres = "postgres"
+)
+
+// Drivers lists the supported databases, the repository tests run against
+// each of them.
+var Drivers = []string{MySQL, Postgres}
+
+// Query is a statement that is written differently per dialect.
+type Query struct {
+	MySQL    string
+	Postgres string
+}
+
+// For returns the text of the statement for the driver, still with ?
+// placeholders or named parameters.
+func (q Query) For(driverName string) string {
+	if IsPostgres(driverName) {
+		return q.Postgres
+	}
+
+	return q.MySQL
+}
+
+// IsPostgres tells whether the driver speaks Postgres, pgx included.
+func IsPostgres(driverName string) bool {
+	return sqlx.BindType(driverName) == sqlx.DOLLAR
+}
+
+// Execer is what InsertID runs the statement on, *sqlx.DB and *sql.Tx both
+// are one.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// InsertID runs an already rebound INSERT and returns the id of the new row.
+// MySQL reports it as the last insert id, Postgres has no such thing and
+// returns it from RETURNING id instead.
+func InsertID(ctx context.Context, db Execer, driverName, query string, args ...any) (int64, error) {
+	if !IsPostgres(driverName) {
+		result, err := db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		return result.LastInsertId()
+	}
+
+	var id int64
+	err := db.QueryRowContext(ctx, strings.TrimRight(query, " \t\n;")+" RETURNING id", args...).Scan(&id)
+
+	return id, err
+}
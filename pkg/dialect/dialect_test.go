@@ -0,0 +1,89 @@
+package dialect
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery_For(t *testing.T) {
+	q := Query{MySQL: "ON DUPLICATE KEY UPDATE", Postgres: "ON CONFLICT DO NOTHING"}
+
+	assert.Equal(t, q.MySQL, q.For(MySQL))
+	assert.Equal(t, q.Postgres, q.For(Postgres))
+	assert.Equal(t, q.Postgres, q.For("pgx"))
+}
+
+func TestIsPostgres(t *testing.T) {
+	assert.False(t, IsPostgres(MySQL))
+	assert.True(t, IsPostgres(Postgres))
+	assert.True(t, IsPostgres("pgx"))
+	assert.False(t, IsPostgres("sqlite3"))
+}
+
+func TestInsertID(t *testing.T) {
+	const query = "INSERT INTO partners (code) VALUES (?);\n"
+
+	tests := []struct {
+		name       string
+		driverName string
+		wantID     int64
+		wantErr    bool
+		mockFn     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:       "MySQL Last Insert ID",
+			driverName: MySQL,
+			wantID:     7,
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO partners (code) VALUES (?)")).WithArgs("DEALER01").
+					WillReturnResult(sqlmock.NewResult(7, 1))
+			},
+		},
+		{
+			name:       "MySQL Insert Failed",
+			driverName: MySQL,
+			wantErr:    true,
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO partners").WillReturnError(fmt.Errorf("database error"))
+			},
+		},
+		{
+			name:       "Postgres Returning ID",
+			driverName: Postgres,
+			wantID:     7,
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO partners (code) VALUES ($1) RETURNING id")).WithArgs("DEALER01").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+			},
+		},
+		{
+			name:       "Postgres Insert Failed",
+			driverName: Postgres,
+			wantErr:    true,
+			mockFn: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO partners").WillReturnError(fmt.Errorf("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer sqlDB.Close()
+
+			db := sqlx.NewDb(sqlDB, tt.driverName)
+			tt.mockFn(mock)
+
+			id, err := InsertID(context.Background(), db, db.DriverName(), db.Rebind(query), "DEALER01")
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantID, id)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
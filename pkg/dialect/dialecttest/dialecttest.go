@@ -0,0 +1,136 @@
+// Package dialecttest runs the repository tests against every dialect with
+// sqlmock, so both databases are held to the same test suite.
+package dialecttest
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/hilmiikhsan/multifinance-service/pkg/dialect"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var dollarPlaceholder = regexp.MustCompile(`\$\d+`)
+
+// queryMatcher matches the expectations, written with ? placeholders, against
+// queries sqlx rebound to $n for Postgres.
+var queryMatcher = sqlmock.QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+	return sqlmock.QueryMatcherRegexp.Match(expectedSQL, dollarPlaceholder.ReplaceAllString(actualSQL, "?"))
+})
+
+// Run runs fn once per dialect as a subtest named after the driver, each with
+// its own sqlmock database.
+func Run(t *testing.T, fn func(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock)) {
+	for _, driverName := range dialect.Drivers {
+		t.Run(driverName, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(queryMatcher))
+			if err != nil {
+				t.Fatalf("open sqlmock: %v", err)
+			}
+			defer sqlDB.Close()
+
+			fn(t, sqlx.NewDb(sqlDB, driverName), mock)
+		})
+	}
+}
+
+// ExpectedInsert is an INSERT that dialect.InsertID runs, an exec on MySQL and
+// a query returning the id on Postgres.
+type ExpectedInsert struct {
+	exec  *sqlmock.ExpectedExec
+	query *sqlmock.ExpectedQuery
+}
+
+// ExpectInsert expects the INSERT matching expectedSQL to run through
+// dialect.InsertID on db.
+func ExpectInsert(mock sqlmock.Sqlmock, db *sqlx.DB, expectedSQL string) *ExpectedInsert {
+	if dialect.IsPostgres(db.DriverName()) {
+		return &ExpectedInsert{query: mock.ExpectQuery(expectedSQL)}
+	}
+
+	return &ExpectedInsert{exec: mock.ExpectExec(expectedSQL)}
+}
+
+// WithArgs matches the arguments of the INSERT.
+func (e *ExpectedInsert) WithArgs(args ...driver.Value) *ExpectedInsert {
+	if e.query != nil {
+		e.query.WithArgs(args...)
+	} else {
+		e.exec.WithArgs(args...)
+	}
+
+	return e
+}
+
+// WillReturnID makes the INSERT create a row with the id.
+func (e *ExpectedInsert) WillReturnID(id int64) {
+	if e.query != nil {
+		e.query.WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+		return
+	}
+
+	e.exec.WillReturnResult(sqlmock.NewResult(id, 1))
+}
+
+// WillReturnIDError makes the INSERT run but reading the id of the new row
+// fail with err.
+func (e *ExpectedInsert) WillReturnIDError(err error) {
+	if e.query != nil {
+		e.query.WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0).RowError(0, err))
+		return
+	}
+
+	e.exec.WillReturnResult(sqlmock.NewErrorResult(err))
+}
+
+// WillReturnError makes the INSERT fail with err.
+func (e *ExpectedInsert) WillReturnError(err error) {
+	if e.query != nil {
+		e.query.WillReturnError(err)
+		return
+	}
+
+	e.exec.WillReturnError(err)
+}
+
+// DuplicateKey returns the error the database of db reports when value is
+// already in the named unique key of table. The migrations give a named key
+// the same name on both databases.
+func DuplicateKey(db *sqlx.DB, table, key, value string) error {
+	err := duplicateEntry(db, table, key, key, value)
+	if errPq, ok := err.(*pq.Error); ok {
+		// the detail names the columns of the key, not the key itself
+		errPq.Detail = ""
+	}
+
+	return err
+}
+
+// DuplicateColumn returns the error the database of db reports when value is
+// already in an inline UNIQUE column of table, MySQL names the key after the
+// column and Postgres <table>_<column>_key.
+func DuplicateColumn(db *sqlx.DB, table, column, value string) error {
+	return duplicateEntry(db, table, column, fmt.Sprintf("%s_%s_key", table, column), value)
+}
+
+func duplicateEntry(db *sqlx.DB, table, mysqlKey, constraint, value string) error {
+	if dialect.IsPostgres(db.DriverName()) {
+		return &pq.Error{
+			Code:       pq.ErrorCode("23505"),
+			Message:    fmt.Sprintf(`duplicate key value violates unique constraint "%s"`, constraint),
+			Detail:     fmt.Sprintf("Key (%s)=(%s) already exists.", mysqlKey, value),
+			Table:      table,
+			Constraint: constraint,
+		}
+	}
+
+	return &mysql.MySQLError{
+		Number:  1062,
+		Message: fmt.Sprintf("Duplicate entry '%s' for key '%s.%s'", value, table, mysqlKey),
+	}
+}
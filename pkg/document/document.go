@@ -0,0 +1,345 @@
+package document
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/hilmiikhsan/multifinance-service/pkg/export"
+	"gopkg.in/yaml.v3"
+)
+
+// Contract templates live in templates/contract_<version>.yaml. A contract is
+// always rendered with the version it was first issued with, so a published
+// template is never edited: changes go into a new version.
+
+const (
+	TypeContract = "contract"
+
+	ContentTypePDF = "application/pdf"
+)
+
+//go:embed templates/*.yaml
+var templates embed.FS
+
+var ErrUnknownTemplate = errors.New("document template version does not exist")
+
+type Party struct {
+	Name    string `yaml:"name"`
+	Address string `yaml:"address"`
+}
+
+type Clause struct {
+	Heading string `yaml:"heading"`
+	Body    string `yaml:"body"`
+
+	body *template.Template
+}
+
+type Template struct {
+	Version string   `yaml:"version"`
+	Title   string   `yaml:"title"`
+	Lender  Party    `yaml:"lender"`
+	Clauses []Clause `yaml:"clauses"`
+}
+
+// Customer is the identity of the debtor as printed on the contract.
+type Customer struct {
+	FullName    string
+	LegalName   string
+	Nik         string
+	BirthPlace  string
+	BirthDate   time.Time
+	Province    string
+	Email       string
+	PhoneNumber string
+}
+
+type Installment struct {
+	Number    int
+	DueDate   time.Time
+	Principal float64
+	Interest  float64
+	Amount    float64
+	Balance   float64
+}
+
+type ContractData struct {
+	ContractNumber    string
+	IssuedAt          time.Time
+	Customer          Customer
+	AssetName         string
+	OnTheRoadPrice    float64
+	AdminFee          float64
+	InterestAmount    float64
+	InstallmentAmount float64
+	TenorMonth        int
+	Schedule          []Installment
+}
+
+func (d *ContractData) FirstDueDate() time.Time {
+	if len(d.Schedule) == 0 {
+		return d.IssuedAt
+	}
+
+	return d.Schedule[0].DueDate
+}
+
+// BuildSchedule spreads the on the road price and the flat interest evenly
+// over the tenor, one month apart starting a month after issuedAt. The last
+// installment absorbs the rounding so the schedule adds up to the total payable.
+func BuildSchedule(issuedAt time.Time, onTheRoadPrice, interestAmount, installmentAmount float64, tenorMonth int) []Installment {
+	if tenorMonth < 1 {
+		return nil
+	}
+
+	var (
+		res       = make([]Installment, 0, tenorMonth)
+		principal = math.Floor(onTheRoadPrice / float64(tenorMonth))
+		interest  = math.Floor(interestAmount / float64(tenorMonth))
+		balance   = onTheRoadPrice + interestAmount
+	)
+
+	for i := 1; i <= tenorMonth; i++ {
+		item := Installment{
+			Number:    i,
+			DueDate:   issuedAt.AddDate(0, i, 0),
+			Principal: principal,
+			Interest:  interest,
+			Amount:    installmentAmount,
+		}
+
+		if i == tenorMonth {
+			item.Principal = onTheRoadPrice - principal*float64(tenorMonth-1)
+			item.Interest = interestAmount - interest*float64(tenorMonth-1)
+			item.Amount = balance
+		}
+
+		balance -= item.Amount
+		item.Balance = balance
+		res = append(res, item)
+	}
+
+	return res
+}
+
+// NextDue finds the first installment of the schedule that is not fully paid
+// and what is left of it. The admin fee is charged at booking and falls due
+// with the first installment; payments settle the oldest charge first.
+func NextDue(schedule []Installment, adminFee, paidAmount float64) (Installment, float64, bool) {
+	var charged float64
+	for i, installment := range schedule {
+		charged += installment.Amount
+		if i == 0 {
+			charged += adminFee
+		}
+
+		if charged > paidAmount {
+			return installment, charged - paidAmount, true
+		}
+	}
+
+	return Installment{}, 0, false
+}
+
+// Payment is an amount paid towards a contract.
+type Payment struct {
+	Amount float64
+	PaidAt time.Time
+}
+
+// LateInstallments counts the installments of the schedule whose due date has
+// passed without the payments made by the end of that day covering them,
+// whether they were paid late or are still outstanding. Payments must be
+// ordered by PaidAt and settle the oldest charge first, as in NextDue.
+func LateInstallments(schedule []Installment, adminFee float64, payments []Payment, now time.Time) int {
+	var (
+		charged, paid float64
+		next, late    int
+	)
+
+	for i, installment := range schedule {
+		dueBy := installment.DueDate.AddDate(0, 0, 1)
+		if now.Before(dueBy) {
+			break
+		}
+
+		charged += installment.Amount
+		if i == 0 {
+			charged += adminFee
+		}
+
+		for next < len(payments) && payments[next].PaidAt.Before(dueBy) {
+			paid += payments[next].Amount
+			next++
+		}
+
+		if paid < charged {
+			late++
+		}
+	}
+
+	return late
+}
+
+var (
+	locale = export.LookupLocale(export.LocaleID)
+
+	templateFuncs = template.FuncMap{
+		"rupiah": formatRupiah,
+		"date":   formatDate,
+	}
+
+	months = [...]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+)
+
+func formatRupiah(v float64) string {
+	return "Rp " + locale.FormatAmount(v)
+}
+
+func formatDate(t time.Time) string {
+	t = t.In(locale.Location)
+	return fmt.Sprintf("%d %s %d", t.Day(), months[t.Month()-1], t.Year())
+}
+
+func LoadContractTemplate(version string) (*Template, error) {
+	data, err := templates.ReadFile(fmt.Sprintf("templates/%s_%s.yaml", TypeContract, version))
+	if err != nil {
+		return nil, ErrUnknownTemplate
+	}
+
+	res := new(Template)
+	if err := yaml.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+
+	if res.Version != version {
+		return nil, fmt.Errorf("contract template %s declares version %q", version, res.Version)
+	}
+
+	for i := range res.Clauses {
+		res.Clauses[i].body, err = template.New(res.Clauses[i].Heading).Funcs(templateFuncs).Option("missingkey=error").Parse(res.Clauses[i].Body)
+		if err != nil {
+			return nil, fmt.Errorf("contract template %s clause %q: %w", version, res.Clauses[i].Heading, err)
+		}
+	}
+
+	return res, nil
+}
+
+// Render writes the contract as a PDF. The output only depends on data and
+// the template, so a lost file can be regenerated byte for byte.
+func (t *Template) Render(w io.Writer, data *ContractData) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.SetCreationDate(data.IssuedAt)
+	pdf.SetModificationDate(data.IssuedAt)
+	pdf.SetCatalogSort(true)
+	pdf.SetTitle(fmt.Sprintf("%s %s", t.Title, data.ContractNumber), true)
+	pdf.SetAuthor(t.Lender.Name, true)
+	pdf.AliasNbPages("")
+
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 10, tr(fmt.Sprintf("%s - template %s - halaman %d/{nb}", data.ContractNumber, t.Version, pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 8, tr(t.Title), "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, tr("Nomor: "+data.ContractNumber), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 6, tr("Tanggal: "+formatDate(data.IssuedAt)), "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+
+	section := func(title string) {
+		pdf.Ln(2)
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(0, 7, tr(title), "", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+	}
+
+	row := func(label, value string) {
+		pdf.CellFormat(50, 6, tr(label), "", 0, "L", false, 0, "")
+		pdf.MultiCell(0, 6, tr(": "+value), "", "L", false)
+	}
+
+	section("Kreditur")
+	row("Nama", t.Lender.Name)
+	row("Alamat", t.Lender.Address)
+
+	section("Debitur")
+	row("Nama Lengkap", data.Customer.FullName)
+	row("Nama Sesuai KTP", data.Customer.LegalName)
+	row("NIK", data.Customer.Nik)
+	row("Tempat, Tanggal Lahir", fmt.Sprintf("%s, %s", data.Customer.BirthPlace, formatDate(data.Customer.BirthDate)))
+	if data.Customer.Province != "" {
+		row("Provinsi", data.Customer.Province)
+	}
+	row("Email", data.Customer.Email)
+	if data.Customer.PhoneNumber != "" {
+		row("Nomor Telepon", data.Customer.PhoneNumber)
+	}
+
+	section("Rincian Pembiayaan")
+	row("Objek Pembiayaan", data.AssetName)
+	row("Harga On The Road", formatRupiah(data.OnTheRoadPrice))
+	row("Biaya Administrasi", formatRupiah(data.AdminFee))
+	row("Bunga", formatRupiah(data.InterestAmount))
+	row("Jangka Waktu", fmt.Sprintf("%d bulan", data.TenorMonth))
+	row("Angsuran per Bulan", formatRupiah(data.InstallmentAmount))
+
+	section("Jadwal Angsuran")
+	widths := []float64{12, 38, 30, 30, 30, 30}
+	pdf.SetFont("Helvetica", "B", 9)
+	for i, header := range []string{"No", "Jatuh Tempo", "Pokok", "Bunga", "Angsuran", "Sisa"} {
+		pdf.CellFormat(widths[i], 6, tr(header), "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, item := range data.Schedule {
+		pdf.CellFormat(widths[0], 6, fmt.Sprint(item.Number), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[1], 6, tr(formatDate(item.DueDate)), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], 6, locale.FormatAmount(item.Principal), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], 6, locale.FormatAmount(item.Interest), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], 6, locale.FormatAmount(item.Amount), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[5], 6, locale.FormatAmount(item.Balance), "1", 0, "R", false, 0, "")
+		pdf.Ln(-1)
+	}
+
+	section("Ketentuan")
+	for _, clause := range t.Clauses {
+		var body bytes.Buffer
+		if err := clause.body.Execute(&body, data); err != nil {
+			return fmt.Errorf("render clause %q: %w", clause.Heading, err)
+		}
+
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.MultiCell(0, 6, tr(clause.Heading), "", "L", false)
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.MultiCell(0, 5, tr(strings.TrimSpace(body.String())), "", "J", false)
+		pdf.Ln(2)
+	}
+
+	pdf.Ln(8)
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(85, 6, "Kreditur,", "", 0, "C", false, 0, "")
+	pdf.CellFormat(85, 6, "Debitur,", "", 1, "C", false, 0, "")
+	pdf.Ln(20)
+	pdf.CellFormat(85, 6, tr(t.Lender.Name), "", 0, "C", false, 0, "")
+	pdf.CellFormat(85, 6, tr(data.Customer.LegalName), "", 1, "C", false, 0, "")
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,155 @@
+package document
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSchedule(t *testing.T) {
+	issuedAt := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	// 1,000,000 over 3 months with 30,000 interest does not split evenly
+	got := BuildSchedule(issuedAt, 1000000, 30000, 343333, 3)
+
+	assert.Len(t, got, 3)
+	assert.Equal(t, time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC), got[0].DueDate)
+
+	var principal, interest, amount float64
+	for _, item := range got {
+		principal += item.Principal
+		interest += item.Interest
+		amount += item.Amount
+	}
+	assert.Equal(t, float64(1000000), principal)
+	assert.Equal(t, float64(30000), interest)
+	assert.Equal(t, float64(1030000), amount)
+	assert.Equal(t, float64(343334), got[2].Amount)
+	assert.Equal(t, float64(0), got[2].Balance)
+
+	assert.Nil(t, BuildSchedule(issuedAt, 1000000, 0, 0, 0))
+}
+
+func TestLateInstallments(t *testing.T) {
+	// due on 10 February, 10 March and 10 April, the admin fee with the first
+	schedule := BuildSchedule(time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC), 300000, 0, 100000, 3)
+	paidAt := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		payments []Payment
+		now      time.Time
+		want     int
+	}{
+		{
+			name:     "Paid On Time",
+			payments: []Payment{{Amount: 105000, PaidAt: paidAt(time.February, 10, 15)}, {Amount: 100000, PaidAt: paidAt(time.March, 9, 8)}},
+			now:      paidAt(time.April, 5, 0),
+			want:     0,
+		},
+		{
+			name:     "Paid After The Due Date",
+			payments: []Payment{{Amount: 105000, PaidAt: paidAt(time.February, 12, 8)}, {Amount: 100000, PaidAt: paidAt(time.March, 10, 8)}},
+			now:      paidAt(time.April, 5, 0),
+			want:     1,
+		},
+		{
+			name:     "Outstanding Installments",
+			payments: []Payment{{Amount: 105000, PaidAt: paidAt(time.February, 10, 8)}},
+			now:      paidAt(time.April, 20, 0),
+			want:     2,
+		},
+		{
+			name:     "Admin Fee Left Unpaid",
+			payments: []Payment{{Amount: 100000, PaidAt: paidAt(time.February, 1, 8)}},
+			now:      paidAt(time.February, 20, 0),
+			want:     1,
+		},
+		{
+			name: "Due Day Not Over",
+			now:  paidAt(time.February, 10, 12),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LateInstallments(schedule, 5000, tt.payments, tt.now))
+		})
+	}
+}
+
+func TestLoadContractTemplate(t *testing.T) {
+	tmpl, err := LoadContractTemplate("v1")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", tmpl.Version)
+	assert.NotEmpty(t, tmpl.Clauses)
+
+	_, err = LoadContractTemplate("v0")
+	assert.ErrorIs(t, err, ErrUnknownTemplate)
+}
+
+func TestTemplate_Render(t *testing.T) {
+	tmpl, err := LoadContractTemplate("v1")
+	assert.NoError(t, err)
+
+	issuedAt := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+	data := &ContractData{
+		ContractNumber: "TRX202412010001",
+		IssuedAt:       issuedAt,
+		Customer: Customer{
+			FullName:   "Budi Santoso",
+			LegalName:  "BUDI SANTOSO",
+			Nik:        "3174010101900001",
+			BirthPlace: "Jakarta",
+			BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+			Email:      "budi@example.com",
+		},
+		AssetName:         "Yamaha NMAX",
+		OnTheRoadPrice:    30000000,
+		AdminFee:          600000,
+		InterestAmount:    3600000,
+		InstallmentAmount: 2800000,
+		TenorMonth:        12,
+		Schedule:          BuildSchedule(issuedAt, 30000000, 3600000, 2800000, 12),
+	}
+
+	var first, second bytes.Buffer
+	assert.NoError(t, tmpl.Render(&first, data))
+	assert.NoError(t, tmpl.Render(&second, data))
+
+	assert.True(t, bytes.HasPrefix(first.Bytes(), []byte("%PDF-")))
+	assert.Equal(t, first.Bytes(), second.Bytes(), "rendering the same contract twice must give the same file")
+}
+
+func TestRenderStatement(t *testing.T) {
+	data := &StatementData{
+		Period:   time.Date(2024, 12, 1, 0, 0, 0, 0, locale.Location),
+		IssuedAt: time.Date(2025, 1, 1, 0, 0, 5, 0, time.UTC),
+		Customer: Customer{
+			FullName: "Budi Santoso",
+			Nik:      "3174010101900001",
+			Email:    "budi@example.com",
+		},
+		OpeningBalance: 28000000,
+		NewBookings:    33600000,
+		Payments:       2800000,
+		Fees:           600000,
+		ClosingBalance: 59400000,
+		Lines: []StatementLine{
+			{LineType: constants.StatementLineBooking, ContractNumber: "TRX202412020001", Amount: 33600000, OccurredAt: time.Date(2024, 12, 2, 3, 0, 0, 0, time.UTC)},
+			{LineType: constants.StatementLinePayment, ContractNumber: "TRX202410010001", Amount: 2800000, OccurredAt: time.Date(2024, 12, 20, 3, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	var first, second bytes.Buffer
+	assert.NoError(t, RenderStatement(&first, data))
+	assert.NoError(t, RenderStatement(&second, data))
+
+	assert.True(t, bytes.HasPrefix(first.Bytes(), []byte("%PDF-")))
+	assert.Equal(t, first.Bytes(), second.Bytes(), "rendering the same statement twice must give the same file")
+}
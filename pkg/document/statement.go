@@ -0,0 +1,128 @@
+package document
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+)
+
+const TypeStatement = "statement"
+
+var statementLineLabels = map[string]string{
+	constants.StatementLineBooking: "Pembiayaan baru",
+	constants.StatementLineFee:     "Biaya administrasi",
+	constants.StatementLinePayment: "Pembayaran",
+}
+
+type StatementLine struct {
+	LineType       string
+	ContractNumber string
+	Amount         float64
+	OccurredAt     time.Time
+}
+
+// StatementData is an issued statement. Period is the first day of the month
+// it covers.
+type StatementData struct {
+	Period         time.Time
+	IssuedAt       time.Time
+	Customer       Customer
+	OpeningBalance float64
+	NewBookings    float64
+	Payments       float64
+	Fees           float64
+	ClosingBalance float64
+	Lines          []StatementLine
+}
+
+// RenderStatement writes the statement as a PDF. Like a contract, the output
+// only depends on data, so the same statement always gives the same file.
+func RenderStatement(w io.Writer, data *StatementData) error {
+	var (
+		period = fmt.Sprintf("%s %d", months[data.Period.Month()-1], data.Period.Year())
+		pdf    = fpdf.New("P", "mm", "A4", "")
+	)
+
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.SetCreationDate(data.IssuedAt)
+	pdf.SetModificationDate(data.IssuedAt)
+	pdf.SetCatalogSort(true)
+	pdf.SetTitle("Laporan Bulanan "+period, true)
+	pdf.AliasNbPages("")
+
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 10, tr(fmt.Sprintf("Laporan %s - halaman %d/{nb}", period, pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 8, "LAPORAN BULANAN PEMBIAYAAN", "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, tr("Periode: "+period), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 6, tr("Tanggal Terbit: "+formatDate(data.IssuedAt)), "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+
+	row := func(label, value string) {
+		pdf.CellFormat(50, 6, tr(label), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, tr(": "+value), "", 1, "L", false, 0, "")
+	}
+
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(0, 7, "Debitur", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	row("Nama", data.Customer.FullName)
+	row("NIK", data.Customer.Nik)
+	row("Email", data.Customer.Email)
+	pdf.Ln(2)
+
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(0, 7, "Ringkasan", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	row("Saldo Awal", formatRupiah(data.OpeningBalance))
+	row("Pembiayaan Baru", formatRupiah(data.NewBookings))
+	row("Biaya", formatRupiah(data.Fees))
+	row("Pembayaran", formatRupiah(data.Payments))
+	pdf.SetFont("Helvetica", "B", 10)
+	row("Saldo Akhir", formatRupiah(data.ClosingBalance))
+	pdf.Ln(2)
+
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(0, 7, "Rincian Transaksi", "", 1, "L", false, 0, "")
+
+	if len(data.Lines) == 0 {
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(0, 6, "Tidak ada transaksi pada periode ini.", "", 1, "L", false, 0, "")
+		return pdf.Output(w)
+	}
+
+	widths := []float64{38, 50, 42, 40}
+	pdf.SetFont("Helvetica", "B", 9)
+	for i, header := range []string{"Tanggal", "Keterangan", "No. Kontrak", "Jumlah"} {
+		pdf.CellFormat(widths[i], 6, header, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, line := range data.Lines {
+		amount := line.Amount
+		if line.LineType == constants.StatementLinePayment {
+			amount = -amount
+		}
+
+		pdf.CellFormat(widths[0], 6, tr(formatDate(line.OccurredAt)), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], 6, tr(statementLineLabels[line.LineType]), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], 6, tr(line.ContractNumber), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[3], 6, locale.FormatAmount(amount), "1", 0, "R", false, 0, "")
+		pdf.Ln(-1)
+	}
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,116 @@
+package eligibility
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/hilmiikhsan/multifinance-service/pkg/err_msg"
+)
+
+const (
+	ReasonMinAge           = "min_age"
+	ReasonMaxAgeAtTenorEnd = "max_age_at_tenor_end"
+)
+
+// Applicant is the data every rule is evaluated against. TenorMonth is zero
+// at registration, when no financing has been requested yet.
+type Applicant struct {
+	BirthDate  time.Time
+	TenorMonth int
+	AppliedAt  time.Time
+}
+
+type Reason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type Rule interface {
+	Evaluate(applicant Applicant) *Reason
+}
+
+type Engine struct {
+	rules []Rule
+}
+
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{
+		rules: rules,
+	}
+}
+
+// Evaluate runs every rule and returns the reasons of the ones that failed.
+func (e *Engine) Evaluate(applicant Applicant) []Reason {
+	var reasons []Reason
+
+	for _, rule := range e.rules {
+		if reason := rule.Evaluate(applicant); reason != nil {
+			reasons = append(reasons, *reason)
+		}
+	}
+
+	return reasons
+}
+
+// NewRejectionError wraps the failed rules into the error envelope, keyed by reason code.
+func NewRejectionError(reasons []Reason) *err_msg.CustomError {
+	err := err_msg.NewCustomErrors(fiber.StatusUnprocessableEntity, err_msg.WithMessage(constants.ErrCustomerNotEligible))
+	for _, reason := range reasons {
+		err.Add(reason.Code, reason.Message)
+	}
+
+	return err
+}
+
+type minAgeRule struct {
+	minAge int
+}
+
+// MinAgeAtApplication rejects applicants younger than minAge on the application date.
+func MinAgeAtApplication(minAge int) Rule {
+	return &minAgeRule{minAge: minAge}
+}
+
+func (r *minAgeRule) Evaluate(applicant Applicant) *Reason {
+	if AgeAt(applicant.BirthDate, applicant.AppliedAt) >= r.minAge {
+		return nil
+	}
+
+	return &Reason{
+		Code:    ReasonMinAge,
+		Message: fmt.Sprintf("Applicant must be at least %d years old.", r.minAge),
+	}
+}
+
+type maxAgeAtTenorEndRule struct {
+	maxAge int
+}
+
+// MaxAgeAtTenorEnd rejects applicants older than maxAge when the final installment falls due.
+func MaxAgeAtTenorEnd(maxAge int) Rule {
+	return &maxAgeAtTenorEndRule{maxAge: maxAge}
+}
+
+func (r *maxAgeAtTenorEndRule) Evaluate(applicant Applicant) *Reason {
+	tenorEnd := applicant.AppliedAt.AddDate(0, applicant.TenorMonth, 0)
+	if AgeAt(applicant.BirthDate, tenorEnd) <= r.maxAge {
+		return nil
+	}
+
+	return &Reason{
+		Code:    ReasonMaxAgeAtTenorEnd,
+		Message: fmt.Sprintf("Applicant must not be older than %d years when the final installment is due.", r.maxAge),
+	}
+}
+
+// AgeAt returns the age in completed years on the given date.
+func AgeAt(birthDate, at time.Time) int {
+	age := at.Year() - birthDate.Year()
+	if at.Month() < birthDate.Month() || (at.Month() == birthDate.Month() && at.Day() < birthDate.Day()) {
+		age--
+	}
+
+	return age
+}
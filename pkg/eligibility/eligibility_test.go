@@ -0,0 +1,83 @@
+package eligibility
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngine_Evaluate(t *testing.T) {
+	engine := NewEngine(
+		MinAgeAtApplication(21),
+		MaxAgeAtTenorEnd(60),
+	)
+
+	appliedAt := time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		applicant Applicant
+		want      []string
+	}{
+		{
+			name: "Eligible applicant",
+			applicant: Applicant{
+				BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+				TenorMonth: 6,
+				AppliedAt:  appliedAt,
+			},
+		},
+		{
+			name: "Turns 21 on the application date",
+			applicant: Applicant{
+				BirthDate: time.Date(2003, 12, 20, 0, 0, 0, 0, time.UTC),
+				AppliedAt: appliedAt,
+			},
+		},
+		{
+			name: "Too young at application",
+			applicant: Applicant{
+				BirthDate: time.Date(2003, 12, 21, 0, 0, 0, 0, time.UTC),
+				AppliedAt: appliedAt,
+			},
+			want: []string{ReasonMinAge},
+		},
+		{
+			name: "Too old when the final installment is due",
+			applicant: Applicant{
+				BirthDate:  time.Date(1964, 3, 1, 0, 0, 0, 0, time.UTC),
+				TenorMonth: 6,
+				AppliedAt:  appliedAt,
+			},
+			want: []string{ReasonMaxAgeAtTenorEnd},
+		},
+		{
+			name: "Old enough at application only without tenor",
+			applicant: Applicant{
+				BirthDate: time.Date(1964, 3, 1, 0, 0, 0, 0, time.UTC),
+				AppliedAt: appliedAt,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, reason := range engine.Evaluate(tt.applicant) {
+				got = append(got, reason.Code)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewRejectionError(t *testing.T) {
+	err := NewRejectionError([]Reason{
+		{Code: ReasonMinAge, Message: "Applicant must be at least 21 years old."},
+	})
+
+	assert.Equal(t, 422, err.Code)
+	assert.Equal(t, []string{"Applicant must be at least 21 years old."}, err.Errors[ReasonMinAge])
+}
@@ -0,0 +1,102 @@
+package err_msg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/lib/pq"
+)
+
+// NewDatabaseErrors is the error a repository returns for a failed query.
+// MySQL and Postgres errors get the same codes and field errors, anything
+// else is an internal server error.
+func NewDatabaseErrors(err error) *CustomError {
+	var errMysql *mysql.MySQLError
+	if errors.As(err, &errMysql) {
+		return errorMysqlHandler(errMysql)
+	}
+
+	var errPq *pq.Error
+	if errors.As(err, &errPq) {
+		return errorPqHandler(errPq)
+	}
+
+	return NewCustomErrors(fiber.StatusInternalServerError, WithMessage(constants.ErrInternalServerError))
+}
+
+// IsDuplicateEntry tells whether err is a unique key violation on either
+// database.
+func IsDuplicateEntry(err error) bool {
+	return IsMysqlError(err, MysqlErrDuplicateEntry) || IsPostgresError(err, PostgresErrUniqueViolation)
+}
+
+// DuplicateKey returns the unique key a duplicate entry error is about. Keys
+// carry the same name on both databases, see the Postgres migrations.
+func DuplicateKey(err error) (string, bool) {
+	var errMysql *mysql.MySQLError
+	if errors.As(err, &errMysql) && errMysql.Number == MysqlErrDuplicateEntry {
+		return mysqlDuplicateKeyName(errMysql), true
+	}
+
+	var errPq *pq.Error
+	if errors.As(err, &errPq) && errPq.Code == PostgresErrUniqueViolation {
+		return postgresDuplicateKeyName(errPq), true
+	}
+
+	return "", false
+}
+
+// HasCode tells whether err is a CustomError answered with code.
+func HasCode(err error, code int) bool {
+	var customErr *CustomError
+	return errors.As(err, &customErr) && customErr.Code == code
+}
+
+func duplicateEntryError(key string) *CustomError {
+	customErr := NewCustomErrors(fiber.StatusConflict, WithMessage(constants.ErrDuplicateEntry))
+	if key != "" {
+		msg := fmt.Sprintf("%s already exists.", strings.ReplaceAll(key, "_", " "))
+		if key == "email" {
+			msg = "email already registered."
+		}
+		customErr.Add(key, msg)
+	}
+	return customErr
+}
+
+func rowIsReferencedError(column string) *CustomError {
+	customErr := NewCustomErrors(fiber.StatusConflict, WithMessage(constants.ErrRowIsReferenced))
+	if column != "" {
+		customErr.Add(column, fmt.Sprintf("%s is still in use.", strings.ReplaceAll(column, "_", " ")))
+	}
+	return customErr
+}
+
+func noReferencedRowError(column string) *CustomError {
+	customErr := NewCustomErrors(fiber.StatusUnprocessableEntity, WithMessage(constants.ErrReferencedRowMissing))
+	if column != "" {
+		customErr.Add(column, fmt.Sprintf("invalid %s.", strings.ReplaceAll(column, "_", " ")))
+	}
+	return customErr
+}
+
+func dataTooLongError(column string) *CustomError {
+	customErr := NewCustomErrors(fiber.StatusUnprocessableEntity, WithMessage(constants.ErrDataTooLong))
+	if column != "" {
+		customErr.Add(column, fmt.Sprintf("%s is too long.", strings.ReplaceAll(column, "_", " ")))
+	}
+	return customErr
+}
+
+// the transaction was rolled back, the same request can be sent again
+func concurrentUpdateError() *CustomError {
+	return NewCustomErrors(fiber.StatusConflict, WithMessage(constants.ErrConcurrentUpdate))
+}
+
+func lockWaitTimeoutError() *CustomError {
+	return NewCustomErrors(fiber.StatusServiceUnavailable, WithMessage(constants.ErrLockWaitTimeout))
+}
@@ -0,0 +1,91 @@
+package err_msg
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var grpcCodes = map[int]codes.Code{
+	400: codes.InvalidArgument,
+	401: codes.Unauthenticated,
+	403: codes.PermissionDenied,
+	404: codes.NotFound,
+	409: codes.AlreadyExists,
+	422: codes.FailedPrecondition,
+	429: codes.ResourceExhausted,
+	503: codes.Unavailable,
+}
+
+// GRPCStatus is the gRPC counterpart of Errors. The HTTP code an error would
+// get over REST picks the gRPC code, and field errors are sent as BadRequest
+// details. Unknown errors become Internal without their message.
+func GRPCStatus(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	var (
+		httpCode  = 500
+		msg       = "Your request has been failed to process"
+		fields    map[string][]string
+		customErr *CustomError
+		pqErr     *pq.Error
+		mysqlErr  *mysql.MySQLError
+		validErr  validator.ValidationErrors
+	)
+
+	switch {
+	case errors.As(err, &customErr):
+		httpCode, fields, msg = customErr.Code, customErr.Errors, customErr.Msg
+	case errors.As(err, &validErr):
+		httpCode, fields = errorValidationHandler[struct{}](validErr, nil)
+	case errors.As(err, &pqErr):
+		customErr = errorPqHandler(pqErr)
+		httpCode, fields, msg = customErr.Code, customErr.Errors, customErr.Msg
+	case errors.As(err, &mysqlErr):
+		customErr = errorMysqlHandler(mysqlErr)
+		httpCode, fields, msg = customErr.Code, customErr.Errors, customErr.Msg
+	}
+
+	code, ok := grpcCodes[httpCode]
+	if !ok {
+		code = codes.Internal
+		if httpCode < 500 {
+			code = codes.InvalidArgument
+		}
+	}
+
+	st := status.New(code, msg)
+	if len(fields) == 0 {
+		return st
+	}
+
+	keys := make([]string, 0, len(fields))
+	for field := range fields {
+		keys = append(keys, field)
+	}
+	sort.Strings(keys)
+
+	badRequest := new(errdetails.BadRequest)
+	for _, field := range keys {
+		for _, description := range fields[field] {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: description,
+			})
+		}
+	}
+
+	if withDetails, err := st.WithDetails(badRequest); err == nil {
+		return withDetails
+	}
+
+	return st
+}
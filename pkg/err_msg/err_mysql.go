@@ -0,0 +1,85 @@
+package err_msg
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+)
+
+// MySQL server error numbers, see
+// https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+const (
+	MysqlErrDuplicateEntry  uint16 = 1062
+	MysqlErrLockWaitTimeout uint16 = 1205
+	MysqlErrDeadlock        uint16 = 1213
+	MysqlErrDataTooLong     uint16 = 1406
+	MysqlErrRowIsReferenced uint16 = 1451
+	MysqlErrNoReferencedRow uint16 = 1452
+
+	// the same foreign key errors from servers that leave the details out
+	mysqlErrNoReferencedRowNoDetail uint16 = 1216
+	mysqlErrRowIsReferencedNoDetail uint16 = 1217
+)
+
+var (
+	// Duplicate entry '3171011501900001' for key 'customers.nik'
+	mysqlDuplicateKey = regexp.MustCompile(`for key '(?:[^'.]+\.)?([^']+)'`)
+	// ... CONSTRAINT `fk` FOREIGN KEY (`customer_id`) REFERENCES ...
+	mysqlForeignKey = regexp.MustCompile("FOREIGN KEY \\(`([^`]+)`")
+	// Data too long for column 'full_name' at row 1
+	mysqlColumn = regexp.MustCompile(`column '([^']+)'`)
+)
+
+// IsMysqlError tells whether err, or an error it wraps, is a MySQL error with
+// one of the numbers.
+func IsMysqlError(err error, numbers ...uint16) bool {
+	var errMysql *mysql.MySQLError
+	if !errors.As(err, &errMysql) {
+		return false
+	}
+
+	for _, number := range numbers {
+		if errMysql.Number == number {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mysqlDuplicateKeyName returns the unique key of a duplicate entry error,
+// without the table name MySQL 8 puts in front of it.
+func mysqlDuplicateKeyName(errMysql *mysql.MySQLError) string {
+	return mysqlMatch(mysqlDuplicateKey, errMysql)
+}
+
+func mysqlMatch(re *regexp.Regexp, errMysql *mysql.MySQLError) string {
+	match := re.FindStringSubmatch(errMysql.Message)
+	if len(match) < 2 {
+		return ""
+	}
+
+	return match[1]
+}
+
+func errorMysqlHandler(errMysql *mysql.MySQLError) *CustomError {
+	switch errMysql.Number {
+	case MysqlErrDuplicateEntry:
+		return duplicateEntryError(mysqlDuplicateKeyName(errMysql))
+	case MysqlErrRowIsReferenced, mysqlErrRowIsReferencedNoDetail:
+		return rowIsReferencedError(mysqlMatch(mysqlForeignKey, errMysql))
+	case MysqlErrNoReferencedRow, mysqlErrNoReferencedRowNoDetail:
+		return noReferencedRowError(mysqlMatch(mysqlForeignKey, errMysql))
+	case MysqlErrDataTooLong:
+		return dataTooLongError(mysqlMatch(mysqlColumn, errMysql))
+	case MysqlErrDeadlock:
+		return concurrentUpdateError()
+	case MysqlErrLockWaitTimeout:
+		return lockWaitTimeoutError()
+	}
+
+	return NewCustomErrors(fiber.StatusInternalServerError, WithMessage(constants.ErrInternalServerError))
+}
@@ -0,0 +1,136 @@
+package err_msg
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestNewDatabaseErrors_Mysql(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   int
+		wantMsg    string
+		wantErrors map[string][]string
+	}{
+		{
+			name:       "Duplicate Entry",
+			err:        &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '3171011501900001' for key 'customers.nik'"},
+			wantCode:   fiber.StatusConflict,
+			wantMsg:    constants.ErrDuplicateEntry,
+			wantErrors: map[string][]string{"nik": {"nik already exists."}},
+		},
+		{
+			name:       "Duplicate Email Without Table Name",
+			err:        &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'jane@example.com' for key 'email'"},
+			wantCode:   fiber.StatusConflict,
+			wantMsg:    constants.ErrDuplicateEntry,
+			wantErrors: map[string][]string{"email": {"email already registered."}},
+		},
+		{
+			name:       "Row Is Referenced",
+			err:        &mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row: a foreign key constraint fails (`multifinance`.`transactions`, CONSTRAINT `fk_transactions_customer` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`id`))"},
+			wantCode:   fiber.StatusConflict,
+			wantMsg:    constants.ErrRowIsReferenced,
+			wantErrors: map[string][]string{"customer_id": {"customer id is still in use."}},
+		},
+		{
+			name:       "No Referenced Row",
+			err:        &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails (`multifinance`.`transactions`, CONSTRAINT `fk_transactions_customer` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`id`))"},
+			wantCode:   fiber.StatusUnprocessableEntity,
+			wantMsg:    constants.ErrReferencedRowMissing,
+			wantErrors: map[string][]string{"customer_id": {"invalid customer id."}},
+		},
+		{
+			name:       "Data Too Long",
+			err:        &mysql.MySQLError{Number: 1406, Message: "Data too long for column 'full_name' at row 1"},
+			wantCode:   fiber.StatusUnprocessableEntity,
+			wantMsg:    constants.ErrDataTooLong,
+			wantErrors: map[string][]string{"full_name": {"full name is too long."}},
+		},
+		{
+			name:       "Deadlock",
+			err:        fmt.Errorf("commit: %w", &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock; try restarting transaction"}),
+			wantCode:   fiber.StatusConflict,
+			wantMsg:    constants.ErrConcurrentUpdate,
+			wantErrors: map[string][]string{},
+		},
+		{
+			name:       "Lock Wait Timeout",
+			err:        &mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded; try restarting transaction"},
+			wantCode:   fiber.StatusServiceUnavailable,
+			wantMsg:    constants.ErrLockWaitTimeout,
+			wantErrors: map[string][]string{},
+		},
+		{
+			name:       "Other MySQL Error",
+			err:        &mysql.MySQLError{Number: 1146, Message: "Table 'multifinance.missing' doesn't exist"},
+			wantCode:   fiber.StatusInternalServerError,
+			wantMsg:    constants.ErrInternalServerError,
+			wantErrors: map[string][]string{},
+		},
+		{
+			name:       "Not A MySQL Error",
+			err:        errors.New("connection refused"),
+			wantCode:   fiber.StatusInternalServerError,
+			wantMsg:    constants.ErrInternalServerError,
+			wantErrors: map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewDatabaseErrors(tt.err)
+			assert.Equal(t, tt.wantCode, got.Code)
+			assert.Equal(t, tt.wantMsg, got.Msg)
+			assert.Equal(t, tt.wantErrors, got.Errors)
+		})
+	}
+}
+
+func TestErrors_Mysql(t *testing.T) {
+	err := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'DEALER01' for key 'partners.uq_partners_code'"}
+
+	code, errs := Errors[error](err)
+	assert.Equal(t, fiber.StatusConflict, code)
+	if customErr, ok := errs.(*CustomError); assert.True(t, ok) {
+		assert.Equal(t, []string{"uq partners code already exists."}, customErr.Errors["uq_partners_code"])
+	}
+
+	assert.Equal(t, codes.AlreadyExists, GRPCStatus(err).Code())
+}
+
+func TestIsMysqlError(t *testing.T) {
+	deadlock := fmt.Errorf("insert: %w", &mysql.MySQLError{Number: MysqlErrDeadlock})
+
+	assert.True(t, IsMysqlError(deadlock, MysqlErrLockWaitTimeout, MysqlErrDeadlock))
+	assert.False(t, IsMysqlError(deadlock, MysqlErrDuplicateEntry))
+	assert.False(t, IsMysqlError(errors.New("deadlock"), MysqlErrDeadlock))
+
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'x' for key 'customers.email'"}
+	assert.True(t, IsDuplicateEntry(duplicate))
+	assert.False(t, IsDuplicateEntry(deadlock))
+
+	key, ok := DuplicateKey(duplicate)
+	assert.True(t, ok)
+	assert.Equal(t, "email", key)
+
+	_, ok = DuplicateKey(deadlock)
+	assert.False(t, ok)
+}
+
+func TestHasCode(t *testing.T) {
+	notFound := NewCustomErrors(fiber.StatusNotFound, WithMessage(constants.ErrUserNotFound))
+
+	assert.True(t, HasCode(notFound, fiber.StatusNotFound))
+	assert.True(t, HasCode(fmt.Errorf("find customer: %w", notFound), fiber.StatusNotFound))
+	assert.False(t, HasCode(notFound, fiber.StatusConflict))
+	assert.False(t, HasCode(errors.New(constants.ErrUserNotFound), fiber.StatusNotFound))
+}
@@ -1,70 +1,107 @@
 package err_msg
 
 import (
-	"fmt"
+	"errors"
 	"regexp"
 	"strings"
 
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
 	"github.com/lib/pq"
-	"github.com/rs/zerolog/log"
 )
 
-func errorPqHandler(errPq *pq.Error) (int, map[string][]string) {
-	var (
-		errors    = make(map[string][]string)
-		code      = 500
-		column    string
-		columnMsg string
-	)
+// Postgres error codes, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	PostgresErrUniqueViolation      pq.ErrorCode = "23505"
+	PostgresErrForeignKeyViolation  pq.ErrorCode = "23503"
+	PostgresErrStringDataTruncation pq.ErrorCode = "22001"
+	PostgresErrSerializationFailure pq.ErrorCode = "40001"
+	PostgresErrDeadlock             pq.ErrorCode = "40P01"
+	PostgresErrLockNotAvailable     pq.ErrorCode = "55P03"
+)
 
-	log.Debug().Msgf("pq error code name: %s", errPq.Code.Name())
-	log.Debug().Msgf("pq error detail: %s", errPq.Detail)
+var (
+	// Key (customer_id)=(7) is not present in table "customers".
+	pqDetailKey = regexp.MustCompile(`Key \(([^)]+)\)`)
+)
 
-	if errPq.Code.Name() == "foreign_key_violation" {
-		regex := regexp.MustCompile(`Key \(([^)]+)\)`)
-		match := regex.FindStringSubmatch(errPq.Detail)
+// IsPostgresError tells whether err, or an error it wraps, is a Postgres error
+// with one of the codes.
+func IsPostgresError(err error, codes ...pq.ErrorCode) bool {
+	var errPq *pq.Error
+	if !errors.As(err, &errPq) {
+		return false
+	}
 
-		if len(match) > 1 {
-			column = match[1]
-			columnMsg = strings.ReplaceAll(column, "_", " ")
+	for _, code := range codes {
+		if errPq.Code == code {
+			return true
 		}
+	}
 
-		errors[column] = append(errors[column], "invalid "+columnMsg+".")
-		code = 500
-	} else if errPq.Code.Name() == "unique_violation" {
-		code = 409
-		regex := regexp.MustCompile(`Key \(([^)]+)\)`)
-		match := regex.FindStringSubmatch(errPq.Detail)
+	return false
+}
 
-		if len(match) > 1 {
-			column = match[1]
-		}
+// postgresDuplicateKeyName returns the unique constraint of a duplicate entry
+// error. Postgres names an inline UNIQUE column <table>_<column>_key where
+// MySQL names it <column>, that part is cut off so both report the same key.
+func postgresDuplicateKeyName(errPq *pq.Error) string {
+	if column, ok := postgresConstraintColumn(errPq, "_key"); ok {
+		return column
+	}
 
-		if strings.Contains(column, ",") { // checking for unique_violation is compound key
-			sliceOfColumns := strings.Split(column, ", ")
-			columns := strings.Join(sliceOfColumns, "_and_")
-			column = columns
-			columnMsg = "combination of " + strings.ReplaceAll(columns, "_", " ")
-			errors[column] = append(errors[column], fmt.Sprintf("%s already exists.", columnMsg))
-		} else { // unique_violation is not compound key
-			columnMsg = strings.ReplaceAll(column, "_", " ")
-			msg := fmt.Sprintf("%s already exists.", columnMsg)
-			if column == "email" {
-				msg = "email already registered."
-			}
-			errors[column] = append(errors[column], msg)
-		}
-	} else if errPq.Code.Name() == "not_null_violation" { // null value in column violates not-null constraint
-		// pq: null value in column "product_id" of relation "product_inquiries" violates not-null constraint
-		regex := regexp.MustCompile(`column \"(.+?)\" of relation \"(.+?)\"`)
-		matches := regex.FindStringSubmatch(errPq.Error())
-		if len(matches) >= 3 {
-			column = matches[1]
-			// tableName := matches[2]
-			columnNameMsg := strings.ReplaceAll(column, "_", " ")
-			errors[column] = append(errors[column], fmt.Sprintf("%s tidak boleh kosong.", columnNameMsg))
+	if errPq.Constraint != "" {
+		return errPq.Constraint
+	}
+
+	return pqDetailColumn(errPq)
+}
+
+// postgresConstraintColumn returns the column of a constraint Postgres named
+// itself, <table>_<column><suffix>.
+func postgresConstraintColumn(errPq *pq.Error, suffix string) (string, bool) {
+	if errPq.Table == "" {
+		return "", false
+	}
+
+	column, ok := strings.CutPrefix(errPq.Constraint, errPq.Table+"_")
+	if !ok {
+		return "", false
+	}
+
+	return strings.CutSuffix(column, suffix)
+}
+
+func pqDetailColumn(errPq *pq.Error) string {
+	match := pqDetailKey.FindStringSubmatch(errPq.Detail)
+	if len(match) < 2 {
+		return ""
+	}
+
+	return match[1]
+}
+
+func errorPqHandler(errPq *pq.Error) *CustomError {
+	switch errPq.Code {
+	case PostgresErrUniqueViolation:
+		return duplicateEntryError(postgresDuplicateKeyName(errPq))
+	case PostgresErrForeignKeyViolation:
+		// the detail names the key of the parent row when it is still
+		// referenced, the column of the child row is in the constraint name
+		if strings.Contains(errPq.Detail, "is still referenced") {
+			column, _ := postgresConstraintColumn(errPq, "_fkey")
+			return rowIsReferencedError(column)
 		}
+		return noReferencedRowError(pqDetailColumn(errPq))
+	case PostgresErrStringDataTruncation:
+		// Postgres leaves the column out: value too long for type character varying(16)
+		return dataTooLongError(errPq.Column)
+	case PostgresErrDeadlock, PostgresErrSerializationFailure:
+		return concurrentUpdateError()
+	case PostgresErrLockNotAvailable:
+		return lockWaitTimeoutError()
 	}
 
-	return code, errors
+	return NewCustomErrors(fiber.StatusInternalServerError, WithMessage(constants.ErrInternalServerError))
 }
@@ -0,0 +1,160 @@
+package err_msg
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hilmiikhsan/multifinance-service/constants"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestNewDatabaseErrors_Postgres(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   int
+		wantMsg    string
+		wantErrors map[string][]string
+	}{
+		{
+			name: "Duplicate Inline Unique Column",
+			err: &pq.Error{
+				Code:       "23505",
+				Message:    `duplicate key value violates unique constraint "customers_nik_key"`,
+				Detail:     "Key (nik)=(3171011501900001) already exists.",
+				Table:      "customers",
+				Constraint: "customers_nik_key",
+			},
+			wantCode:   fiber.StatusConflict,
+			wantMsg:    constants.ErrDuplicateEntry,
+			wantErrors: map[string][]string{"nik": {"nik already exists."}},
+		},
+		{
+			name: "Duplicate Email",
+			err: &pq.Error{
+				Code:       "23505",
+				Detail:     "Key (email)=(jane@example.com) already exists.",
+				Table:      "customers",
+				Constraint: "customers_email_key",
+			},
+			wantCode:   fiber.StatusConflict,
+			wantMsg:    constants.ErrDuplicateEntry,
+			wantErrors: map[string][]string{"email": {"email already registered."}},
+		},
+		{
+			name: "Duplicate Named Constraint",
+			err: &pq.Error{
+				Code:       "23505",
+				Detail:     "Key (code)=(DEALER01) already exists.",
+				Table:      "partners",
+				Constraint: "uq_partners_code",
+			},
+			wantCode:   fiber.StatusConflict,
+			wantMsg:    constants.ErrDuplicateEntry,
+			wantErrors: map[string][]string{"uq_partners_code": {"uq partners code already exists."}},
+		},
+		{
+			name: "Row Is Referenced",
+			err: &pq.Error{
+				Code:       "23503",
+				Detail:     `Key (id)=(7) is still referenced from table "transactions".`,
+				Table:      "transactions",
+				Constraint: "transactions_customer_id_fkey",
+			},
+			wantCode:   fiber.StatusConflict,
+			wantMsg:    constants.ErrRowIsReferenced,
+			wantErrors: map[string][]string{"customer_id": {"customer id is still in use."}},
+		},
+		{
+			name: "No Referenced Row",
+			err: &pq.Error{
+				Code:       "23503",
+				Detail:     `Key (customer_id)=(7) is not present in table "customers".`,
+				Table:      "transactions",
+				Constraint: "transactions_customer_id_fkey",
+			},
+			wantCode:   fiber.StatusUnprocessableEntity,
+			wantMsg:    constants.ErrReferencedRowMissing,
+			wantErrors: map[string][]string{"customer_id": {"invalid customer id."}},
+		},
+		{
+			name:       "Data Too Long",
+			err:        &pq.Error{Code: "22001", Message: "value too long for type character varying(16)"},
+			wantCode:   fiber.StatusUnprocessableEntity,
+			wantMsg:    constants.ErrDataTooLong,
+			wantErrors: map[string][]string{},
+		},
+		{
+			name:       "Deadlock",
+			err:        fmt.Errorf("commit: %w", &pq.Error{Code: "40P01", Message: "deadlock detected"}),
+			wantCode:   fiber.StatusConflict,
+			wantMsg:    constants.ErrConcurrentUpdate,
+			wantErrors: map[string][]string{},
+		},
+		{
+			name:       "Serialization Failure",
+			err:        &pq.Error{Code: "40001", Message: "could not serialize access due to concurrent update"},
+			wantCode:   fiber.StatusConflict,
+			wantMsg:    constants.ErrConcurrentUpdate,
+			wantErrors: map[string][]string{},
+		},
+		{
+			name:       "Lock Not Available",
+			err:        &pq.Error{Code: "55P03", Message: "canceling statement due to lock timeout"},
+			wantCode:   fiber.StatusServiceUnavailable,
+			wantMsg:    constants.ErrLockWaitTimeout,
+			wantErrors: map[string][]string{},
+		},
+		{
+			name:       "Other Postgres Error",
+			err:        &pq.Error{Code: "42P01", Message: `relation "missing" does not exist`},
+			wantCode:   fiber.StatusInternalServerError,
+			wantMsg:    constants.ErrInternalServerError,
+			wantErrors: map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewDatabaseErrors(tt.err)
+			assert.Equal(t, tt.wantCode, got.Code)
+			assert.Equal(t, tt.wantMsg, got.Msg)
+			assert.Equal(t, tt.wantErrors, got.Errors)
+		})
+	}
+}
+
+func TestErrors_Postgres(t *testing.T) {
+	err := &pq.Error{Code: "23505", Table: "partners", Constraint: "uq_partners_code"}
+
+	code, errs := Errors[error](err)
+	assert.Equal(t, fiber.StatusConflict, code)
+	if customErr, ok := errs.(*CustomError); assert.True(t, ok) {
+		assert.Equal(t, []string{"uq partners code already exists."}, customErr.Errors["uq_partners_code"])
+	}
+
+	assert.Equal(t, codes.AlreadyExists, GRPCStatus(err).Code())
+}
+
+func TestIsPostgresError(t *testing.T) {
+	deadlock := fmt.Errorf("insert: %w", &pq.Error{Code: PostgresErrDeadlock})
+
+	assert.True(t, IsPostgresError(deadlock, PostgresErrLockNotAvailable, PostgresErrDeadlock))
+	assert.False(t, IsPostgresError(deadlock, PostgresErrUniqueViolation))
+	assert.False(t, IsPostgresError(errors.New("deadlock"), PostgresErrDeadlock))
+
+	duplicate := &pq.Error{Code: PostgresErrUniqueViolation, Table: "customers", Constraint: "customers_email_key"}
+	assert.True(t, IsDuplicateEntry(duplicate))
+	assert.False(t, IsDuplicateEntry(deadlock))
+
+	key, ok := DuplicateKey(duplicate)
+	assert.True(t, ok)
+	assert.Equal(t, "email", key)
+
+	_, ok = DuplicateKey(deadlock)
+	assert.False(t, ok)
+}
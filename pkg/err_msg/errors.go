@@ -1,13 +1,16 @@
 package err_msg
 
 import (
+	"errors"
+
 	"github.com/go-playground/validator/v10"
+	"github.com/go-sql-driver/mysql"
 	"github.com/lib/pq"
 )
 
-func Errors[T any](err error, payloads ...*T) (code int, errors any) {
+func Errors[T any](err error, payloads ...*T) (code int, errs any) {
 	var payload *T
-	errors = make(map[string][]string)
+	errs = make(map[string][]string)
 	code = 500
 
 	if len(payloads) > 0 {
@@ -17,19 +20,25 @@ func Errors[T any](err error, payloads ...*T) (code int, errors any) {
 	// REQUEST VALIDATION ERRORS
 	if payload != nil {
 		if errValidator, ok := err.(validator.ValidationErrors); ok {
-			code, errors = errorValidationHandler(errValidator, payload)
+			code, errs = errorValidationHandler(errValidator, payload)
 		}
 	}
 
 	// DATABASE ERRORS
-	if errPq, ok := err.(*pq.Error); ok {
-		code, errors = errorPqHandler(errPq)
+	var errPq *pq.Error
+	if errors.As(err, &errPq) {
+		code, errs = errorCustomHandler(errorPqHandler(errPq))
+	}
+
+	var errMysql *mysql.MySQLError
+	if errors.As(err, &errMysql) {
+		code, errs = errorCustomHandler(errorMysqlHandler(errMysql))
 	}
 
 	// CUSTOM ERRORS
 	if errHttp, ok := err.(*CustomError); ok {
-		code, errors = errorCustomHandler(errHttp)
+		code, errs = errorCustomHandler(errHttp)
 	}
 
-	return code, errors
+	return code, errs
 }
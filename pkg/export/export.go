@@ -0,0 +1,239 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+
+	xlsxSheet = "Sheet1"
+
+	// csv output is flushed to the client every csvFlushRows rows
+	csvFlushRows = 500
+)
+
+var ErrUnknownFormat = errors.New("export format is not supported")
+
+// formulaPrefixes start a formula when a spreadsheet opens the cell
+const formulaPrefixes = "=+-@\t\r"
+
+type Kind int
+
+const (
+	KindText Kind = iota
+	KindInteger
+	KindAmount
+	KindDate
+)
+
+type Column struct {
+	Header string
+	Kind   Kind
+}
+
+// Writer writes one table. Values passed to WriteRow line up with the columns
+// given to the constructor: string for KindText, int for KindInteger,
+// float64 for KindAmount and time.Time for KindDate.
+type Writer interface {
+	WriteRow(values ...any) error
+	Close() error
+}
+
+// NewWriter writes the header row straight away. CSV rows reach w as they
+// are written; XLSX rows are buffered on disk by the stream writer and the
+// workbook is written to w on Close.
+func NewWriter(format string, w io.Writer, locale Locale, columns []Column) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, locale, columns)
+	case FormatXLSX:
+		return newXLSXWriter(w, locale, columns)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+type csvWriter struct {
+	w       *csv.Writer
+	locale  Locale
+	columns []Column
+	record  []string
+	rows    int
+}
+
+func newCSVWriter(w io.Writer, locale Locale, columns []Column) (*csvWriter, error) {
+	res := &csvWriter{
+		w:       csv.NewWriter(w),
+		locale:  locale,
+		columns: columns,
+		record:  make([]string, len(columns)),
+	}
+	res.w.Comma = locale.CSVComma
+
+	for i, column := range columns {
+		res.record[i] = column.Header
+	}
+
+	if err := res.w.Write(res.record); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *csvWriter) WriteRow(values ...any) error {
+	for i, column := range c.columns {
+		switch column.Kind {
+		case KindInteger:
+			c.record[i] = strconv.Itoa(values[i].(int))
+		case KindAmount:
+			c.record[i] = c.locale.FormatAmount(values[i].(float64))
+		case KindDate:
+			c.record[i] = c.locale.FormatDate(values[i].(time.Time))
+		default:
+			c.record[i] = escapeFormula(values[i].(string))
+		}
+	}
+
+	if err := c.w.Write(c.record); err != nil {
+		return err
+	}
+
+	c.rows++
+	if c.rows%csvFlushRows == 0 {
+		c.w.Flush()
+		return c.w.Error()
+	}
+
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type xlsxWriter struct {
+	w       io.Writer
+	file    *excelize.File
+	stream  *excelize.StreamWriter
+	locale  Locale
+	columns []Column
+	styles  map[Kind]int
+	row     int
+}
+
+func newXLSXWriter(w io.Writer, locale Locale, columns []Column) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+
+	stream, err := file.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	amountFormat, dateFormat := locale.XLSXAmountFormat, locale.XLSXDateFormat
+	amountStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: &amountFormat})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	dateStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	headerStyle, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	res := &xlsxWriter{
+		w:       w,
+		file:    file,
+		stream:  stream,
+		locale:  locale,
+		columns: columns,
+		styles:  map[Kind]int{KindAmount: amountStyle, KindDate: dateStyle},
+		row:     1,
+	}
+
+	header := make([]any, len(columns))
+	for i, column := range columns {
+		header[i] = excelize.Cell{StyleID: headerStyle, Value: column.Header}
+	}
+
+	if err := stream.SetRow("A1", header); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (x *xlsxWriter) WriteRow(values ...any) error {
+	x.row++
+
+	cells := make([]any, len(x.columns))
+	for i, column := range x.columns {
+		value := values[i]
+		if text, ok := value.(string); ok {
+			value = escapeFormula(text)
+		}
+		if t, ok := value.(time.Time); ok {
+			// excel has no time zones, so the cell keeps the wall clock of the locale
+			t = t.In(x.locale.Location)
+			value = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+		}
+		cells[i] = excelize.Cell{StyleID: x.styles[column.Kind], Value: value}
+	}
+
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+
+	return x.stream.SetRow(cell, cells)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+
+	return x.file.Write(x.w)
+}
+
+// escapeFormula keeps text such as an asset name from running as a formula
+// when the export is opened, a leading quote makes the spreadsheet show the
+// cell as text.
+func escapeFormula(text string) string {
+	if text != "" && strings.ContainsRune(formulaPrefixes, rune(text[0])) {
+		return "'" + text
+	}
+
+	return text
+}
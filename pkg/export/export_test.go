@@ -0,0 +1,146 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+var testColumns = []Column{
+	{Header: "Contract", Kind: KindText},
+	{Header: "Tenor", Kind: KindInteger},
+	{Header: "Price", Kind: KindAmount},
+	{Header: "Date", Kind: KindDate},
+}
+
+func TestLookupLocale(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{code: "en", want: LocaleEN},
+		{code: "en-US,en;q=0.9", want: LocaleEN},
+		{code: "id-ID", want: LocaleID},
+		{code: "fr", want: LocaleID},
+		{code: "", want: LocaleID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.want, LookupLocale(tt.code).Code)
+		})
+	}
+}
+
+func TestLocale_FormatAmount(t *testing.T) {
+	tests := []struct {
+		locale string
+		value  float64
+		want   string
+	}{
+		{locale: LocaleID, value: 1234567.5, want: "1.234.567,50"},
+		{locale: LocaleEN, value: 1234567.5, want: "1,234,567.50"},
+		{locale: LocaleEN, value: 999.999, want: "1,000.00"},
+		{locale: LocaleEN, value: 0, want: "0.00"},
+		{locale: LocaleID, value: -1500, want: "-1.500,00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, LookupLocale(tt.locale).FormatAmount(tt.value))
+		})
+	}
+}
+
+func TestNewWriter_CSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(FormatCSV, &buf, LookupLocale(LocaleID), testColumns)
+	assert.NoError(t, err)
+	assert.NoError(t, w.WriteRow("KTR-1", 6, 12500000.0, time.Date(2024, 12, 1, 17, 30, 0, 0, time.UTC)))
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, "Contract;Tenor;Price;Date\nKTR-1;6;12.500.000,00;02/12/2024 00:30\n", buf.String())
+}
+
+func TestNewWriter_XLSX(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(FormatXLSX, &buf, LookupLocale(LocaleEN), testColumns)
+	assert.NoError(t, err)
+	assert.NoError(t, w.WriteRow("KTR-1", 6, 12500000.0, time.Date(2024, 12, 1, 17, 30, 0, 0, time.UTC)))
+	assert.NoError(t, w.Close())
+
+	file, err := excelize.OpenReader(&buf)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	rows, err := file.GetRows(xlsxSheet, excelize.Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Contract", "Tenor", "Price", "Date"}, rows[0])
+	assert.Equal(t, []string{"KTR-1", "6", "12500000"}, rows[1][:3])
+
+	date, err := file.GetCellValue(xlsxSheet, "D2")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-12-01 17:30", date)
+}
+
+func TestNewWriter_EscapesFormulas(t *testing.T) {
+	columns := []Column{{Header: "Asset", Kind: KindText}, {Header: "Price", Kind: KindAmount}}
+	assets := []string{"=HYPERLINK(\"http://evil\")", "+1+1", "-2+3", "@SUM(A1)", "\tcmd", "\rcmd", "Yamaha NMAX", ""}
+	want := []string{"'=HYPERLINK(\"http://evil\")", "'+1+1", "'-2+3", "'@SUM(A1)", "'\tcmd", "'\rcmd", "Yamaha NMAX", ""}
+
+	t.Run(FormatCSV, func(t *testing.T) {
+		var buf bytes.Buffer
+
+		w, err := NewWriter(FormatCSV, &buf, LookupLocale(LocaleEN), columns)
+		assert.NoError(t, err)
+		for _, asset := range assets {
+			assert.NoError(t, w.WriteRow(asset, -1500.0))
+		}
+		assert.NoError(t, w.Close())
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		assert.NoError(t, err)
+		for i, record := range records[1:] {
+			assert.Equal(t, want[i], record[0])
+			// amounts are numbers, a negative one is not escaped
+			assert.Equal(t, "-1,500.00", record[1])
+		}
+	})
+
+	t.Run(FormatXLSX, func(t *testing.T) {
+		var buf bytes.Buffer
+
+		w, err := NewWriter(FormatXLSX, &buf, LookupLocale(LocaleEN), columns)
+		assert.NoError(t, err)
+		for _, asset := range assets {
+			assert.NoError(t, w.WriteRow(asset, -1500.0))
+		}
+		assert.NoError(t, w.Close())
+
+		file, err := excelize.OpenReader(&buf)
+		assert.NoError(t, err)
+		defer file.Close()
+
+		for i := range assets {
+			cell, err := excelize.CoordinatesToCellName(1, i+2)
+			assert.NoError(t, err)
+
+			value, err := file.GetCellValue(xlsxSheet, cell)
+			assert.NoError(t, err)
+			assert.Equal(t, want[i], value)
+
+			formula, err := file.GetCellFormula(xlsxSheet, cell)
+			assert.NoError(t, err)
+			assert.Empty(t, formula)
+		}
+	})
+}
+
+func TestNewWriter_UnknownFormat(t *testing.T) {
+	_, err := NewWriter("pdf", &bytes.Buffer{}, LookupLocale(LocaleEN), testColumns)
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
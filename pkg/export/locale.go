@@ -0,0 +1,86 @@
+package export
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	LocaleID = "id"
+	LocaleEN = "en"
+)
+
+// Locale decides how numbers and dates are written. Indonesian spreadsheets
+// use a comma as the decimal mark, so their CSV files are split by semicolons.
+type Locale struct {
+	Code             string
+	DecimalMark      string
+	ThousandsMark    string
+	DateLayout       string
+	XLSXDateFormat   string
+	XLSXAmountFormat string
+	CSVComma         rune
+	Location         *time.Location
+}
+
+var locales = map[string]Locale{
+	LocaleID: {
+		Code:             LocaleID,
+		DecimalMark:      ",",
+		ThousandsMark:    ".",
+		DateLayout:       "02/01/2006 15:04",
+		XLSXDateFormat:   "dd/mm/yyyy hh:mm",
+		XLSXAmountFormat: "#,##0.00",
+		CSVComma:         ';',
+		Location:         time.FixedZone("WIB", 7*60*60),
+	},
+	LocaleEN: {
+		Code:             LocaleEN,
+		DecimalMark:      ".",
+		ThousandsMark:    ",",
+		DateLayout:       "2006-01-02 15:04",
+		XLSXDateFormat:   "yyyy-mm-dd hh:mm",
+		XLSXAmountFormat: "#,##0.00",
+		CSVComma:         ',',
+		Location:         time.UTC,
+	},
+}
+
+// LookupLocale matches a locale code or an Accept-Language value such as
+// "en-US,en;q=0.9" and falls back to Indonesian.
+func LookupLocale(code string) Locale {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_,;"); i >= 0 {
+		code = code[:i]
+	}
+
+	if locale, ok := locales[code]; ok {
+		return locale
+	}
+
+	return locales[LocaleID]
+}
+
+// FormatAmount writes v with two decimals and grouped thousands.
+func (l Locale) FormatAmount(v float64) string {
+	negative := v < 0
+	cents := int64(math.Round(math.Abs(v) * 100))
+
+	whole := strconv.FormatInt(cents/100, 10)
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + l.ThousandsMark + whole[i:]
+	}
+
+	res := whole + l.DecimalMark + strconv.FormatInt(100+cents%100, 10)[1:]
+	if negative {
+		res = "-" + res
+	}
+
+	return res
+}
+
+func (l Locale) FormatDate(t time.Time) string {
+	return t.In(l.Location).Format(l.DateLayout)
+}
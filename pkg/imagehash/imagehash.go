@@ -0,0 +1,116 @@
+package imagehash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"math/bits"
+	"os"
+
+	// decoders for the formats customers upload
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+const (
+	dHashWidth  = 9
+	dHashHeight = 8
+)
+
+// Hashes identify an uploaded photo. SHA256 only matches byte-identical files,
+// Perceptual also matches re-encoded, resized or slightly edited copies.
+type Hashes struct {
+	SHA256        string
+	Perceptual    uint64
+	HasPerceptual bool
+}
+
+// HashFile hashes the file at path. Files that are not a decodable image only
+// get the content hash.
+func HashFile(path string) (*Hashes, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read photo: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	res := &Hashes{
+		SHA256: hex.EncodeToString(sum[:]),
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open photo: %w", err)
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return res, nil
+	}
+
+	res.Perceptual = DHash(img)
+	res.HasPerceptual = true
+
+	return res, nil
+}
+
+// DHash computes a 64 bit difference hash: the image is shrunk to 9x8 grey
+// cells and every bit records whether a cell is brighter than its right neighbour.
+func DHash(img image.Image) uint64 {
+	var (
+		bounds = img.Bounds()
+		cells  [dHashHeight][dHashWidth]float64
+		hash   uint64
+	)
+
+	for y := 0; y < dHashHeight; y++ {
+		for x := 0; x < dHashWidth; x++ {
+			cells[y][x] = averageLuminance(img, image.Rect(
+				bounds.Min.X+x*bounds.Dx()/dHashWidth,
+				bounds.Min.Y+y*bounds.Dy()/dHashHeight,
+				bounds.Min.X+(x+1)*bounds.Dx()/dHashWidth,
+				bounds.Min.Y+(y+1)*bounds.Dy()/dHashHeight,
+			))
+		}
+	}
+
+	for y := 0; y < dHashHeight; y++ {
+		for x := 0; x < dHashWidth-1; x++ {
+			hash <<= 1
+			if cells[y][x] > cells[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+
+	return hash
+}
+
+// Distance is the number of differing bits between two perceptual hashes.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func averageLuminance(img image.Image, rect image.Rectangle) float64 {
+	if rect.Empty() {
+		rect = image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+1, rect.Min.Y+1)
+	}
+
+	var (
+		sum   float64
+		count int
+	)
+
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+			count++
+		}
+	}
+
+	return sum / float64(count)
+}
@@ -0,0 +1,67 @@
+package imagehash
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pattern draws a scale independent wave so resized copies keep their shape.
+func pattern(width, height int, shift float64, mirror bool) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			fx, fy := float64(x)/float64(width), float64(y)/float64(height)
+			if mirror {
+				fx = 1 - fx
+			}
+			v := uint8(100 + shift + 80*math.Sin(fx*2.5*math.Pi)*math.Cos(fy*2*math.Pi))
+			img.Set(x, y, color.RGBA{R: v, G: v, B: v, A: 255})
+		}
+	}
+	return img
+}
+
+func TestDHash(t *testing.T) {
+	original := DHash(pattern(360, 240, 0, false))
+	brighter := DHash(pattern(360, 240, 20, false))
+	resized := DHash(pattern(120, 80, 0, false))
+	mirrored := DHash(pattern(360, 240, 0, true))
+
+	assert.LessOrEqual(t, Distance(original, brighter), 4)
+	assert.LessOrEqual(t, Distance(original, resized), 4)
+	assert.Greater(t, Distance(original, mirrored), 20)
+}
+
+func TestHashFile(t *testing.T) {
+	dir := t.TempDir()
+
+	photoPath := filepath.Join(dir, "ktp.png")
+	f, err := os.Create(photoPath)
+	assert.NoError(t, err)
+	assert.NoError(t, png.Encode(f, pattern(120, 80, 0, false)))
+	assert.NoError(t, f.Close())
+
+	got, err := HashFile(photoPath)
+	assert.NoError(t, err)
+	assert.Len(t, got.SHA256, 64)
+	assert.True(t, got.HasPerceptual)
+	assert.Equal(t, DHash(pattern(120, 80, 0, false)), got.Perceptual)
+
+	textPath := filepath.Join(dir, "ktp.pdf")
+	assert.NoError(t, os.WriteFile(textPath, []byte("not an image"), 0o600))
+
+	got, err = HashFile(textPath)
+	assert.NoError(t, err)
+	assert.Len(t, got.SHA256, 64)
+	assert.False(t, got.HasPerceptual)
+
+	_, err = HashFile(filepath.Join(dir, "missing.png"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveHTTPRequest(t *testing.T) {
+	ObserveHTTPRequest(http.MethodGet, "/api/v1/transaction/:id", http.StatusNotFound, 20*time.Millisecond)
+	ObserveHTTPRequest(http.MethodGet, "/api/v1/transaction/:id", http.StatusNotFound, 30*time.Millisecond)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/api/v1/transaction/:id", "404")))
+	assert.Equal(t, 1, testutil.CollectAndCount(httpDuration))
+}
+
+func TestRedisHook(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	rdb.AddHook(RedisHook{})
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		command string
+		status  string
+		run     func()
+	}{
+		{
+			name:    "Command",
+			command: "set",
+			status:  "ok",
+			run:     func() { rdb.Set(ctx, "key", "value", 0) },
+		},
+		{
+			name:    "Missing Key",
+			command: "get",
+			status:  "ok",
+			run:     func() { rdb.Get(ctx, "missing") },
+		},
+		{
+			name:    "Failed Command",
+			command: "incr",
+			status:  "error",
+			run:     func() { rdb.Incr(ctx, "key") },
+		},
+		{
+			name:    "Pipeline",
+			command: "pipeline",
+			status:  "ok",
+			run: func() {
+				pipe := rdb.Pipeline()
+				pipe.Get(ctx, "key")
+				pipe.Get(ctx, "missing")
+				_, _ = pipe.Exec(ctx)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := redisCount(t, tt.command, tt.status)
+			tt.run()
+
+			assert.Equal(t, before+1, redisCount(t, tt.command, tt.status))
+		})
+	}
+}
+
+// redisCount returns how many commands were timed under the labels.
+func redisCount(t *testing.T, command, status string) uint64 {
+	families, err := Registry.Gather()
+	assert.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != "multifinance_redis_command_duration_seconds" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			if labels["command"] == command && labels["status"] == status {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestHandler(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	assert.NoError(t, RegisterDB(db, "test_mysql"))
+	Bookings.WithLabelValues("default", "12").Inc()
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body, err := io.ReadAll(rec.Body)
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, string(body), `multifinance_bookings_total{channel="default",tenor="12"} 1`)
+	assert.Contains(t, string(body), `go_sql_open_connections{db_name="test_mysql"}`)
+	assert.Contains(t, string(body), "go_goroutines")
+}
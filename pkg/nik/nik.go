@@ -0,0 +1,114 @@
+package nik
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// A NIK (Nomor Induk Kependudukan) is laid out as PPRRDD-DDMMYY-SSSS:
+// province, regency and district codes, the birth date (day + 40 for women)
+// and a sequence number within the district for that birth date.
+
+const (
+	GenderMale   = "M"
+	GenderFemale = "F"
+
+	femaleDayOffset = 40
+)
+
+var (
+	ErrInvalidFormat    = errors.New("nik must be exactly 16 digits")
+	ErrUnknownProvince  = errors.New("nik province code is not registered")
+	ErrUnknownRegency   = errors.New("nik regency code is not registered")
+	ErrInvalidDistrict  = errors.New("nik district code is not valid")
+	ErrInvalidBirthDate = errors.New("nik birth date is not valid")
+	ErrInvalidSequence  = errors.New("nik sequence number is not valid")
+
+	numericRegex = regexp.MustCompile(`^\d{16}$`)
+)
+
+type NIK struct {
+	Number       string
+	ProvinceCode string
+	RegencyCode  string
+	DistrictCode string
+	BirthDay     int
+	BirthMonth   int
+	BirthYear    int // two digit year as written in the NIK
+	Gender       string
+	Sequence     string
+}
+
+// Parse decodes a NIK and rejects numbers whose region or birth date cannot exist.
+func Parse(number string) (*NIK, error) {
+	if !numericRegex.MatchString(number) {
+		return nil, ErrInvalidFormat
+	}
+
+	res := &NIK{
+		Number:       number,
+		ProvinceCode: number[0:2],
+		RegencyCode:  number[0:4],
+		DistrictCode: number[0:6],
+		Gender:       GenderMale,
+		Sequence:     number[12:16],
+	}
+
+	province, ok := LookupProvince(res.ProvinceCode)
+	if !ok {
+		return nil, ErrUnknownProvince
+	}
+
+	regency, _ := strconv.Atoi(number[2:4])
+	if !province.HasRegency(regency) {
+		return nil, ErrUnknownRegency
+	}
+
+	if number[4:6] == "00" {
+		return nil, ErrInvalidDistrict
+	}
+
+	res.BirthDay, _ = strconv.Atoi(number[6:8])
+	res.BirthMonth, _ = strconv.Atoi(number[8:10])
+	res.BirthYear, _ = strconv.Atoi(number[10:12])
+
+	if res.BirthDay > femaleDayOffset {
+		res.BirthDay -= femaleDayOffset
+		res.Gender = GenderFemale
+	}
+
+	// the century is not encoded, so the date only has to exist in one of them
+	if !isValidDate(1900+res.BirthYear, res.BirthMonth, res.BirthDay) && !isValidDate(2000+res.BirthYear, res.BirthMonth, res.BirthDay) {
+		return nil, ErrInvalidBirthDate
+	}
+
+	if res.Sequence == "0000" {
+		return nil, ErrInvalidSequence
+	}
+
+	return res, nil
+}
+
+// MatchesBirthDate reports whether the birth date encoded in the NIK equals the given date.
+func (n *NIK) MatchesBirthDate(birthDate time.Time) bool {
+	return n.BirthDay == birthDate.Day() &&
+		n.BirthMonth == int(birthDate.Month()) &&
+		n.BirthYear == birthDate.Year()%100
+}
+
+// ProvinceName returns the name of the province the NIK was issued in.
+func (n *NIK) ProvinceName() string {
+	province, _ := LookupProvince(n.ProvinceCode)
+	return province.Name
+}
+
+func isValidDate(year, month, day int) bool {
+	if month < 1 || month > 12 || day < 1 {
+		return false
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	return date.Day() == day && int(date.Month()) == month
+}
@@ -0,0 +1,107 @@
+package nik
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		number  string
+		want    *NIK
+		wantErr error
+	}{
+		{
+			name:   "Valid male NIK",
+			number: "3174010101900001",
+			want: &NIK{
+				Number:       "3174010101900001",
+				ProvinceCode: "31",
+				RegencyCode:  "3174",
+				DistrictCode: "317401",
+				BirthDay:     1,
+				BirthMonth:   1,
+				BirthYear:    90,
+				Gender:       GenderMale,
+				Sequence:     "0001",
+			},
+		},
+		{
+			name:   "Valid female NIK",
+			number: "3273025206850002",
+			want: &NIK{
+				Number:       "3273025206850002",
+				ProvinceCode: "32",
+				RegencyCode:  "3273",
+				DistrictCode: "327302",
+				BirthDay:     12,
+				BirthMonth:   6,
+				BirthYear:    85,
+				Gender:       GenderFemale,
+				Sequence:     "0002",
+			},
+		},
+		{
+			name:    "Not numeric",
+			number:  "31740101019000A1",
+			wantErr: ErrInvalidFormat,
+		},
+		{
+			name:    "Too short",
+			number:  "317401010190",
+			wantErr: ErrInvalidFormat,
+		},
+		{
+			name:    "Unknown province",
+			number:  "2074010101900001",
+			wantErr: ErrUnknownProvince,
+		},
+		{
+			name:    "Unknown regency",
+			number:  "3150010101900001",
+			wantErr: ErrUnknownRegency,
+		},
+		{
+			name:    "Empty district",
+			number:  "3174000101900001",
+			wantErr: ErrInvalidDistrict,
+		},
+		{
+			name:    "Impossible birth date",
+			number:  "3174013102900001",
+			wantErr: ErrInvalidBirthDate,
+		},
+		{
+			name:    "Female day out of range",
+			number:  "3174017201900001",
+			wantErr: ErrInvalidBirthDate,
+		},
+		{
+			name:    "Zero sequence",
+			number:  "3174010101900000",
+			wantErr: ErrInvalidSequence,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.number)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNIK_MatchesBirthDate(t *testing.T) {
+	parsed, err := Parse("3273025206850002")
+	assert.NoError(t, err)
+
+	assert.True(t, parsed.MatchesBirthDate(time.Date(1985, 6, 12, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, parsed.MatchesBirthDate(time.Date(1985, 6, 13, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, parsed.MatchesBirthDate(time.Date(1986, 6, 12, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "Jawa Barat", parsed.ProvinceName())
+}
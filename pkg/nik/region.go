@@ -0,0 +1,73 @@
+package nik
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// regionsCSV holds the Kemendagri province table with the number of regencies
+// (kabupaten, coded 01..n) and cities (kota, coded 71..) of every province.
+// Provinces split off after 2022 are listed as well, but the original codes
+// are kept because NIKs issued before the split are not reissued.
+//
+//go:embed regions.csv
+var regionsCSV string
+
+type Province struct {
+	Code         string
+	Name         string
+	RegencyCount int
+	CityCount    int
+}
+
+var provinces = loadProvinces(regionsCSV)
+
+func loadProvinces(data string) map[string]Province {
+	res := make(map[string]Province)
+
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		log.Fatal().Err(err).Msg("pkg::nik - Failed to read bundled region table")
+	}
+
+	for i, record := range records {
+		if i == 0 { // skip header
+			continue
+		}
+
+		regencyCount, _ := strconv.Atoi(record[2])
+		cityCount, _ := strconv.Atoi(record[3])
+
+		res[record[0]] = Province{
+			Code:         record[0],
+			Name:         record[1],
+			RegencyCount: regencyCount,
+			CityCount:    cityCount,
+		}
+	}
+
+	return res
+}
+
+// LookupProvince returns the province registered under the given two digit code.
+func LookupProvince(code string) (Province, bool) {
+	province, ok := provinces[code]
+	return province, ok
+}
+
+// HasRegency reports whether the two digit regency code exists in the province.
+// Regencies are numbered from 01 and cities from 71.
+func (p Province) HasRegency(code int) bool {
+	switch {
+	case code >= 1 && code <= p.RegencyCount:
+		return true
+	case code >= 71 && code < 71+p.CityCount:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelInApp = "in_app"
+)
+
+// Channels lists every channel in the order a notification goes out on them.
+var Channels = []string{ChannelEmail, ChannelSMS, ChannelInApp}
+
+// Message is one rendered notification for one channel. To is the address
+// the channel delivers to: an email address, a phone number or, for the
+// in-app inbox, unused.
+type Message struct {
+	CustomerID int64
+	Channel    string
+	To         string
+	Subject    string
+	Body       string
+}
+
+// Sender delivers messages of one channel.
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+var _ Sender = &MemorySender{}
+
+// MemorySender keeps the messages it was given in memory, for tests.
+type MemorySender struct {
+	mu       sync.Mutex
+	messages []Message
+	err      error
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+// FailWith makes every later Send return err, nil lets them through again.
+func (s *MemorySender) FailWith(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
+
+func (s *MemorySender) Send(ctx context.Context, msg *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+
+	s.messages = append(s.messages, *msg)
+	return nil
+}
+
+// Messages returns a copy of the messages sent so far, oldest first.
+func (s *MemorySender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message(nil), s.messages...)
+}
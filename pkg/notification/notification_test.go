@@ -0,0 +1,139 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	var (
+		dueDate = time.Date(2025, 1, 31, 17, 0, 0, 0, time.UTC)
+		booking = &BookingConfirmed{
+			FullName:          "Budi Santoso",
+			ContractNumber:    "TRX-1-1733047200",
+			AssetName:         "Yamaha NMAX",
+			OnTheRoadPrice:    30000000,
+			InstallmentAmount: 2750000,
+			TenorMonth:        12,
+			FirstDueDate:      dueDate,
+		}
+	)
+
+	got, err := Render(TemplateBookingConfirmed, "id", ChannelEmail, booking)
+	assert.NoError(t, err)
+	assert.Equal(t, "Pembiayaan TRX-1-1733047200 telah aktif", got.Subject)
+	assert.Contains(t, got.Body, "Halo Budi Santoso,")
+	assert.Contains(t, got.Body, "Rp 30.000.000")
+	// due dates are written in Jakarta time for Indonesian customers
+	assert.Contains(t, got.Body, "1 Februari 2025")
+
+	got, err = Render(TemplateBookingConfirmed, "en-US", ChannelSMS, booking)
+	assert.NoError(t, err)
+	assert.Empty(t, got.Subject)
+	assert.Equal(t, "Financing TRX-1-1733047200 is active. Installment Rp 2,750,000/mo x 12, first due 31 January 2025.", got.Body)
+
+	_, err = Render("welcome", "id", ChannelEmail, booking)
+	assert.ErrorIs(t, err, ErrUnknownTemplate)
+
+	_, err = Render(TemplateBookingConfirmed, "id", "fax", booking)
+	assert.ErrorIs(t, err, ErrUnknownTemplate)
+
+	_, err = Render(TemplateInstallmentDue, "id", ChannelEmail, booking)
+	assert.Error(t, err)
+}
+
+func TestRender_SMSLength(t *testing.T) {
+	data := map[string]any{
+		TemplateBookingConfirmed: &BookingConfirmed{ContractNumber: "TRX-99999-1733047200", AssetName: "Honda PCX 160", OnTheRoadPrice: 999999999, InstallmentAmount: 99999999, TenorMonth: 24, FirstDueDate: time.Now()},
+		TemplateInstallmentDue:   &InstallmentDue{ContractNumber: "TRX-99999-1733047200", InstallmentNumber: 24, Amount: 99999999, DueDate: time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for name := range catalog {
+		for _, locale := range []string{"id", "en"} {
+			for _, channel := range Channels {
+				got, err := Render(name, locale, channel, data[name])
+				assert.NoError(t, err, "%s %s %s", name, locale, channel)
+
+				if channel == ChannelSMS {
+					assert.LessOrEqual(t, utf8.RuneCountInString(got.Body), 160, "%s %s", name, locale)
+				}
+			}
+		}
+	}
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	var (
+		gotTo  []string
+		gotMsg string
+	)
+
+	sender := NewSMTPSender("smtp.example.test", 587, "", "", "no-reply@multifinance.test")
+	sender.now = func() time.Time { return time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC) }
+	sender.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		assert.Equal(t, "smtp.example.test:587", addr)
+		gotTo, gotMsg = to, string(msg)
+		return nil
+	}
+
+	err := sender.Send(context.Background(), &Message{To: "budi@example.com", Subject: "Angsuran jatuh tempo", Body: "Halo\nBudi"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"budi@example.com"}, gotTo)
+	assert.Contains(t, gotMsg, "Subject: Angsuran jatuh tempo\r\n")
+	assert.True(t, strings.HasSuffix(gotMsg, "\r\n\r\nHalo\r\nBudi\r\n"))
+
+	err = sender.Send(context.Background(), &Message{To: "budi@example.com\r\nBcc: all@example.com"})
+	assert.ErrorIs(t, err, ErrInvalidRecipient)
+}
+
+func TestSMSGateway_Send(t *testing.T) {
+	var (
+		status   = http.StatusAccepted
+		received map[string]string
+	)
+
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sms-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(status)
+	}))
+	defer gateway.Close()
+
+	sms := NewSMSGateway(gateway.URL, "sms-key", time.Second)
+	msg := &Message{To: "+6281234567890", Body: "Angsuran jatuh tempo"}
+
+	assert.NoError(t, sms.Send(context.Background(), msg))
+	assert.Equal(t, map[string]string{"to": "+6281234567890", "message": "Angsuran jatuh tempo"}, received)
+
+	status = http.StatusBadGateway
+	assert.Error(t, sms.Send(context.Background(), msg))
+
+	assert.ErrorIs(t, sms.Send(context.Background(), &Message{}), ErrInvalidRecipient)
+
+	assert.Error(t, NewSMSGateway(gateway.URL, "wrong", time.Second).Send(context.Background(), msg))
+}
+
+func TestMemorySender_Send(t *testing.T) {
+	sender := NewMemorySender()
+
+	assert.NoError(t, sender.Send(context.Background(), &Message{To: "a"}))
+
+	sender.FailWith(errors.New("down"))
+	assert.Error(t, sender.Send(context.Background(), &Message{To: "b"}))
+
+	assert.Len(t, sender.Messages(), 1)
+}
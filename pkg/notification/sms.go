@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+var _ Sender = &SMSGateway{}
+
+// SMSGateway posts text messages to an HTTP SMS gateway as
+// {"to": "<phone number>", "message": "<text>"} with the API key as a bearer
+// token. Any answer outside 2xx is a failed send.
+type SMSGateway struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+func NewSMSGateway(url, apiKey string, timeout time.Duration) *SMSGateway {
+	return &SMSGateway{
+		url:    url,
+		apiKey: apiKey,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (g *SMSGateway) Send(ctx context.Context, msg *Message) error {
+	if msg.To == "" {
+		return ErrInvalidRecipient
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"to":      msg.To,
+		"message": msg.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode sms: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build sms request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+g.apiKey)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send sms: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		answer, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("sms gateway answered with status %d: %s", resp.StatusCode, answer)
+	}
+
+	return nil
+}
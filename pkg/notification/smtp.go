@@ -0,0 +1,76 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var _ Sender = &SMTPSender{}
+
+var ErrInvalidRecipient = errors.New("notification recipient is not a valid address")
+
+// SMTPSender sends plain text emails through an SMTP relay. The relay is
+// expected to offer STARTTLS whenever a username is set, net/smtp refuses to
+// send credentials over an unencrypted connection otherwise.
+type SMTPSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+	now  func() time.Time
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSMTPSender(host string, port int, username, password, from string) *SMTPSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPSender{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		from: from,
+		auth: auth,
+		now:  time.Now,
+		send: smtp.SendMail,
+	}
+}
+
+// Send does not honour ctx, net/smtp has no way to cancel a conversation
+// with the relay once it started.
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	// a line break in an address would let it add headers of its own
+	if msg.To == "" || strings.ContainsAny(msg.To, "\r\n") {
+		return ErrInvalidRecipient
+	}
+
+	if err := s.send(s.addr, s.auth, s.from, []string{msg.To}, s.build(msg)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SMTPSender) build(msg *Message) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", s.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", s.now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	buf.WriteString("\r\n")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,155 @@
+package notification
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hilmiikhsan/multifinance-service/pkg/export"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	TemplateBookingConfirmed = "booking_confirmed"
+	TemplateInstallmentDue   = "installment_due"
+)
+
+//go:embed templates.yaml
+var templatesYAML []byte
+
+var ErrUnknownTemplate = errors.New("notification template does not exist")
+
+// BookingConfirmed is the data of TemplateBookingConfirmed.
+type BookingConfirmed struct {
+	FullName          string
+	ContractNumber    string
+	AssetName         string
+	OnTheRoadPrice    float64
+	InstallmentAmount float64
+	TenorMonth        int
+	FirstDueDate      time.Time
+}
+
+// InstallmentDue is the data of TemplateInstallmentDue.
+type InstallmentDue struct {
+	FullName          string
+	ContractNumber    string
+	InstallmentNumber int
+	Amount            float64
+	DueDate           time.Time
+}
+
+// Content is a template rendered for one channel. Subject is empty for SMS.
+type Content struct {
+	Subject string
+	Body    string
+}
+
+type text struct {
+	Subject string `yaml:"subject"`
+	Body    string `yaml:"body"`
+}
+
+type parsedText struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// catalog is keyed by template, then locale, then channel
+var catalog = mustParseCatalog(templatesYAML)
+
+var monthsID = [...]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+// Render writes the template in the language of locale for channel. Unknown
+// locales fall back to Indonesian, like the rest of the service.
+func Render(name, locale, channel string, data any) (*Content, error) {
+	locales, ok := catalog[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTemplate, name)
+	}
+
+	texts := locales[export.LookupLocale(locale).Code]
+
+	parsed, ok := texts[channel]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s has no %s text", ErrUnknownTemplate, name, channel)
+	}
+
+	var (
+		res = new(Content)
+		buf bytes.Buffer
+	)
+
+	if parsed.subject != nil {
+		if err := parsed.subject.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s subject: %w", name, err)
+		}
+		res.Subject = buf.String()
+		buf.Reset()
+	}
+
+	if err := parsed.body.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s body: %w", name, err)
+	}
+	res.Body = buf.String()
+
+	return res, nil
+}
+
+func mustParseCatalog(raw []byte) map[string]map[string]map[string]parsedText {
+	var texts map[string]map[string]map[string]text
+	if err := yaml.Unmarshal(raw, &texts); err != nil {
+		panic(fmt.Sprintf("notification: invalid templates.yaml: %v", err))
+	}
+
+	res := make(map[string]map[string]map[string]parsedText, len(texts))
+	for name, locales := range texts {
+		res[name] = make(map[string]map[string]parsedText, len(locales))
+
+		for _, code := range []string{export.LocaleID, export.LocaleEN} {
+			channels, ok := locales[code]
+			if !ok {
+				panic(fmt.Sprintf("notification: template %s has no %s texts", name, code))
+			}
+
+			funcs := templateFuncs(export.LookupLocale(code))
+			res[name][code] = make(map[string]parsedText, len(channels))
+
+			for channel, t := range channels {
+				id := strings.Join([]string{name, code, channel}, ".")
+
+				var parsed parsedText
+				if t.Subject != "" {
+					parsed.subject = template.Must(template.New(id + ".subject").Option("missingkey=error").Funcs(funcs).Parse(t.Subject))
+				}
+				parsed.body = template.Must(template.New(id + ".body").Option("missingkey=error").Funcs(funcs).Parse(t.Body))
+
+				res[name][code][channel] = parsed
+			}
+		}
+	}
+
+	return res
+}
+
+func templateFuncs(locale export.Locale) template.FuncMap {
+	return template.FuncMap{
+		"amount": func(v float64) string {
+			// rupiah are written without cents
+			formatted := locale.FormatAmount(math.Round(v))
+			return "Rp " + formatted[:len(formatted)-3]
+		},
+		"date": func(t time.Time) string {
+			t = t.In(locale.Location)
+			if locale.Code == export.LocaleID {
+				return fmt.Sprintf("%d %s %d", t.Day(), monthsID[t.Month()-1], t.Year())
+			}
+			return t.Format("2 January 2006")
+		},
+	}
+}
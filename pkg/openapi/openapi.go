@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+var (
+	once     sync.Once
+	document []byte
+	parseErr error
+)
+
+// Spec is the part of the document the route coverage test looks at.
+type Spec struct {
+	OpenAPI string                          `yaml:"openapi"`
+	Paths   map[string]map[string]yaml.Node `yaml:"paths"`
+}
+
+// JSON returns the document as JSON, it is converted once and reused.
+func JSON() ([]byte, error) {
+	once.Do(func() {
+		var doc map[string]any
+		if err := yaml.Unmarshal(spec, &doc); err != nil {
+			parseErr = fmt.Errorf("failed to parse openapi document: %w", err)
+			return
+		}
+
+		document, parseErr = json.Marshal(doc)
+		if parseErr != nil {
+			parseErr = fmt.Errorf("failed to encode openapi document: %w", parseErr)
+		}
+	})
+
+	return document, parseErr
+}
+
+// Parse reads the paths of the document.
+func Parse() (*Spec, error) {
+	s := new(Spec)
+	if err := yaml.Unmarshal(spec, s); err != nil {
+		return nil, fmt.Errorf("failed to parse openapi document: %w", err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,281 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Handler processes one event. An error leaves the event pending, it is
+// claimed and handed out again once it has been idle for the retry delay.
+// An error retrying cannot fix is wrapped with Permanent.
+type Handler func(ctx context.Context, event *Event) error
+
+// PermanentError is a handler error retrying cannot fix, the event is moved
+// to the dead-letter stream straight away.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent marks err as one retrying cannot fix.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &PermanentError{Err: err}
+}
+
+// ConsumeResult counts what one poll did. Dropped entries could not be read
+// as an event and are acknowledged so they do not come back. Dead lettered
+// events failed permanently or on their last delivery and were moved to the
+// dead-letter stream.
+type ConsumeResult struct {
+	Handled      int `json:"handled"`
+	Failed       int `json:"failed"`
+	Dropped      int `json:"dropped"`
+	DeadLettered int `json:"dead_lettered"`
+}
+
+// RedisStreamConsumer reads a stream written by RedisStreamPublisher as a
+// member of a consumer group, so every group sees each event once and the
+// members of a group share the work.
+type RedisStreamConsumer struct {
+	client        *redis.Client
+	stream        string
+	group         string
+	consumer      string
+	count         int64
+	block         time.Duration
+	retryAfter    time.Duration
+	maxDeliveries int64
+}
+
+// NewRedisStreamConsumer retries a failed event once it has been pending for
+// retryAfter, and gives it up to the dead-letter stream after maxDeliveries.
+func NewRedisStreamConsumer(client *redis.Client, stream, group, consumer string, count int64, block, retryAfter time.Duration, maxDeliveries int64) *RedisStreamConsumer {
+	return &RedisStreamConsumer{
+		client:        client,
+		stream:        stream,
+		group:         group,
+		consumer:      consumer,
+		count:         count,
+		block:         block,
+		retryAfter:    retryAfter,
+		maxDeliveries: maxDeliveries,
+	}
+}
+
+// DeadLetterStream is where the events given up on are kept, with the group,
+// the number of deliveries and the last error, for someone to look into.
+func (c *RedisStreamConsumer) DeadLetterStream() string {
+	return c.stream + ":dead"
+}
+
+// EnsureGroup creates the group at the start of the stream, so a new
+// consumer also processes the events published before it first ran.
+func (c *RedisStreamConsumer) EnsureGroup(ctx context.Context) error {
+	err := c.client.XGroupCreateMkStream(ctx, c.stream, c.group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group %s on %s: %w", c.group, c.stream, err)
+	}
+
+	return nil
+}
+
+// Poll hands one batch of events to handle. Events of the group left pending
+// for the retry delay, by this consumer or one that stopped, are claimed
+// first and new events fill the rest of the batch, waiting up to the block
+// duration for one when nothing was claimed. A failing event is retried after
+// the events behind it instead of holding them up.
+func (c *RedisStreamConsumer) Poll(ctx context.Context, handle Handler) (*ConsumeResult, error) {
+	messages, deliveries, err := c.claim(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if remaining := c.count - int64(len(messages)); remaining > 0 {
+		block := c.block
+		if len(messages) > 0 {
+			block = -1
+		}
+
+		fresh, err := c.read(ctx, remaining, block)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, message := range fresh {
+			deliveries[message.ID] = 1
+		}
+		messages = append(messages, fresh...)
+	}
+
+	res := new(ConsumeResult)
+	for _, message := range messages {
+		event, err := decodeEvent(message.Values)
+		switch {
+		case err != nil:
+			res.Dropped++
+		case deliveries[message.ID] > c.maxDeliveries:
+			// handed out without being acknowledged, as when the worker crashed on it
+			if err := c.deadLetter(ctx, message, deliveries[message.ID], "not acknowledged"); err != nil {
+				return res, err
+			}
+			res.DeadLettered++
+		default:
+			var permanent *PermanentError
+
+			err = handle(ctx, event)
+			switch {
+			case err == nil:
+				res.Handled++
+			case errors.As(err, &permanent) || deliveries[message.ID] >= c.maxDeliveries:
+				if err := c.deadLetter(ctx, message, deliveries[message.ID], err.Error()); err != nil {
+					return res, err
+				}
+				res.DeadLettered++
+			default:
+				res.Failed++
+				continue
+			}
+		}
+
+		if err := c.client.XAck(ctx, c.stream, c.group, message.ID).Err(); err != nil {
+			return res, fmt.Errorf("failed to acknowledge %s: %w", message.ID, err)
+		}
+	}
+
+	return res, nil
+}
+
+// claim takes over the entries of the group pending for the retry delay and
+// returns them with how often each has now been delivered.
+func (c *RedisStreamConsumer) claim(ctx context.Context) ([]redis.XMessage, map[string]int64, error) {
+	deliveries := make(map[string]int64)
+
+	pending, err := c.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: c.stream,
+		Group:  c.group,
+		Idle:   c.retryAfter,
+		Start:  "-",
+		End:    "+",
+		Count:  c.count,
+	}).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, nil, fmt.Errorf("failed to list pending entries of %s: %w", c.stream, err)
+	}
+
+	if len(pending) == 0 {
+		return nil, deliveries, nil
+	}
+
+	ids := make([]string, 0, len(pending))
+	for _, entry := range pending {
+		ids = append(ids, entry.ID)
+		deliveries[entry.ID] = entry.RetryCount + 1
+	}
+
+	// another consumer claiming an entry first resets its idle time, so it is skipped here
+	messages, err := c.client.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   c.stream,
+		Group:    c.group,
+		Consumer: c.consumer,
+		MinIdle:  c.retryAfter,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to claim pending entries of %s: %w", c.stream, err)
+	}
+
+	return messages, deliveries, nil
+}
+
+// deadLetter copies the entry to the dead-letter stream before it is
+// acknowledged, so it is not lost when the acknowledgement fails.
+func (c *RedisStreamConsumer) deadLetter(ctx context.Context, message redis.XMessage, deliveries int64, reason string) error {
+	values := make(map[string]interface{}, len(message.Values)+4)
+	for key, value := range message.Values {
+		values[key] = value
+	}
+	values["source_id"] = message.ID
+	values["group"] = c.group
+	values["deliveries"] = deliveries
+	values["error"] = reason
+
+	if err := c.client.XAdd(ctx, &redis.XAddArgs{Stream: c.DeadLetterStream(), Values: values}).Err(); err != nil {
+		return fmt.Errorf("failed to dead letter %s: %w", message.ID, err)
+	}
+
+	return nil
+}
+
+func (c *RedisStreamConsumer) read(ctx context.Context, count int64, block time.Duration) ([]redis.XMessage, error) {
+	streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    c.group,
+		Consumer: c.consumer,
+		Streams:  []string{c.stream, ">"},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read %s: %w", c.stream, err)
+	}
+
+	if len(streams) == 0 {
+		return nil, nil
+	}
+
+	return streams[0].Messages, nil
+}
+
+func decodeEvent(values map[string]interface{}) (*Event, error) {
+	field := func(name string) string {
+		v, _ := values[name].(string)
+		return v
+	}
+
+	version, err := strconv.Atoi(field("version"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid event version: %w", err)
+	}
+
+	occurredAt, err := time.Parse(time.RFC3339Nano, field("occurred_at"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid event time: %w", err)
+	}
+
+	event := &Event{
+		ID:            field("id"),
+		Type:          field("type"),
+		Version:       version,
+		AggregateType: field("aggregate_type"),
+		AggregateID:   field("aggregate_id"),
+		OccurredAt:    occurredAt,
+		Payload:       json.RawMessage(field("payload")),
+	}
+
+	if event.ID == "" || event.Type == "" || !json.Valid(event.Payload) {
+		return nil, errors.New("incomplete event")
+	}
+
+	return event, nil
+}
@@ -0,0 +1,182 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRetryAfter = time.Minute
+
+// newTestConsumer reads events:customer as worker-1 of the notification
+// group on a miniredis whose clock only moves by the retry delay on advance.
+func newTestConsumer(t *testing.T, maxDeliveries int64) (advance func(), client *redis.Client, consumer *RedisStreamConsumer, publish func(id string)) {
+	mr := miniredis.RunT(t)
+	now := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+	mr.SetTime(now)
+
+	advance = func() {
+		now = now.Add(testRetryAfter)
+		mr.SetTime(now)
+	}
+
+	client = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	publisher := NewRedisStreamPublisher(client, "events:", 0)
+	consumer = NewRedisStreamConsumer(client, publisher.Stream("customer"), "notification", "worker-1", 10, 10*time.Millisecond, testRetryAfter, maxDeliveries)
+
+	ctx := context.Background()
+	assert.NoError(t, consumer.EnsureGroup(ctx))
+	assert.NoError(t, consumer.EnsureGroup(ctx))
+
+	publish = func(id string) {
+		assert.NoError(t, publisher.Publish(ctx, &Event{
+			ID:            id,
+			Type:          "transaction.booked",
+			Version:       1,
+			AggregateType: "customer",
+			AggregateID:   "1",
+			OccurredAt:    time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC),
+			Payload:       json.RawMessage(`{"customer_id":1}`),
+		}))
+	}
+
+	return advance, client, consumer, publish
+}
+
+func TestRedisStreamConsumer_Poll(t *testing.T) {
+	advance, client, consumer, publish := newTestConsumer(t, 5)
+	ctx := context.Background()
+
+	publish("first")
+	publish("second")
+	assert.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: "events:customer", Values: map[string]interface{}{"id": "broken"}}).Err())
+
+	var seen []string
+	handle := func(ctx context.Context, event *Event) error {
+		seen = append(seen, event.ID)
+		if event.ID == "first" && len(seen) == 1 {
+			return errors.New("database is down")
+		}
+		return nil
+	}
+
+	res, err := consumer.Poll(ctx, handle)
+	assert.NoError(t, err)
+	assert.Equal(t, &ConsumeResult{Handled: 1, Failed: 1, Dropped: 1}, res)
+
+	// the failed event waits out the retry delay without holding up new events
+	publish("third")
+	res, err = consumer.Poll(ctx, handle)
+	assert.NoError(t, err)
+	assert.Equal(t, &ConsumeResult{Handled: 1}, res)
+
+	advance()
+	res, err = consumer.Poll(ctx, handle)
+	assert.NoError(t, err)
+	assert.Equal(t, &ConsumeResult{Handled: 1}, res)
+	assert.Equal(t, []string{"first", "second", "third", "first"}, seen)
+
+	res, err = consumer.Poll(ctx, handle)
+	assert.NoError(t, err)
+	assert.Equal(t, &ConsumeResult{}, res)
+}
+
+func TestRedisStreamConsumer_Poll_DeadLetter(t *testing.T) {
+	tests := []struct {
+		name string
+		// polls one retry delay apart, the last one gives the event up
+		polls     int
+		handleErr error
+		setupFn   func(t *testing.T, client *redis.Client)
+		wantError string
+		wantCalls int
+		wantTries string
+	}{
+		{
+			name:      "Failed On Every Delivery",
+			polls:     3,
+			handleErr: errors.New("database is down"),
+			wantError: "database is down",
+			wantCalls: 3,
+			wantTries: "3",
+		},
+		{
+			name:      "Failed Permanently",
+			polls:     1,
+			handleErr: Permanent(errors.New("template not found")),
+			wantError: "template not found",
+			wantCalls: 1,
+			wantTries: "1",
+		},
+		{
+			name:  "Never Acknowledged By A Stopped Consumer",
+			polls: 1,
+			setupFn: func(t *testing.T, client *redis.Client) {
+				ctx := context.Background()
+				streams, err := client.XReadGroup(ctx, &redis.XReadGroupArgs{Group: "notification", Consumer: "worker-2", Streams: []string{"events:customer", ">"}}).Result()
+				assert.NoError(t, err)
+				assert.NoError(t, client.Do(ctx, "XCLAIM", "events:customer", "notification", "worker-2", 0, streams[0].Messages[0].ID, "RETRYCOUNT", 3).Err())
+			},
+			wantError: "not acknowledged",
+			wantCalls: 0,
+			wantTries: "4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			advance, client, consumer, publish := newTestConsumer(t, 3)
+			ctx := context.Background()
+
+			publish("poison")
+			if tt.setupFn != nil {
+				tt.setupFn(t, client)
+				advance()
+			}
+
+			var calls int
+			handle := func(ctx context.Context, event *Event) error {
+				calls++
+				return tt.handleErr
+			}
+
+			var res *ConsumeResult
+			for i := 0; i < tt.polls; i++ {
+				if i > 0 {
+					advance()
+				}
+
+				var err error
+				res, err = consumer.Poll(ctx, handle)
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, &ConsumeResult{DeadLettered: 1}, res)
+			assert.Equal(t, tt.wantCalls, calls)
+
+			pending, err := client.XPending(ctx, "events:customer", "notification").Result()
+			assert.NoError(t, err)
+			assert.Equal(t, int64(0), pending.Count)
+
+			dead, err := client.XRange(ctx, consumer.DeadLetterStream(), "-", "+").Result()
+			assert.NoError(t, err)
+			if assert.Len(t, dead, 1) {
+				assert.Equal(t, "poison", dead[0].Values["id"])
+				assert.Equal(t, "notification", dead[0].Values["group"])
+				assert.Equal(t, tt.wantTries, dead[0].Values["deliveries"])
+				assert.Equal(t, tt.wantError, dead[0].Values["error"])
+			}
+
+			// nothing comes back once the event is given up
+			advance()
+			res, err = consumer.Poll(ctx, handle)
+			assert.NoError(t, err)
+			assert.Equal(t, &ConsumeResult{}, res)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Event is the envelope every domain event is published in. Version is the
+// schema version of Payload for Type: a payload only gains optional fields
+// within a version, anything else ships as a new version of the event.
+type Event struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	Version       int             `json:"version"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// Publisher hands an event to the consumers. Delivery is at least once: an
+// event may be published again after a failure, so consumers skip IDs they
+// have already seen.
+type Publisher interface {
+	Publish(ctx context.Context, event *Event) error
+}
+
+var _ Publisher = &RedisStreamPublisher{}
+
+// RedisStreamPublisher appends events to one stream per aggregate type, so
+// the events of an aggregate are read back in the order they were published.
+type RedisStreamPublisher struct {
+	client *redis.Client
+	prefix string
+	maxLen int64
+}
+
+// NewRedisStreamPublisher publishes to <prefix><aggregate type>. Streams are
+// trimmed to about maxLen entries, 0 keeps every entry.
+func NewRedisStreamPublisher(client *redis.Client, prefix string, maxLen int64) *RedisStreamPublisher {
+	return &RedisStreamPublisher{
+		client: client,
+		prefix: prefix,
+		maxLen: maxLen,
+	}
+}
+
+func (p *RedisStreamPublisher) Stream(aggregateType string) string {
+	return p.prefix + aggregateType
+}
+
+func (p *RedisStreamPublisher) Publish(ctx context.Context, event *Event) error {
+	err := p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.Stream(event.AggregateType),
+		MaxLen: p.maxLen,
+		Approx: p.maxLen > 0,
+		Values: map[string]interface{}{
+			"id":             event.ID,
+			"type":           event.Type,
+			"version":        strconv.Itoa(event.Version),
+			"aggregate_type": event.AggregateType,
+			"aggregate_id":   event.AggregateID,
+			"occurred_at":    event.OccurredAt.UTC().Format(time.RFC3339Nano),
+			"payload":        string(event.Payload),
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to publish event %s: %w", event.ID, err)
+	}
+
+	return nil
+}
+
+var _ Publisher = &MemoryPublisher{}
+
+// MemoryPublisher keeps the published events in memory, for tests.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []Event
+	fail   func(event *Event) error
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// FailWith makes Publish return the error of fn for the events it returns
+// one for; those events are not recorded.
+func (p *MemoryPublisher) FailWith(fn func(event *Event) error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.fail = fn
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, event *Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.fail != nil {
+		if err := p.fail(event); err != nil {
+			return err
+		}
+	}
+
+	p.events = append(p.events, *event)
+	return nil
+}
+
+// Events returns a copy of the events published so far, oldest first.
+func (p *MemoryPublisher) Events() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Event(nil), p.events...)
+}
@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisStreamPublisher_Publish(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	publisher := NewRedisStreamPublisher(client, "events:", 0)
+
+	ctx := context.Background()
+	occurredAt := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, id := range []string{"first", "second"} {
+		assert.NoError(t, publisher.Publish(ctx, &Event{
+			ID:            id,
+			Type:          "customer.registered",
+			Version:       1,
+			AggregateType: "customer",
+			AggregateID:   "1",
+			OccurredAt:    occurredAt,
+			Payload:       json.RawMessage(`{"customer_id":1}`),
+		}))
+	}
+
+	entries, err := client.XRange(ctx, "events:customer", "-", "+").Result()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "first", entries[0].Values["id"])
+	assert.Equal(t, "second", entries[1].Values["id"])
+	assert.Equal(t, "1", entries[0].Values["version"])
+	assert.Equal(t, "2024-12-01T10:00:00Z", entries[0].Values["occurred_at"])
+	assert.Equal(t, `{"customer_id":1}`, entries[0].Values["payload"])
+
+	mr.Close()
+	assert.Error(t, publisher.Publish(ctx, &Event{ID: "third", AggregateType: "customer"}))
+}
+
+func TestMemoryPublisher_Publish(t *testing.T) {
+	publisher := NewMemoryPublisher()
+	failed := errors.New("broker is down")
+
+	publisher.FailWith(func(event *Event) error {
+		if event.AggregateID == "2" {
+			return failed
+		}
+		return nil
+	})
+
+	assert.NoError(t, publisher.Publish(context.Background(), &Event{ID: "a", AggregateID: "1"}))
+	assert.ErrorIs(t, publisher.Publish(context.Background(), &Event{ID: "b", AggregateID: "2"}), failed)
+
+	events := publisher.Events()
+	assert.Len(t, events, 1)
+	assert.Equal(t, "a", events[0].ID)
+}
@@ -0,0 +1,287 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: multifinance/v1/credit_limit.proto
+
+package multifinancev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreditLimit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tenor       int32   `protobuf:"varint,1,opt,name=tenor,proto3" json:"tenor,omitempty"`
+	LimitAmount float64 `protobuf:"fixed64,2,opt,name=limit_amount,json=limitAmount,proto3" json:"limit_amount,omitempty"`
+}
+
+func (x *CreditLimit) Reset() {
+	*x = CreditLimit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_multifinance_v1_credit_limit_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreditLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreditLimit) ProtoMessage() {}
+
+func (x *CreditLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_multifinance_v1_credit_limit_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreditLimit.ProtoReflect.Descriptor instead.
+func (*CreditLimit) Descriptor() ([]byte, []int) {
+	return file_multifinance_v1_credit_limit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreditLimit) GetTenor() int32 {
+	if x != nil {
+		return x.Tenor
+	}
+	return 0
+}
+
+func (x *CreditLimit) GetLimitAmount() float64 {
+	if x != nil {
+		return x.LimitAmount
+	}
+	return 0
+}
+
+type ListCreditLimitsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListCreditLimitsRequest) Reset() {
+	*x = ListCreditLimitsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_multifinance_v1_credit_limit_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCreditLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCreditLimitsRequest) ProtoMessage() {}
+
+func (x *ListCreditLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_multifinance_v1_credit_limit_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCreditLimitsRequest.ProtoReflect.Descriptor instead.
+func (*ListCreditLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_multifinance_v1_credit_limit_proto_rawDescGZIP(), []int{1}
+}
+
+type ListCreditLimitsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limits []*CreditLimit `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty"`
+}
+
+func (x *ListCreditLimitsResponse) Reset() {
+	*x = ListCreditLimitsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_multifinance_v1_credit_limit_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCreditLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCreditLimitsResponse) ProtoMessage() {}
+
+func (x *ListCreditLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_multifinance_v1_credit_limit_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCreditLimitsResponse.ProtoReflect.Descriptor instead.
+func (*ListCreditLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_multifinance_v1_credit_limit_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListCreditLimitsResponse) GetLimits() []*CreditLimit {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+var File_multifinance_v1_credit_limit_proto protoreflect.FileDescriptor
+
+var file_multifinance_v1_credit_limit_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x76,
+	0x31, 0x2f, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x69, 0x6e, 0x61, 0x6e,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x22, 0x46, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x65, 0x6e, 0x6f, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x65, 0x6e, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x19, 0x0a,
+	0x17, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x50, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74,
+	0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x69, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x32, 0x7d, 0x0a, 0x12, 0x43, 0x72,
+	0x65, 0x64, 0x69, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x67, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x12, 0x28, 0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x69, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x72, 0x65, 0x64, 0x69,
+	0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29,
+	0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x53, 0x5a, 0x51, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x69, 0x6c, 0x6d, 0x69, 0x69, 0x6b, 0x68,
+	0x73, 0x61, 0x6e, 0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f,
+	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x3b,
+	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x76, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_multifinance_v1_credit_limit_proto_rawDescOnce sync.Once
+	file_multifinance_v1_credit_limit_proto_rawDescData = file_multifinance_v1_credit_limit_proto_rawDesc
+)
+
+func file_multifinance_v1_credit_limit_proto_rawDescGZIP() []byte {
+	file_multifinance_v1_credit_limit_proto_rawDescOnce.Do(func() {
+		file_multifinance_v1_credit_limit_proto_rawDescData = protoimpl.X.CompressGZIP(file_multifinance_v1_credit_limit_proto_rawDescData)
+	})
+	return file_multifinance_v1_credit_limit_proto_rawDescData
+}
+
+var file_multifinance_v1_credit_limit_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_multifinance_v1_credit_limit_proto_goTypes = []any{
+	(*CreditLimit)(nil),              // 0: multifinance.v1.CreditLimit
+	(*ListCreditLimitsRequest)(nil),  // 1: multifinance.v1.ListCreditLimitsRequest
+	(*ListCreditLimitsResponse)(nil), // 2: multifinance.v1.ListCreditLimitsResponse
+}
+var file_multifinance_v1_credit_limit_proto_depIdxs = []int32{
+	0, // 0: multifinance.v1.ListCreditLimitsResponse.limits:type_name -> multifinance.v1.CreditLimit
+	1, // 1: multifinance.v1.CreditLimitService.ListCreditLimits:input_type -> multifinance.v1.ListCreditLimitsRequest
+	2, // 2: multifinance.v1.CreditLimitService.ListCreditLimits:output_type -> multifinance.v1.ListCreditLimitsResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_multifinance_v1_credit_limit_proto_init() }
+func file_multifinance_v1_credit_limit_proto_init() {
+	if File_multifinance_v1_credit_limit_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_multifinance_v1_credit_limit_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*CreditLimit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_multifinance_v1_credit_limit_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ListCreditLimitsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_multifinance_v1_credit_limit_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ListCreditLimitsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_multifinance_v1_credit_limit_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_multifinance_v1_credit_limit_proto_goTypes,
+		DependencyIndexes: file_multifinance_v1_credit_limit_proto_depIdxs,
+		MessageInfos:      file_multifinance_v1_credit_limit_proto_msgTypes,
+	}.Build()
+	File_multifinance_v1_credit_limit_proto = out.File
+	file_multifinance_v1_credit_limit_proto_rawDesc = nil
+	file_multifinance_v1_credit_limit_proto_goTypes = nil
+	file_multifinance_v1_credit_limit_proto_depIdxs = nil
+}
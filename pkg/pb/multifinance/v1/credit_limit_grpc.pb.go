@@ -0,0 +1,125 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: multifinance/v1/credit_limit.proto
+
+package multifinancev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CreditLimitService_ListCreditLimits_FullMethodName = "/multifinance.v1.CreditLimitService/ListCreditLimits"
+)
+
+// CreditLimitServiceClient is the client API for CreditLimitService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CreditLimitService serves the limits of the customer in the access token.
+type CreditLimitServiceClient interface {
+	ListCreditLimits(ctx context.Context, in *ListCreditLimitsRequest, opts ...grpc.CallOption) (*ListCreditLimitsResponse, error)
+}
+
+type creditLimitServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCreditLimitServiceClient(cc grpc.ClientConnInterface) CreditLimitServiceClient {
+	return &creditLimitServiceClient{cc}
+}
+
+func (c *creditLimitServiceClient) ListCreditLimits(ctx context.Context, in *ListCreditLimitsRequest, opts ...grpc.CallOption) (*ListCreditLimitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCreditLimitsResponse)
+	err := c.cc.Invoke(ctx, CreditLimitService_ListCreditLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreditLimitServiceServer is the server API for CreditLimitService service.
+// All implementations must embed UnimplementedCreditLimitServiceServer
+// for forward compatibility.
+//
+// CreditLimitService serves the limits of the customer in the access token.
+type CreditLimitServiceServer interface {
+	ListCreditLimits(context.Context, *ListCreditLimitsRequest) (*ListCreditLimitsResponse, error)
+	mustEmbedUnimplementedCreditLimitServiceServer()
+}
+
+// UnimplementedCreditLimitServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCreditLimitServiceServer struct{}
+
+func (UnimplementedCreditLimitServiceServer) ListCreditLimits(context.Context, *ListCreditLimitsRequest) (*ListCreditLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCreditLimits not implemented")
+}
+func (UnimplementedCreditLimitServiceServer) mustEmbedUnimplementedCreditLimitServiceServer() {}
+func (UnimplementedCreditLimitServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeCreditLimitServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CreditLimitServiceServer will
+// result in compilation errors.
+type UnsafeCreditLimitServiceServer interface {
+	mustEmbedUnimplementedCreditLimitServiceServer()
+}
+
+func RegisterCreditLimitServiceServer(s grpc.ServiceRegistrar, srv CreditLimitServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCreditLimitServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CreditLimitService_ServiceDesc, srv)
+}
+
+func _CreditLimitService_ListCreditLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCreditLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CreditLimitServiceServer).ListCreditLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CreditLimitService_ListCreditLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CreditLimitServiceServer).ListCreditLimits(ctx, req.(*ListCreditLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CreditLimitService_ServiceDesc is the grpc.ServiceDesc for CreditLimitService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CreditLimitService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "multifinance.v1.CreditLimitService",
+	HandlerType: (*CreditLimitServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCreditLimits",
+			Handler:    _CreditLimitService_ListCreditLimits_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "multifinance/v1/credit_limit.proto",
+}
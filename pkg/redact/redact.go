@@ -0,0 +1,263 @@
+// Package redact hides the fields tagged log:"redact" or log:"mask" when a
+// value is logged. Set Marshal as zerolog.InterfaceMarshalFunc and every
+// Any/Interface field goes through it.
+package redact
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	TagName   = "log"
+	TagRedact = "redact"
+	TagMask   = "mask"
+
+	Redacted = "[REDACTED]"
+
+	// characters Mask leaves visible at the end of a value
+	visibleSuffix = 4
+)
+
+// types without a tagged field, directly or in a nested value, are logged as
+// they are; the answer is kept per type
+var tagged sync.Map
+
+// Marshal is json.Marshal on the value with its tagged fields hidden.
+func Marshal(v any) ([]byte, error) {
+	return json.Marshal(Value(v))
+}
+
+// Value returns v ready to be encoded: structs with tagged fields become maps
+// keyed like encoding/json would, everything else is returned as is.
+func Value(v any) any {
+	if v == nil {
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if !hasTags(rv.Type()) {
+		return v
+	}
+
+	return walk(rv)
+}
+
+// Mask keeps the last characters of a value, or the first letter and the
+// domain of an email address, so log lines can still be told apart.
+func Mask(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	if at := strings.LastIndexByte(s, '@'); at > 0 {
+		return s[:1] + strings.Repeat("*", at-1) + s[at:]
+	}
+
+	if len(s) <= visibleSuffix {
+		return strings.Repeat("*", len(s))
+	}
+
+	return strings.Repeat("*", len(s)-visibleSuffix) + s[len(s)-visibleSuffix:]
+}
+
+func walk(rv reflect.Value) any {
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return walk(rv.Elem())
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil
+		}
+		items := make([]any, rv.Len())
+		for i := range items {
+			items[i] = walk(rv.Index(i))
+		}
+		return items
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil
+		}
+		items := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			items[mapKey(iter.Key())] = walk(iter.Value())
+		}
+		return items
+	case reflect.Struct:
+		if !rv.CanInterface() {
+			return nil
+		}
+		if !hasTags(rv.Type()) {
+			return rv.Interface()
+		}
+		fields := make(map[string]any)
+		walkStruct(rv, fields)
+		return fields
+	default:
+		if !rv.CanInterface() {
+			return nil
+		}
+		return rv.Interface()
+	}
+}
+
+func walkStruct(rv reflect.Value, fields map[string]any) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, omitEmpty, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		value := rv.Field(i)
+
+		// embedded structs without a name of their own are flattened
+		if field.Anonymous && name == "" {
+			for value.Kind() == reflect.Pointer {
+				if value.IsNil() {
+					break
+				}
+				value = value.Elem()
+			}
+			if value.Kind() == reflect.Struct {
+				walkStruct(value, fields)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if omitEmpty && isEmpty(value) {
+			continue
+		}
+
+		switch field.Tag.Get(TagName) {
+		case TagRedact:
+			fields[name] = Redacted
+		case TagMask:
+			fields[name] = mask(value)
+		default:
+			fields[name] = walk(value)
+		}
+	}
+}
+
+// mask masks strings and values stored as strings such as sql.NullString,
+// anything else is redacted.
+func mask(rv reflect.Value) any {
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() == reflect.String {
+		return Mask(rv.String())
+	}
+
+	if !rv.CanInterface() {
+		return Redacted
+	}
+
+	if valuer, ok := rv.Interface().(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err == nil && value == nil {
+			return nil
+		}
+		if s, ok := value.(string); ok && err == nil {
+			return Mask(s)
+		}
+	}
+
+	return Redacted
+}
+
+func jsonName(field reflect.StructField) (name string, omitEmpty bool, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, options, _ := strings.Cut(tag, ",")
+	for _, option := range strings.Split(options, ",") {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+
+	return name, omitEmpty, false
+}
+
+func mapKey(key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return key.String()
+	}
+
+	b, err := json.Marshal(key.Interface())
+	if err != nil {
+		return ""
+	}
+
+	return strings.Trim(string(b), `"`)
+}
+
+// isEmpty follows the omitempty rules of encoding/json.
+func isEmpty(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return rv.IsZero()
+	}
+
+	return false
+}
+
+func hasTags(rt reflect.Type) bool {
+	if found, ok := tagged.Load(rt); ok {
+		return found.(bool)
+	}
+
+	found := findTags(rt, map[reflect.Type]bool{})
+	tagged.Store(rt, found)
+
+	return found
+}
+
+func findTags(rt reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[rt] {
+		return false
+	}
+	seen[rt] = true
+
+	switch rt.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return findTags(rt.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			if field.Tag.Get(TagName) != "" || findTags(field.Type, seen) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
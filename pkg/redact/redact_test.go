@@ -0,0 +1,114 @@
+package redact
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type credentials struct {
+	Email    string `json:"email" log:"mask"`
+	Password string `json:"password" log:"redact"`
+}
+
+type customer struct {
+	ID          int64          `db:"id"`
+	Nik         string         `db:"nik" log:"mask"`
+	PhoneNumber sql.NullString `db:"phone_number" log:"mask"`
+	Salary      float64        `db:"salary" log:"redact"`
+	Note        string         `json:"note,omitempty"`
+	Internal    string         `json:"-"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type signup struct {
+	credentials
+	Device  string       `json:"device"`
+	Members []customer   `json:"members"`
+	Owner   *credentials `json:"owner"`
+}
+
+type plain struct {
+	Name string `json:"name"`
+}
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "Empty", value: "", want: ""},
+		{name: "NIK", value: "3171011501900001", want: "************0001"},
+		{name: "Short", value: "1234", want: "****"},
+		{name: "Email", value: "jane@example.com", want: "j***@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Mask(tt.value))
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	createdAt := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{
+			name:  "Tagged Fields",
+			value: credentials{Email: "jane@example.com", Password: "Secret123!"},
+			want:  `{"email":"j***@example.com","password":"[REDACTED]"}`,
+		},
+		{
+			name: "Entity Without JSON Tags",
+			value: &customer{
+				ID:          1,
+				Nik:         "3171011501900001",
+				PhoneNumber: sql.NullString{String: "081234567890", Valid: true},
+				Salary:      10000000,
+				Internal:    "hidden",
+				CreatedAt:   createdAt,
+			},
+			want: `{"ID":1,"Nik":"************0001","PhoneNumber":"********7890","Salary":"[REDACTED]","created_at":"2024-12-01T00:00:00Z"}`,
+		},
+		{
+			name: "Nested And Embedded",
+			value: signup{
+				credentials: credentials{Email: "jane@example.com", Password: "Secret123!"},
+				Device:      "device-1",
+				Members:     []customer{{ID: 2, Nik: "3171011501900002", Note: "vip", CreatedAt: createdAt}},
+			},
+			want: `{"device":"device-1","email":"j***@example.com","members":[{"ID":2,"Nik":"************0002","PhoneNumber":null,"Salary":"[REDACTED]","created_at":"2024-12-01T00:00:00Z","note":"vip"}],"owner":null,"password":"[REDACTED]"}`,
+		},
+		{
+			name:  "Map Of Tagged Values",
+			value: map[int]credentials{7: {Email: "jane@example.com", Password: "Secret123!"}},
+			want:  `{"7":{"email":"j***@example.com","password":"[REDACTED]"}}`,
+		},
+		{
+			name:  "Untagged Type",
+			value: plain{Name: "jane"},
+			want:  `{"name":"jane"}`,
+		},
+		{
+			name:  "Nil",
+			value: nil,
+			want:  `null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.value)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+var _ Locker = &RedisLocker{}
+
+// RedisLocker claims keys with SET NX, every worker pointing at the same
+// Redis shares the claims.
+type RedisLocker struct {
+	client *redis.Client
+	value  string
+}
+
+// NewRedisLocker stores value, usually the worker instance, in the keys it
+// claims so an operator can see who holds them.
+func NewRedisLocker(client *redis.Client, value string) *RedisLocker {
+	return &RedisLocker{
+		client: client,
+		value:  value,
+	}
+}
+
+func (l *RedisLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return l.client.SetNX(ctx, key, l.value, ttl).Result()
+}
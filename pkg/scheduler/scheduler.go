@@ -0,0 +1,247 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrUnknownJob   = errors.New("scheduled job does not exist")
+	ErrJobLocked    = errors.New("scheduled job is already claimed by another worker")
+	ErrDuplicateJob = errors.New("scheduled job is already registered")
+)
+
+// Job is recurring work run on a cron schedule. Spec is a standard five field
+// expression read in the location of the scheduler. Timeout bounds a run and
+// is also how long its claim is held, so it must be shorter than the gap
+// between two runs.
+type Job struct {
+	Name    string
+	Spec    string
+	Timeout time.Duration
+	Run     func(ctx context.Context) (any, error)
+}
+
+// Run is one execution of a job. ID is set by the recorder.
+type Run struct {
+	ID          int64
+	Job         string
+	Instance    string
+	ScheduledAt time.Time
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	Result      any
+	Err         error
+}
+
+// Locker claims a key for every worker sharing it. Acquire reports false when
+// another worker holds the key.
+type Locker interface {
+	Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// Recorder keeps the history of runs. A run is recorded when it starts and
+// again when it finishes.
+type Recorder interface {
+	Started(ctx context.Context, run *Run) error
+	Finished(ctx context.Context, run *Run) error
+}
+
+type entry struct {
+	job      Job
+	schedule cron.Schedule
+}
+
+// Scheduler runs jobs on their schedules. Every run is claimed in the locker
+// under its scheduled time before it starts, so workers sharing the locker
+// run each occurrence once between them. The claim is left to expire rather
+// than released, so a worker whose clock runs late cannot claim the same
+// occurrence after a quick run has finished.
+type Scheduler struct {
+	locker   Locker
+	recorder Recorder
+	instance string
+	location *time.Location
+	entries  []entry
+	now      func() time.Time
+}
+
+func New(locker Locker, recorder Recorder, instance string, location *time.Location) *Scheduler {
+	return &Scheduler{
+		locker:   locker,
+		recorder: recorder,
+		instance: instance,
+		location: location,
+		now:      time.Now,
+	}
+}
+
+// Add registers a job. It fails on an invalid expression and on a timeout
+// that would let a run overlap the next one.
+func (s *Scheduler) Add(job Job) error {
+	for _, e := range s.entries {
+		if e.job.Name == job.Name {
+			return fmt.Errorf("%w: %s", ErrDuplicateJob, job.Name)
+		}
+	}
+
+	schedule, err := cron.ParseStandard(job.Spec)
+	if err != nil {
+		return fmt.Errorf("invalid schedule %q of job %s: %w", job.Spec, job.Name, err)
+	}
+
+	if job.Timeout <= 0 {
+		return fmt.Errorf("job %s needs a timeout", job.Name)
+	}
+
+	if gap := shortestGap(schedule, s.now().In(s.location)); job.Timeout > gap {
+		return fmt.Errorf("timeout %s of job %s is longer than the %s between two of its runs", job.Timeout, job.Name, gap)
+	}
+
+	s.entries = append(s.entries, entry{job: job, schedule: schedule})
+	return nil
+}
+
+// Start runs every job on its schedule until ctx is done, then waits for the
+// runs in flight to finish. Those runs are not cancelled, their timeout still
+// applies.
+func (s *Scheduler) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, e := range s.entries {
+		wg.Add(1)
+		go func(e entry) {
+			defer wg.Done()
+			s.loop(ctx, e)
+		}(e)
+	}
+
+	wg.Wait()
+}
+
+// RunNow runs a job once, right away, with the same claim and history as a
+// scheduled run.
+func (s *Scheduler) RunNow(ctx context.Context, name string) (*Run, error) {
+	for _, e := range s.entries {
+		if e.job.Name == name {
+			return s.execute(ctx, e.job, s.now().In(s.location).Truncate(time.Second))
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownJob, name)
+}
+
+// Jobs returns the registered jobs in the order they were added.
+func (s *Scheduler) Jobs() []Job {
+	res := make([]Job, 0, len(s.entries))
+	for _, e := range s.entries {
+		res = append(res, e.job)
+	}
+
+	return res
+}
+
+// loop runs one job, one run at a time. Occurrences missed while a run was in
+// flight are skipped rather than caught up.
+func (s *Scheduler) loop(ctx context.Context, e entry) {
+	for {
+		next := e.schedule.Next(s.now().In(s.location))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// shutdown must not cut a run short, only stop new ones
+		run, err := s.execute(context.WithoutCancel(ctx), e.job, next)
+		switch {
+		case errors.Is(err, ErrJobLocked):
+			log.Debug().Str("job", e.job.Name).Time("scheduled_at", next).Msg("scheduler::loop - Run claimed by another worker")
+		case err != nil:
+			log.Error().Err(err).Str("job", e.job.Name).Time("scheduled_at", next).Msg("scheduler::loop - Failed to run job")
+		case run.Err != nil:
+			log.Error().Err(run.Err).Str("job", e.job.Name).Dur("duration", run.FinishedAt.Sub(run.StartedAt)).Msg("scheduler::loop - Job failed")
+		default:
+			log.Info().Str("job", e.job.Name).Dur("duration", run.FinishedAt.Sub(run.StartedAt)).Any("result", run.Result).Msg("scheduler::loop - Job finished")
+		}
+	}
+}
+
+// execute claims and runs one occurrence. The returned error is about the
+// scheduling; what the job itself returned is in the run.
+func (s *Scheduler) execute(ctx context.Context, job Job, scheduledAt time.Time) (*Run, error) {
+	key := fmt.Sprintf("scheduler:%s:%d", job.Name, scheduledAt.Unix())
+
+	ok, err := s.locker.Acquire(ctx, key, job.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim %s: %w", key, err)
+	}
+
+	if !ok {
+		return nil, ErrJobLocked
+	}
+
+	run := &Run{
+		Job:         job.Name,
+		Instance:    s.instance,
+		ScheduledAt: scheduledAt,
+		StartedAt:   s.now(),
+	}
+
+	// the history is for people, a job still runs when it cannot be written
+	if err := s.recorder.Started(ctx, run); err != nil {
+		log.Warn().Err(err).Str("job", job.Name).Msg("scheduler::execute - Failed to record job start")
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, job.Timeout)
+	run.Result, run.Err = safeRun(runCtx, job)
+	cancel()
+
+	run.FinishedAt = s.now()
+
+	if err := s.recorder.Finished(ctx, run); err != nil {
+		log.Warn().Err(err).Str("job", job.Name).Msg("scheduler::execute - Failed to record job result")
+	}
+
+	return run, nil
+}
+
+// safeRun turns a panic in a job into a failed run, so one broken job does
+// not take the worker and the other jobs down with it.
+func safeRun(ctx context.Context, job Job) (res any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job %s panicked: %v", job.Name, r)
+		}
+	}()
+
+	return job.Run(ctx)
+}
+
+// shortestGap looks at the next runs of a schedule for the shortest time
+// between two of them.
+func shortestGap(schedule cron.Schedule, from time.Time) time.Duration {
+	var (
+		gap  time.Duration
+		prev = schedule.Next(from)
+	)
+
+	for i := 0; i < 32; i++ {
+		next := schedule.Next(prev)
+		if d := next.Sub(prev); gap == 0 || d < gap {
+			gap = d
+		}
+		prev = next
+	}
+
+	return gap
+}
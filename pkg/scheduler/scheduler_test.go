@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryRecorder struct {
+	mu       sync.Mutex
+	started  []Run
+	finished []Run
+}
+
+func (r *memoryRecorder) Started(ctx context.Context, run *Run) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run.ID = int64(len(r.started) + 1)
+	r.started = append(r.started, *run)
+	return nil
+}
+
+func (r *memoryRecorder) Finished(ctx context.Context, run *Run) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.finished = append(r.finished, *run)
+	return nil
+}
+
+func newTestScheduler(t *testing.T, instance string) (*Scheduler, *memoryRecorder, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	recorder := new(memoryRecorder)
+	return New(NewRedisLocker(client, instance), recorder, instance, time.UTC), recorder, mr
+}
+
+func TestScheduler_Add(t *testing.T) {
+	run := func(ctx context.Context) (any, error) { return nil, nil }
+
+	tests := []struct {
+		name    string
+		job     Job
+		wantErr bool
+	}{
+		{name: "Daily Job", job: Job{Name: "daily", Spec: "0 8 * * *", Timeout: time.Hour, Run: run}},
+		{name: "Invalid Expression", job: Job{Name: "bad", Spec: "0 25 * * *", Timeout: time.Hour, Run: run}, wantErr: true},
+		{name: "Missing Timeout", job: Job{Name: "no-timeout", Spec: "0 8 * * *", Run: run}, wantErr: true},
+		{name: "Timeout Longer Than Gap", job: Job{Name: "overlap", Spec: "*/5 * * * *", Timeout: 10 * time.Minute, Run: run}, wantErr: true},
+		{name: "Duplicate Name", job: Job{Name: "daily", Spec: "0 9 * * *", Timeout: time.Hour, Run: run}, wantErr: true},
+	}
+
+	s, _, _ := newTestScheduler(t, "worker-1")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, s.Add(tt.job) != nil)
+		})
+	}
+
+	assert.Len(t, s.Jobs(), 1)
+}
+
+func TestScheduler_RunNow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	s, recorder, mr := newTestScheduler(t, "worker-1")
+	s.now = func() time.Time { return now }
+
+	calls := 0
+	assert.NoError(t, s.Add(Job{
+		Name:    "reminder",
+		Spec:    "0 8 * * *",
+		Timeout: time.Minute,
+		Run: func(ctx context.Context) (any, error) {
+			calls++
+			return map[string]int{"sent": 3}, nil
+		},
+	}))
+	assert.NoError(t, s.Add(Job{
+		Name:    "broken",
+		Spec:    "0 9 * * *",
+		Timeout: time.Minute,
+		Run: func(ctx context.Context) (any, error) {
+			panic("boom")
+		},
+	}))
+
+	run, err := s.RunNow(context.Background(), "reminder")
+	assert.NoError(t, err)
+	assert.NoError(t, run.Err)
+	assert.Equal(t, int64(1), run.ID)
+	assert.Equal(t, now, run.ScheduledAt)
+	mr.CheckGet(t, "scheduler:reminder:1735718400", "worker-1")
+	assert.Equal(t, time.Minute, mr.TTL("scheduler:reminder:1735718400"))
+
+	// the claim outlives the run, so the same occurrence is not run twice
+	_, err = s.RunNow(context.Background(), "reminder")
+	assert.ErrorIs(t, err, ErrJobLocked)
+	assert.Equal(t, 1, calls)
+	assert.Len(t, recorder.finished, 1)
+
+	run, err = s.RunNow(context.Background(), "broken")
+	assert.NoError(t, err)
+	assert.ErrorContains(t, run.Err, "panicked: boom")
+
+	_, err = s.RunNow(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrUnknownJob)
+}
+
+func TestScheduler_Start(t *testing.T) {
+	s, recorder, _ := newTestScheduler(t, "worker-1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{}, 1)
+
+	assert.NoError(t, s.Add(Job{
+		Name:    "slow",
+		Spec:    "@every 1s",
+		Timeout: 900 * time.Millisecond,
+		Run: func(ctx context.Context) (any, error) {
+			started <- struct{}{}
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(200 * time.Millisecond):
+				return "done", nil
+			}
+		},
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		s.Start(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(3 * time.Second):
+		t.Fatal("job did not start")
+	}
+
+	// shutting down while the job runs lets it finish
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("scheduler did not stop")
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	assert.Len(t, recorder.finished, 1)
+	assert.NoError(t, recorder.finished[0].Err)
+	assert.Equal(t, "done", recorder.finished[0].Result)
+}
@@ -0,0 +1,219 @@
+package scoring
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	CharacteristicAge          = "age"
+	CharacteristicSalary       = "salary"
+	CharacteristicTenureMonths = "tenure_months"
+	CharacteristicLatePayments = "late_payments"
+	CharacteristicUtilisation  = "utilisation"
+)
+
+//go:embed scorecard.yaml
+var defaultScorecard []byte
+
+// Inputs are the customer attributes a score is computed from. They are stored
+// next to every score so the result can be explained later.
+type Inputs struct {
+	Age          int     `json:"age"`
+	Salary       float64 `json:"salary"`
+	TenureMonths int     `json:"tenure_months"`
+	LatePayments int     `json:"late_payments"`
+	Utilisation  float64 `json:"utilisation"`
+}
+
+type Grade struct {
+	Name            string  `yaml:"name"`
+	MinScore        int     `yaml:"min_score"`
+	LimitMultiplier float64 `yaml:"limit_multiplier"`
+	Approve         bool    `yaml:"approve"`
+}
+
+type Result struct {
+	Version     string
+	Score       int
+	Grade       Grade
+	ReasonCodes []string
+	Inputs      Inputs
+}
+
+type Scorer interface {
+	Score(inputs Inputs) (*Result, error)
+}
+
+type Bin struct {
+	Min    *float64 `yaml:"min"`
+	Max    *float64 `yaml:"max"`
+	Points int      `yaml:"points"`
+	Reason string   `yaml:"reason"`
+}
+
+func (b Bin) contains(value float64) bool {
+	if b.Min != nil && value < *b.Min {
+		return false
+	}
+
+	if b.Max != nil && value >= *b.Max {
+		return false
+	}
+
+	return true
+}
+
+var _ Scorer = &Scorecard{}
+
+type Scorecard struct {
+	Version         string           `yaml:"version"`
+	BaseScore       int              `yaml:"base_score"`
+	Characteristics map[string][]Bin `yaml:"characteristics"`
+	Grades          []Grade          `yaml:"grades"`
+}
+
+// LoadScorecard reads a scorecard from path, or the bundled default when path is empty.
+func LoadScorecard(path string) (*Scorecard, error) {
+	data := defaultScorecard
+
+	if path != "" {
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read scorecard: %w", err)
+		}
+	}
+
+	return ParseScorecard(data)
+}
+
+func ParseScorecard(data []byte) (*Scorecard, error) {
+	scorecard := new(Scorecard)
+	if err := yaml.Unmarshal(data, scorecard); err != nil {
+		return nil, fmt.Errorf("failed to parse scorecard: %w", err)
+	}
+
+	if len(scorecard.Grades) == 0 {
+		return nil, errors.New("scorecard has no grades")
+	}
+
+	sort.SliceStable(scorecard.Grades, func(i, j int) bool {
+		return scorecard.Grades[i].MinScore > scorecard.Grades[j].MinScore
+	})
+
+	return scorecard, nil
+}
+
+func (s *Scorecard) Score(inputs Inputs) (*Result, error) {
+	values := map[string]float64{
+		CharacteristicAge:          float64(inputs.Age),
+		CharacteristicSalary:       inputs.Salary,
+		CharacteristicTenureMonths: float64(inputs.TenureMonths),
+		CharacteristicLatePayments: float64(inputs.LatePayments),
+		CharacteristicUtilisation:  inputs.Utilisation,
+	}
+
+	type shortfall struct {
+		reason string
+		points int
+	}
+
+	var (
+		score      = s.BaseScore
+		shortfalls []shortfall
+	)
+
+	for name, bins := range s.Characteristics {
+		value, ok := values[name]
+		if !ok {
+			return nil, fmt.Errorf("scorecard characteristic %q is not supported", name)
+		}
+
+		bin, found := findBin(bins, value)
+		if !found {
+			return nil, fmt.Errorf("scorecard has no bin for %s = %v", name, value)
+		}
+
+		score += bin.Points
+
+		if best := maxPoints(bins); bin.Points < best && bin.Reason != "" {
+			shortfalls = append(shortfalls, shortfall{reason: bin.Reason, points: best - bin.Points})
+		}
+	}
+
+	// the characteristics that cost the most points are reported first
+	sort.SliceStable(shortfalls, func(i, j int) bool {
+		if shortfalls[i].points == shortfalls[j].points {
+			return shortfalls[i].reason < shortfalls[j].reason
+		}
+		return shortfalls[i].points > shortfalls[j].points
+	})
+
+	res := &Result{
+		Version:     s.Version,
+		Score:       score,
+		Grade:       s.gradeFor(score),
+		ReasonCodes: make([]string, 0, len(shortfalls)),
+		Inputs:      inputs,
+	}
+
+	for _, sf := range shortfalls {
+		res.ReasonCodes = append(res.ReasonCodes, sf.reason)
+	}
+
+	return res, nil
+}
+
+func (s *Scorecard) gradeFor(score int) Grade {
+	for _, grade := range s.Grades {
+		if score >= grade.MinScore {
+			return grade
+		}
+	}
+
+	return s.Grades[len(s.Grades)-1]
+}
+
+func findBin(bins []Bin, value float64) (Bin, bool) {
+	for _, bin := range bins {
+		if bin.contains(value) {
+			return bin, true
+		}
+	}
+
+	return Bin{}, false
+}
+
+func maxPoints(bins []Bin) int {
+	best := math.MinInt
+	for _, bin := range bins {
+		if bin.Points > best {
+			best = bin.Points
+		}
+	}
+
+	return best
+}
+
+// MonthsBetween counts the whole months elapsed from one date to another, used
+// for the customer tenure characteristic.
+func MonthsBetween(from, to time.Time) int {
+	months := (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+	if to.Day() < from.Day() {
+		months--
+	}
+
+	if months < 0 {
+		return 0
+	}
+
+	return months
+}
@@ -0,0 +1,99 @@
+package scoring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScorecard_Score(t *testing.T) {
+	scorecard, err := LoadScorecard("")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		inputs      Inputs
+		wantScore   int
+		wantGrade   string
+		wantReasons []string
+	}{
+		{
+			name: "Top grade without reasons",
+			inputs: Inputs{
+				Age:          40,
+				Salary:       25000000,
+				TenureMonths: 36,
+				LatePayments: 0,
+				Utilisation:  0.1,
+			},
+			wantScore:   860,
+			wantGrade:   "A",
+			wantReasons: []string{},
+		},
+		{
+			name: "New customer on average salary",
+			inputs: Inputs{
+				Age:    36,
+				Salary: 7000000,
+			},
+			wantScore:   750,
+			wantGrade:   "B",
+			wantReasons: []string{"TENURE_SHORT", "SALARY_AVERAGE"},
+		},
+		{
+			name: "Late payments and high utilisation",
+			inputs: Inputs{
+				Age:          23,
+				Salary:       4000000,
+				TenureMonths: 12,
+				LatePayments: 4,
+				Utilisation:  0.95,
+			},
+			wantScore:   500,
+			wantGrade:   "D",
+			wantReasons: []string{"LATE_PAYMENTS_MANY", "SALARY_BELOW_AVERAGE", "UTILISATION_VERY_HIGH", "AGE_YOUNG", "TENURE_BELOW_TWO_YEARS"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scorecard.Score(tt.inputs)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.wantScore, got.Score)
+			assert.Equal(t, tt.wantGrade, got.Grade.Name)
+			assert.Equal(t, tt.wantReasons, got.ReasonCodes)
+			assert.Equal(t, tt.inputs, got.Inputs)
+			assert.Equal(t, "v1", got.Version)
+		})
+	}
+}
+
+func TestParseScorecard(t *testing.T) {
+	_, err := ParseScorecard([]byte("version: v1\nbase_score: 100\n"))
+	assert.Error(t, err)
+
+	scorecard, err := ParseScorecard([]byte(`
+version: test
+base_score: 100
+characteristics:
+  unknown:
+    - { points: 10 }
+grades:
+  - { name: A, min_score: 0, approve: true }
+`))
+	assert.NoError(t, err)
+
+	_, err = scorecard.Score(Inputs{})
+	assert.Error(t, err)
+}
+
+func TestMonthsBetween(t *testing.T) {
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 0, MonthsBetween(from, time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 1, MonthsBetween(from, time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 23, MonthsBetween(from, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 0, MonthsBetween(from, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,202 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func TestInit(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  bool
+	}{
+		{name: "None", exporter: ExporterNone},
+		{name: "Stdout", exporter: ExporterStdout},
+		{name: "Unknown Exporter", exporter: "zipkin", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shutdown, err := Init(context.Background(), Options{
+				ServiceName: "multifinance-service",
+				Environment: "test",
+				Exporter:    tt.exporter,
+				SampleRatio: 1,
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
+
+func TestLogHook(t *testing.T) {
+	newRecorder(t)
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).Hook(LogHook{})
+
+	ctx, span := Start(context.Background(), "test")
+	defer span.End()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantTrace bool
+	}{
+		{name: "With Span", ctx: ctx, wantTrace: true},
+		{name: "Without Span", ctx: context.Background()},
+		{name: "Without Context"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+
+			event := logger.Info()
+			if tt.ctx != nil {
+				event = event.Ctx(tt.ctx)
+			}
+			event.Msg("message")
+
+			var fields map[string]any
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &fields))
+
+			if !tt.wantTrace {
+				assert.NotContains(t, fields, "trace_id")
+				assert.NotContains(t, fields, "span_id")
+				return
+			}
+
+			assert.Equal(t, span.SpanContext().TraceID().String(), fields["trace_id"])
+			assert.Equal(t, span.SpanContext().SpanID().String(), fields["span_id"])
+		})
+	}
+}
+
+func TestStart_BindsLogger(t *testing.T) {
+	newRecorder(t)
+
+	var buf bytes.Buffer
+	ctx := zerolog.New(&buf).Hook(LogHook{}).WithContext(context.Background())
+
+	ctx, parent := Start(ctx, "parent")
+	defer parent.End()
+
+	ctx, child := Start(ctx, "child")
+	defer child.End()
+
+	// the event names no context, the logger in ctx carries the child span
+	zerolog.Ctx(ctx).Info().Msg("message")
+
+	var fields map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &fields))
+	assert.Equal(t, child.SpanContext().TraceID().String(), fields["trace_id"])
+	assert.Equal(t, child.SpanContext().SpanID().String(), fields["span_id"])
+}
+
+func TestRedisHook(t *testing.T) {
+	recorder := newRecorder(t)
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	rdb.AddHook(RedisHook{})
+
+	parent, span := Start(context.Background(), "parent")
+	defer span.End()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		run        func(ctx context.Context)
+		wantSpan   string
+		wantStatus codes.Code
+	}{
+		{
+			name:     "Command",
+			ctx:      parent,
+			run:      func(ctx context.Context) { rdb.Set(ctx, "key", "value", 0) },
+			wantSpan: "redis set",
+		},
+		{
+			name:     "Missing Key",
+			ctx:      parent,
+			run:      func(ctx context.Context) { rdb.Get(ctx, "missing") },
+			wantSpan: "redis get",
+		},
+		{
+			name:       "Failed Command",
+			ctx:        parent,
+			run:        func(ctx context.Context) { rdb.Incr(ctx, "key") },
+			wantSpan:   "redis incr",
+			wantStatus: codes.Error,
+		},
+		{
+			name: "Pipeline",
+			ctx:  parent,
+			run: func(ctx context.Context) {
+				pipe := rdb.Pipeline()
+				pipe.Get(ctx, "missing")
+				pipe.Set(ctx, "other", "value", 0)
+				_, _ = pipe.Exec(ctx)
+			},
+			wantSpan: "redis pipeline",
+		},
+		{
+			name: "Without Parent",
+			ctx:  context.Background(),
+			run:  func(ctx context.Context) { rdb.Get(ctx, "key") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(recorder.Ended())
+
+			tt.run(tt.ctx)
+
+			ended := recorder.Ended()[before:]
+			if tt.wantSpan == "" {
+				assert.Empty(t, ended)
+				return
+			}
+
+			if assert.Len(t, ended, 1) {
+				assert.Equal(t, tt.wantSpan, ended[0].Name())
+				assert.Equal(t, span.SpanContext().SpanID(), ended[0].Parent().SpanID())
+				assert.Equal(t, tt.wantStatus, ended[0].Status().Code)
+			}
+		})
+	}
+}
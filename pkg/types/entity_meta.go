@@ -22,3 +22,12 @@ func (r *Meta) CountTotalPage(page, paginate, totalData int) {
 		r.TotalPage++
 	}
 }
+
+// CursorMeta describes a keyset page. Next and Prev are empty when there is
+// no page in that direction, and TotalData is only set when it was asked for.
+type CursorMeta struct {
+	Paginate  int    `json:"paginate"`
+	Next      string `json:"next,omitempty"`
+	Prev      string `json:"prev,omitempty"`
+	TotalData *int   `json:"total_data,omitempty"`
+}
@@ -18,6 +18,15 @@ func CalculateInterest(onTheRoadPrice int, tenorMonth int) int {
 	return interest
 }
 
+// CalculateDebtToIncomeRatio returns the share of the monthly income spent on installments.
+func CalculateDebtToIncomeRatio(monthlyInstallment, monthlyIncome float64) float64 {
+	if monthlyIncome <= 0 {
+		return 0
+	}
+
+	return monthlyInstallment / monthlyIncome
+}
+
 func CalculateInstallment(onTheRoadPrice int, interestAmount int, tenorMonth int) int {
 	totalPayable := onTheRoadPrice + interestAmount
 	return totalPayable / tenorMonth
@@ -26,3 +26,29 @@ func GetIntEnv(key string, defaultValue int) int {
 	}
 	return intValue
 }
+
+// GetFloatEnv reads an environment variable as a float and falls back to the default value if not set or invalid.
+func GetFloatEnv(key string, defaultValue float64) float64 {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return floatValue
+}
+
+// GetBoolEnv reads an environment variable as a bool and falls back to the default value if not set or invalid.
+func GetBoolEnv(key string, defaultValue bool) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue
+	}
+	return boolValue
+}
@@ -9,6 +9,7 @@ import (
 	// "github.com/go-playground/locales/en"
 	// ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	"github.com/hilmiikhsan/multifinance-service/pkg/nik"
 	// en_translations "github.com/go-playground/validator/v10/translations/en"
 	"github.com/rs/zerolog/log"
 )
@@ -177,14 +178,9 @@ func otpNumberValidation(fl validator.FieldLevel) bool {
 }
 
 func validateNIK(fl validator.FieldLevel) bool {
-	// Regular expression to ensure NIK is exactly 16 numeric digits
-	numericRegex := regexp.MustCompile(`^\d{16}$`)
-
-	// Get the field value as a string
-	nik := fl.Field().String()
-
-	// Validate against the regex
-	return numericRegex.MatchString(nik)
+	// Decode the NIK to ensure it is 16 digits with a known region and a possible birth date
+	_, err := nik.Parse(fl.Field().String())
+	return err == nil
 }
 
 func validateBirthDate(fl validator.FieldLevel) bool {
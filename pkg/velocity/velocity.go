@@ -0,0 +1,241 @@
+package velocity
+
+import (
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DefaultChannel = "default"
+
+	LimitCount  = "count"
+	LimitAmount = "amount"
+)
+
+//go:embed velocity.yaml
+var defaultRules []byte
+
+// Rule caps the bookings of one customer on one channel within a rolling window.
+type Rule struct {
+	Window    time.Duration `yaml:"window"`
+	MaxCount  int           `yaml:"max_count"`
+	MaxAmount float64       `yaml:"max_amount"`
+}
+
+type Rules struct {
+	Default  []Rule            `yaml:"default"`
+	Channels map[string][]Rule `yaml:"channels"`
+}
+
+// Channel returns the channel the rules are kept under, unknown channels share
+// the default rules so callers cannot create new counters at will.
+func (r *Rules) Channel(channel string) string {
+	if _, ok := r.Channels[channel]; ok {
+		return channel
+	}
+
+	return DefaultChannel
+}
+
+func (r *Rules) For(channel string) []Rule {
+	if rules, ok := r.Channels[channel]; ok {
+		return rules
+	}
+
+	return r.Default
+}
+
+// LoadRules reads the rules from path, or the bundled default when path is empty.
+func LoadRules(path string) (*Rules, error) {
+	data := defaultRules
+
+	if path != "" {
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read velocity rules: %w", err)
+		}
+	}
+
+	return ParseRules(data)
+}
+
+func ParseRules(data []byte) (*Rules, error) {
+	rules := new(Rules)
+	if err := yaml.Unmarshal(data, rules); err != nil {
+		return nil, fmt.Errorf("failed to parse velocity rules: %w", err)
+	}
+
+	if len(rules.Default) == 0 {
+		return nil, errors.New("velocity rules have no default rules")
+	}
+
+	for channel, channelRules := range rules.Channels {
+		if channel == DefaultChannel {
+			return nil, fmt.Errorf("velocity channel %q is reserved", channel)
+		}
+
+		for _, rule := range channelRules {
+			if rule.Window <= 0 {
+				return nil, fmt.Errorf("velocity rule for channel %q has no window", channel)
+			}
+		}
+	}
+
+	for _, rule := range rules.Default {
+		if rule.Window <= 0 {
+			return nil, errors.New("default velocity rule has no window")
+		}
+	}
+
+	return rules, nil
+}
+
+// Breach is returned when a booking would exceed one of the rules.
+type Breach struct {
+	Channel string
+	Rule    Rule
+	Limit   string
+	Current float64
+}
+
+func (b *Breach) Error() string {
+	return fmt.Sprintf("velocity %s limit for %s on channel %s reached", b.Limit, b.Rule.Window, b.Channel)
+}
+
+// Reservation is a booking counted against the rules. It is released again
+// when the booking does not go through.
+type Reservation struct {
+	// Channel is the channel the booking counts under, see Rules.Channel.
+	Channel string
+
+	key    string
+	member string
+}
+
+type Limiter interface {
+	Reserve(ctx context.Context, customerID int, channel string, amount float64) (*Reservation, error)
+	Release(ctx context.Context, reservation *Reservation) error
+}
+
+var _ Limiter = &RedisLimiter{}
+
+// reserveScript checks every rule and records the booking in one step, so
+// concurrent requests cannot all pass the check before any is counted.
+// Bookings are kept in a sorted set scored by time with the amount as the
+// suffix of the member.
+var reserveScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local member = ARGV[2]
+local amount = tonumber(ARGV[3])
+local rules = tonumber(ARGV[4])
+
+local longest = 0
+for i = 0, rules - 1 do
+	local window = tonumber(ARGV[5 + i * 3])
+	if window > longest then
+		longest = window
+	end
+end
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - longest)
+
+for i = 0, rules - 1 do
+	local window = tonumber(ARGV[5 + i * 3])
+	local maxCount = tonumber(ARGV[6 + i * 3])
+	local maxAmount = tonumber(ARGV[7 + i * 3])
+
+	local entries = redis.call('ZRANGEBYSCORE', KEYS[1], '(' .. (now - window), '+inf')
+	local total = 0
+	for _, entry in ipairs(entries) do
+		total = total + tonumber(string.match(entry, ':([^:]+)$'))
+	end
+
+	if maxCount > 0 and #entries + 1 > maxCount then
+		return {i + 1, 'count', tostring(#entries)}
+	end
+
+	if maxAmount > 0 and total + amount > maxAmount then
+		return {i + 1, 'amount', tostring(total)}
+	end
+end
+
+redis.call('ZADD', KEYS[1], now, member)
+redis.call('PEXPIRE', KEYS[1], longest)
+
+return {0, '', ''}
+`)
+
+type RedisLimiter struct {
+	client *redis.Client
+	rules  *Rules
+	now    func() time.Time
+}
+
+func NewRedisLimiter(client *redis.Client, rules *Rules) *RedisLimiter {
+	return &RedisLimiter{
+		client: client,
+		rules:  rules,
+		now:    time.Now,
+	}
+}
+
+func (l *RedisLimiter) Reserve(ctx context.Context, customerID int, channel string, amount float64) (*Reservation, error) {
+	var (
+		now   = l.now()
+		rules = l.rules.For(channel)
+	)
+
+	channel = l.rules.Channel(channel)
+
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("failed to generate reservation id: %w", err)
+	}
+
+	reservation := &Reservation{
+		Channel: channel,
+		key:     fmt.Sprintf("velocity:%s:%d", channel, customerID),
+		member:  fmt.Sprintf("%d-%s:%s", now.UnixNano(), hex.EncodeToString(suffix), strconv.FormatFloat(amount, 'f', -1, 64)),
+	}
+
+	args := []interface{}{now.UnixMilli(), reservation.member, amount, len(rules)}
+	for _, rule := range rules {
+		args = append(args, rule.Window.Milliseconds(), rule.MaxCount, rule.MaxAmount)
+	}
+
+	res, err := reserveScript.Run(ctx, l.client, []string{reservation.key}, args...).Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve velocity: %w", err)
+	}
+
+	if index, _ := res[0].(int64); index > 0 {
+		current, _ := strconv.ParseFloat(fmt.Sprint(res[2]), 64)
+		return nil, &Breach{
+			Channel: channel,
+			Rule:    rules[index-1],
+			Limit:   fmt.Sprint(res[1]),
+			Current: current,
+		}
+	}
+
+	return reservation, nil
+}
+
+func (l *RedisLimiter) Release(ctx context.Context, reservation *Reservation) error {
+	if err := l.client.ZRem(ctx, reservation.key, reservation.member).Err(); err != nil {
+		return fmt.Errorf("failed to release velocity: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package velocity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLimiter(t *testing.T, rules string) (*RedisLimiter, *time.Time) {
+	mr := miniredis.RunT(t)
+
+	parsed, err := ParseRules([]byte(rules))
+	assert.NoError(t, err)
+
+	now := time.Date(2024, 12, 24, 10, 0, 0, 0, time.UTC)
+	limiter := NewRedisLimiter(redis.NewClient(&redis.Options{Addr: mr.Addr()}), parsed)
+	limiter.now = func() time.Time { return now }
+
+	return limiter, &now
+}
+
+func TestRedisLimiter_Reserve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Count limit in the hourly window", func(t *testing.T) {
+		limiter, now := newLimiter(t, `
+default:
+  - { window: 1h, max_count: 2 }
+  - { window: 24h, max_count: 3 }
+`)
+
+		for i := 0; i < 2; i++ {
+			_, err := limiter.Reserve(ctx, 1, "", 100000)
+			assert.NoError(t, err)
+		}
+
+		_, err := limiter.Reserve(ctx, 1, "", 100000)
+		breach, ok := err.(*Breach)
+		assert.True(t, ok)
+		assert.Equal(t, LimitCount, breach.Limit)
+		assert.Equal(t, time.Hour, breach.Rule.Window)
+		assert.Equal(t, float64(2), breach.Current)
+
+		// other customers have their own counters
+		_, err = limiter.Reserve(ctx, 2, "", 100000)
+		assert.NoError(t, err)
+
+		// the hour rolls over but the day still counts both bookings
+		*now = now.Add(61 * time.Minute)
+		_, err = limiter.Reserve(ctx, 1, "", 100000)
+		assert.NoError(t, err)
+
+		_, err = limiter.Reserve(ctx, 1, "", 100000)
+		breach, ok = err.(*Breach)
+		assert.True(t, ok)
+		assert.Equal(t, 24*time.Hour, breach.Rule.Window)
+	})
+
+	t.Run("Amount limit and release", func(t *testing.T) {
+		limiter, _ := newLimiter(t, `
+default:
+  - { window: 1h, max_amount: 1000000 }
+`)
+
+		first, err := limiter.Reserve(ctx, 1, "", 600000)
+		assert.NoError(t, err)
+
+		_, err = limiter.Reserve(ctx, 1, "", 500000)
+		breach, ok := err.(*Breach)
+		assert.True(t, ok)
+		assert.Equal(t, LimitAmount, breach.Limit)
+		assert.Equal(t, float64(600000), breach.Current)
+
+		// a booking that did not go through no longer counts
+		assert.NoError(t, limiter.Release(ctx, first))
+
+		_, err = limiter.Reserve(ctx, 1, "", 500000)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Rules per channel", func(t *testing.T) {
+		limiter, _ := newLimiter(t, `
+default:
+  - { window: 1h, max_count: 1 }
+channels:
+  partner:
+    - { window: 1h, max_count: 2 }
+`)
+
+		for i := 0; i < 2; i++ {
+			reservation, err := limiter.Reserve(ctx, 1, "partner", 100000)
+			assert.NoError(t, err)
+			assert.Equal(t, "partner", reservation.Channel)
+		}
+
+		_, err := limiter.Reserve(ctx, 1, "partner", 100000)
+		assert.Error(t, err)
+
+		reservation, err := limiter.Reserve(ctx, 1, "web", 100000)
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultChannel, reservation.Channel)
+
+		// unknown channels share the default counter
+		_, err = limiter.Reserve(ctx, 1, "kiosk", 100000)
+		breach, ok := err.(*Breach)
+		assert.True(t, ok)
+		assert.Equal(t, DefaultChannel, breach.Channel)
+	})
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := LoadRules("")
+	assert.NoError(t, err)
+	assert.Equal(t, []Rule{
+		{Window: time.Hour, MaxCount: 10, MaxAmount: 100000000},
+		{Window: 24 * time.Hour, MaxCount: 30, MaxAmount: 250000000},
+	}, rules.For("partner"))
+	assert.Equal(t, rules.Default, rules.For("kiosk"))
+
+	_, err = ParseRules([]byte("channels: {}\n"))
+	assert.Error(t, err)
+
+	_, err = ParseRules([]byte("default:\n  - { max_count: 1 }\n"))
+	assert.Error(t, err)
+}
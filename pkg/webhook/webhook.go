@@ -0,0 +1,180 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Every delivery carries the event ID and type, the unix time it was signed
+// at and an HMAC-SHA256 over "<timestamp>.<body>" keyed with the secret of the
+// subscription. Receivers recompute the signature and reject old timestamps
+// so a captured delivery cannot be replayed later.
+const (
+	HeaderID        = "X-Webhook-ID"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	signatureVersion = "v1="
+	secretPrefix     = "whsec_"
+
+	// only the start of a receiver answer is kept for the delivery log
+	maxResponseBody = 1024
+)
+
+var (
+	ErrInvalidSignature = errors.New("webhook signature does not match")
+	ErrExpiredTimestamp = errors.New("webhook timestamp is outside the tolerance")
+)
+
+// GenerateSecret returns a new signing secret for a subscription.
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	return secretPrefix + hex.EncodeToString(buf), nil
+}
+
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signatureVersion + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature headers of a delivery the way a receiver should.
+func Verify(secret, timestamp, signature string, body []byte, now time.Time, tolerance time.Duration) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if age := now.Sub(time.Unix(ts, 0)); age > tolerance || age < -tolerance {
+		return ErrExpiredTimestamp
+	}
+
+	if !hmac.Equal([]byte(Sign(secret, ts, body)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+type Message struct {
+	ID    string
+	Event string
+	Body  []byte
+}
+
+// Result describes one delivery attempt. StatusCode is zero when no answer
+// was received.
+type Result struct {
+	StatusCode   int
+	ResponseBody string
+	Duration     time.Duration
+}
+
+// StatusError is returned for an answer outside 2xx.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook receiver answered with status %d", e.StatusCode)
+}
+
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{
+		client: &http.Client{
+			Timeout: timeout,
+			// a redirect would resend the signed body to a host nobody subscribed
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// Send posts the message to url. Any answer outside 2xx is an error, the
+// result is filled in as far as the attempt got either way.
+func (s *Sender) Send(ctx context.Context, url, secret string, msg *Message) (*Result, error) {
+	var (
+		res       = new(Result)
+		timestamp = s.now().Unix()
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(msg.Body))
+	if err != nil {
+		return res, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "multifinance-webhook/1")
+	req.Header.Set(HeaderID, msg.ID)
+	req.Header.Set(HeaderEvent, msg.Event)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, msg.Body))
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	res.Duration = time.Since(start)
+	if err != nil {
+		return res, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	res.StatusCode = resp.StatusCode
+	res.ResponseBody = strings.ToValidUTF8(string(body), "")
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return res, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	return res, nil
+}
+
+// Backoff doubles the wait after every failed attempt, starting at Base and
+// capped at Max. After MaxAttempts failures a delivery is given up on.
+type Backoff struct {
+	Base        time.Duration
+	Max         time.Duration
+	MaxAttempts int
+}
+
+// Next returns how long to wait after the given failed attempt, counted from
+// 1, and false once no attempt is left.
+func (b Backoff) Next(attempt int) (time.Duration, bool) {
+	if attempt >= b.MaxAttempts {
+		return 0, false
+	}
+
+	wait := float64(b.Base) * math.Pow(2, float64(attempt-1))
+	if wait > float64(b.Max) {
+		return b.Max, true
+	}
+
+	return time.Duration(wait), true
+}
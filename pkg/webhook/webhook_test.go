@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	var (
+		now  = time.Unix(1733047200, 0)
+		body = []byte(`{"type":"contract.created"}`)
+		sig  = Sign("whsec_test", now.Unix(), body)
+	)
+
+	assert.NoError(t, Verify("whsec_test", "1733047200", sig, body, now.Add(time.Minute), 5*time.Minute))
+	assert.ErrorIs(t, Verify("whsec_other", "1733047200", sig, body, now, 5*time.Minute), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("whsec_test", "1733047200", sig, []byte(`{}`), now, 5*time.Minute), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("whsec_test", "1733047200", sig, body, now.Add(time.Hour), 5*time.Minute), ErrExpiredTimestamp)
+	assert.ErrorIs(t, Verify("whsec_test", "yesterday", sig, body, now, 5*time.Minute), ErrInvalidSignature)
+}
+
+func TestSender_Send(t *testing.T) {
+	var (
+		received http.Header
+		status   = http.StatusNoContent
+		body     = []byte(`{"id":"e1"}`)
+	)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		received = r.Header.Clone()
+
+		if err := Verify("whsec_test", r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature), payload, time.Now(), time.Minute); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(status)
+		w.Write([]byte("ok"))
+	}))
+	defer receiver.Close()
+
+	sender := NewSender(time.Second)
+	msg := &Message{ID: "e1", Event: "contract.created", Body: body}
+
+	res, err := sender.Send(context.Background(), receiver.URL, "whsec_test", msg)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	assert.Equal(t, "e1", received.Get(HeaderID))
+	assert.Equal(t, "contract.created", received.Get(HeaderEvent))
+
+	_, err = sender.Send(context.Background(), receiver.URL, "whsec_wrong", msg)
+	var statusErr *StatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+
+	status = http.StatusInternalServerError
+	res, err = sender.Send(context.Background(), receiver.URL, "whsec_test", msg)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	assert.Equal(t, "ok", res.ResponseBody)
+
+	receiver.Close()
+	res, err = sender.Send(context.Background(), receiver.URL, "whsec_test", msg)
+	assert.Error(t, err)
+	assert.Zero(t, res.StatusCode)
+}
+
+func TestBackoff_Next(t *testing.T) {
+	backoff := Backoff{Base: 30 * time.Second, Max: 20 * time.Minute, MaxAttempts: 8}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+		wantOk  bool
+	}{
+		{attempt: 1, want: 30 * time.Second, wantOk: true},
+		{attempt: 2, want: time.Minute, wantOk: true},
+		{attempt: 4, want: 4 * time.Minute, wantOk: true},
+		{attempt: 7, want: 20 * time.Minute, wantOk: true},
+		{attempt: 8, wantOk: false},
+	}
+	for _, tt := range tests {
+		got, ok := backoff.Next(tt.attempt)
+		assert.Equal(t, tt.wantOk, ok, "attempt %d", tt.attempt)
+		assert.Equal(t, tt.want, got, "attempt %d", tt.attempt)
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	first, err := GenerateSecret()
+	assert.NoError(t, err)
+
+	second, err := GenerateSecret()
+	assert.NoError(t, err)
+
+	assert.Len(t, first, len(secretPrefix)+64)
+	assert.NotEqual(t, first, second)
+}